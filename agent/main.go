@@ -1,16 +1,19 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"syscall"
 	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/shellhub-io/shellhub/pkg/agent"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/selfupdater"
-	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -24,7 +27,49 @@ import (
 // to be used during development only.
 var AgentVersion string
 
+// reload reloads the agent's configuration from the configuration file when the agent receives a SIGHUP, until the
+// context is done. When the reloaded configuration is invalid, the current one is kept.
+func reload(ctx context.Context, ag *agent.Agent, configFile string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			cfg, err := agent.LoadConfig(configFile)
+			if err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"version": AgentVersion,
+					"config":  configFile,
+				}).Error("Failed to reload the agent's configuration, keeping the current one")
+
+				continue
+			}
+
+			pending := ag.Reload(cfg)
+
+			log.WithFields(log.Fields{
+				"version": AgentVersion,
+				"config":  configFile,
+			}).Info("Agent's configuration reloaded")
+
+			if len(pending) > 0 {
+				log.WithFields(log.Fields{
+					"version":  AgentVersion,
+					"config":   configFile,
+					"settings": pending,
+				}).Warn("Some changed settings are only applied when the agent restarts")
+			}
+		}
+	}
+}
+
 func main() {
+	var configFile string
+
 	// Default command.
 	rootCmd := &cobra.Command{ // nolint: exhaustruct
 		Use: "agent",
@@ -51,9 +96,14 @@ func main() {
 			//  This behavior is driven by the [envconfig] package. Check it out for more information.
 			//
 			// [envconfig]: https://github.com/sethvargo/go-envconfig
-			cfg, err := envs.ParseWithPrefix[agent.Config]("SHELLHUB_")
+			//
+			// The variables take precedence over the configuration file, when it is set.
+			cfg, err := agent.LoadConfig(configFile)
 			if err != nil {
-				log.Fatal(err)
+				log.WithError(err).WithFields(log.Fields{
+					"version": AgentVersion,
+					"config":  configFile,
+				}).Fatal("Failed to load the agent's configuration")
 			}
 
 			if os.Geteuid() == 0 && cfg.SingleUserPassword != "" {
@@ -70,7 +120,12 @@ func main() {
 				os.Exit(1)
 			}
 
-			updater, err := selfupdater.NewUpdater(AgentVersion)
+			updater, err := selfupdater.NewUpdater(AgentVersion, &selfupdater.Config{
+				URL:       cfg.UpdateURL,
+				PublicKey: cfg.UpdatePublicKey,
+				Deadline:  time.Duration(cfg.UpdateDeadline) * time.Second,
+				Proxy:     cfg.Proxy,
+			})
 			if err != nil {
 				log.Panic(err)
 			}
@@ -119,6 +174,35 @@ func main() {
 
 			ctx := cmd.Context()
 
+			if cfg.ControlSocket != "" {
+				go func() {
+					if err := ag.ServeControl(ctx, cfg.ControlSocket); err != nil {
+						log.WithError(err).WithFields(log.Fields{
+							"version":        AgentVersion,
+							"mode":           mode,
+							"control_socket": cfg.ControlSocket,
+						}).Warn("Failed to serve the control socket")
+					}
+				}()
+			}
+
+			if configFile != "" {
+				go reload(ctx, ag, configFile)
+			}
+
+			go func() {
+				// NOTICE: An update is only confirmed when the updated agent connects to the server; otherwise, it is
+				// rolled back to the previous binary after the update's deadline.
+				<-ag.Connected()
+
+				if err := updater.ConfirmUpdate(); err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"version": AgentVersion,
+						"mode":    mode,
+					}).Error("Failed to confirm the update")
+				}
+			}()
+
 			go func() {
 				// NOTICE: We only start to ping the server when the agent is ready to accept connections.
 				// It will make the agent ping to server after the ticker time set on ping function, what is 10 minutes
@@ -143,6 +227,20 @@ func main() {
 				}).Info("Stopped pinging server")
 			}()
 
+			if cfg.MetricsInterval > 0 {
+				go func() {
+					if err := ag.Metrics(ctx, time.Duration(cfg.MetricsInterval)*time.Second); err != nil {
+						log.WithError(err).WithFields(log.Fields{
+							"version":          AgentVersion,
+							"mode":             mode,
+							"tenant_id":        cfg.TenantID,
+							"server_address":   cfg.ServerAddress,
+							"metrics_interval": cfg.MetricsInterval,
+						}).Error("Failed to report the device's metrics")
+					}
+				}()
+			}
+
 			log.WithFields(log.Fields{
 				"version":            AgentVersion,
 				"mode":               mode,
@@ -168,7 +266,16 @@ func main() {
 							goto sleep
 						}
 
-						if nextVersion.GreaterThan(currentVersion) {
+						// NOTICE: The version is pinned by the device's update policy, what never downgrades the agent.
+						if nextVersion != nil && !nextVersion.Equal(currentVersion) {
+							if err := ag.ReportUpdate(nextVersion, nil); err != nil {
+								log.WithError(err).WithFields(log.Fields{
+									"version":      AgentVersion,
+									"next_version": nextVersion.String(),
+									"tenant_id":    cfg.TenantID,
+								}).Warn("Failed to report the update")
+							}
+
 							if err := updater.ApplyUpdate(nextVersion); err != nil {
 								log.WithError(err).WithFields(log.Fields{
 									"version":            AgentVersion,
@@ -177,6 +284,16 @@ func main() {
 									"server_address":     cfg.ServerAddress,
 									"preferred_hostname": cfg.PreferredHostname,
 								}).Error("Failed to apply update")
+
+								if err := ag.ReportUpdate(nextVersion, err); err != nil {
+									log.WithError(err).WithFields(log.Fields{
+										"version":      AgentVersion,
+										"next_version": nextVersion.String(),
+										"tenant_id":    cfg.TenantID,
+									}).Warn("Failed to report the update")
+								}
+
+								goto sleep
 							}
 
 							log.WithFields(log.Fields{
@@ -196,9 +313,10 @@ func main() {
 							"tenant_id":          cfg.TenantID,
 							"server_address":     cfg.ServerAddress,
 							"preferred_hostname": cfg.PreferredHostname,
-						}).Info("Sleeping for 24 hours")
+							"update_interval":    cfg.UpdateCheckInterval,
+						}).Info("Sleeping until the next update check")
 
-						time.Sleep(time.Hour * 24)
+						time.Sleep(time.Duration(cfg.UpdateCheckInterval) * time.Second)
 					}
 				}()
 			}
@@ -223,15 +341,22 @@ func main() {
 		},
 	}
 
-	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+	// NOTICE: The configuration file can also be set through an environment variable, what is easier to set by the
+	// service managers.
+	rootCmd.Flags().StringVar(&configFile, "config", os.Getenv("SHELLHUB_CONFIG_FILE"), "Path to the agent's YAML configuration file")
+
+	infoCmd := &cobra.Command{ // nolint: exhaustruct
 		Use:   "info",
 		Short: "Show information about the agent",
 		Run: func(cmd *cobra.Command, args []string) {
 			loglevel.SetLogLevel()
 
-			cfg, err := envs.ParseWithPrefix[agent.Config]("SHELLHUB_")
+			cfg, err := agent.LoadConfig(configFile)
 			if err != nil {
-				log.Fatal(err)
+				log.WithError(err).WithFields(log.Fields{
+					"version": AgentVersion,
+					"config":  configFile,
+				}).Fatal("Failed to load the configuration")
 			}
 
 			ag, err := agent.NewAgentWithConfig(cfg, new(agent.HostMode))
@@ -269,7 +394,48 @@ func main() {
 			// TODO: Should it have line break or not?
 			cmd.Println(string(data))
 		},
-	})
+	}
+
+	infoCmd.Flags().StringVar(&configFile, "config", os.Getenv("SHELLHUB_CONFIG_FILE"), "Path to the agent's YAML configuration file")
+
+	rootCmd.AddCommand(infoCmd)
+
+	// NOTICE: The control socket's path is read from the same environment variable as the agent, so the commands
+	// reach the running agent without any flag.
+	socket := os.Getenv("SHELLHUB_CONTROL_SOCKET")
+	if socket == "" {
+		socket = agent.DefaultControlSocket
+	}
+
+	control := func(use, short, method, path string) *cobra.Command {
+		cmd := &cobra.Command{ // nolint: exhaustruct
+			Use:   use,
+			Short: short,
+			Run: func(cmd *cobra.Command, args []string) {
+				loglevel.SetLogLevel()
+
+				data, err := agent.RequestControl(socket, method, path)
+				if err != nil {
+					log.WithError(err).WithFields(log.Fields{
+						"version":        AgentVersion,
+						"control_socket": socket,
+					}).Fatal("Failed to request the running agent")
+				}
+
+				// NOTICE: this output was made to enable the agent's user to check and parse the agent's state with a
+				// know format without having to parse the log output.
+				cmd.Print(string(data))
+			},
+		}
+
+		cmd.Flags().StringVar(&socket, "socket", socket, "Path to the running agent's control socket")
+
+		return cmd
+	}
+
+	rootCmd.AddCommand(control("status", "Show the running agent's connection to the server", http.MethodGet, agent.ControlStatusPath))
+	rootCmd.AddCommand(control("inspect", "Show the running agent's device and configuration", http.MethodGet, agent.ControlInfoPath))
+	rootCmd.AddCommand(control("reconnect", "Reconnect the running agent to the server", http.MethodPost, agent.ControlReconnectPath))
 
 	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
 		Use:   "sftp",
@@ -281,6 +447,23 @@ It is initialized by the agent when a new SFTP session is created.`,
 		},
 	})
 
+	rootCmd.AddCommand(&cobra.Command{ // nolint: exhaustruct
+		Use:   selfupdater.WatchdogCommand + " <executable>",
+		Short: "Rolls back an update not confirmed in time",
+		Long: `Rolls back an update not confirmed in time. This command is used internally by the agent and should not be used
+directly. It is started, from the previous binary, when the agent updates itself.`,
+		Args:   cobra.ExactArgs(1),
+		Hidden: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := selfupdater.Watchdog(args[0]); err != nil {
+				log.WithError(err).WithFields(log.Fields{
+					"version":    AgentVersion,
+					"executable": args[0],
+				}).Fatal("Failed to roll back the update")
+			}
+		},
+	})
+
 	rootCmd.Version = AgentVersion
 
 	rootCmd.SetVersionTemplate(fmt.Sprintf("{{ .Name }} version: {{ .Version }}\ngo: %s\n",
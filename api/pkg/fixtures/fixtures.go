@@ -8,19 +8,28 @@ import (
 )
 
 const (
-	FixtureAnnouncements    = "announcements"     // Check "fixtures.data.announcements" for fixture info
-	FixtureConnectedDevices = "connected_devices" // Check "fixtures.data.connected_devices" for fixture info
-	FixtureDevices          = "devices"           // Check "fixtures.data.devices" for fixture info
-	FixtureSessions         = "sessions"          // Check "fixtures.data.sessions" for fixture info
-	FixtureActiveSessions   = "active_sessions"   // Check "fixtures.data.active_sessions" for fixture info
-	FixtureRecordedSessions = "recorded_sessions" // Check "fixtures.data.recorded_sessions" for fixture info
-	FixtureFirewallRules    = "firewall_rules"    // Check "fixtures.data.firewall_rules" for fixture info
-	FixturePublicKeys       = "public_keys"       // Check "fixtures.data.public_keys" for fixture info
-	FixturePrivateKeys      = "private_keys"      // Check "fixtures.data.private_keys" for fixture info
-	FixtureLicenses         = "licenses"          // Check "fixtures.data.licenses" for fixture info
-	FixtureUsers            = "users"             // Check "fixtures.data.users" for fixture iefo
-	FixtureNamespaces       = "namespaces"        // Check "fixtures.data.namespaces" for fixture info
-	FixtureRecoveryTokens   = "recovery_tokens"   // Check "fixtures.data.recovery_tokens" for fixture info
+	FixtureAnnouncements     = "announcements"       // Check "fixtures.data.announcements" for fixture info
+	FixtureConnectedDevices  = "connected_devices"   // Check "fixtures.data.connected_devices" for fixture info
+	FixtureDevices           = "devices"             // Check "fixtures.data.devices" for fixture info
+	FixtureSessions          = "sessions"            // Check "fixtures.data.sessions" for fixture info
+	FixtureActiveSessions    = "active_sessions"     // Check "fixtures.data.active_sessions" for fixture info
+	FixtureRecordedSessions  = "recorded_sessions"   // Check "fixtures.data.recorded_sessions" for fixture info
+	FixtureFirewallRules     = "firewall_rules"      // Check "fixtures.data.firewall_rules" for fixture info
+	FixturePublicKeys        = "public_keys"         // Check "fixtures.data.public_keys" for fixture info
+	FixturePrivateKeys       = "private_keys"        // Check "fixtures.data.private_keys" for fixture info
+	FixtureLicenses          = "licenses"            // Check "fixtures.data.licenses" for fixture info
+	FixtureUsers             = "users"               // Check "fixtures.data.users" for fixture iefo
+	FixtureNamespaces        = "namespaces"          // Check "fixtures.data.namespaces" for fixture info
+	FixtureRecoveryTokens    = "recovery_tokens"     // Check "fixtures.data.recovery_tokens" for fixture info
+	FixtureTunnels           = "tunnels"             // Check "fixtures.data.tunnels" for fixture info
+	FixtureDeviceMetrics     = "device_metrics"      // Check "fixtures.data.device_metrics" for fixture info
+	FixtureJobs              = "jobs"                // Check "fixtures.data.jobs" for fixture info
+	FixtureJobResults        = "job_results"         // Check "fixtures.data.job_results" for fixture info
+	FixtureJobSchedules      = "job_schedules"       // Check "fixtures.data.job_schedules" for fixture info
+	FixtureFilePushes        = "file_pushes"         // Check "fixtures.data.file_pushes" for fixture info
+	FixtureUpdatePolicies    = "update_policies"     // Check "fixtures.data.update_policies" for fixture info
+	FixtureDeviceAcceptRules = "device_accept_rules" // Check "fixtures.data.device_accept_rules" for fixture info
+	FixtureEnrollmentTokens  = "enrollment_tokens"   // Check "fixtures.data.enrollment_tokens" for fixture info
 )
 
 // Init configures the mongotest for the provided host's database. It is necessary
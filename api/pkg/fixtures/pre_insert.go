@@ -18,6 +18,15 @@ func setupPreInsertFuncs() []mongotest.PreInsertFunc {
 	fns = append(fns, preInsertSessions()...)
 	fns = append(fns, preInsertActiveSessions()...)
 	fns = append(fns, preInsertRecordedSessions()...)
+	fns = append(fns, preInsertTunnels()...)
+	fns = append(fns, preInsertDeviceMetrics()...)
+	fns = append(fns, preInsertJobs()...)
+	fns = append(fns, preInsertJobResults()...)
+	fns = append(fns, preInsertJobSchedules()...)
+	fns = append(fns, preInsertFilePushes()...)
+	fns = append(fns, preInsertUpdatePolicies()...)
+	fns = append(fns, preInsertDeviceAcceptRules()...)
+	fns = append(fns, preInsertEnrollmentTokens()...)
 
 	return fns
 }
@@ -117,3 +126,73 @@ func preInsertRecordedSessions() []mongotest.PreInsertFunc {
 		mongotest.SimpleConvertTime("recorded_sessions", "time"),
 	}
 }
+
+func preInsertTunnels() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("tunnels", "_id"),
+		mongotest.SimpleConvertTime("tunnels", "created_at"),
+	}
+}
+
+func preInsertDeviceMetrics() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("device_metrics", "_id"),
+		mongotest.SimpleConvertTime("device_metrics", "time"),
+	}
+}
+
+func preInsertJobs() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("jobs", "_id"),
+		mongotest.SimpleConvertTime("jobs", "created_at"),
+		mongotest.SimpleConvertTime("jobs", "finished_at"),
+	}
+}
+
+func preInsertJobResults() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("job_results", "_id"),
+	}
+}
+
+func preInsertJobSchedules() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("job_schedules", "_id"),
+		mongotest.SimpleConvertTime("job_schedules", "created_at"),
+		mongotest.SimpleConvertTime("job_schedules", "last_run_at"),
+	}
+}
+
+func preInsertFilePushes() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("file_pushes", "_id"),
+		mongotest.SimpleConvertTime("file_pushes", "created_at"),
+		mongotest.SimpleConvertTime("file_pushes", "finished_at"),
+	}
+}
+
+func preInsertUpdatePolicies() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("update_policies", "_id"),
+		mongotest.SimpleConvertTime("update_policies", "created_at"),
+		mongotest.SimpleConvertTime("update_policies", "updated_at"),
+	}
+}
+
+func preInsertDeviceAcceptRules() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("device_accept_rules", "_id"),
+		mongotest.SimpleConvertTime("device_accept_rules", "created_at"),
+		mongotest.SimpleConvertTime("device_accept_rules", "updated_at"),
+	}
+}
+
+func preInsertEnrollmentTokens() []mongotest.PreInsertFunc {
+	return []mongotest.PreInsertFunc{
+		mongotest.SimpleConvertObjID("enrollment_tokens", "_id"),
+		mongotest.SimpleConvertTime("enrollment_tokens", "expires_at"),
+		mongotest.SimpleConvertTime("enrollment_tokens", "revoked_at"),
+		mongotest.SimpleConvertTime("enrollment_tokens", "last_used_at"),
+		mongotest.SimpleConvertTime("enrollment_tokens", "created_at"),
+	}
+}
@@ -4,18 +4,53 @@ type Action int
 
 // AllActions is a struct to act like an Enum and facilitate to indicate the action used in the service.
 type AllActions struct {
-	Device    DeviceActions
-	Session   SessionActions
-	Firewall  FirewallActions
-	PublicKey PublicKeyActions
-	Namespace NamespaceActions
-	Billing   BillingActions
+	Device       DeviceActions
+	Tunnel       TunnelActions
+	Job          JobActions
+	Schedule     JobScheduleActions
+	FilePush     FilePushActions
+	UpdatePolicy UpdatePolicyActions
+	AcceptRule   DeviceAcceptRuleActions
+	Enrollment   EnrollmentTokenActions
+	Session      SessionActions
+	Firewall     FirewallActions
+	PublicKey    PublicKeyActions
+	Namespace    NamespaceActions
+	Billing      BillingActions
 }
 
 type DeviceActions struct {
 	Accept, Reject, Update, Remove, Connect, Rename, CreateTag, UpdateTag, RemoveTag, RenameTag, DeleteTag int
 }
 
+type TunnelActions struct {
+	Create, Remove, Connect int
+}
+
+type JobActions struct {
+	Create int
+}
+
+type JobScheduleActions struct {
+	Create, Update, Remove int
+}
+
+type FilePushActions struct {
+	Create, Retry int
+}
+
+type UpdatePolicyActions struct {
+	Create, Update, Remove int
+}
+
+type DeviceAcceptRuleActions struct {
+	Create, Update, Remove int
+}
+
+type EnrollmentTokenActions struct {
+	Create, Revoke int
+}
+
 type SessionActions struct {
 	Play, Close, Remove, Details int
 }
@@ -29,7 +64,7 @@ type PublicKeyActions struct {
 }
 
 type NamespaceActions struct {
-	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, Delete int
+	Rename, AddMember, RemoveMember, EditMember, EnableSessionRecord, RequireEnrollmentToken, Delete int
 }
 
 type BillingActions struct {
@@ -52,6 +87,37 @@ var Actions = AllActions{
 		RenameTag: DeviceRenameTag,
 		DeleteTag: DeviceDeleteTag,
 	},
+	Tunnel: TunnelActions{
+		Create:  TunnelCreate,
+		Remove:  TunnelRemove,
+		Connect: TunnelConnect,
+	},
+	Job: JobActions{
+		Create: JobCreate,
+	},
+	Schedule: JobScheduleActions{
+		Create: JobScheduleCreate,
+		Update: JobScheduleUpdate,
+		Remove: JobScheduleRemove,
+	},
+	FilePush: FilePushActions{
+		Create: FilePushCreate,
+		Retry:  FilePushRetry,
+	},
+	UpdatePolicy: UpdatePolicyActions{
+		Create: UpdatePolicyCreate,
+		Update: UpdatePolicyUpdate,
+		Remove: UpdatePolicyRemove,
+	},
+	AcceptRule: DeviceAcceptRuleActions{
+		Create: DeviceAcceptRuleCreate,
+		Update: DeviceAcceptRuleUpdate,
+		Remove: DeviceAcceptRuleRemove,
+	},
+	Enrollment: EnrollmentTokenActions{
+		Create: EnrollmentTokenCreate,
+		Revoke: EnrollmentTokenRevoke,
+	},
 	Session: SessionActions{
 		Play:    SessionPlay,
 		Close:   SessionClose,
@@ -72,12 +138,13 @@ var Actions = AllActions{
 		UpdateTag: PublicKeyUpdateTag,
 	},
 	Namespace: NamespaceActions{
-		Rename:              NamespaceRename,
-		AddMember:           NamespaceAddMember,
-		RemoveMember:        NamespaceRemoveMember,
-		EditMember:          NamespaceEditMember,
-		EnableSessionRecord: NamespaceEnableSessionRecord,
-		Delete:              NamespaceDelete,
+		Rename:                 NamespaceRename,
+		AddMember:              NamespaceAddMember,
+		RemoveMember:           NamespaceRemoveMember,
+		EditMember:             NamespaceEditMember,
+		EnableSessionRecord:    NamespaceEnableSessionRecord,
+		RequireEnrollmentToken: NamespaceRequireEnrollmentToken,
+		Delete:                 NamespaceDelete,
 	},
 	Billing: BillingActions{
 		CreateCustomer:      BillingCreateCustomer,
@@ -339,6 +339,13 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Tunnel.Create,
+				Actions.Tunnel.Remove,
+				Actions.Tunnel.Connect,
+
+				Actions.FilePush.Create,
+				Actions.FilePush.Retry,
+
 				Actions.Session.Details,
 			},
 			requiredMocks: func() {
@@ -362,6 +369,25 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Tunnel.Create,
+				Actions.Tunnel.Remove,
+				Actions.Tunnel.Connect,
+
+				Actions.Job.Create,
+				Actions.Schedule.Create,
+				Actions.Schedule.Update,
+				Actions.Schedule.Remove,
+				Actions.FilePush.Create,
+				Actions.FilePush.Retry,
+				Actions.UpdatePolicy.Create,
+				Actions.UpdatePolicy.Update,
+				Actions.UpdatePolicy.Remove,
+				Actions.AcceptRule.Create,
+				Actions.AcceptRule.Update,
+				Actions.AcceptRule.Remove,
+				Actions.Enrollment.Create,
+				Actions.Enrollment.Revoke,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
@@ -380,6 +406,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.RequireEnrollmentToken,
 			},
 			requiredMocks: func() {
 			},
@@ -402,6 +429,25 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Device.RenameTag,
 				Actions.Device.DeleteTag,
 
+				Actions.Tunnel.Create,
+				Actions.Tunnel.Remove,
+				Actions.Tunnel.Connect,
+
+				Actions.Job.Create,
+				Actions.Schedule.Create,
+				Actions.Schedule.Update,
+				Actions.Schedule.Remove,
+				Actions.FilePush.Create,
+				Actions.FilePush.Retry,
+				Actions.UpdatePolicy.Create,
+				Actions.UpdatePolicy.Update,
+				Actions.UpdatePolicy.Remove,
+				Actions.AcceptRule.Create,
+				Actions.AcceptRule.Update,
+				Actions.AcceptRule.Remove,
+				Actions.Enrollment.Create,
+				Actions.Enrollment.Revoke,
+
 				Actions.Session.Play,
 				Actions.Session.Close,
 				Actions.Session.Remove,
@@ -420,6 +466,7 @@ func TestCheckPermission(t *testing.T) {
 				Actions.Namespace.RemoveMember,
 				Actions.Namespace.EditMember,
 				Actions.Namespace.EnableSessionRecord,
+				Actions.Namespace.RequireEnrollmentToken,
 				Actions.Namespace.Delete,
 
 				Actions.Billing.AddPaymentMethod,
@@ -54,6 +54,26 @@ const (
 	BillingCreateSubscription
 	BillingGetPaymentMethod
 	BillingGetSubscription
+
+	TunnelCreate
+	TunnelRemove
+	TunnelConnect
+
+	JobCreate
+	JobScheduleCreate
+	JobScheduleUpdate
+	JobScheduleRemove
+	FilePushCreate
+	FilePushRetry
+	UpdatePolicyCreate
+	UpdatePolicyUpdate
+	UpdatePolicyRemove
+	DeviceAcceptRuleCreate
+	DeviceAcceptRuleUpdate
+	DeviceAcceptRuleRemove
+	EnrollmentTokenCreate
+	EnrollmentTokenRevoke
+	NamespaceRequireEnrollmentToken
 )
 
 var observerPermissions = Permissions{
@@ -76,6 +96,13 @@ var operatorPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	TunnelCreate,
+	TunnelRemove,
+	TunnelConnect,
+
+	FilePushCreate,
+	FilePushRetry,
+
 	SessionDetails,
 }
 
@@ -94,6 +121,25 @@ var adminPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	TunnelCreate,
+	TunnelRemove,
+	TunnelConnect,
+
+	JobCreate,
+	JobScheduleCreate,
+	JobScheduleUpdate,
+	JobScheduleRemove,
+	FilePushCreate,
+	FilePushRetry,
+	UpdatePolicyCreate,
+	UpdatePolicyUpdate,
+	UpdatePolicyRemove,
+	DeviceAcceptRuleCreate,
+	DeviceAcceptRuleUpdate,
+	DeviceAcceptRuleRemove,
+	EnrollmentTokenCreate,
+	EnrollmentTokenRevoke,
+
 	DeviceUpdate,
 
 	SessionPlay,
@@ -120,6 +166,7 @@ var adminPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceRequireEnrollmentToken,
 }
 
 var ownerPermissions = Permissions{
@@ -137,6 +184,25 @@ var ownerPermissions = Permissions{
 	DeviceRenameTag,
 	DeviceDeleteTag,
 
+	TunnelCreate,
+	TunnelRemove,
+	TunnelConnect,
+
+	JobCreate,
+	JobScheduleCreate,
+	JobScheduleUpdate,
+	JobScheduleRemove,
+	FilePushCreate,
+	FilePushRetry,
+	UpdatePolicyCreate,
+	UpdatePolicyUpdate,
+	UpdatePolicyRemove,
+	DeviceAcceptRuleCreate,
+	DeviceAcceptRuleUpdate,
+	DeviceAcceptRuleRemove,
+	EnrollmentTokenCreate,
+	EnrollmentTokenRevoke,
+
 	DeviceUpdate,
 
 	SessionPlay,
@@ -163,6 +229,7 @@ var ownerPermissions = Permissions{
 	NamespaceRemoveMember,
 	NamespaceEditMember,
 	NamespaceEnableSessionRecord,
+	NamespaceRequireEnrollmentToken,
 	NamespaceDelete,
 
 	BillingCreateCustomer,
@@ -0,0 +1,152 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListDeviceAcceptRulesURL  = "/devices/accept-rules"
+	CreateDeviceAcceptRuleURL = "/devices/accept-rules"
+	GetDeviceAcceptRuleURL    = "/devices/accept-rules/:id"
+	UpdateDeviceAcceptRuleURL = "/devices/accept-rules/:id"
+	DeleteDeviceAcceptRuleURL = "/devices/accept-rules/:id"
+)
+
+func (h *Handler) ListDeviceAcceptRules(c gateway.Context) error {
+	var req requests.DeviceAcceptRuleList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rules, count, err := h.service.ListDeviceAcceptRules(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, rules)
+}
+
+func (h *Handler) GetDeviceAcceptRule(c gateway.Context) error {
+	var req requests.DeviceAcceptRuleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	rule, err := h.service.GetDeviceAcceptRule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) CreateDeviceAcceptRule(c gateway.Context) error {
+	var req requests.DeviceAcceptRuleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.DeviceAcceptRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.AcceptRule.Create, func() error {
+		var err error
+		rule, err = h.service.CreateDeviceAcceptRule(c.Ctx(), tenant, req.DeviceAcceptRuleData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) UpdateDeviceAcceptRule(c gateway.Context) error {
+	var req requests.DeviceAcceptRuleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var rule *models.DeviceAcceptRule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.AcceptRule.Update, func() error {
+		var err error
+		rule, err = h.service.UpdateDeviceAcceptRule(c.Ctx(), tenant, req.ID, req.DeviceAcceptRuleData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, rule)
+}
+
+func (h *Handler) DeleteDeviceAcceptRule(c gateway.Context) error {
+	var req requests.DeviceAcceptRuleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.AcceptRule.Remove, func() error {
+		return h.service.DeleteDeviceAcceptRule(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
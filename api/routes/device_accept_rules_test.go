@@ -0,0 +1,181 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListDeviceAcceptRules(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListDeviceAcceptRules", gomock.Anything, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+		Return([]models.DeviceAcceptRule{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices/accept-rules?page=1&per_page=10", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateDeviceAcceptRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	data := requests.DeviceAcceptRuleData{
+		Name:        "office",
+		MACPrefixes: []string{"00:1a:2b"},
+		Sources:     []string{"10.0.0.0/8"},
+	}
+
+	cases := []struct {
+		title          string
+		payload        requests.DeviceAcceptRuleData
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the name is empty",
+			payload:        requests.DeviceAcceptRuleData{Hostname: "web-*"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when a source is empty",
+			payload:        requests.DeviceAcceptRuleData{Name: "office", Sources: []string{""}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is operator",
+			payload:        data,
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "fails when the rule has no conditions",
+			payload: requests.DeviceAcceptRuleData{Name: "office"},
+			role:    guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateDeviceAcceptRule", gomock.Anything, "tenant", requests.DeviceAcceptRuleData{Name: "office"}).
+					Return(nil, svc.ErrDeviceAcceptRuleEmpty).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:   "success when the data is valid",
+			payload: data,
+			role:    guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateDeviceAcceptRule", gomock.Anything, "tenant", data).
+					Return(&models.DeviceAcceptRule{ID: "id", Name: "office"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/accept-rules", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceAcceptRule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is operator",
+			id:             "id",
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the rule is not found",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("DeleteDeviceAcceptRule", gomock.Anything, "tenant", "id").
+					Return(svc.ErrDeviceAcceptRuleNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the rule is deleted",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("DeleteDeviceAcceptRule", gomock.Anything, "tenant", "id").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/devices/accept-rules/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
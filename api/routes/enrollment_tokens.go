@@ -0,0 +1,122 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListEnrollmentTokensURL  = "/devices/enrollment-tokens"
+	CreateEnrollmentTokenURL = "/devices/enrollment-tokens"
+	GetEnrollmentTokenURL    = "/devices/enrollment-tokens/:id"
+	RevokeEnrollmentTokenURL = "/devices/enrollment-tokens/:id"
+)
+
+func (h *Handler) ListEnrollmentTokens(c gateway.Context) error {
+	var req requests.EnrollmentTokenList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	tokens, count, err := h.service.ListEnrollmentTokens(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, tokens)
+}
+
+func (h *Handler) GetEnrollmentToken(c gateway.Context) error {
+	var req requests.EnrollmentTokenGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	token, err := h.service.GetEnrollmentToken(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+func (h *Handler) CreateEnrollmentToken(c gateway.Context) error {
+	var req requests.EnrollmentTokenCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var token *models.EnrollmentTokenCreated
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Enrollment.Create, func() error {
+		var err error
+		token, err = h.service.CreateEnrollmentToken(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, token)
+}
+
+func (h *Handler) RevokeEnrollmentToken(c gateway.Context) error {
+	var req requests.EnrollmentTokenRevoke
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Enrollment.Revoke, func() error {
+		return h.service.RevokeEnrollmentToken(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,178 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListEnrollmentTokens(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListEnrollmentTokens", gomock.Anything, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+		Return([]models.EnrollmentToken{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/devices/enrollment-tokens?page=1&per_page=10", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Service)
+
+	data := requests.EnrollmentTokenCreate{
+		Name:   "fleet",
+		Tags:   []string{"production"},
+		Status: "accepted",
+	}
+
+	cases := []struct {
+		title          string
+		payload        requests.EnrollmentTokenCreate
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the name is empty",
+			payload:        requests.EnrollmentTokenCreate{Status: "accepted"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the status is invalid",
+			payload:        requests.EnrollmentTokenCreate{Name: "fleet", Status: "rejected"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when a tag is invalid",
+			payload:        requests.EnrollmentTokenCreate{Name: "fleet", Tags: []string{"a"}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is operator",
+			payload:        data,
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "success when the data is valid",
+			payload: data,
+			role:    guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateEnrollmentToken", gomock.Anything, "tenant", data).
+					Return(&models.EnrollmentTokenCreated{EnrollmentToken: models.EnrollmentToken{ID: "id", Name: "fleet"}, Token: "secret"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/enrollment-tokens", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRevokeEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is operator",
+			id:             "id",
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the token is not found",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("RevokeEnrollmentToken", gomock.Anything, "tenant", "id").
+					Return(svc.ErrEnrollmentTokenNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the token is revoked",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("RevokeEnrollmentToken", gomock.Anything, "tenant", "id").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/devices/enrollment-tokens/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"net/http"
+	"path/filepath"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	errs "github.com/shellhub-io/shellhub/api/routes/errors"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListFilePushesURL = "/files/pushes"
+	CreateFilePushURL = "/files/pushes"
+	GetFilePushURL    = "/files/pushes/:id"
+	RetryFilePushURL  = "/files/pushes/:id/retry"
+)
+
+// MaxFilePushSize is the maximum size, in bytes, of the request creating a file push, what includes the file.
+var MaxFilePushSize int64 = 100 << 20
+
+func (h *Handler) ListFilePushes(c gateway.Context) error {
+	var req requests.FilePushList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	pushes, count, err := h.service.ListFilePushes(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, pushes)
+}
+
+func (h *Handler) CreateFilePush(c gateway.Context) error {
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, MaxFilePushSize)
+
+	var req requests.FilePushCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return errs.NewErrInvalidEntity(map[string]string{"file": "required"})
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var push *models.FilePush
+	err = guard.EvaluatePermission(c.Role(), guard.Actions.FilePush.Create, func() error {
+		var err error
+		push, err = h.service.CreateFilePush(c.Ctx(), tenant, req, filepath.Base(header.Filename), file)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, push)
+}
+
+func (h *Handler) GetFilePush(c gateway.Context) error {
+	var req requests.FilePushGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	push, err := h.service.GetFilePush(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, push)
+}
+
+func (h *Handler) RetryFilePush(c gateway.Context) error {
+	var req requests.FilePushRetry
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var push *models.FilePush
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.FilePush.Retry, func() error {
+		var err error
+		push, err = h.service.RetryFilePush(c.Ctx(), tenant, req.ID)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, push)
+}
@@ -0,0 +1,235 @@
+package routes
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateFilePush(t *testing.T) {
+	mock := new(mocks.Service)
+
+	type form struct {
+		fields map[string][]string
+		file   string
+	}
+
+	cases := []struct {
+		title          string
+		form           form
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the file is not sent",
+			form:           form{fields: map[string][]string{"path": {"/etc/app.conf"}, "tags": {"production"}}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the path is relative",
+			form:           form{fields: map[string][]string{"path": {"etc/app.conf"}, "tags": {"production"}}, file: "key=value\n"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the tags are not sent",
+			form:           form{fields: map[string][]string{"path": {"/etc/app.conf"}}, file: "key=value\n"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the mode is invalid",
+			form:           form{fields: map[string][]string{"path": {"/etc/app.conf"}, "tags": {"production"}, "mode": {"0999"}}, file: "key=value\n"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is observer",
+			form:           form{fields: map[string][]string{"path": {"/etc/app.conf"}, "tags": {"production"}}, file: "key=value\n"},
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when no device has the tags",
+			form:  form{fields: map[string][]string{"path": {"/etc/app.conf"}, "tags": {"production"}}, file: "key=value\n"},
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateFilePush", gomock.Anything, "tenant", requests.FilePushCreate{Path: "/etc/app.conf", Tags: []string{"production"}}, "app.conf", gomock.Anything).
+					Return(nil, svc.ErrFilePushNoDevices).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the file is pushed",
+			form: form{
+				fields: map[string][]string{"path": {"/etc/app.conf"}, "tags": {"production", "linux"}, "owner": {"root"}, "mode": {"0600"}},
+				file:   "key=value\n",
+			},
+			role: guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("CreateFilePush", gomock.Anything, "tenant", requests.FilePushCreate{Path: "/etc/app.conf", Owner: "root", Mode: "0600", Tags: []string{"production", "linux"}}, "app.conf", gomock.Anything).
+					Return(&models.FilePush{ID: "id", Path: "/etc/app.conf"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			body := new(bytes.Buffer)
+			writer := multipart.NewWriter(body)
+
+			for name, values := range tc.form.fields {
+				for _, value := range values {
+					assert.NoError(t, writer.WriteField(name, value))
+				}
+			}
+
+			if tc.form.file != "" {
+				part, err := writer.CreateFormFile("file", "app.conf")
+				assert.NoError(t, err)
+
+				_, err = part.Write([]byte(tc.form.file))
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, writer.Close())
+
+			req := httptest.NewRequest(http.MethodPost, "/api/files/pushes", body)
+			req.Header.Set("Content-Type", writer.FormDataContentType())
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetFilePush(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the file push is not found",
+			id:    "id",
+			requiredMocks: func() {
+				mock.On("GetFilePush", gomock.Anything, "tenant", "id").
+					Return(nil, svc.ErrFilePushNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the file push is found",
+			id:    "id",
+			requiredMocks: func() {
+				mock.On("GetFilePush", gomock.Anything, "tenant", "id").
+					Return(&models.FilePush{ID: "id"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/files/pushes/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRetryFilePush(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is observer",
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the file push has not finished",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("RetryFilePush", gomock.Anything, "tenant", "id").
+					Return(nil, svc.ErrFilePushNotFinished).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the file push is retried",
+			role:  guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("RetryFilePush", gomock.Anything, "tenant", "id").
+					Return(&models.FilePush{ID: "id"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/files/pushes/id/retry", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const DeviceFilesURL = "/devices/:uid/files/:action"
+
+// FilesProxyAddress is the address of the SSH server, what proxies the file browser's requests to the devices through
+// their tunnels.
+var FilesProxyAddress = "http://ssh:8080"
+
+func (h *Handler) DeviceFiles(c gateway.Context) error {
+	// NOTICE: The request isn't bound, because its body, when uploading a file, is the file's content.
+	req := requests.DeviceFiles{
+		DeviceParam: requests.DeviceParam{UID: c.Param("uid")},
+		Action:      c.Param("action"),
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Device.Connect, func() error {
+		return h.service.AuthorizeFiles(c.Ctx(), models.UID(req.UID), tenant)
+	})
+	if err != nil {
+		return err
+	}
+
+	target, err := url.Parse(FilesProxyAddress)
+	if err != nil {
+		return err
+	}
+
+	proxy := &httputil.ReverseProxy{
+		Director: func(r *http.Request) {
+			r.URL.Scheme = target.Scheme
+			r.URL.Host = target.Host
+			r.URL.Path = "/files/" + url.PathEscape(req.UID) + "/" + url.PathEscape(req.Action)
+			r.URL.RawPath = ""
+			r.Host = target.Host
+
+			// NOTICE: The user's token is never forwarded to the device, what receives only the device's credentials.
+			r.Header.Del("Authorization")
+			r.Header.Del("Cookie")
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			log.WithError(err).WithField("uid", req.UID).Error("failed to proxy the file browser's request")
+
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+
+	proxy.ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package routes
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestDeviceFiles(t *testing.T) {
+	mock := new(mocks.Service)
+
+	ssh := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		fmt.Fprintf(w, "%s %s?%s [%s] [%s] %s", r.Method, r.URL.Path, r.URL.RawQuery, r.Header.Get("Authorization"), r.Header.Get(models.FilesAuthorizationHeader), body)
+	}))
+	defer ssh.Close()
+
+	address := FilesProxyAddress
+	FilesProxyAddress = ssh.URL
+	defer func() { FilesProxyAddress = address }()
+
+	cases := []struct {
+		title          string
+		method         string
+		action         string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			title:          "fails when the action is invalid",
+			method:         http.MethodGet,
+			action:         "chmod",
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:  "fails when the device is not found",
+			method: http.MethodGet,
+			action: "list",
+			role:   guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("AuthorizeFiles", gomock.Anything, models.UID("123"), "tenant").
+					Return(svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:  "success when the request is proxied to the device",
+			method: http.MethodPut,
+			action: "upload",
+			role:   guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("AuthorizeFiles", gomock.Anything, models.UID("123"), "tenant").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "PUT /files/123/upload?path=%2Ftmp%2Ffile [] [Basic cm9vdDpzZWNyZXQ=] content",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(tc.method, "/api/devices/123/files/"+tc.action+"?path=%2Ftmp%2Ffile", strings.NewReader("content"))
+			req.Header.Set("Content-Type", "application/octet-stream")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set(models.FilesAuthorizationHeader, "Basic cm9vdDpzZWNyZXQ=")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
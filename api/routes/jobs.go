@@ -0,0 +1,263 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListJobsURL  = "/jobs"
+	CreateJobURL = "/jobs"
+	GetJobURL    = "/jobs/:id"
+
+	ListJobSchedulesURL    = "/jobs/schedules"
+	CreateJobScheduleURL   = "/jobs/schedules"
+	GetJobScheduleURL      = "/jobs/schedules/:id"
+	UpdateJobScheduleURL   = "/jobs/schedules/:id"
+	DeleteJobScheduleURL   = "/jobs/schedules/:id"
+	ListJobScheduleJobsURL = "/jobs/schedules/:id/jobs"
+)
+
+func (h *Handler) ListJobs(c gateway.Context) error {
+	var req requests.JobList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	jobs, count, err := h.service.ListJobs(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, jobs)
+}
+
+func (h *Handler) CreateJob(c gateway.Context) error {
+	var req requests.JobCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var job *models.Job
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Job.Create, func() error {
+		var err error
+		job, err = h.service.CreateJob(c.Ctx(), tenant, req)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) GetJob(c gateway.Context) error {
+	var req requests.JobGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	job, err := h.service.GetJob(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, job)
+}
+
+func (h *Handler) ListJobSchedules(c gateway.Context) error {
+	var req requests.JobScheduleList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	schedules, count, err := h.service.ListJobSchedules(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, schedules)
+}
+
+func (h *Handler) GetJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	schedule, err := h.service.GetJobSchedule(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) CreateJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var schedule *models.JobSchedule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Schedule.Create, func() error {
+		var err error
+		schedule, err = h.service.CreateJobSchedule(c.Ctx(), tenant, req.JobScheduleData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) UpdateJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var schedule *models.JobSchedule
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Schedule.Update, func() error {
+		var err error
+		schedule, err = h.service.UpdateJobSchedule(c.Ctx(), tenant, req.ID, req.JobScheduleData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, schedule)
+}
+
+func (h *Handler) DeleteJobSchedule(c gateway.Context) error {
+	var req requests.JobScheduleDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Schedule.Remove, func() error {
+		return h.service.DeleteJobSchedule(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListJobScheduleJobs(c gateway.Context) error {
+	var req requests.JobScheduleJobs
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	jobs, count, err := h.service.ListJobScheduleJobs(c.Ctx(), tenant, req.ID, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, jobs)
+}
@@ -0,0 +1,429 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListJobs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "success when the jobs are listed",
+			requiredMocks: func() {
+				mock.On("ListJobs", gomock.Anything, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+					Return([]models.Job{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs?page=1&per_page=10", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.JobCreate
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when neither devices nor tags are sent",
+			payload:        requests.JobCreate{Command: "uptime", User: "root"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the command is empty",
+			payload:        requests.JobCreate{User: "root", Devices: []string{"123"}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the timeout is invalid",
+			payload:        requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"123"}, Timeout: 7200},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is observer",
+			payload:        requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"123"}},
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "fails when the device is not found",
+			payload: requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"1234"}},
+			role:    guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateJob", gomock.Anything, "tenant", requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"1234"}}).
+					Return(nil, svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:          "fails when the role is operator",
+			payload:        requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"123"}},
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "success when the devices have the tags",
+			payload: requests.JobCreate{Command: "uptime", User: "root", Tags: []string{"production"}},
+			role:    guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJob", gomock.Anything, "tenant", requests.JobCreate{Command: "uptime", User: "root", Tags: []string{"production"}}).
+					Return(&models.Job{ID: "id", Command: "uptime", User: "root"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the job is not found",
+			id:    "nonexistent",
+			requiredMocks: func() {
+				mock.On("GetJob", gomock.Anything, "tenant", "nonexistent").
+					Return(nil, svc.ErrJobNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the job exists",
+			id:    "id",
+			requiredMocks: func() {
+				mock.On("GetJob", gomock.Anything, "tenant", "id").
+					Return(&models.Job{ID: "id", TenantID: "tenant"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/jobs/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleObserver)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobSchedules(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListJobSchedules", gomock.Anything, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+		Return([]models.JobSchedule{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/schedules?page=1&per_page=10", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateJobSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	data := requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root", Tags: []string{"production"}}
+
+	cases := []struct {
+		title          string
+		payload        requests.JobScheduleData
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the tags are empty",
+			payload:        requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the offline behavior is invalid",
+			payload:        requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root", Tags: []string{"production"}, Offline: "wait"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is observer",
+			payload:        data,
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "fails when the cron expression is invalid",
+			payload: requests.JobScheduleData{Name: "uptime", Cron: "every day", Command: "uptime", User: "root", Tags: []string{"production"}},
+			role:    guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateJobSchedule", gomock.Anything, "tenant", requests.JobScheduleData{Name: "uptime", Cron: "every day", Command: "uptime", User: "root", Tags: []string{"production"}}).
+					Return(nil, svc.ErrJobScheduleCronInvalid).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is operator",
+			payload:        data,
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "success when the data is valid",
+			payload: data,
+			role:    guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateJobSchedule", gomock.Anything, "tenant", data).
+					Return(&models.JobSchedule{ID: "id", Name: "uptime"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/jobs/schedules", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateJobSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	data := requests.JobScheduleData{Name: "uptime", Cron: "@hourly", Command: "uptime", User: "root", Tags: []string{"production"}}
+
+	cases := []struct {
+		title          string
+		id             string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the schedule is not found",
+			id:    "nonexistent",
+			requiredMocks: func() {
+				mock.On("UpdateJobSchedule", gomock.Anything, "tenant", "nonexistent", data).
+					Return(nil, svc.ErrJobScheduleNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the schedule exists",
+			id:    "id",
+			requiredMocks: func() {
+				mock.On("UpdateJobSchedule", gomock.Anything, "tenant", "id", data).
+					Return(&models.JobSchedule{ID: "id"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(data)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/api/jobs/schedules/"+tc.id, strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleAdministrator)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteJobSchedule(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is observer",
+			id:             "id",
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the schedule exists",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("DeleteJobSchedule", gomock.Anything, "tenant", "id").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/jobs/schedules/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobScheduleJobs(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListJobScheduleJobs", gomock.Anything, "tenant", "id", paginator.Query{Page: 1, PerPage: 10}).
+		Return([]models.Job{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/jobs/schedules/id/jobs?page=1&per_page=10", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	CreateDeviceMetricsURL = "/devices/metrics"
+	ListDeviceMetricsURL   = "/devices/:uid/metrics"
+)
+
+// CreateDeviceMetrics stores the metrics reported by a device. The device is identified by the header set by the
+// gateway from the device's token.
+func (h *Handler) CreateDeviceMetrics(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var metrics models.DeviceMetrics
+	if err := c.Bind(&metrics); err != nil {
+		return err
+	}
+
+	if err := h.service.CreateDeviceMetrics(c.Ctx(), models.UID(uid), &metrics); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceMetrics(c gateway.Context) error {
+	var req requests.DeviceMetricsList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	metrics, err := h.service.ListDeviceMetrics(c.Ctx(), models.UID(req.UID), tenant, req.From, req.To)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, metrics)
+}
@@ -0,0 +1,149 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestCreateDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is not identified",
+			uid:            "",
+			body:           `{"cpu": 10}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title: "fails when the device is not found",
+			uid:   "123",
+			body:  `{"cpu": 10}`,
+			requiredMocks: func() {
+				mock.On("CreateDeviceMetrics", gomock.Anything, models.UID("123"), &models.DeviceMetrics{CPU: 10}).
+					Return(svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the metrics are stored",
+			uid:   "123",
+			body:  `{"cpu": 10, "load": [0.1, 0.2, 0.3], "memory": {"total": 2048, "used": 1024}}`,
+			requiredMocks: func() {
+				mock.On("CreateDeviceMetrics", gomock.Anything, models.UID("123"), &models.DeviceMetrics{
+					CPU:    10,
+					Load:   []float64{0.1, 0.2, 0.3},
+					Memory: models.DeviceMetricsUsage{Total: 2048, Used: 1024},
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/metrics", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Device-UID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Service)
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		title            string
+		query            string
+		requiredMocks    func()
+		expectedStatus   int
+		expectedResponse []models.DeviceMetrics
+	}{
+		{
+			title:          "fails when the range is not a valid time",
+			query:          "?from=yesterday",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnprocessableEntity,
+		},
+		{
+			title: "fails when the range is invalid",
+			query: "?from=2023-01-02T00:00:00Z&to=2023-01-01T00:00:00Z",
+			requiredMocks: func() {
+				mock.On("ListDeviceMetrics", gomock.Anything, models.UID("123"), "tenant", to, from).
+					Return(nil, svc.ErrMetricsRangeInvalid).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the device is not found",
+			query: "",
+			requiredMocks: func() {
+				mock.On("ListDeviceMetrics", gomock.Anything, models.UID("123"), "tenant", time.Time{}, time.Time{}).
+					Return(nil, svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the metrics are listed",
+			query: "?from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z",
+			requiredMocks: func() {
+				mock.On("ListDeviceMetrics", gomock.Anything, models.UID("123"), "tenant", from, to).
+					Return([]models.DeviceMetrics{{UID: "123", TenantID: "tenant", Time: from, CPU: 10}}, nil).Once()
+			},
+			expectedStatus:   http.StatusOK,
+			expectedResponse: []models.DeviceMetrics{{UID: "123", TenantID: "tenant", Time: from, CPU: 10}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/123/metrics"+tc.query, nil)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+			if tc.expectedResponse != nil {
+				var metrics []models.DeviceMetrics
+				assert.NoError(t, json.NewDecoder(rec.Body).Decode(&metrics))
+				assert.Equal(t, tc.expectedResponse, metrics)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -23,6 +23,7 @@ const (
 	EditNamespaceUserURL       = "/namespaces/:tenant/members/:uid"
 	GetSessionRecordURL        = "/users/security"
 	EditSessionRecordStatusURL = "/users/security/:tenant"
+	EditNamespaceEnrollmentURL = "/namespaces/:tenant/enrollment"
 )
 
 const (
@@ -302,6 +303,36 @@ func (h *Handler) EditSessionRecordStatus(c gateway.Context) error {
 	return c.NoContent(http.StatusOK)
 }
 
+func (h *Handler) EditNamespaceEnrollment(c gateway.Context) error {
+	var req requests.NamespaceEditEnrollment
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var uid string
+	if c.ID() != nil {
+		uid = c.ID().ID
+	}
+
+	ns, err := h.service.GetNamespace(c.Ctx(), req.Tenant)
+	if err != nil || ns == nil {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	err = guard.EvaluateNamespace(ns, uid, guard.Actions.Namespace.RequireEnrollmentToken, func() error {
+		return h.service.EditNamespaceEnrollment(c.Ctx(), ns.TenantID, req.RequireToken)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
 func (h *Handler) GetSessionRecord(c gateway.Context) error {
 	var tenant string
 	if v := c.Tenant(); v != nil {
@@ -430,3 +430,74 @@ func TestEditNamespace(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestEditNamespaceEnrollment(t *testing.T) {
+	mock := new(mocks.Service)
+
+	namespace := &models.Namespace{
+		Name:     "namespace-name",
+		Owner:    "owner-name",
+		TenantID: "tenant-id",
+		Members: []models.Member{
+			{ID: "123", Username: "owner", Role: guard.RoleOwner},
+			{ID: "456", Username: "operator", Role: guard.RoleOperator},
+		},
+		Settings: &models.NamespaceSettings{},
+	}
+
+	cases := []struct {
+		title          string
+		uid            string
+		req            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the namespace does not exist",
+			uid:   "123",
+			req:   `{"require_token": true}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(nil, svc.ErrNamespaceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "fails when the member is an operator",
+			uid:   "456",
+			req:   `{"require_token": true}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace, nil).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the member is the owner",
+			uid:   "123",
+			req:   `{"require_token": true}`,
+			requiredMocks: func() {
+				mock.On("GetNamespace", gomock.Anything, "tenant-id").Return(namespace, nil).Once()
+				mock.On("EditNamespaceEnrollment", gomock.Anything, "tenant-id", true).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPut, "/api/namespaces/tenant-id/enrollment", strings.NewReader(tc.req))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-ID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
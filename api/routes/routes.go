@@ -35,6 +35,9 @@ func NewRouter(service services.Service) *echo.Echo {
 	internalAPI.POST(HeartbeatDeviceURL, gateway.Handler(handler.HeartbeatDevice))
 	internalAPI.GET(LookupDeviceURL, gateway.Handler(handler.LookupDevice))
 
+	internalAPI.GET(LookupTunnelURL, gateway.Handler(handler.LookupTunnel))
+	internalAPI.GET(LookupTunnelByPortURL, gateway.Handler(handler.LookupTunnelByPort))
+
 	internalAPI.PATCH(SetSessionAuthenticatedURL, gateway.Handler(handler.SetSessionAuthenticated))
 	internalAPI.POST(CreateSessionURL, gateway.Handler(handler.CreateSession))
 	internalAPI.POST(FinishSessionURL, gateway.Handler(handler.FinishSession))
@@ -59,6 +62,7 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.PATCH(UpdateUserDataURL, gateway.Handler(handler.UpdateUserData))
 	publicAPI.PATCH(UpdateUserPasswordURL, gateway.Handler(handler.UpdateUserPassword))
 	publicAPI.PUT(EditSessionRecordStatusURL, gateway.Handler(handler.EditSessionRecordStatus))
+	publicAPI.PUT(EditNamespaceEnrollmentURL, gateway.Handler(handler.EditNamespaceEnrollment))
 	publicAPI.GET(GetSessionRecordURL, gateway.Handler(handler.GetSessionRecord))
 
 	publicAPI.GET(GetDeviceListURL, apiMiddleware.Authorize(gateway.Handler(handler.GetDeviceList)))
@@ -72,6 +76,55 @@ func NewRouter(service services.Service) *echo.Echo {
 	publicAPI.DELETE(RemoveTagURL, gateway.Handler(handler.RemoveDeviceTag))
 	publicAPI.PUT(UpdateTagURL, gateway.Handler(handler.UpdateDeviceTag))
 
+	publicAPI.POST(CreateDeviceMetricsURL, gateway.Handler(handler.CreateDeviceMetrics))
+	publicAPI.GET(ListDeviceMetricsURL, gateway.Handler(handler.ListDeviceMetrics))
+	publicAPI.GET(GetDeviceUpdateTargetURL, gateway.Handler(handler.GetDeviceUpdateTarget))
+	publicAPI.POST(ReportDeviceUpdateURL, gateway.Handler(handler.ReportDeviceUpdate))
+
+	publicAPI.GET(ListTunnelsURL, gateway.Handler(handler.ListTunnels))
+	publicAPI.POST(CreateTunnelURL, gateway.Handler(handler.CreateTunnel))
+	publicAPI.DELETE(DeleteTunnelURL, gateway.Handler(handler.DeleteTunnel))
+	publicAPI.POST(GrantTunnelURL, gateway.Handler(handler.GrantTunnel))
+
+	publicAPI.GET(ListJobsURL, gateway.Handler(handler.ListJobs))
+	publicAPI.POST(CreateJobURL, gateway.Handler(handler.CreateJob))
+	publicAPI.GET(GetJobURL, gateway.Handler(handler.GetJob))
+
+	publicAPI.GET(ListJobSchedulesURL, gateway.Handler(handler.ListJobSchedules))
+	publicAPI.POST(CreateJobScheduleURL, gateway.Handler(handler.CreateJobSchedule))
+	publicAPI.GET(GetJobScheduleURL, gateway.Handler(handler.GetJobSchedule))
+	publicAPI.PUT(UpdateJobScheduleURL, gateway.Handler(handler.UpdateJobSchedule))
+	publicAPI.DELETE(DeleteJobScheduleURL, gateway.Handler(handler.DeleteJobSchedule))
+	publicAPI.GET(ListJobScheduleJobsURL, gateway.Handler(handler.ListJobScheduleJobs))
+
+	publicAPI.GET(ListFilePushesURL, gateway.Handler(handler.ListFilePushes))
+	publicAPI.POST(CreateFilePushURL, gateway.Handler(handler.CreateFilePush))
+	publicAPI.GET(GetFilePushURL, gateway.Handler(handler.GetFilePush))
+	publicAPI.POST(RetryFilePushURL, gateway.Handler(handler.RetryFilePush))
+
+	publicAPI.GET(ListUpdatePoliciesURL, gateway.Handler(handler.ListUpdatePolicies))
+	publicAPI.POST(CreateUpdatePolicyURL, gateway.Handler(handler.CreateUpdatePolicy))
+	publicAPI.GET(GetUpdatePolicyURL, gateway.Handler(handler.GetUpdatePolicy))
+	publicAPI.PUT(UpdateUpdatePolicyURL, gateway.Handler(handler.UpdateUpdatePolicy))
+	publicAPI.DELETE(DeleteUpdatePolicyURL, gateway.Handler(handler.DeleteUpdatePolicy))
+	publicAPI.GET(ListDeviceUpdatesURL, gateway.Handler(handler.ListDeviceUpdates))
+
+	publicAPI.GET(ListDeviceAcceptRulesURL, gateway.Handler(handler.ListDeviceAcceptRules))
+	publicAPI.POST(CreateDeviceAcceptRuleURL, gateway.Handler(handler.CreateDeviceAcceptRule))
+	publicAPI.GET(GetDeviceAcceptRuleURL, gateway.Handler(handler.GetDeviceAcceptRule))
+	publicAPI.PUT(UpdateDeviceAcceptRuleURL, gateway.Handler(handler.UpdateDeviceAcceptRule))
+	publicAPI.DELETE(DeleteDeviceAcceptRuleURL, gateway.Handler(handler.DeleteDeviceAcceptRule))
+
+	publicAPI.GET(ListEnrollmentTokensURL, gateway.Handler(handler.ListEnrollmentTokens))
+	publicAPI.POST(CreateEnrollmentTokenURL, gateway.Handler(handler.CreateEnrollmentToken))
+	publicAPI.GET(GetEnrollmentTokenURL, gateway.Handler(handler.GetEnrollmentToken))
+	publicAPI.DELETE(RevokeEnrollmentTokenURL, gateway.Handler(handler.RevokeEnrollmentToken))
+
+	publicAPI.GET(DeviceFilesURL, gateway.Handler(handler.DeviceFiles))
+	publicAPI.PUT(DeviceFilesURL, gateway.Handler(handler.DeviceFiles))
+	publicAPI.POST(DeviceFilesURL, gateway.Handler(handler.DeviceFiles))
+	publicAPI.DELETE(DeviceFilesURL, gateway.Handler(handler.DeviceFiles))
+
 	publicAPI.GET(GetTagsURL, gateway.Handler(handler.GetTags))
 	publicAPI.PUT(RenameTagURL, gateway.Handler(handler.RenameTag))
 	publicAPI.DELETE(DeleteTagsURL, gateway.Handler(handler.DeleteTag))
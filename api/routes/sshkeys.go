@@ -89,6 +89,10 @@ func (h *Handler) CreatePublicKey(c gateway.Context) error {
 		req.TenantID = tenant
 	}
 
+	if c.ID() != nil {
+		req.UserID = c.ID().ID
+	}
+
 	var res *responses.PublicKeyCreate
 	err := guard.EvaluatePermission(c.Role(), guard.Actions.PublicKey.Create, func() error {
 		var err error
@@ -160,7 +164,12 @@ func (h *Handler) DeletePublicKey(c gateway.Context) error {
 }
 
 func (h *Handler) CreatePrivateKey(c gateway.Context) error {
-	privKey, err := h.service.CreatePrivateKey(c.Ctx())
+	var req requests.PrivateKeyCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	privKey, err := h.service.CreatePrivateKey(c.Ctx(), req)
 	if err != nil {
 		return err
 	}
@@ -415,14 +415,14 @@ func TestCreatePrivateKey(t *testing.T) {
 		{
 			title: "fails when try to deleting an existing public key",
 			requiredMocks: func() {
-				mock.On("CreatePrivateKey", gomock.Anything).Return(nil, svc.ErrNotFound).Once()
+				mock.On("CreatePrivateKey", gomock.Anything, requests.PrivateKeyCreate{}).Return(nil, svc.ErrNotFound).Once()
 			},
 			expectedStatus: http.StatusNotFound,
 		},
 		{
 			title: "success when try to creating an existing private key",
 			requiredMocks: func() {
-				mock.On("CreatePrivateKey", gomock.Anything).Return(&models.PrivateKey{}, nil)
+				mock.On("CreatePrivateKey", gomock.Anything, requests.PrivateKeyCreate{}).Return(&models.PrivateKey{}, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -0,0 +1,173 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListTunnelsURL  = "/devices/:uid/tunnels"
+	CreateTunnelURL = "/devices/:uid/tunnels"
+	DeleteTunnelURL = "/devices/:uid/tunnels/:address"
+	GrantTunnelURL  = "/devices/:uid/tunnels/:address/grants"
+	LookupTunnelURL = "/tunnels/:address"
+	// LookupTunnelByPortURL is the path to get a tunnel in the port mode by its listen port.
+	LookupTunnelByPortURL = "/tunnels/ports/:port"
+)
+
+func (h *Handler) ListTunnels(c gateway.Context) error {
+	var req requests.TunnelList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	tunnels, count, err := h.service.ListTunnels(c.Ctx(), models.UID(req.UID), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, tunnels)
+}
+
+func (h *Handler) CreateTunnel(c gateway.Context) error {
+	var req requests.TunnelCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var tunnel *models.Tunnel
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Tunnel.Create, func() error {
+		var err error
+		tunnel, err = h.service.CreateTunnel(c.Ctx(), models.UID(req.UID), tenant, req.Host, req.Port, req.Mode)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tunnel)
+}
+
+func (h *Handler) DeleteTunnel(c gateway.Context) error {
+	var req requests.TunnelDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Tunnel.Remove, func() error {
+		return h.service.DeleteTunnel(c.Ctx(), models.UID(req.UID), tenant, req.Address)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) GrantTunnel(c gateway.Context) error {
+	var req requests.TunnelGrant
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var user string
+	if c.ID() != nil {
+		user = c.ID().ID
+	}
+
+	var grant *models.TunnelGrant
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.Tunnel.Connect, func() error {
+		var err error
+		grant, err = h.service.GrantTunnel(c.Ctx(), models.UID(req.UID), tenant, req.Address, user, req.Source, time.Duration(req.Duration)*time.Second)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, grant)
+}
+
+func (h *Handler) LookupTunnel(c gateway.Context) error {
+	var req requests.TunnelGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tunnel, err := h.service.LookupTunnel(c.Ctx(), req.Address, req.Source)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tunnel)
+}
+
+func (h *Handler) LookupTunnelByPort(c gateway.Context) error {
+	var req requests.TunnelGetByPort
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	tunnel, err := h.service.LookupTunnelByPort(c.Ctx(), req.Port, req.Source)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, tunnel)
+}
@@ -0,0 +1,377 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListTunnels(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the device is not found",
+			uid:   "1234",
+			requiredMocks: func() {
+				mock.On("ListTunnels", gomock.Anything, models.UID("1234"), "tenant", paginator.Query{Page: 1, PerPage: 10}).
+					Return(nil, 0, svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the device exists",
+			uid:   "123",
+			requiredMocks: func() {
+				mock.On("ListTunnels", gomock.Anything, models.UID("123"), "tenant", paginator.Query{Page: 1, PerPage: 10}).
+					Return([]models.Tunnel{}, 0, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/devices/%s/tunnels?page=1&per_page=10", tc.uid), nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateTunnel(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.TunnelCreate
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the port is invalid",
+			payload: requests.TunnelCreate{
+				DeviceParam: requests.DeviceParam{UID: "123"},
+				Host:        "localhost",
+				Port:        70000,
+			},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the mode is invalid",
+			payload: requests.TunnelCreate{
+				DeviceParam: requests.DeviceParam{UID: "123"},
+				Host:        "localhost",
+				Port:        80,
+				Mode:        "udp",
+			},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is observer",
+			payload: requests.TunnelCreate{
+				DeviceParam: requests.DeviceParam{UID: "123"},
+				Host:        "localhost",
+				Port:        80,
+			},
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the device is not found",
+			payload: requests.TunnelCreate{
+				DeviceParam: requests.DeviceParam{UID: "1234"},
+				Host:        "localhost",
+				Port:        80,
+			},
+			role: guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateTunnel", gomock.Anything, models.UID("1234"), "tenant", "localhost", 80, "").
+					Return(nil, svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the device exists",
+			payload: requests.TunnelCreate{
+				DeviceParam: requests.DeviceParam{UID: "123"},
+				Host:        "localhost",
+				Port:        80,
+			},
+			role: guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("CreateTunnel", gomock.Anything, models.UID("123"), "tenant", "localhost", 80, "").
+					Return(&models.Tunnel{Address: "address", Device: "123", Host: "localhost", Port: 80}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/devices/%s/tunnels", tc.payload.UID), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteTunnel(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		address        string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:   "fails when the tunnel is not found",
+			uid:     "123",
+			address: "address",
+			requiredMocks: func() {
+				mock.On("DeleteTunnel", gomock.Anything, models.UID("123"), "tenant", "address").
+					Return(svc.ErrTunnelNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "success when the tunnel exists",
+			uid:     "123",
+			address: "other",
+			requiredMocks: func() {
+				mock.On("DeleteTunnel", gomock.Anything, models.UID("123"), "tenant", "other").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/devices/%s/tunnels/%s", tc.uid, tc.address), nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", guard.RoleOwner)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGrantTunnel(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		payload        requests.TunnelGrant
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title: "fails when the source is invalid",
+			payload: requests.TunnelGrant{
+				DeviceParam:        requests.DeviceParam{UID: "123"},
+				TunnelAddressParam: requests.TunnelAddressParam{Address: "address"},
+				Source:             "localhost",
+			},
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "fails when the role is observer",
+			payload: requests.TunnelGrant{
+				DeviceParam:        requests.DeviceParam{UID: "123"},
+				TunnelAddressParam: requests.TunnelAddressParam{Address: "address"},
+				Source:             "10.0.0.1",
+			},
+			role:           guard.RoleObserver,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "success when the tunnel exists",
+			payload: requests.TunnelGrant{
+				DeviceParam:        requests.DeviceParam{UID: "123"},
+				TunnelAddressParam: requests.TunnelAddressParam{Address: "address"},
+				Source:             "10.0.0.1",
+				Duration:           600,
+			},
+			role: guard.RoleOperator,
+			requiredMocks: func() {
+				mock.On("GrantTunnel", gomock.Anything, models.UID("123"), "tenant", "address", "user", "10.0.0.1", 10*time.Minute).
+					Return(&models.TunnelGrant{Source: "10.0.0.1", User: "user"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/devices/%s/tunnels/%s/grants", tc.payload.UID, tc.payload.Address), strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			req.Header.Set("X-ID", "user")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestLookupTunnel(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		address        string
+		source         string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the source is missing",
+			address:        "address",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:   "fails when the tunnel is not found",
+			address: "address",
+			source:  "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("LookupTunnel", gomock.Anything, "address", "10.0.0.1").
+					Return(nil, svc.ErrTunnelNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title:   "fails when the access is not granted",
+			address: "address",
+			source:  "10.0.0.2",
+			requiredMocks: func() {
+				mock.On("LookupTunnel", gomock.Anything, "address", "10.0.0.2").
+					Return(nil, svc.ErrTunnelForbidden).Once()
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "success when the tunnel exists",
+			address: "other",
+			source:  "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("LookupTunnel", gomock.Anything, "other", "10.0.0.1").
+					Return(&models.Tunnel{Address: "other", Device: "123", Host: "localhost", Port: 80}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/internal/tunnels/%s?source=%s", tc.address, tc.source), nil)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestLookupTunnelByPort(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("LookupTunnelByPort", gomock.Anything, 40000, "10.0.0.1").
+		Return(&models.Tunnel{Address: "address", Device: "123", Host: "localhost", Port: 80, ListenPort: 40000}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/internal/tunnels/ports/40000?source=10.0.0.1", nil)
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
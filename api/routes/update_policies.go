@@ -0,0 +1,225 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/shellhub-io/shellhub/api/pkg/gateway"
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	client "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	ListUpdatePoliciesURL = "/updates/policies"
+	CreateUpdatePolicyURL = "/updates/policies"
+	GetUpdatePolicyURL    = "/updates/policies/:id"
+	UpdateUpdatePolicyURL = "/updates/policies/:id"
+	DeleteUpdatePolicyURL = "/updates/policies/:id"
+	ListDeviceUpdatesURL  = "/updates/devices"
+
+	GetDeviceUpdateTargetURL = "/devices/update"
+	ReportDeviceUpdateURL    = "/devices/update"
+)
+
+func (h *Handler) ListUpdatePolicies(c gateway.Context) error {
+	var req requests.UpdatePolicyList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	policies, count, err := h.service.ListUpdatePolicies(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, policies)
+}
+
+func (h *Handler) GetUpdatePolicy(c gateway.Context) error {
+	var req requests.UpdatePolicyGet
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	policy, err := h.service.GetUpdatePolicy(c.Ctx(), tenant, req.ID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *Handler) CreateUpdatePolicy(c gateway.Context) error {
+	var req requests.UpdatePolicyCreate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var policy *models.UpdatePolicy
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.UpdatePolicy.Create, func() error {
+		var err error
+		policy, err = h.service.CreateUpdatePolicy(c.Ctx(), tenant, req.UpdatePolicyData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *Handler) UpdateUpdatePolicy(c gateway.Context) error {
+	var req requests.UpdatePolicyUpdate
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	var policy *models.UpdatePolicy
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.UpdatePolicy.Update, func() error {
+		var err error
+		policy, err = h.service.UpdateUpdatePolicy(c.Ctx(), tenant, req.ID, req.UpdatePolicyData)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, policy)
+}
+
+func (h *Handler) DeleteUpdatePolicy(c gateway.Context) error {
+	var req requests.UpdatePolicyDelete
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	err := guard.EvaluatePermission(c.Role(), guard.Actions.UpdatePolicy.Remove, func() error {
+		return h.service.DeleteUpdatePolicy(c.Ctx(), tenant, req.ID)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (h *Handler) ListDeviceUpdates(c gateway.Context) error {
+	var req requests.DeviceUpdateList
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	req.Normalize()
+
+	var tenant string
+	if c.Tenant() != nil {
+		tenant = c.Tenant().ID
+	}
+
+	states, count, err := h.service.ListDeviceUpdates(c.Ctx(), tenant, req.Query)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(count))
+
+	return c.JSON(http.StatusOK, states)
+}
+
+// GetDeviceUpdateTarget replies to the device's poll of its update policy with the version it should update to. The
+// device is identified by the header set by the gateway from the device's token.
+func (h *Handler) GetDeviceUpdateTarget(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	target, err := h.service.GetDeviceUpdateTarget(c.Ctx(), models.UID(uid))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, target)
+}
+
+// ReportDeviceUpdate stores the update attempt reported by a device. The device is identified by the header set by the
+// gateway from the device's token.
+func (h *Handler) ReportDeviceUpdate(c gateway.Context) error {
+	uid := c.Request().Header.Get(client.DeviceUIDHeader)
+	if uid == "" {
+		return svc.NewErrAuthUnathorized(nil)
+	}
+
+	var req requests.DeviceUpdateReport
+	if err := c.Bind(&req); err != nil {
+		return err
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	if err := h.service.ReportDeviceUpdate(c.Ctx(), models.UID(uid), req); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusOK)
+}
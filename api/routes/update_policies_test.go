@@ -0,0 +1,312 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	svc "github.com/shellhub-io/shellhub/api/services"
+	"github.com/shellhub-io/shellhub/api/services/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	gomock "github.com/stretchr/testify/mock"
+)
+
+func TestListUpdatePolicies(t *testing.T) {
+	mock := new(mocks.Service)
+
+	mock.On("ListUpdatePolicies", gomock.Anything, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+		Return([]models.UpdatePolicy{}, 0, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/updates/policies?page=1&per_page=10", nil)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Role", guard.RoleObserver)
+	req.Header.Set("X-Tenant-ID", "tenant")
+	rec := httptest.NewRecorder()
+
+	e := NewRouter(mock)
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateUpdatePolicy(t *testing.T) {
+	mock := new(mocks.Service)
+
+	canary := 10
+	invalid := 101
+
+	data := requests.UpdatePolicyData{
+		Name:    "canary",
+		Tags:    []string{"production"},
+		Version: "v0.15.0",
+		Canary:  &canary,
+		Windows: []requests.UpdateWindowData{{Days: []int{6, 0}, Start: "22:00", End: "04:00"}},
+	}
+
+	cases := []struct {
+		title          string
+		payload        requests.UpdatePolicyData
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the version is empty",
+			payload:        requests.UpdatePolicyData{Name: "canary"},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the canary is greater than 100",
+			payload:        requests.UpdatePolicyData{Name: "canary", Version: "v0.15.0", Canary: &invalid},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the window's day is invalid",
+			payload:        requests.UpdatePolicyData{Name: "canary", Version: "v0.15.0", Windows: []requests.UpdateWindowData{{Days: []int{7}, Start: "22:00", End: "04:00"}}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the window's time is invalid",
+			payload:        requests.UpdatePolicyData{Name: "canary", Version: "v0.15.0", Windows: []requests.UpdateWindowData{{Start: "10pm", End: "04:00"}}},
+			role:           guard.RoleOwner,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:          "fails when the role is operator",
+			payload:        data,
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title:   "fails when the version is invalid",
+			payload: requests.UpdatePolicyData{Name: "canary", Version: "latest"},
+			role:    guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("CreateUpdatePolicy", gomock.Anything, "tenant", requests.UpdatePolicyData{Name: "canary", Version: "latest"}).
+					Return(nil, svc.ErrUpdatePolicyVersionInvalid).Once()
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title:   "success when the data is valid",
+			payload: data,
+			role:    guard.RoleAdministrator,
+			requiredMocks: func() {
+				mock.On("CreateUpdatePolicy", gomock.Anything, "tenant", data).
+					Return(&models.UpdatePolicy{ID: "id", Name: "canary"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			jsonData, err := json.Marshal(tc.payload)
+			if err != nil {
+				assert.NoError(t, err)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/updates/policies", strings.NewReader(string(jsonData)))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteUpdatePolicy(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		id             string
+		role           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the role is operator",
+			id:             "id",
+			role:           guard.RoleOperator,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			title: "fails when the policy is not found",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("DeleteUpdatePolicy", gomock.Anything, "tenant", "id").
+					Return(svc.ErrUpdatePolicyNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the policy is deleted",
+			id:    "id",
+			role:  guard.RoleOwner,
+			requiredMocks: func() {
+				mock.On("DeleteUpdatePolicy", gomock.Anything, "tenant", "id").
+					Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodDelete, "/api/updates/policies/"+tc.id, nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Role", tc.role)
+			req.Header.Set("X-Tenant-ID", "tenant")
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceUpdateTarget(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is not identified",
+			uid:            "",
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title: "fails when the device is not found",
+			uid:   "123",
+			requiredMocks: func() {
+				mock.On("GetDeviceUpdateTarget", gomock.Anything, models.UID("123")).
+					Return(nil, svc.ErrDeviceNotFound).Once()
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			title: "success when the target is got",
+			uid:   "123",
+			requiredMocks: func() {
+				mock.On("GetDeviceUpdateTarget", gomock.Anything, models.UID("123")).
+					Return(&models.DeviceUpdateTarget{Version: "v0.15.0"}, nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/devices/update", nil)
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Device-UID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestReportDeviceUpdate(t *testing.T) {
+	mock := new(mocks.Service)
+
+	cases := []struct {
+		title          string
+		uid            string
+		body           string
+		requiredMocks  func()
+		expectedStatus int
+	}{
+		{
+			title:          "fails when the device is not identified",
+			uid:            "",
+			body:           `{"version": "v0.15.0", "status": "updating"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			title:          "fails when the status is invalid",
+			uid:            "123",
+			body:           `{"version": "v0.15.0", "status": "updated"}`,
+			requiredMocks:  func() {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			title: "success when the update is reported",
+			uid:   "123",
+			body:  `{"version": "v0.15.0", "status": "failed", "error": "signature does not match"}`,
+			requiredMocks: func() {
+				mock.On("ReportDeviceUpdate", gomock.Anything, models.UID("123"), requests.DeviceUpdateReport{
+					Version: "v0.15.0",
+					Status:  "failed",
+					Error:   "signature does not match",
+				}).Return(nil).Once()
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.title, func(t *testing.T) {
+			tc.requiredMocks()
+
+			req := httptest.NewRequest(http.MethodPost, "/api/devices/update", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Device-UID", tc.uid)
+			rec := httptest.NewRecorder()
+
+			e := NewRouter(mock)
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.expectedStatus, rec.Result().StatusCode)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
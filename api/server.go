@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/getsentry/sentry-go"
+	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	echoMiddleware "github.com/labstack/echo/v4/middleware"
 	"github.com/shellhub-io/shellhub/api/pkg/echo/handlers"
@@ -126,7 +127,19 @@ func startServer(cfg *config, store store.Store, cache storecache.Cache) error {
 
 	log.Info("Starting API server")
 
-	requestClient := requests.NewClient()
+	options, err := asynq.ParseRedisURI(cfg.RedisURI)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to parse redis uri")
+	}
+
+	// withAsynq sets the Asynq client to the API internal client, what is used to enqueue the jobs' executions.
+	withAsynq := func(o *requests.Options) error {
+		o.Asynq = asynq.NewClient(options)
+
+		return nil
+	}
+
+	requestClient := requests.NewClient(withAsynq)
 
 	var locator geoip.Locator
 	if cfg.GeoIP {
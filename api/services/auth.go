@@ -15,6 +15,7 @@ import (
 
 	"github.com/cnf/structhash"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	"github.com/shellhub-io/shellhub/pkg/clock"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -67,11 +68,15 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	type Device struct {
 		Name      string
 		Namespace string
+		// Info is the hash of the device's info, used to update the device when its info changes.
+		Info string
 	}
 
+	infoHash := hex.EncodeToString(structhash.Md5(req.Info, 1))
+
 	var value *Device
 
-	if err := s.cache.Get(ctx, strings.Join([]string{"auth_device", key}, "/"), &value); err == nil && value != nil {
+	if err := s.cache.Get(ctx, strings.Join([]string{"auth_device", key}, "/"), &value); err == nil && value != nil && value.Info == infoHash {
 		return &models.DeviceAuthResponse{
 			UID:       key,
 			Token:     tokenStr,
@@ -82,11 +87,12 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	var info *models.DeviceInfo
 	if req.Info != nil {
 		info = &models.DeviceInfo{
-			ID:         req.Info.ID,
-			PrettyName: req.Info.PrettyName,
-			Version:    req.Info.Version,
-			Arch:       req.Info.Arch,
-			Platform:   req.Info.Platform,
+			ID:              req.Info.ID,
+			PrettyName:      req.Info.PrettyName,
+			Version:         req.Info.Version,
+			Arch:            req.Info.Arch,
+			Platform:        req.Info.Platform,
+			DeviceInventory: req.Info.DeviceInventory,
 		}
 	}
 	device := models.Device{
@@ -105,12 +111,42 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 		return nil, NewErrNamespaceNotFound(device.TenantID, err)
 	}
 
+	// NOTICE: The device is stored on every authentication, so it is looked up before to know whether it registers for
+	// the first time.
+	_, err = s.store.DeviceGetByUID(ctx, models.UID(device.UID), device.TenantID)
+	if err != nil && err != store.ErrNoDocuments {
+		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
+	}
+
+	registered := err == nil
+
+	// NOTICE: The enrollment token is checked only when the device registers for the first time, so the devices
+	// registered before the namespace required one, or with a token revoked since, keep connecting.
+	var enrollment *models.EnrollmentToken
+	if !registered {
+		if enrollment, err = s.enrollDevice(ctx, namespace, req.EnrollmentToken); err != nil {
+			return nil, err
+		}
+	}
+
+	if enrollment != nil {
+		device.EnrollmentToken = enrollment.ID
+	}
+
 	hostname := strings.ToLower(req.Hostname)
 
 	if err := s.store.DeviceCreate(ctx, device, hostname); err != nil {
 		return nil, NewErrDeviceCreate(device, err)
 	}
 
+	// NOTICE: The enrollment token's use is only counted after the device is stored, so it isn't used by a device that
+	// fails to register.
+	if enrollment != nil {
+		if err := s.useEnrollmentToken(ctx, enrollment, models.UID(device.UID)); err != nil {
+			return nil, err
+		}
+	}
+
 	for _, uid := range req.Sessions {
 		if err := s.store.SessionSetLastSeen(ctx, models.UID(uid)); err != nil {
 			continue
@@ -121,7 +157,37 @@ func (s *service) AuthDevice(ctx context.Context, req requests.DeviceAuth, remot
 	if err != nil {
 		return nil, NewErrDeviceNotFound(models.UID(device.UID), err)
 	}
-	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name}, time.Second*30); err != nil {
+
+	// NOTICE: The tags requested by the device are only applied when it registers for the first time, so the ones set,
+	// or removed, by the namespace's members aren't overwritten when the device reconnects. The enrollment token's tags
+	// take precedence over them.
+	tags := req.Tags
+	if enrollment != nil && len(enrollment.Tags) > 0 {
+		tags = enrollment.Tags
+	}
+
+	if len(tags) > 0 && !registered {
+		if err := s.store.DeviceUpdateTag(ctx, models.UID(device.UID), tags); err != nil {
+			return nil, err
+		}
+	}
+
+	// NOTICE: The pending devices are evaluated against the namespace's auto-accept rules every time they register, so
+	// a rule also accepts the devices registered before it was created. A device registered with an enrollment token
+	// that accepts its devices is accepted by the token instead.
+	if dev.Status == models.DeviceStatusPending {
+		if enrollment != nil && enrollment.Status == models.DeviceStatusAccepted {
+			err = s.acceptDevice(ctx, dev, models.DeviceAcceptance{EnrollmentToken: enrollment.ID, Name: enrollment.Name})
+		} else {
+			err = s.autoAcceptDevice(ctx, dev, hostname, req.EnrollmentToken)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.cache.Set(ctx, strings.Join([]string{"auth_device", key}, "/"), &Device{Name: dev.Name, Namespace: namespace.Name, Info: infoHash}, time.Second*30); err != nil {
 		return nil, err
 	}
 
@@ -305,6 +371,7 @@ func (s *service) AuthPublicKey(ctx context.Context, req requests.PublicKeyAuth)
 
 	return &models.PublicKeyAuthResponse{
 		Signature: base64.StdEncoding.EncodeToString(signature),
+		Identity:  privKey.Identity,
 	}, nil
 }
 
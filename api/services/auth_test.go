@@ -12,6 +12,7 @@ import (
 	"github.com/cnf/structhash"
 	"github.com/shellhub-io/shellhub/api/store"
 	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
 	"github.com/shellhub-io/shellhub/pkg/api/requests"
 	storecache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/errors"
@@ -60,7 +61,7 @@ func TestAuthDevice(t *testing.T) {
 	mock.On("SessionSetLastSeen", ctx, models.UID(authReq.Sessions[0])).
 		Return(nil).Once()
 	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
-		Return(device, nil).Once()
+		Return(device, nil).Twice()
 	mock.On("NamespaceGet", ctx, namespace.TenantID).
 		Return(namespace, nil).Once()
 
@@ -86,6 +87,378 @@ func TestAuthDevice(t *testing.T) {
 	mock.AssertExpectations(t)
 }
 
+func TestAuthDeviceInventory(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	inventory := models.DeviceInventory{
+		Kernel:   "6.1.0",
+		Hostname: "device",
+		Memory:   2048,
+		Interfaces: []models.DeviceInventoryInterface{
+			{Name: "eth0", MAC: "mac", IPs: []string{"192.168.0.2"}},
+		},
+		Docker: true,
+	}
+
+	authReq := requests.DeviceAuth{
+		TenantID: "tenant",
+		Identity: &requests.DeviceIdentity{
+			MAC: "mac",
+		},
+		Info: &requests.DeviceInfo{
+			ID:              "debian",
+			PrettyName:      "Debian GNU/Linux 12",
+			DeviceInventory: inventory,
+		},
+	}
+
+	auth := models.DeviceAuth{
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		TenantID: authReq.TenantID,
+	}
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+	device := &models.Device{
+		UID: hex.EncodeToString(uid[:]),
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		Info: &models.DeviceInfo{
+			ID:              "debian",
+			PrettyName:      "Debian GNU/Linux 12",
+			DeviceInventory: inventory,
+		},
+		TenantID:   authReq.TenantID,
+		LastSeen:   now,
+		RemoteAddr: "0.0.0.0",
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
+
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceCreate", ctx, *device, "").
+		Return(nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(device, nil).Twice()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	authRes, err := service.AuthDevice(ctx, authReq, "0.0.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, device.UID, authRes.UID)
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthDeviceTags(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	authReq := requests.DeviceAuth{
+		TenantID: "tenant",
+		Identity: &requests.DeviceIdentity{
+			MAC: "mac",
+		},
+		Info: &requests.DeviceInfo{
+			ID:         "docker",
+			PrettyName: "nginx",
+		},
+		Tags: []string{"web", "production"},
+	}
+
+	auth := models.DeviceAuth{
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		TenantID: authReq.TenantID,
+	}
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+	device := &models.Device{
+		UID: hex.EncodeToString(uid[:]),
+		Identity: &models.DeviceIdentity{
+			MAC: authReq.Identity.MAC,
+		},
+		Info: &models.DeviceInfo{
+			ID:         "docker",
+			PrettyName: "nginx",
+		},
+		TenantID:   authReq.TenantID,
+		LastSeen:   now,
+		RemoteAddr: "0.0.0.0",
+	}
+
+	clockMock.On("Now").Return(now).Once()
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
+
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceCreate", ctx, *device, "").
+		Return(nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(device, nil).Once()
+	mock.On("DeviceUpdateTag", ctx, models.UID(device.UID), []string{"web", "production"}).
+		Return(nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	authRes, err := service.AuthDevice(ctx, authReq, "0.0.0.0")
+	assert.NoError(t, err)
+	assert.Equal(t, device.UID, authRes.UID)
+
+	mock.AssertExpectations(t)
+
+	t.Run("does not apply the tags to a registered device", func(t *testing.T) {
+		mock := new(mocks.Store)
+
+		clockMock.On("Now").Return(now).Once()
+
+		mock.On("NamespaceGet", ctx, namespace.TenantID).
+			Return(namespace, nil).Once()
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+			Return(device, nil).Twice()
+		mock.On("DeviceCreate", ctx, *device, "").
+			Return(nil).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+		_, err := service.AuthDevice(ctx, authReq, "0.0.0.0")
+		assert.NoError(t, err)
+
+		mock.AssertExpectations(t)
+	})
+}
+
+func TestAuthDeviceAutoAccept(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	authReq := requests.DeviceAuth{
+		TenantID: "tenant",
+		Hostname: "web-01",
+		Info: &requests.DeviceInfo{
+			ID:       "ubuntu",
+			Platform: "docker",
+		},
+		EnrollmentToken: "b3f1c2d4e6",
+	}
+
+	auth := models.DeviceAuth{
+		Hostname: authReq.Hostname,
+		TenantID: authReq.TenantID,
+	}
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+	device := &models.Device{
+		UID: hex.EncodeToString(uid[:]),
+		Info: &models.DeviceInfo{
+			ID:       "ubuntu",
+			Platform: "docker",
+		},
+		TenantID:   authReq.TenantID,
+		LastSeen:   now,
+		RemoteAddr: "10.1.2.3",
+	}
+
+	pending := *device
+	pending.Name = "web-01"
+	pending.Status = models.DeviceStatusPending
+
+	clockMock.On("Now").Return(now).Once()
+	namespace := &models.Namespace{Name: "group1", Owner: "hash1", TenantID: "tenant"}
+
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("EnrollmentTokenGetByHash", ctx, "tenant", models.HashEnrollmentToken("b3f1c2d4e6")).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceCreate", ctx, *device, "web-01").
+		Return(nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(&pending, nil).Once()
+	mock.On("DeviceAcceptRuleList", ctx, "tenant", paginator.Query{Page: -1, PerPage: -1}).
+		Return([]models.DeviceAcceptRule{{ID: "token", Name: "token", TokenHash: models.HashEnrollmentToken("b3f1c2d4e5")}}, 1, nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	authRes, err := service.AuthDevice(ctx, authReq, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, device.UID, authRes.UID)
+
+	mock.AssertExpectations(t)
+}
+
+func TestAuthDeviceEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	authReq := requests.DeviceAuth{
+		TenantID: "tenant",
+		Hostname: "web-01",
+		Identity: &requests.DeviceIdentity{
+			MAC: "00:1a:2b:3c:4d:5e",
+		},
+		Tags:            []string{"web"},
+		EnrollmentToken: "f1e2d3c4b5",
+	}
+
+	auth := models.DeviceAuth{
+		Hostname: authReq.Hostname,
+		Identity: &models.DeviceIdentity{MAC: authReq.Identity.MAC},
+		TenantID: authReq.TenantID,
+	}
+	uid := sha256.Sum256(structhash.Dump(auth, 1))
+	device := &models.Device{
+		UID:        hex.EncodeToString(uid[:]),
+		Identity:   &models.DeviceIdentity{MAC: authReq.Identity.MAC},
+		TenantID:   authReq.TenantID,
+		LastSeen:   now,
+		RemoteAddr: "10.1.2.3",
+	}
+
+	namespace := &models.Namespace{
+		Name:     "group1",
+		Owner:    "hash1",
+		TenantID: "tenant",
+		Settings: &models.NamespaceSettings{RequireEnrollmentToken: true},
+	}
+
+	token := &models.EnrollmentToken{
+		ID:       "id",
+		TenantID: "tenant",
+		Name:     "fleet",
+		Hash:     models.HashEnrollmentToken("f1e2d3c4b5"),
+		Tags:     []string{"production"},
+		Status:   models.DeviceStatusAccepted,
+	}
+
+	enrolled := *device
+	enrolled.EnrollmentToken = "id"
+
+	pending := enrolled
+	pending.Name = "web-01"
+	pending.Status = models.DeviceStatusPending
+
+	clockMock.On("Now").Return(now).Times(4)
+
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("EnrollmentTokenGetByHash", ctx, "tenant", token.Hash).
+		Return(token, nil).Once()
+	mock.On("DeviceCreate", ctx, enrolled, "web-01").
+		Return(nil).Once()
+	mock.On("EnrollmentTokenUse", ctx, "id", now).
+		Return(nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(&pending, nil).Once()
+	mock.On("DeviceUpdateTag", ctx, models.UID(device.UID), []string{"production"}).
+		Return(nil).Once()
+	mock.On("NamespaceGet", ctx, namespace.TenantID).
+		Return(namespace, nil).Once()
+	mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+		Return(&pending, nil).Once()
+	mock.On("DeviceGetByMac", ctx, "00:1a:2b:3c:4d:5e", "tenant", models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).Once()
+	mock.On("DeviceGetByName", ctx, "web-01", "tenant", models.DeviceStatusAccepted).
+		Return(nil, store.ErrNoDocuments).Once()
+	envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+	envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+	mock.On("DeviceUpdateStatus", ctx, models.UID(device.UID), models.DeviceStatusAccepted).
+		Return(nil).Once()
+	mock.On("DeviceSetAcceptance", ctx, models.UID(device.UID), &models.DeviceAcceptance{EnrollmentToken: "id", Name: "fleet", AcceptedAt: now}).
+		Return(nil).Once()
+
+	service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	authRes, err := service.AuthDevice(ctx, authReq, "10.1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, device.UID, authRes.UID)
+
+	mock.AssertExpectations(t)
+
+	t.Run("fails when the namespace requires a token and none is sent", func(t *testing.T) {
+		mock := new(mocks.Store)
+
+		req := authReq
+		req.EnrollmentToken = ""
+
+		clockMock.On("Now").Return(now).Once()
+
+		mock.On("NamespaceGet", ctx, namespace.TenantID).
+			Return(namespace, nil).Once()
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+			Return(nil, store.ErrNoDocuments).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+		_, err := service.AuthDevice(ctx, req, "10.1.2.3")
+		assert.Equal(t, NewErrEnrollmentTokenRequired(nil), err)
+
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("does not use the token when the device fails to be stored", func(t *testing.T) {
+		mock := new(mocks.Store)
+
+		clockMock.On("Now").Return(now).Twice()
+
+		mock.On("NamespaceGet", ctx, namespace.TenantID).
+			Return(namespace, nil).Once()
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+			Return(nil, store.ErrNoDocuments).Once()
+		mock.On("EnrollmentTokenGetByHash", ctx, "tenant", token.Hash).
+			Return(token, nil).Once()
+		mock.On("DeviceCreate", ctx, enrolled, "web-01").
+			Return(errors.New("error", "", 0)).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+		_, err := service.AuthDevice(ctx, authReq, "10.1.2.3")
+		assert.Equal(t, NewErrDeviceCreate(enrolled, errors.New("error", "", 0)), err)
+
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("succeeds without a token when the device is already registered", func(t *testing.T) {
+		mock := new(mocks.Store)
+
+		req := authReq
+		req.EnrollmentToken = ""
+
+		accepted := *device
+		accepted.Name = "web-01"
+		accepted.Status = models.DeviceStatusAccepted
+
+		clockMock.On("Now").Return(now).Once()
+
+		mock.On("NamespaceGet", ctx, namespace.TenantID).
+			Return(namespace, nil).Once()
+		mock.On("DeviceGetByUID", ctx, models.UID(device.UID), device.TenantID).
+			Return(&accepted, nil).Twice()
+		mock.On("DeviceCreate", ctx, *device, "web-01").
+			Return(nil).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+		authRes, err := service.AuthDevice(ctx, req, "10.1.2.3")
+		assert.NoError(t, err)
+		assert.Equal(t, device.UID, authRes.UID)
+
+		mock.AssertExpectations(t)
+	})
+}
+
 func TestAuthUser(t *testing.T) {
 	mock := new(mocks.Store)
 
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"net"
+	"path"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type DeviceAcceptRuleService interface {
+	ListDeviceAcceptRules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error)
+	GetDeviceAcceptRule(ctx context.Context, tenant, id string) (*models.DeviceAcceptRule, error)
+	CreateDeviceAcceptRule(ctx context.Context, tenant string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error)
+	UpdateDeviceAcceptRule(ctx context.Context, tenant, id string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error)
+	DeleteDeviceAcceptRule(ctx context.Context, tenant, id string) error
+}
+
+// ListDeviceAcceptRules lists the auto-accept rules from a namespace.
+func (s *service) ListDeviceAcceptRules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error) {
+	return s.store.DeviceAcceptRuleList(ctx, tenant, pagination)
+}
+
+// GetDeviceAcceptRule gets an auto-accept rule from a namespace.
+func (s *service) GetDeviceAcceptRule(ctx context.Context, tenant, id string) (*models.DeviceAcceptRule, error) {
+	rule, err := s.store.DeviceAcceptRuleGet(ctx, id)
+	if err != nil {
+		return nil, NewErrDeviceAcceptRuleNotFound(id, err)
+	}
+
+	if rule.TenantID != tenant {
+		return nil, NewErrDeviceAcceptRuleNotFound(id, nil)
+	}
+
+	return rule, nil
+}
+
+// CreateDeviceAcceptRule creates an auto-accept rule to a namespace. The pending devices are evaluated against it the
+// next time they register.
+func (s *service) CreateDeviceAcceptRule(ctx context.Context, tenant string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error) {
+	rule := new(models.DeviceAcceptRule)
+	if err := applyDeviceAcceptRuleData(rule, data); err != nil {
+		return nil, err
+	}
+
+	rule.ID = uuid.Generate()
+	rule.TenantID = tenant
+	rule.CreatedAt = clock.Now()
+	rule.UpdatedAt = rule.CreatedAt
+
+	if err := s.store.DeviceAcceptRuleCreate(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// UpdateDeviceAcceptRule replaces the conditions of an auto-accept rule from a namespace. The devices already accepted
+// by it are kept.
+func (s *service) UpdateDeviceAcceptRule(ctx context.Context, tenant, id string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error) {
+	rule, err := s.GetDeviceAcceptRule(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyDeviceAcceptRuleData(rule, data); err != nil {
+		return nil, err
+	}
+
+	rule.UpdatedAt = clock.Now()
+
+	if err := s.store.DeviceAcceptRuleUpdate(ctx, rule); err != nil {
+		return nil, NewErrDeviceAcceptRuleNotFound(id, err)
+	}
+
+	return rule, nil
+}
+
+// DeleteDeviceAcceptRule deletes an auto-accept rule from a namespace. The devices already accepted by it are kept.
+func (s *service) DeleteDeviceAcceptRule(ctx context.Context, tenant, id string) error {
+	if err := s.store.DeviceAcceptRuleDelete(ctx, tenant, id); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return NewErrDeviceAcceptRuleNotFound(id, err)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyDeviceAcceptRuleData validates the conditions sent by the user and sets them to the rule.
+func applyDeviceAcceptRuleData(rule *models.DeviceAcceptRule, data requests.DeviceAcceptRuleData) error {
+	rule.Name = data.Name
+	rule.MACPrefixes = data.MACPrefixes
+	rule.Hostname = data.Hostname
+	rule.Info = data.Info
+	rule.Sources = data.Sources
+
+	// NOTICE: The token is stored hashed, so it is kept when it isn't sent again to update the rule.
+	if data.Token != "" {
+		rule.TokenHash = models.HashEnrollmentToken(data.Token)
+	}
+
+	if rule.MACPrefixes == nil {
+		rule.MACPrefixes = []string{}
+	}
+
+	if rule.Info == nil {
+		rule.Info = map[string]string{}
+	}
+
+	if rule.Sources == nil {
+		rule.Sources = []string{}
+	}
+
+	if rule.IsEmpty() {
+		return NewErrDeviceAcceptRuleEmpty(nil)
+	}
+
+	if _, err := path.Match(rule.Hostname, ""); err != nil {
+		return NewErrDeviceAcceptRuleInvalid("hostname", rule.Hostname, err)
+	}
+
+	for field, pattern := range rule.Info {
+		if _, ok := models.DeviceAcceptRuleInfoFields[field]; !ok {
+			return NewErrDeviceAcceptRuleInvalid("info", field, nil)
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return NewErrDeviceAcceptRuleInvalid("info", pattern, err)
+		}
+	}
+
+	for _, source := range rule.Sources {
+		if _, _, err := net.ParseCIDR(source); err != nil && net.ParseIP(source) == nil {
+			return NewErrDeviceAcceptRuleInvalid("sources", source, err)
+		}
+	}
+
+	return nil
+}
+
+// autoAcceptDevice accepts the pending device, registering with the hostname and the enrollment token, when it matches
+// any of its namespace's auto-accept rules, the oldest first, recording the rule that accepted it.
+func (s *service) autoAcceptDevice(ctx context.Context, device *models.Device, hostname, token string) error {
+	rules, _, err := s.store.DeviceAcceptRuleList(ctx, device.TenantID, paginator.Query{Page: -1, PerPage: -1})
+	if err != nil {
+		return err
+	}
+
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(device, hostname, token) {
+			continue
+		}
+
+		return s.acceptDevice(ctx, device, models.DeviceAcceptance{Rule: rule.ID, Name: rule.Name})
+	}
+
+	return nil
+}
+
+// acceptDevice accepts the pending device automatically, recording what accepted it. When the device can't be
+// accepted, like when the namespace has reached its devices' limit, it is kept pending to be accepted by the
+// namespace's members.
+func (s *service) acceptDevice(ctx context.Context, device *models.Device, acceptance models.DeviceAcceptance) error {
+	if err := s.UpdateDeviceStatus(ctx, device.TenantID, models.UID(device.UID), models.DeviceStatusAccepted); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"uid":              device.UID,
+			"tenant_id":        device.TenantID,
+			"rule":             acceptance.Rule,
+			"enrollment_token": acceptance.EnrollmentToken,
+		}).Warn("failed to accept the device automatically")
+
+		return nil
+	}
+
+	acceptance.AcceptedAt = clock.Now()
+
+	return s.store.DeviceSetAcceptance(ctx, models.UID(device.UID), &acceptance)
+}
@@ -0,0 +1,510 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"net"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDeviceAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	_, _, errSource := net.ParseCIDR("10.0.0.0/33")
+
+	type Expected struct {
+		rule *models.DeviceAcceptRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		data          requests.DeviceAcceptRuleData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the rule has no conditions",
+			tenant:        "tenant",
+			data:          requests.DeviceAcceptRuleData{Name: "office"},
+			requiredMocks: func() {},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrDeviceAcceptRuleEmpty(nil),
+			},
+		},
+		{
+			description:   "fails when the info's field is unknown",
+			tenant:        "tenant",
+			data:          requests.DeviceAcceptRuleData{Name: "office", Info: map[string]string{"owner": "admin"}, Token: "b3f1c2d4e5"},
+			requiredMocks: func() {},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrDeviceAcceptRuleInvalid("info", "owner", nil),
+			},
+		},
+		{
+			description:   "fails when the source is invalid",
+			tenant:        "tenant",
+			data:          requests.DeviceAcceptRuleData{Name: "office", Sources: []string{"10.0.0.0/33"}, Token: "b3f1c2d4e5"},
+			requiredMocks: func() {},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrDeviceAcceptRuleInvalid("sources", "10.0.0.0/33", errSource),
+			},
+		},
+		{
+			description: "fails when the store device accept rule create fails",
+			tenant:      "tenant",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Token: "b3f1c2d4e5"},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceAcceptRuleCreate", ctx, &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Info:        map[string]string{},
+					Sources:     []string{},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}).Return(goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			data: requests.DeviceAcceptRuleData{
+				Name:        "office",
+				MACPrefixes: []string{"00:1a:2b"},
+				Hostname:    "web-*",
+				Info:        map[string]string{"platform": "docker"},
+				Sources:     []string{"10.0.0.0/8", "192.168.0.10"},
+				Token:       "b3f1c2d4e5",
+			},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceAcceptRuleCreate", ctx, &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{"00:1a:2b"},
+					Hostname:    "web-*",
+					Info:        map[string]string{"platform": "docker"},
+					Sources:     []string{"10.0.0.0/8", "192.168.0.10"},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				rule: &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{"00:1a:2b"},
+					Hostname:    "web-*",
+					Info:        map[string]string{"platform": "docker"},
+					Sources:     []string{"10.0.0.0/8", "192.168.0.10"},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds without a token",
+			tenant:      "tenant",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Sources: []string{"10.0.0.0/8"}},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceAcceptRuleCreate", ctx, &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Info:        map[string]string{},
+					Sources:     []string{"10.0.0.0/8"},
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				rule: &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Info:        map[string]string{},
+					Sources:     []string{"10.0.0.0/8"},
+					CreatedAt:   now,
+					UpdatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			rule, err := service.CreateDeviceAcceptRule(ctx, tc.tenant, tc.data)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateDeviceAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		rule *models.DeviceAcceptRule
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		data          requests.DeviceAcceptRuleData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the rule is not found",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Token: "b3f1c2d4e5"},
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrDeviceAcceptRuleNotFound("id", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the rule belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Token: "b3f1c2d4e5"},
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleGet", ctx, "id").
+					Return(&models.DeviceAcceptRule{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				rule: nil,
+				err:  NewErrDeviceAcceptRuleNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Token: "b3f1c2d4e5"},
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleGet", ctx, "id").
+					Return(&models.DeviceAcceptRule{
+						ID:          "id",
+						TenantID:    "tenant",
+						Name:        "lab",
+						MACPrefixes: []string{"00:1a:2b"},
+						Info:        map[string]string{},
+						Sources:     []string{},
+					}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceAcceptRuleUpdate", ctx, &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Info:        map[string]string{},
+					Sources:     []string{},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					UpdatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				rule: &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Info:        map[string]string{},
+					Sources:     []string{},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					UpdatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "keeps the token when it is not sent",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.DeviceAcceptRuleData{Name: "office", Hostname: "web-*"},
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleGet", ctx, "id").
+					Return(&models.DeviceAcceptRule{
+						ID:          "id",
+						TenantID:    "tenant",
+						Name:        "lab",
+						MACPrefixes: []string{},
+						Info:        map[string]string{},
+						Sources:     []string{},
+						TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceAcceptRuleUpdate", ctx, &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Hostname:    "web-*",
+					Info:        map[string]string{},
+					Sources:     []string{},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					UpdatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				rule: &models.DeviceAcceptRule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "office",
+					MACPrefixes: []string{},
+					Hostname:    "web-*",
+					Info:        map[string]string{},
+					Sources:     []string{},
+					TokenHash:   models.HashEnrollmentToken("b3f1c2d4e5"),
+					UpdatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			rule, err := service.UpdateDeviceAcceptRule(ctx, tc.tenant, tc.id, tc.data)
+			assert.Equal(t, tc.expected, Expected{rule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteDeviceAcceptRule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the rule is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleDelete", ctx, "tenant", "id").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceAcceptRuleNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("DeviceAcceptRuleDelete", ctx, "tenant", "id").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.DeleteDeviceAcceptRule(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestAutoAcceptDevice(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	device := &models.Device{
+		UID:        "uid",
+		Name:       "web-01",
+		TenantID:   "tenant",
+		Status:     models.DeviceStatusPending,
+		Identity:   &models.DeviceIdentity{MAC: "00:1A:2B:3c:4d:5e"},
+		Info:       &models.DeviceInfo{ID: "ubuntu", Platform: "docker", Arch: "arm64"},
+		RemoteAddr: "10.1.2.3",
+	}
+
+	rules := []models.DeviceAcceptRule{
+		{ID: "mac", Name: "mac", MACPrefixes: []string{"00:1a:2c"}},
+		{ID: "office", Name: "office", MACPrefixes: []string{"00:1a:2b"}, Sources: []string{"192.168.0.0/16"}},
+		{ID: "containers", Name: "containers", Hostname: "WEB-*", Info: map[string]string{"platform": "docker", "arch": "arm*"}, Sources: []string{"10.0.0.0/8"}},
+		{ID: "token", Name: "token", TokenHash: models.HashEnrollmentToken("b3f1c2d4e5")},
+	}
+
+	accept := func(rule string) {
+		mock.On("NamespaceGet", ctx, "tenant").
+			Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+		mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+			Return(device, nil).Once()
+		mock.On("DeviceGetByMac", ctx, "00:1A:2B:3c:4d:5e", "tenant", models.DeviceStatusAccepted).
+			Return(nil, store.ErrNoDocuments).Once()
+		mock.On("DeviceGetByName", ctx, "web-01", "tenant", models.DeviceStatusAccepted).
+			Return(nil, store.ErrNoDocuments).Once()
+		envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+		envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+		mock.On("DeviceUpdateStatus", ctx, models.UID("uid"), models.DeviceStatusAccepted).
+			Return(nil).Once()
+		clockMock.On("Now").Return(now).Once()
+		mock.On("DeviceSetAcceptance", ctx, models.UID("uid"), &models.DeviceAcceptance{Rule: rule, Name: rule, AcceptedAt: now}).
+			Return(nil).Once()
+	}
+
+	cases := []struct {
+		description   string
+		hostname      string
+		token         string
+		rules         []models.DeviceAcceptRule
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "keeps the device pending when the namespace has no rules",
+			hostname:      "web-01",
+			rules:         []models.DeviceAcceptRule{},
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description:   "keeps the device pending when only some of the rule's conditions match",
+			hostname:      "web-01",
+			rules:         rules[:2],
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description:   "keeps the device pending when the token does not match",
+			hostname:      "db-01",
+			token:         "b3f1c2d4e6",
+			rules:         rules,
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description:   "keeps the device pending when it registers without the rule's token",
+			hostname:      "db-01",
+			rules:         rules,
+			requiredMocks: func() {},
+			expected:      nil,
+		},
+		{
+			description: "accepts the device matching the hostname, the info and the source",
+			hostname:    "web-01",
+			rules:       rules,
+			requiredMocks: func() {
+				accept("containers")
+			},
+			expected: nil,
+		},
+		{
+			description: "accepts the device registering with the rule's token",
+			hostname:    "db-01",
+			token:       "b3f1c2d4e5",
+			rules:       rules,
+			requiredMocks: func() {
+				accept("token")
+			},
+			expected: nil,
+		},
+		{
+			description: "keeps the device pending when the namespace has reached its devices' limit",
+			hostname:    "db-01",
+			token:       "b3f1c2d4e5",
+			rules:       rules,
+			requiredMocks: func() {
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", MaxDevices: 1, DevicesCount: 1}, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(device, nil).Once()
+				mock.On("DeviceGetByMac", ctx, "00:1A:2B:3c:4d:5e", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				mock.On("DeviceGetByName", ctx, "web-01", "tenant", models.DeviceStatusAccepted).
+					Return(nil, store.ErrNoDocuments).Once()
+				envMock.On("Get", "SHELLHUB_CLOUD").Return("false").Once()
+				envMock.On("Get", "SHELLHUB_ENTERPRISE").Return("false").Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock.On("DeviceAcceptRuleList", ctx, "tenant", paginator.Query{Page: -1, PerPage: -1}).
+				Return(tc.rules, len(tc.rules), nil).Once()
+
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.autoAcceptDevice(ctx, device, tc.hostname, tc.token)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
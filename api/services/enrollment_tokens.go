@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type EnrollmentTokenService interface {
+	ListEnrollmentTokens(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error)
+	GetEnrollmentToken(ctx context.Context, tenant, id string) (*models.EnrollmentToken, error)
+	CreateEnrollmentToken(ctx context.Context, tenant string, req requests.EnrollmentTokenCreate) (*models.EnrollmentTokenCreated, error)
+	RevokeEnrollmentToken(ctx context.Context, tenant, id string) error
+}
+
+// ListEnrollmentTokens lists the enrollment tokens from a namespace, newest first.
+func (s *service) ListEnrollmentTokens(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error) {
+	return s.store.EnrollmentTokenList(ctx, tenant, pagination)
+}
+
+// GetEnrollmentToken gets an enrollment token from a namespace.
+func (s *service) GetEnrollmentToken(ctx context.Context, tenant, id string) (*models.EnrollmentToken, error) {
+	token, err := s.store.EnrollmentTokenGet(ctx, id)
+	if err != nil {
+		return nil, NewErrEnrollmentTokenNotFound(id, err)
+	}
+
+	if token.TenantID != tenant {
+		return nil, NewErrEnrollmentTokenNotFound(id, nil)
+	}
+
+	return token, nil
+}
+
+// CreateEnrollmentToken creates an enrollment token to a namespace. Only the hash of the token's secret is stored, so
+// the secret is returned only here.
+func (s *service) CreateEnrollmentToken(ctx context.Context, tenant string, req requests.EnrollmentTokenCreate) (*models.EnrollmentTokenCreated, error) {
+	now := clock.Now()
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, NewErrEnrollmentTokenExpiration(*req.ExpiresAt, nil)
+	}
+
+	secret, err := generateEnrollmentToken()
+	if err != nil {
+		return nil, err
+	}
+
+	token := models.EnrollmentToken{
+		ID:        uuid.Generate(),
+		TenantID:  tenant,
+		Name:      req.Name,
+		Hash:      models.HashEnrollmentToken(secret),
+		Tags:      req.Tags,
+		Status:    models.DeviceStatus(req.Status),
+		SingleUse: req.SingleUse,
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+
+	if token.Tags == nil {
+		token.Tags = []string{}
+	}
+
+	if token.Status == "" {
+		token.Status = models.DeviceStatusPending
+	}
+
+	if err := s.store.EnrollmentTokenCreate(ctx, &token); err != nil {
+		return nil, err
+	}
+
+	return &models.EnrollmentTokenCreated{EnrollmentToken: token, Token: secret}, nil
+}
+
+// RevokeEnrollmentToken revokes an enrollment token from a namespace, so no other device registers with it. The devices
+// already registered with it are kept.
+func (s *service) RevokeEnrollmentToken(ctx context.Context, tenant, id string) error {
+	if err := s.store.EnrollmentTokenRevoke(ctx, tenant, id, clock.Now()); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return NewErrEnrollmentTokenNotFound(id, err)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// enrollDevice checks the enrollment token a new device registers with. It returns the token when it is valid and one
+// of the namespace's enrollment tokens, or nil when the token is not one of them, what may still be matched by an
+// auto-accept rule. When the namespace requires an enrollment token, a new device registering without a valid one is
+// refused.
+func (s *service) enrollDevice(ctx context.Context, namespace *models.Namespace, secret string) (*models.EnrollmentToken, error) {
+	required := namespace.Settings != nil && namespace.Settings.RequireEnrollmentToken
+	if secret == "" && !required {
+		return nil, nil
+	}
+
+	if secret == "" {
+		return nil, NewErrEnrollmentTokenRequired(nil)
+	}
+
+	token, err := s.store.EnrollmentTokenGetByHash(ctx, namespace.TenantID, models.HashEnrollmentToken(secret))
+	switch {
+	case err == store.ErrNoDocuments && !required:
+		return nil, nil
+	case err == store.ErrNoDocuments:
+		return nil, NewErrEnrollmentTokenInvalid(err)
+	case err != nil:
+		return nil, err
+	}
+
+	if !token.IsValid(clock.Now()) {
+		return nil, NewErrEnrollmentTokenInvalid(nil)
+	}
+
+	return token, nil
+}
+
+// useEnrollmentToken counts the use of the enrollment token by the device registered with it. The use is counted only
+// while the token is still valid, so a single-use token isn't used by two devices registering at the same time; when it
+// can't be counted, the device is removed.
+func (s *service) useEnrollmentToken(ctx context.Context, token *models.EnrollmentToken, uid models.UID) error {
+	err := s.store.EnrollmentTokenUse(ctx, token.ID, clock.Now())
+	if err == nil {
+		return nil
+	}
+
+	if err := s.store.DeviceDelete(ctx, uid); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"uid":              uid,
+			"enrollment_token": token.ID,
+		}).Error("failed to remove the device registered with an enrollment token that can't be used")
+	}
+
+	switch err {
+	case store.ErrNoDocuments:
+		return NewErrEnrollmentTokenInvalid(err)
+	default:
+		return err
+	}
+}
+
+// generateEnrollmentToken generates a random hexadecimal secret to an enrollment token.
+func generateEnrollmentToken() (string, error) {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
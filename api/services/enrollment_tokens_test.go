@@ -0,0 +1,367 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestGetEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		token *models.EnrollmentToken
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the token is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				token: nil,
+				err:   NewErrEnrollmentTokenNotFound("id", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the token belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGet", ctx, "id").
+					Return(&models.EnrollmentToken{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				token: nil,
+				err:   NewErrEnrollmentTokenNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGet", ctx, "id").
+					Return(&models.EnrollmentToken{ID: "id", TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{
+				token: &models.EnrollmentToken{ID: "id", TenantID: "tenant"},
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			token, err := service.GetEnrollmentToken(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{token: token, err: err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	t.Run("fails when the token would be already expired", func(t *testing.T) {
+		clockMock.On("Now").Return(now).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+		token, err := service.CreateEnrollmentToken(ctx, "tenant", requests.EnrollmentTokenCreate{Name: "fleet", ExpiresAt: &past})
+		assert.Nil(t, token)
+		assert.Equal(t, NewErrEnrollmentTokenExpiration(past, nil), err)
+	})
+
+	t.Run("fails when the store enrollment token create fails", func(t *testing.T) {
+		clockMock.On("Now").Return(now).Once()
+		uuidMock.On("Generate").Return("id").Once()
+		mock.On("EnrollmentTokenCreate", ctx, testifymock.AnythingOfType("*models.EnrollmentToken")).
+			Return(goerrors.New("error")).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+		token, err := service.CreateEnrollmentToken(ctx, "tenant", requests.EnrollmentTokenCreate{Name: "fleet"})
+		assert.Nil(t, token)
+		assert.Equal(t, goerrors.New("error"), err)
+	})
+
+	t.Run("succeeds", func(t *testing.T) {
+		var stored *models.EnrollmentToken
+
+		clockMock.On("Now").Return(now).Once()
+		uuidMock.On("Generate").Return("id").Once()
+		mock.On("EnrollmentTokenCreate", ctx, testifymock.AnythingOfType("*models.EnrollmentToken")).
+			Run(func(args testifymock.Arguments) {
+				stored = args.Get(1).(*models.EnrollmentToken)
+			}).
+			Return(nil).Once()
+
+		service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+		token, err := service.CreateEnrollmentToken(ctx, "tenant", requests.EnrollmentTokenCreate{
+			Name:      "fleet",
+			Tags:      []string{"production"},
+			Status:    "accepted",
+			SingleUse: true,
+			ExpiresAt: &future,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, token.Token, 64)
+		assert.Equal(t, &models.EnrollmentToken{
+			ID:        "id",
+			TenantID:  "tenant",
+			Name:      "fleet",
+			Hash:      models.HashEnrollmentToken(token.Token),
+			Tags:      []string{"production"},
+			Status:    models.DeviceStatusAccepted,
+			SingleUse: true,
+			ExpiresAt: &future,
+			CreatedAt: now,
+		}, stored)
+		assert.Equal(t, *stored, token.EnrollmentToken)
+	})
+
+	mock.AssertExpectations(t)
+}
+
+func TestRevokeEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the token is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("EnrollmentTokenRevoke", ctx, "tenant", "id", now).
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrEnrollmentTokenNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("EnrollmentTokenRevoke", ctx, "tenant", "id", now).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.RevokeEnrollmentToken(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestEnrollDevice(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	optional := &models.Namespace{TenantID: "tenant"}
+	required := &models.Namespace{TenantID: "tenant", Settings: &models.NamespaceSettings{RequireEnrollmentToken: true}}
+
+	hash := models.HashEnrollmentToken("f1e2d3c4b5")
+	revokedAt := now.Add(-time.Hour)
+
+	type Expected struct {
+		token *models.EnrollmentToken
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		namespace     *models.Namespace
+		secret        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "succeeds without a token when the namespace does not require one",
+			namespace:     optional,
+			secret:        "",
+			requiredMocks: func() {},
+			expected: Expected{
+				token: nil,
+				err:   nil,
+			},
+		},
+		{
+			description:   "fails without a token when the namespace requires one",
+			namespace:     required,
+			secret:        "",
+			requiredMocks: func() {},
+			expected: Expected{
+				token: nil,
+				err:   NewErrEnrollmentTokenRequired(nil),
+			},
+		},
+		{
+			description: "succeeds with an unknown token when the namespace does not require one",
+			namespace:   optional,
+			secret:      "f1e2d3c4b5",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGetByHash", ctx, "tenant", hash).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				token: nil,
+				err:   nil,
+			},
+		},
+		{
+			description: "fails with an unknown token when the namespace requires one",
+			namespace:   required,
+			secret:      "f1e2d3c4b5",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGetByHash", ctx, "tenant", hash).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				token: nil,
+				err:   NewErrEnrollmentTokenInvalid(store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails with a revoked token",
+			namespace:   optional,
+			secret:      "f1e2d3c4b5",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGetByHash", ctx, "tenant", hash).
+					Return(&models.EnrollmentToken{ID: "id", RevokedAt: &revokedAt}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				token: nil,
+				err:   NewErrEnrollmentTokenInvalid(nil),
+			},
+		},
+		{
+			description: "succeeds with a valid token",
+			namespace:   required,
+			secret:      "f1e2d3c4b5",
+			requiredMocks: func() {
+				mock.On("EnrollmentTokenGetByHash", ctx, "tenant", hash).
+					Return(&models.EnrollmentToken{ID: "id", SingleUse: true}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				token: &models.EnrollmentToken{ID: "id", SingleUse: true},
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			token, err := service.enrollDevice(ctx, tc.namespace, tc.secret)
+			assert.Equal(t, tc.expected, Expected{token: token, err: err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUseEnrollmentToken(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	token := &models.EnrollmentToken{ID: "id", SingleUse: true}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "removes the device when the single-use token is used meanwhile",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("EnrollmentTokenUse", ctx, "id", now).
+					Return(store.ErrNoDocuments).Once()
+				mock.On("DeviceDelete", ctx, models.UID("uid")).
+					Return(nil).Once()
+			},
+			expected: NewErrEnrollmentTokenInvalid(store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("EnrollmentTokenUse", ctx, "id", now).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.useEnrollmentToken(ctx, token, models.UID("uid"))
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -108,6 +109,28 @@ var (
 	ErrTokenSigned                  = errors.New("token signed", ErrLayer, ErrCodeInvalid)
 	ErrTypeAssertion                = errors.New("type assertion failed", ErrLayer, ErrCodeInvalid)
 	ErrSessionNotFound              = errors.New("session not found", ErrLayer, ErrCodeNotFound)
+	ErrTunnelNotFound               = errors.New("tunnel not found", ErrLayer, ErrCodeNotFound)
+	ErrTunnelDeviceNotAccepted      = errors.New("tunnel device not accepted", ErrLayer, ErrCodeForbidden)
+	ErrTunnelForbidden              = errors.New("tunnel access not granted", ErrLayer, ErrCodeForbidden)
+	ErrTunnelPortsUnavailable       = errors.New("tunnel ports unavailable", ErrLayer, ErrCodeLimit)
+	ErrMetricsRangeInvalid          = errors.New("metrics range invalid", ErrLayer, ErrCodeInvalid)
+	ErrJobNotFound                  = errors.New("job not found", ErrLayer, ErrCodeNotFound)
+	ErrJobNoDevices                 = errors.New("job has no devices", ErrLayer, ErrCodeInvalid)
+	ErrJobScheduleNotFound          = errors.New("job schedule not found", ErrLayer, ErrCodeNotFound)
+	ErrJobScheduleCronInvalid       = errors.New("job schedule cron invalid", ErrLayer, ErrCodeInvalid)
+	ErrJobScheduleNotifyURLInvalid  = errors.New("job schedule notify url invalid", ErrLayer, ErrCodeInvalid)
+	ErrFilePushNotFound             = errors.New("file push not found", ErrLayer, ErrCodeNotFound)
+	ErrFilePushNoDevices            = errors.New("file push has no devices", ErrLayer, ErrCodeInvalid)
+	ErrFilePushNotFinished          = errors.New("file push not finished", ErrLayer, ErrCodeInvalid)
+	ErrUpdatePolicyNotFound         = errors.New("update policy not found", ErrLayer, ErrCodeNotFound)
+	ErrUpdatePolicyVersionInvalid   = errors.New("update policy version invalid", ErrLayer, ErrCodeInvalid)
+	ErrDeviceAcceptRuleNotFound     = errors.New("device accept rule not found", ErrLayer, ErrCodeNotFound)
+	ErrDeviceAcceptRuleEmpty        = errors.New("device accept rule has no conditions", ErrLayer, ErrCodeInvalid)
+	ErrDeviceAcceptRuleInvalid      = errors.New("device accept rule condition invalid", ErrLayer, ErrCodeInvalid)
+	ErrEnrollmentTokenNotFound      = errors.New("enrollment token not found", ErrLayer, ErrCodeNotFound)
+	ErrEnrollmentTokenRequired      = errors.New("enrollment token required", ErrLayer, ErrCodeUnauthorized)
+	ErrEnrollmentTokenInvalid       = errors.New("enrollment token invalid", ErrLayer, ErrCodeUnauthorized)
+	ErrEnrollmentTokenExpiration    = errors.New("enrollment token expiration invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthInvalid                  = errors.New("auth invalid", ErrLayer, ErrCodeInvalid)
 	ErrAuthUnathorized              = errors.New("auth unauthorized", ErrLayer, ErrCodeUnauthorized)
 	ErrNamespaceLimitReached        = errors.New("namespace limit reached", ErrLayer, ErrCodeLimit)
@@ -275,6 +298,119 @@ func NewErrSessionNotFound(id models.UID, next error) error {
 	return NewErrNotFound(ErrSessionNotFound, string(id), next)
 }
 
+// NewErrTunnelNotFound returns an error when the tunnel is not found.
+func NewErrTunnelNotFound(address string, next error) error {
+	return NewErrNotFound(ErrTunnelNotFound, address, next)
+}
+
+// NewErrTunnelDeviceNotAccepted returns an error when the tunnel's device is not accepted.
+func NewErrTunnelDeviceNotAccepted(id models.UID, next error) error {
+	return NewErrForbidden(errors.WithData(ErrTunnelDeviceNotAccepted, map[string]interface{}{"uid": string(id)}), next)
+}
+
+// NewErrTunnelForbidden returns an error when the access to the tunnel wasn't granted to the connection's source.
+func NewErrTunnelForbidden(source string, next error) error {
+	return NewErrForbidden(errors.WithData(ErrTunnelForbidden, map[string]interface{}{"source": source}), next)
+}
+
+// NewErrTunnelPortsUnavailable returns an error when no port is left to a tunnel in the port mode.
+func NewErrTunnelPortsUnavailable(limit int, next error) error {
+	return NewErrLimit(ErrTunnelPortsUnavailable, limit, next)
+}
+
+// NewErrJobNotFound returns an error when the job is not found.
+func NewErrJobNotFound(id string, next error) error {
+	return NewErrNotFound(ErrJobNotFound, id, next)
+}
+
+// NewErrJobNoDevices returns an error when no accepted device matches the job's devices or tags.
+func NewErrJobNoDevices(tags []string, next error) error {
+	return NewErrInvalid(ErrJobNoDevices, map[string]interface{}{"tags": tags}, next)
+}
+
+// NewErrJobScheduleNotFound returns an error when the job schedule is not found.
+func NewErrJobScheduleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrJobScheduleNotFound, id, next)
+}
+
+// NewErrJobScheduleCronInvalid returns an error when the job schedule's cron expression cannot be parsed.
+func NewErrJobScheduleCronInvalid(cron string, next error) error {
+	return NewErrInvalid(ErrJobScheduleCronInvalid, map[string]interface{}{"cron": cron}, next)
+}
+
+// NewErrJobScheduleNotifyURLInvalid returns an error when the job schedule's notification URL isn't an HTTP(S) URL.
+func NewErrJobScheduleNotifyURLInvalid(url string, next error) error {
+	return NewErrInvalid(ErrJobScheduleNotifyURLInvalid, map[string]interface{}{"notify_url": url}, next)
+}
+
+// NewErrFilePushNotFound returns an error when the file push is not found.
+func NewErrFilePushNotFound(id string, next error) error {
+	return NewErrNotFound(ErrFilePushNotFound, id, next)
+}
+
+// NewErrFilePushNoDevices returns an error when no accepted device matches the file push's tags.
+func NewErrFilePushNoDevices(tags []string, next error) error {
+	return NewErrInvalid(ErrFilePushNoDevices, map[string]interface{}{"tags": tags}, next)
+}
+
+// NewErrFilePushNotFinished returns an error when the file push is retried before it has finished.
+func NewErrFilePushNotFinished(id string, next error) error {
+	return NewErrInvalid(ErrFilePushNotFinished, map[string]interface{}{"id": id}, next)
+}
+
+// NewErrUpdatePolicyNotFound returns an error when the update policy is not found.
+func NewErrUpdatePolicyNotFound(id string, next error) error {
+	return NewErrNotFound(ErrUpdatePolicyNotFound, id, next)
+}
+
+// NewErrUpdatePolicyVersionInvalid returns an error when the update policy's version is not a semantic version.
+func NewErrUpdatePolicyVersionInvalid(version string, next error) error {
+	return NewErrInvalid(ErrUpdatePolicyVersionInvalid, map[string]interface{}{"version": version}, next)
+}
+
+// NewErrDeviceAcceptRuleNotFound returns an error when the device accept rule is not found.
+func NewErrDeviceAcceptRuleNotFound(id string, next error) error {
+	return NewErrNotFound(ErrDeviceAcceptRuleNotFound, id, next)
+}
+
+// NewErrDeviceAcceptRuleEmpty returns an error when the device accept rule has no conditions, what would accept every
+// device.
+func NewErrDeviceAcceptRuleEmpty(next error) error {
+	return NewErrInvalid(ErrDeviceAcceptRuleEmpty, nil, next)
+}
+
+// NewErrDeviceAcceptRuleInvalid returns an error when a condition of the device accept rule is invalid.
+func NewErrDeviceAcceptRuleInvalid(condition, value string, next error) error {
+	return NewErrInvalid(ErrDeviceAcceptRuleInvalid, map[string]interface{}{"condition": condition, "value": value}, next)
+}
+
+// NewErrEnrollmentTokenNotFound returns an error when the enrollment token is not found.
+func NewErrEnrollmentTokenNotFound(id string, next error) error {
+	return NewErrNotFound(ErrEnrollmentTokenNotFound, id, next)
+}
+
+// NewErrEnrollmentTokenRequired returns an error when a device registers without an enrollment token into a namespace
+// that requires one.
+func NewErrEnrollmentTokenRequired(next error) error {
+	return NewErrUnathorized(ErrEnrollmentTokenRequired, next)
+}
+
+// NewErrEnrollmentTokenInvalid returns an error when a device registers with an enrollment token that is unknown,
+// revoked, expired or already used.
+func NewErrEnrollmentTokenInvalid(next error) error {
+	return NewErrUnathorized(ErrEnrollmentTokenInvalid, next)
+}
+
+// NewErrEnrollmentTokenExpiration returns an error when the enrollment token would expire before it is created.
+func NewErrEnrollmentTokenExpiration(expiresAt time.Time, next error) error {
+	return NewErrInvalid(ErrEnrollmentTokenExpiration, map[string]interface{}{"expires_at": expiresAt}, next)
+}
+
+// NewErrMetricsRangeInvalid returns an error when the metrics' range starts after it ends.
+func NewErrMetricsRangeInvalid(from, to time.Time, next error) error {
+	return NewErrInvalid(ErrMetricsRangeInvalid, map[string]interface{}{"from": from, "to": to}, next)
+}
+
 // NewErrNamespaceList return an error to be used when cannot list namespaces.
 func NewErrNamespaceList(next error) error {
 	return NewErrInvalid(ErrNamespaceList, nil, next)
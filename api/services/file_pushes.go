@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	// DefaultFilePushConcurrency is the number of devices receiving a pushed file at the same time when none is
+	// requested.
+	DefaultFilePushConcurrency = 10
+	// DefaultFilePushMode is the mode of a pushed file when none is requested.
+	DefaultFilePushMode = "0644"
+)
+
+type FilePushService interface {
+	ListFilePushes(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error)
+	GetFilePush(ctx context.Context, tenant, id string) (*models.FilePush, error)
+	CreateFilePush(ctx context.Context, tenant string, req requests.FilePushCreate, name string, content io.Reader) (*models.FilePush, error)
+	RetryFilePush(ctx context.Context, tenant, id string) (*models.FilePush, error)
+}
+
+// ListFilePushes lists the file pushes from a namespace, without their results.
+func (s *service) ListFilePushes(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error) {
+	return s.store.FilePushList(ctx, tenant, pagination)
+}
+
+// GetFilePush gets a file push from a namespace with the results from each one of its devices.
+func (s *service) GetFilePush(ctx context.Context, tenant, id string) (*models.FilePush, error) {
+	push, err := s.store.FilePushGet(ctx, id)
+	if err != nil {
+		return nil, NewErrFilePushNotFound(id, err)
+	}
+
+	if push.TenantID != tenant {
+		return nil, NewErrFilePushNotFound(id, nil)
+	}
+
+	return push, nil
+}
+
+// CreateFilePush stores the file's content, read from content, and creates a file push to write it on the accepted
+// devices of a namespace having all the tags, enqueueing its delivery.
+func (s *service) CreateFilePush(ctx context.Context, tenant string, req requests.FilePushCreate, name string, content io.Reader) (*models.FilePush, error) {
+	devices, err := s.taggedDevices(ctx, tenant, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, NewErrFilePushNoDevices(req.Tags, nil)
+	}
+
+	push := &models.FilePush{
+		ID:          uuid.Generate(),
+		TenantID:    tenant,
+		Name:        name,
+		Path:        req.Path,
+		Owner:       req.Owner,
+		Group:       req.Group,
+		Mode:        req.Mode,
+		Tags:        req.Tags,
+		Concurrency: req.Concurrency,
+		Status:      models.FilePushStatusPending,
+		Results:     make([]models.FilePushResult, 0, len(devices)),
+	}
+
+	switch len(push.Mode) {
+	case 0:
+		push.Mode = DefaultFilePushMode
+	case 3:
+		push.Mode = "0" + push.Mode
+	}
+
+	if push.Concurrency == 0 {
+		push.Concurrency = DefaultFilePushConcurrency
+	}
+
+	hash := sha256.New()
+
+	size, err := s.store.FilePushContentSave(ctx, push.ID, io.TeeReader(content, hash))
+	if err != nil {
+		return nil, err
+	}
+
+	push.Size = size
+	push.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	push.CreatedAt = clock.Now()
+
+	for _, uid := range devices {
+		push.Results = append(push.Results, models.FilePushResult{Device: uid, Status: models.FilePushResultStatusPending})
+	}
+
+	if err := s.store.FilePushCreate(ctx, push); err != nil {
+		s.store.FilePushContentDelete(ctx, push.ID) //nolint:errcheck
+
+		return nil, err
+	}
+
+	if err := s.client.(internalclient.Client).FilePushRun(push.ID, push.Duration(len(devices))); err != nil {
+		s.failFilePush(ctx, push, err)
+
+		return nil, err
+	}
+
+	return push, nil
+}
+
+// failFilePush finishes the file push whose delivery could not be enqueued, failing its pending devices with the
+// error, so it can be retried instead of staying pending.
+func (s *service) failFilePush(ctx context.Context, push *models.FilePush, err error) {
+	finishedAt := clock.Now()
+
+	for i := range push.Results {
+		result := &push.Results[i]
+		if result.Status != models.FilePushResultStatusPending {
+			continue
+		}
+
+		result.Status = models.FilePushResultStatusError
+		result.Error = err.Error()
+		result.FinishedAt = &finishedAt
+
+		s.store.FilePushSetResult(ctx, push.ID, result) //nolint:errcheck
+	}
+
+	s.store.FilePushSetStatus(ctx, push.ID, models.FilePushStatusFinished, &finishedAt) //nolint:errcheck
+}
+
+// RetryFilePush delivers, again, a finished file push to the devices where it has failed. The transfers interrupted
+// before are resumed from the bytes each device has already received.
+func (s *service) RetryFilePush(ctx context.Context, tenant, id string) (*models.FilePush, error) {
+	push, err := s.GetFilePush(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if push.Status != models.FilePushStatusFinished {
+		return nil, NewErrFilePushNotFinished(id, nil)
+	}
+
+	retried := 0
+	for i := range push.Results {
+		result := &push.Results[i]
+		if !result.IsFailure() {
+			continue
+		}
+
+		result.Status = models.FilePushResultStatusPending
+		result.Error = ""
+		result.StartedAt = nil
+		result.FinishedAt = nil
+
+		if err := s.store.FilePushSetResult(ctx, push.ID, result); err != nil {
+			return nil, err
+		}
+
+		retried++
+	}
+
+	if retried == 0 {
+		return push, nil
+	}
+
+	if err := s.store.FilePushSetStatus(ctx, push.ID, models.FilePushStatusPending, nil); err != nil {
+		return nil, err
+	}
+
+	push.Status = models.FilePushStatusPending
+	push.FinishedAt = nil
+
+	if err := s.client.(internalclient.Client).FilePushRun(push.ID, push.Duration(retried)); err != nil {
+		s.failFilePush(ctx, push, err)
+
+		return nil, err
+	}
+
+	return push, nil
+}
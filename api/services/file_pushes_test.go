@@ -0,0 +1,369 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestGetFilePush(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		push *models.FilePush
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the file push is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  NewErrFilePushNotFound("id", goerrors.New("error")),
+			},
+		},
+		{
+			description: "fails when the file push belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(&models.FilePush{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  NewErrFilePushNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(&models.FilePush{ID: "id", TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{
+				push: &models.FilePush{ID: "id", TenantID: "tenant"},
+				err:  nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			push, err := service.GetFilePush(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{push, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateFilePush(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	tagsFilter := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"},
+		},
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"production"}},
+		},
+		{
+			Type:   "operator",
+			Params: &models.OperatorParams{Name: "and"},
+		},
+	}
+
+	// The content is consumed by the store, what computes its checksum.
+	consume := func(args testifymock.Arguments) {
+		io.ReadAll(args.Get(2).(io.Reader)) //nolint:errcheck
+	}
+
+	push := func() *models.FilePush {
+		return &models.FilePush{
+			ID:          "id",
+			TenantID:    "tenant",
+			Name:        "app.conf",
+			Path:        "/etc/app.conf",
+			Owner:       "root",
+			Mode:        "0600",
+			Size:        10,
+			SHA256:      "d5c5f09b69f25bf5059606bc891a4bdaac96e4ba058fc001cab9a8a4b9ee7c39",
+			Tags:        []string{"production"},
+			Concurrency: 1,
+			Status:      models.FilePushStatusPending,
+			CreatedAt:   now,
+			Results: []models.FilePushResult{
+				{Device: "uid-1", Status: models.FilePushResultStatusPending},
+				{Device: "uid-2", Status: models.FilePushResultStatusPending},
+			},
+		}
+	}
+
+	type Expected struct {
+		push *models.FilePush
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		req           requests.FilePushCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when no device has the tags",
+			tenant:      "tenant",
+			req:         requests.FilePushCreate{Path: "/etc/app.conf", Tags: []string{"production"}},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  NewErrFilePushNoDevices([]string{"production"}, nil),
+			},
+		},
+		{
+			description: "fails when the store file push content save fails",
+			tenant:      "tenant",
+			req:         requests.FilePushCreate{Path: "/etc/app.conf", Tags: []string{"production"}},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid-1"}}, 1, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				mock.On("FilePushContentSave", ctx, "id", testifymock.Anything).
+					Return(int64(0), goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  goerrors.New("error"),
+			},
+		},
+		{
+			description: "fails when the store file push create fails",
+			tenant:      "tenant",
+			req:         requests.FilePushCreate{Path: "/etc/app.conf", Owner: "root", Mode: "600", Tags: []string{"production"}, Concurrency: 1},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid-1"}, {UID: "uid-2"}}, 2, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				mock.On("FilePushContentSave", ctx, "id", testifymock.Anything).
+					Run(consume).Return(int64(10), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FilePushCreate", ctx, push()).
+					Return(goerrors.New("error")).Once()
+				mock.On("FilePushContentDelete", ctx, "id").
+					Return(nil).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  goerrors.New("error"),
+			},
+		},
+		{
+			description: "fails when the file push run enqueue fails",
+			tenant:      "tenant",
+			req:         requests.FilePushCreate{Path: "/etc/app.conf", Owner: "root", Mode: "600", Tags: []string{"production"}, Concurrency: 1},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid-1"}, {UID: "uid-2"}}, 2, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				mock.On("FilePushContentSave", ctx, "id", testifymock.Anything).
+					Run(consume).Return(int64(10), nil).Once()
+				clockMock.On("Now").Return(now).Twice()
+				mock.On("FilePushCreate", ctx, push()).
+					Return(nil).Once()
+				clientMock.On("FilePushRun", "id", 2*models.FilePushGracePeriod).Return(goerrors.New("error")).Once()
+				for _, uid := range []models.UID{"uid-1", "uid-2"} {
+					mock.On("FilePushSetResult", ctx, "id", &models.FilePushResult{Device: uid, Status: models.FilePushResultStatusError, Error: "error", FinishedAt: &now}).
+						Return(nil).Once()
+				}
+				mock.On("FilePushSetStatus", ctx, "id", models.FilePushStatusFinished, &now).
+					Return(nil).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			req:         requests.FilePushCreate{Path: "/etc/app.conf", Owner: "root", Mode: "600", Tags: []string{"production"}, Concurrency: 1},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid-1"}, {UID: "uid-2"}}, 2, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				mock.On("FilePushContentSave", ctx, "id", testifymock.Anything).
+					Run(consume).Return(int64(10), nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("FilePushCreate", ctx, push()).
+					Return(nil).Once()
+				clientMock.On("FilePushRun", "id", 2*models.FilePushGracePeriod).Return(nil).Once()
+			},
+			expected: Expected{
+				push: push(),
+				err:  nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			push, err := service.CreateFilePush(ctx, tc.tenant, tc.req, "app.conf", strings.NewReader("key=value\n"))
+			assert.Equal(t, tc.expected, Expected{push, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestRetryFilePush(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		push *models.FilePush
+		err  error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the file push has not finished",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(&models.FilePush{ID: "id", TenantID: "tenant", Status: models.FilePushStatusRunning}, nil).Once()
+			},
+			expected: Expected{
+				push: nil,
+				err:  NewErrFilePushNotFinished("id", nil),
+			},
+		},
+		{
+			description: "succeeds when no device has failed",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(&models.FilePush{
+						ID:       "id",
+						TenantID: "tenant",
+						Status:   models.FilePushStatusFinished,
+						Results:  []models.FilePushResult{{Device: "uid-1", Status: models.FilePushResultStatusSucceeded}},
+					}, nil).Once()
+			},
+			expected: Expected{
+				push: &models.FilePush{
+					ID:       "id",
+					TenantID: "tenant",
+					Status:   models.FilePushStatusFinished,
+					Results:  []models.FilePushResult{{Device: "uid-1", Status: models.FilePushResultStatusSucceeded}},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds to retry the failed devices",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("FilePushGet", ctx, "id").
+					Return(&models.FilePush{
+						ID:          "id",
+						TenantID:    "tenant",
+						Concurrency: 10,
+						Status:      models.FilePushStatusFinished,
+						FinishedAt:  &now,
+						Results: []models.FilePushResult{
+							{Device: "uid-1", Status: models.FilePushResultStatusSucceeded, Transferred: 10},
+							{Device: "uid-2", Status: models.FilePushResultStatusError, Transferred: 4, Error: "device is not reachable", FinishedAt: &now},
+						},
+					}, nil).Once()
+				mock.On("FilePushSetResult", ctx, "id", &models.FilePushResult{Device: "uid-2", Status: models.FilePushResultStatusPending, Transferred: 4}).
+					Return(nil).Once()
+				mock.On("FilePushSetStatus", ctx, "id", models.FilePushStatusPending, (*time.Time)(nil)).
+					Return(nil).Once()
+				clientMock.On("FilePushRun", "id", models.FilePushGracePeriod).Return(nil).Once()
+			},
+			expected: Expected{
+				push: &models.FilePush{
+					ID:          "id",
+					TenantID:    "tenant",
+					Concurrency: 10,
+					Status:      models.FilePushStatusPending,
+					Results: []models.FilePushResult{
+						{Device: "uid-1", Status: models.FilePushResultStatusSucceeded, Transferred: 10},
+						{Device: "uid-2", Status: models.FilePushResultStatusPending, Transferred: 4},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			push, err := service.RetryFilePush(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{push, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
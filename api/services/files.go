@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type FilesService interface {
+	AuthorizeFiles(ctx context.Context, uid models.UID, tenant string) error
+}
+
+// AuthorizeFiles checks if the files of the device can be managed from the namespace, what requires the device to
+// belong to it and to be accepted.
+func (s *service) AuthorizeFiles(ctx context.Context, uid models.UID, tenant string) error {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return NewErrDeviceStatusInvalid(string(device.Status), nil)
+	}
+
+	return nil
+}
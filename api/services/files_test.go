@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorizeFiles(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		tenant        string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+		},
+		{
+			description: "fails when the device is not accepted",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: NewErrDeviceStatusInvalid("pending", nil),
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.AuthorizeFiles(ctx, tc.uid, tc.tenant)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/robfig/cron/v3"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+type JobScheduleService interface {
+	ListJobSchedules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error)
+	GetJobSchedule(ctx context.Context, tenant, id string) (*models.JobSchedule, error)
+	CreateJobSchedule(ctx context.Context, tenant string, data requests.JobScheduleData) (*models.JobSchedule, error)
+	UpdateJobSchedule(ctx context.Context, tenant, id string, data requests.JobScheduleData) (*models.JobSchedule, error)
+	DeleteJobSchedule(ctx context.Context, tenant, id string) error
+	ListJobScheduleJobs(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.Job, int, error)
+}
+
+// ListJobSchedules lists the job schedules from a namespace.
+func (s *service) ListJobSchedules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error) {
+	return s.store.JobScheduleList(ctx, tenant, pagination)
+}
+
+// GetJobSchedule gets a job schedule from a namespace.
+func (s *service) GetJobSchedule(ctx context.Context, tenant, id string) (*models.JobSchedule, error) {
+	schedule, err := s.store.JobScheduleGet(ctx, id)
+	if err != nil {
+		return nil, NewErrJobScheduleNotFound(id, err)
+	}
+
+	if schedule.TenantID != tenant {
+		return nil, NewErrJobScheduleNotFound(id, nil)
+	}
+
+	return schedule, nil
+}
+
+// CreateJobSchedule creates a job schedule to a namespace.
+//
+// The schedule isn't registered on the workers' scheduler immediately; the workers synchronize their schedulers with
+// the enabled schedules periodically.
+func (s *service) CreateJobSchedule(ctx context.Context, tenant string, data requests.JobScheduleData) (*models.JobSchedule, error) {
+	schedule := &models.JobSchedule{Enabled: true}
+	if err := applyJobScheduleData(schedule, data); err != nil {
+		return nil, err
+	}
+
+	schedule.ID = uuid.Generate()
+	schedule.TenantID = tenant
+	schedule.CreatedAt = clock.Now()
+
+	if err := s.store.JobScheduleCreate(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// UpdateJobSchedule replaces the data of a job schedule from a namespace.
+func (s *service) UpdateJobSchedule(ctx context.Context, tenant, id string, data requests.JobScheduleData) (*models.JobSchedule, error) {
+	schedule, err := s.GetJobSchedule(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyJobScheduleData(schedule, data); err != nil {
+		return nil, err
+	}
+
+	if err := s.store.JobScheduleUpdate(ctx, schedule); err != nil {
+		return nil, NewErrJobScheduleNotFound(id, err)
+	}
+
+	return schedule, nil
+}
+
+// DeleteJobSchedule deletes a job schedule from a namespace. The jobs already created by it are kept.
+func (s *service) DeleteJobSchedule(ctx context.Context, tenant, id string) error {
+	if err := s.store.JobScheduleDelete(ctx, tenant, id); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return NewErrJobScheduleNotFound(id, err)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListJobScheduleJobs lists the jobs created by a job schedule from a namespace, what is the schedule's run history.
+func (s *service) ListJobScheduleJobs(ctx context.Context, tenant, id string, pagination paginator.Query) ([]models.Job, int, error) {
+	if _, err := s.GetJobSchedule(ctx, tenant, id); err != nil {
+		return nil, 0, err
+	}
+
+	return s.store.JobListBySchedule(ctx, id, pagination)
+}
+
+// applyJobScheduleData sets the data sent by the user to the schedule, applying the defaults to what wasn't sent.
+func applyJobScheduleData(schedule *models.JobSchedule, data requests.JobScheduleData) error {
+	if _, err := cron.ParseStandard(data.Cron); err != nil {
+		return NewErrJobScheduleCronInvalid(data.Cron, err)
+	}
+
+	if data.NotifyURL != "" {
+		notify, err := url.Parse(data.NotifyURL)
+		if err != nil || (notify.Scheme != "http" && notify.Scheme != "https") || notify.Hostname() == "" {
+			return NewErrJobScheduleNotifyURLInvalid(data.NotifyURL, err)
+		}
+	}
+
+	schedule.Name = data.Name
+	schedule.Cron = data.Cron
+	schedule.Command = data.Command
+	schedule.User = data.User
+	schedule.Tags = data.Tags
+	schedule.Concurrency = data.Concurrency
+	schedule.Timeout = data.Timeout
+	schedule.Offline = models.JobScheduleOffline(data.Offline)
+	schedule.NotifyURL = data.NotifyURL
+
+	if schedule.Concurrency == 0 {
+		schedule.Concurrency = DefaultJobConcurrency
+	}
+
+	if schedule.Timeout == 0 {
+		schedule.Timeout = DefaultJobTimeout
+	}
+
+	if schedule.Offline == "" {
+		schedule.Offline = models.JobScheduleOfflineSkip
+	}
+
+	if data.Enabled != nil {
+		schedule.Enabled = *data.Enabled
+	}
+
+	return nil
+}
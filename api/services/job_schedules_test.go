@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+
+	"github.com/robfig/cron/v3"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateJobSchedule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	_, errCron := cron.ParseStandard("every day")
+
+	disabled := false
+
+	type Expected struct {
+		schedule *models.JobSchedule
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		data          requests.JobScheduleData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the cron expression is invalid",
+			tenant:        "tenant",
+			data:          requests.JobScheduleData{Name: "uptime", Cron: "every day", Command: "uptime", User: "root", Tags: []string{"production"}},
+			requiredMocks: func() {},
+			expected: Expected{
+				schedule: nil,
+				err:      NewErrJobScheduleCronInvalid("every day", errCron),
+			},
+		},
+		{
+			description:   "fails when the notification URL isn't an HTTP URL",
+			tenant:        "tenant",
+			data:          requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root", Tags: []string{"production"}, NotifyURL: "file:///etc/passwd"},
+			requiredMocks: func() {},
+			expected: Expected{
+				schedule: nil,
+				err:      NewErrJobScheduleNotifyURLInvalid("file:///etc/passwd", nil),
+			},
+		},
+		{
+			description: "fails when the store job schedule create fails",
+			tenant:      "tenant",
+			data:        requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root", Tags: []string{"production"}},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobScheduleCreate", ctx, &models.JobSchedule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "uptime",
+					Cron:        "@daily",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"production"},
+					Concurrency: DefaultJobConcurrency,
+					Timeout:     DefaultJobTimeout,
+					Offline:     models.JobScheduleOfflineSkip,
+					Enabled:     true,
+					CreatedAt:   now,
+				}).Return(goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				schedule: nil,
+				err:      goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			data: requests.JobScheduleData{
+				Name:        "uptime",
+				Cron:        "0 3 * * *",
+				Command:     "uptime",
+				User:        "root",
+				Tags:        []string{"production"},
+				Concurrency: 5,
+				Timeout:     30,
+				Offline:     "queue",
+				NotifyURL:   "https://example.com/notify",
+				Enabled:     &disabled,
+			},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobScheduleCreate", ctx, &models.JobSchedule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "uptime",
+					Cron:        "0 3 * * *",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"production"},
+					Concurrency: 5,
+					Timeout:     30,
+					Offline:     models.JobScheduleOfflineQueue,
+					NotifyURL:   "https://example.com/notify",
+					Enabled:     false,
+					CreatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				schedule: &models.JobSchedule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "uptime",
+					Cron:        "0 3 * * *",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"production"},
+					Concurrency: 5,
+					Timeout:     30,
+					Offline:     models.JobScheduleOfflineQueue,
+					NotifyURL:   "https://example.com/notify",
+					Enabled:     false,
+					CreatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			schedule, err := service.CreateJobSchedule(ctx, tc.tenant, tc.data)
+			assert.Equal(t, tc.expected, Expected{schedule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateJobSchedule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		schedule *models.JobSchedule
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		data          requests.JobScheduleData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the schedule belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.JobScheduleData{Name: "uptime", Cron: "@daily", Command: "uptime", User: "root", Tags: []string{"production"}},
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "id").
+					Return(&models.JobSchedule{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				schedule: nil,
+				err:      NewErrJobScheduleNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds keeping the schedule enabled",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.JobScheduleData{Name: "uptime", Cron: "@hourly", Command: "uptime", User: "root", Tags: []string{"staging"}},
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "id").
+					Return(&models.JobSchedule{ID: "id", TenantID: "tenant", Name: "old", Cron: "@daily", Enabled: true, CreatedAt: now}, nil).Once()
+				mock.On("JobScheduleUpdate", ctx, &models.JobSchedule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "uptime",
+					Cron:        "@hourly",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"staging"},
+					Concurrency: DefaultJobConcurrency,
+					Timeout:     DefaultJobTimeout,
+					Offline:     models.JobScheduleOfflineSkip,
+					Enabled:     true,
+					CreatedAt:   now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				schedule: &models.JobSchedule{
+					ID:          "id",
+					TenantID:    "tenant",
+					Name:        "uptime",
+					Cron:        "@hourly",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"staging"},
+					Concurrency: DefaultJobConcurrency,
+					Timeout:     DefaultJobTimeout,
+					Offline:     models.JobScheduleOfflineSkip,
+					Enabled:     true,
+					CreatedAt:   now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			schedule, err := service.UpdateJobSchedule(ctx, tc.tenant, tc.id, tc.data)
+			assert.Equal(t, tc.expected, Expected{schedule, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteJobSchedule(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the schedule is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobScheduleDelete", ctx, "tenant", "id").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrJobScheduleNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobScheduleDelete", ctx, "tenant", "id").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.DeleteJobSchedule(ctx, tc.tenant, tc.id))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListJobScheduleJobs(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		jobs  []models.Job
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the schedule is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				jobs:  nil,
+				count: 0,
+				err:   NewErrJobScheduleNotFound("id", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobScheduleGet", ctx, "id").
+					Return(&models.JobSchedule{ID: "id", TenantID: "tenant"}, nil).Once()
+				mock.On("JobListBySchedule", ctx, "id", paginator.Query{Page: 1, PerPage: 10}).
+					Return([]models.Job{{ID: "job", Schedule: "id"}}, 1, nil).Once()
+			},
+			expected: Expected{
+				jobs:  []models.Job{{ID: "job", Schedule: "id"}},
+				count: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			jobs, count, err := service.ListJobScheduleJobs(ctx, tc.tenant, tc.id, paginator.Query{Page: 1, PerPage: 10})
+			assert.Equal(t, tc.expected, Expected{jobs, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	// DefaultJobConcurrency is the number of devices executing a job's command at the same time when none is requested.
+	DefaultJobConcurrency = 10
+	// DefaultJobTimeout is the time, in seconds, a job's command is allowed to run on each device when none is requested.
+	DefaultJobTimeout = 60
+)
+
+type JobService interface {
+	ListJobs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error)
+	GetJob(ctx context.Context, tenant, id string) (*models.Job, error)
+	CreateJob(ctx context.Context, tenant string, req requests.JobCreate) (*models.Job, error)
+}
+
+// ListJobs lists the jobs from a namespace, without their results.
+func (s *service) ListJobs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error) {
+	return s.store.JobList(ctx, tenant, pagination)
+}
+
+// GetJob gets a job from a namespace with the results from each one of its devices.
+func (s *service) GetJob(ctx context.Context, tenant, id string) (*models.Job, error) {
+	job, err := s.store.JobGet(ctx, id)
+	if err != nil {
+		return nil, NewErrJobNotFound(id, err)
+	}
+
+	if job.TenantID != tenant {
+		return nil, NewErrJobNotFound(id, nil)
+	}
+
+	return job, nil
+}
+
+// CreateJob creates a job to execute a command on the accepted devices of a namespace, selected by their UIDs or by
+// the tags they have, and enqueues its execution.
+func (s *service) CreateJob(ctx context.Context, tenant string, req requests.JobCreate) (*models.Job, error) {
+	devices, err := s.jobDevices(ctx, tenant, req.Devices, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &models.Job{
+		ID:          uuid.Generate(),
+		TenantID:    tenant,
+		Command:     req.Command,
+		User:        req.User,
+		Tags:        req.Tags,
+		Concurrency: req.Concurrency,
+		Timeout:     req.Timeout,
+		Status:      models.JobStatusPending,
+		CreatedAt:   clock.Now(),
+		Results:     make([]models.JobResult, 0, len(devices)),
+	}
+
+	if job.Concurrency == 0 {
+		job.Concurrency = DefaultJobConcurrency
+	}
+
+	if job.Timeout == 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+
+	for _, uid := range devices {
+		job.Results = append(job.Results, models.JobResult{Device: uid, Status: models.JobResultStatusPending})
+	}
+
+	if err := s.store.JobCreate(ctx, job); err != nil {
+		return nil, err
+	}
+
+	if err := s.client.(internalclient.Client).JobRun(job.ID, job.Duration(len(devices))); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// jobDevices resolves the UIDs of the job's devices. When UIDs are provided, each one of them must be an accepted device
+// from the namespace; otherwise, the accepted devices having all the tags are selected.
+func (s *service) jobDevices(ctx context.Context, tenant string, uids []string, tags []string) ([]models.UID, error) {
+	if len(uids) > 0 {
+		devices := make([]models.UID, 0, len(uids))
+		seen := make(map[string]bool)
+		for _, uid := range uids {
+			if seen[uid] {
+				continue
+			}
+
+			seen[uid] = true
+
+			device, err := s.store.DeviceGetByUID(ctx, models.UID(uid), tenant)
+			if err != nil {
+				return nil, NewErrDeviceNotFound(models.UID(uid), err)
+			}
+
+			if device.Status != models.DeviceStatusAccepted {
+				return nil, NewErrDeviceStatusInvalid(string(device.Status), nil)
+			}
+
+			devices = append(devices, models.UID(device.UID))
+		}
+
+		return devices, nil
+	}
+
+	devices, err := s.taggedDevices(ctx, tenant, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(devices) == 0 {
+		return nil, NewErrJobNoDevices(tags, nil)
+	}
+
+	return devices, nil
+}
+
+// taggedDevices lists the UIDs of the accepted devices from the namespace having all the tags.
+func (s *service) taggedDevices(ctx context.Context, tenant string, tags []string) ([]models.UID, error) {
+	values := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		values = append(values, tag)
+	}
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: tenant},
+		},
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: values},
+		},
+		{
+			Type:   "operator",
+			Params: &models.OperatorParams{Name: "and"},
+		},
+	}
+
+	list, _, err := s.store.DeviceList(ctx, paginator.Query{Page: -1, PerPage: -1}, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]models.UID, 0, len(list))
+	for _, device := range list {
+		devices = append(devices, models.UID(device.UID))
+	}
+
+	return devices, nil
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListJobs(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		jobs  []models.Job
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		pagination    paginator.Query
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the store job list fails",
+			tenant:      "tenant",
+			pagination:  paginator.Query{Page: 1, PerPage: 10},
+			requiredMocks: func() {
+				mock.On("JobList", ctx, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+					Return(nil, 0, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				jobs:  nil,
+				count: 0,
+				err:   goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			pagination:  paginator.Query{Page: 1, PerPage: 10},
+			requiredMocks: func() {
+				mock.On("JobList", ctx, "tenant", paginator.Query{Page: 1, PerPage: 10}).
+					Return([]models.Job{{ID: "id", TenantID: "tenant", Command: "uptime"}}, 1, nil).Once()
+			},
+			expected: Expected{
+				jobs:  []models.Job{{ID: "id", TenantID: "tenant", Command: "uptime"}},
+				count: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			jobs, count, err := service.ListJobs(ctx, tc.tenant, tc.pagination)
+			assert.Equal(t, tc.expected, Expected{jobs, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the job is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "id").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: NewErrJobNotFound("id", goerrors.New("error")),
+			},
+		},
+		{
+			description: "fails when the job belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "id").
+					Return(&models.Job{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: NewErrJobNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("JobGet", ctx, "id").
+					Return(&models.Job{ID: "id", TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{
+				job: &models.Job{ID: "id", TenantID: "tenant"},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			job, err := service.GetJob(ctx, tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateJob(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	tagsFilter := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"},
+		},
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: []interface{}{"production"}},
+		},
+		{
+			Type:   "operator",
+			Params: &models.OperatorParams{Name: "and"},
+		},
+	}
+
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		req           requests.JobCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when a device is not found",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"uid"}},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+			},
+		},
+		{
+			description: "fails when a device is not accepted",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"uid"}},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: NewErrDeviceStatusInvalid(string(models.DeviceStatusPending), nil),
+			},
+		},
+		{
+			description: "fails when no device has the tags",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Tags: []string{"production"}},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{}, 0, nil).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: NewErrJobNoDevices([]string{"production"}, nil),
+			},
+		},
+		{
+			description: "fails when the store job create fails",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"uid"}},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobCreate", ctx, &models.Job{
+					ID:          "id",
+					TenantID:    "tenant",
+					Command:     "uptime",
+					User:        "root",
+					Concurrency: DefaultJobConcurrency,
+					Timeout:     DefaultJobTimeout,
+					Status:      models.JobStatusPending,
+					CreatedAt:   now,
+					Results:     []models.JobResult{{Device: "uid", Status: models.JobResultStatusPending}},
+				}).Return(goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				job: nil,
+				err: goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds to create a job to the devices",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Devices: []string{"uid", "uid"}, Concurrency: 1, Timeout: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobCreate", ctx, &models.Job{
+					ID:          "id",
+					TenantID:    "tenant",
+					Command:     "uptime",
+					User:        "root",
+					Concurrency: 1,
+					Timeout:     10,
+					Status:      models.JobStatusPending,
+					CreatedAt:   now,
+					Results:     []models.JobResult{{Device: "uid", Status: models.JobResultStatusPending}},
+				}).Return(nil).Once()
+				clientMock.On("JobRun", "id", 10*time.Second+models.JobGracePeriod).Return(nil).Once()
+			},
+			expected: Expected{
+				job: &models.Job{
+					ID:          "id",
+					TenantID:    "tenant",
+					Command:     "uptime",
+					User:        "root",
+					Concurrency: 1,
+					Timeout:     10,
+					Status:      models.JobStatusPending,
+					CreatedAt:   now,
+					Results:     []models.JobResult{{Device: "uid", Status: models.JobResultStatusPending}},
+				},
+				err: nil,
+			},
+		},
+		{
+			description: "succeeds to create a job to the devices with the tags",
+			tenant:      "tenant",
+			req:         requests.JobCreate{Command: "uptime", User: "root", Tags: []string{"production"}, Concurrency: 1},
+			requiredMocks: func() {
+				mock.On("DeviceList", ctx, paginator.Query{Page: -1, PerPage: -1}, tagsFilter, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{{UID: "uid-1"}, {UID: "uid-2"}}, 2, nil).Once()
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("JobCreate", ctx, &models.Job{
+					ID:          "id",
+					TenantID:    "tenant",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"production"},
+					Concurrency: 1,
+					Timeout:     DefaultJobTimeout,
+					Status:      models.JobStatusPending,
+					CreatedAt:   now,
+					Results: []models.JobResult{
+						{Device: "uid-1", Status: models.JobResultStatusPending},
+						{Device: "uid-2", Status: models.JobResultStatusPending},
+					},
+				}).Return(nil).Once()
+				clientMock.On("JobRun", "id", 2*(DefaultJobTimeout*time.Second+models.JobGracePeriod)).Return(nil).Once()
+			},
+			expected: Expected{
+				job: &models.Job{
+					ID:          "id",
+					TenantID:    "tenant",
+					Command:     "uptime",
+					User:        "root",
+					Tags:        []string{"production"},
+					Concurrency: 1,
+					Timeout:     DefaultJobTimeout,
+					Status:      models.JobStatusPending,
+					CreatedAt:   now,
+					Results: []models.JobResult{
+						{Device: "uid-1", Status: models.JobResultStatusPending},
+						{Device: "uid-2", Status: models.JobResultStatusPending},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			job, err := service.CreateJob(ctx, tc.tenant, tc.req)
+			assert.Equal(t, tc.expected, Expected{job, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
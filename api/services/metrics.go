@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// DefaultMetricsRange is the period of the device's metrics listed when no range is requested.
+const DefaultMetricsRange = 24 * time.Hour
+
+type MetricsService interface {
+	CreateDeviceMetrics(ctx context.Context, uid models.UID, metrics *models.DeviceMetrics) error
+	ListDeviceMetrics(ctx context.Context, uid models.UID, tenant string, from, to time.Time) ([]models.DeviceMetrics, error)
+}
+
+// CreateDeviceMetrics stores the metrics reported by the device.
+//
+// The sample is timestamped with the server's clock, so the devices' clocks don't need to be in sync to query them.
+func (s *service) CreateDeviceMetrics(ctx context.Context, uid models.UID, metrics *models.DeviceMetrics) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return NewErrDeviceStatusInvalid(string(device.Status), nil)
+	}
+
+	metrics.UID = device.UID
+	metrics.TenantID = device.TenantID
+	metrics.Time = clock.Now()
+
+	return s.store.MetricsCreate(ctx, metrics)
+}
+
+// ListDeviceMetrics lists the metrics of a device from a namespace collected between from and to. When to is zero, it
+// is the current time, and when from is zero, it is [DefaultMetricsRange] before to.
+func (s *service) ListDeviceMetrics(ctx context.Context, uid models.UID, tenant string, from, to time.Time) ([]models.DeviceMetrics, error) {
+	if to.IsZero() {
+		to = clock.Now()
+	}
+
+	if from.IsZero() {
+		from = to.Add(-DefaultMetricsRange)
+	}
+
+	if from.After(to) {
+		return nil, NewErrMetricsRangeInvalid(from, to, nil)
+	}
+
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.store.MetricsList(ctx, uid, from, to)
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateDeviceMetrics(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		metrics       *models.DeviceMetrics
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			metrics:     &models.DeviceMetrics{CPU: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+		},
+		{
+			description: "fails when the device is not accepted",
+			uid:         models.UID("uid"),
+			metrics:     &models.DeviceMetrics{CPU: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: NewErrDeviceStatusInvalid("pending", nil),
+		},
+		{
+			description: "fails when the metrics cannot be stored",
+			uid:         models.UID("uid"),
+			metrics:     &models.DeviceMetrics{CPU: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("MetricsCreate", ctx, &models.DeviceMetrics{UID: "uid", TenantID: "tenant", Time: now, CPU: 10}).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			metrics:     &models.DeviceMetrics{UID: "other", TenantID: "other", CPU: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("MetricsCreate", ctx, &models.DeviceMetrics{UID: "uid", TenantID: "tenant", Time: now, CPU: 10}).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.CreateDeviceMetrics(ctx, tc.uid, tc.metrics)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceMetrics(t *testing.T) {
+	type Expected struct {
+		metrics []models.DeviceMetrics
+		err     error
+	}
+
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		tenant        string
+		from          time.Time
+		to            time.Time
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the range starts after it ends",
+			uid:           models.UID("uid"),
+			tenant:        "tenant",
+			from:          to,
+			to:            from,
+			requiredMocks: func() {},
+			expected: Expected{
+				metrics: nil,
+				err:     NewErrMetricsRangeInvalid(to, from, nil),
+			},
+		},
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				metrics: nil,
+				err:     NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+			},
+		},
+		{
+			description: "succeeds with the default range",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			requiredMocks: func() {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("MetricsList", ctx, models.UID("uid"), now.Add(-DefaultMetricsRange), now).
+					Return([]models.DeviceMetrics{}, nil).Once()
+			},
+			expected: Expected{
+				metrics: []models.DeviceMetrics{},
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			from:        from,
+			to:          to,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("MetricsList", ctx, models.UID("uid"), from, to).
+					Return([]models.DeviceMetrics{{UID: "uid", TenantID: "tenant", Time: from, CPU: 10}}, nil).Once()
+			},
+			expected: Expected{
+				metrics: []models.DeviceMetrics{{UID: "uid", TenantID: "tenant", Time: from, CPU: 10}},
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			metrics, err := service.ListDeviceMetrics(ctx, tc.uid, tc.tenant, tc.from, tc.to)
+			assert.Equal(t, tc.expected, Expected{metrics: metrics, err: err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -4,8 +4,10 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	internalclient "github.com/shellhub-io/shellhub/pkg/api/internalclient"
+
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/shellhub-io/shellhub/pkg/models"
@@ -19,6 +21,8 @@ import (
 	rsa "crypto/rsa"
 
 	template "text/template"
+
+	time "time"
 )
 
 // Service is an autogenerated mock type for the Service type
@@ -346,6 +350,24 @@ func (_m *Service) AuthUserInfo(ctx context.Context, username string, tenant str
 	return r0, r1
 }
 
+// AuthorizeFiles provides a mock function with given fields: ctx, uid, tenant
+func (_m *Service) AuthorizeFiles(ctx context.Context, uid models.UID, tenant string) error {
+	ret := _m.Called(ctx, uid, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthorizeFiles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, tenant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // BillingEvaluate provides a mock function with given fields: _a0, _a1
 func (_m *Service) BillingEvaluate(_a0 internalclient.Client, _a1 string) (bool, error) {
 	ret := _m.Called(_a0, _a1)
@@ -392,6 +414,54 @@ func (_m *Service) BillingReport(_a0 internalclient.Client, _a1 string, _a2 stri
 	return r0
 }
 
+// CreateDeviceAcceptRule provides a mock function with given fields: ctx, tenant, data
+func (_m *Service) CreateDeviceAcceptRule(ctx context.Context, tenant string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceAcceptRule")
+	}
+
+	var r0 *models.DeviceAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error)); ok {
+		return rf(ctx, tenant, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.DeviceAcceptRuleData) *models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, tenant, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.DeviceAcceptRuleData) error); ok {
+		r1 = rf(ctx, tenant, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateDeviceMetrics provides a mock function with given fields: ctx, uid, metrics
+func (_m *Service) CreateDeviceMetrics(ctx context.Context, uid models.UID, metrics *models.DeviceMetrics) error {
+	ret := _m.Called(ctx, uid, metrics)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDeviceMetrics")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceMetrics) error); ok {
+		r0 = rf(ctx, uid, metrics)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateDeviceTag provides a mock function with given fields: ctx, uid, tag
 func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag string) error {
 	ret := _m.Called(ctx, uid, tag)
@@ -410,6 +480,126 @@ func (_m *Service) CreateDeviceTag(ctx context.Context, uid models.UID, tag stri
 	return r0
 }
 
+// CreateEnrollmentToken provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateEnrollmentToken(ctx context.Context, tenant string, req requests.EnrollmentTokenCreate) (*models.EnrollmentTokenCreated, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEnrollmentToken")
+	}
+
+	var r0 *models.EnrollmentTokenCreated
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.EnrollmentTokenCreate) (*models.EnrollmentTokenCreated, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.EnrollmentTokenCreate) *models.EnrollmentTokenCreated); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrollmentTokenCreated)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.EnrollmentTokenCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFilePush provides a mock function with given fields: ctx, tenant, req, name, content
+func (_m *Service) CreateFilePush(ctx context.Context, tenant string, req requests.FilePushCreate, name string, content io.Reader) (*models.FilePush, error) {
+	ret := _m.Called(ctx, tenant, req, name, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFilePush")
+	}
+
+	var r0 *models.FilePush
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.FilePushCreate, string, io.Reader) (*models.FilePush, error)); ok {
+		return rf(ctx, tenant, req, name, content)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.FilePushCreate, string, io.Reader) *models.FilePush); ok {
+		r0 = rf(ctx, tenant, req, name, content)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FilePush)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.FilePushCreate, string, io.Reader) error); ok {
+		r1 = rf(ctx, tenant, req, name, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJob provides a mock function with given fields: ctx, tenant, req
+func (_m *Service) CreateJob(ctx context.Context, tenant string, req requests.JobCreate) (*models.Job, error) {
+	ret := _m.Called(ctx, tenant, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.JobCreate) (*models.Job, error)); ok {
+		return rf(ctx, tenant, req)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.JobCreate) *models.Job); ok {
+		r0 = rf(ctx, tenant, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.JobCreate) error); ok {
+		r1 = rf(ctx, tenant, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateJobSchedule provides a mock function with given fields: ctx, tenant, data
+func (_m *Service) CreateJobSchedule(ctx context.Context, tenant string, data requests.JobScheduleData) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.JobScheduleData) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.JobScheduleData) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.JobScheduleData) error); ok {
+		r1 = rf(ctx, tenant, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateNamespace provides a mock function with given fields: ctx, namespace, userID
 func (_m *Service) CreateNamespace(ctx context.Context, namespace requests.NamespaceCreate, userID string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, namespace, userID)
@@ -440,9 +630,9 @@ func (_m *Service) CreateNamespace(ctx context.Context, namespace requests.Names
 	return r0, r1
 }
 
-// CreatePrivateKey provides a mock function with given fields: ctx
-func (_m *Service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
-	ret := _m.Called(ctx)
+// CreatePrivateKey provides a mock function with given fields: ctx, req
+func (_m *Service) CreatePrivateKey(ctx context.Context, req requests.PrivateKeyCreate) (*models.PrivateKey, error) {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreatePrivateKey")
@@ -450,19 +640,19 @@ func (_m *Service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, er
 
 	var r0 *models.PrivateKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (*models.PrivateKey, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PrivateKeyCreate) (*models.PrivateKey, error)); ok {
+		return rf(ctx, req)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *models.PrivateKey); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, requests.PrivateKeyCreate) *models.PrivateKey); ok {
+		r0 = rf(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.PrivateKey)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, requests.PrivateKeyCreate) error); ok {
+		r1 = rf(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -530,6 +720,66 @@ func (_m *Service) CreateSession(ctx context.Context, session requests.SessionCr
 	return r0, r1
 }
 
+// CreateTunnel provides a mock function with given fields: ctx, uid, tenant, host, port, mode
+func (_m *Service) CreateTunnel(ctx context.Context, uid models.UID, tenant string, host string, port int, mode string) (*models.Tunnel, error) {
+	ret := _m.Called(ctx, uid, tenant, host, port, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTunnel")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, int, string) (*models.Tunnel, error)); ok {
+		return rf(ctx, uid, tenant, host, port, mode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, int, string) *models.Tunnel); ok {
+		r0 = rf(ctx, uid, tenant, host, port, mode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string, int, string) error); ok {
+		r1 = rf(ctx, uid, tenant, host, port, mode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUpdatePolicy provides a mock function with given fields: ctx, tenant, data
+func (_m *Service) CreateUpdatePolicy(ctx context.Context, tenant string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error) {
+	ret := _m.Called(ctx, tenant, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUpdatePolicy")
+	}
+
+	var r0 *models.UpdatePolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.UpdatePolicyData) (*models.UpdatePolicy, error)); ok {
+		return rf(ctx, tenant, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, requests.UpdatePolicyData) *models.UpdatePolicy); ok {
+		r0 = rf(ctx, tenant, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, requests.UpdatePolicyData) error); ok {
+		r1 = rf(ctx, tenant, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeactivateSession provides a mock function with given fields: ctx, uid
 func (_m *Service) DeactivateSession(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -566,6 +816,42 @@ func (_m *Service) DeleteDevice(ctx context.Context, uid models.UID, tenant stri
 	return r0
 }
 
+// DeleteDeviceAcceptRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteDeviceAcceptRule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeviceAcceptRule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteJobSchedule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteJobSchedule(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteJobSchedule")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteNamespace provides a mock function with given fields: ctx, tenantID
 func (_m *Service) DeleteNamespace(ctx context.Context, tenantID string) error {
 	ret := _m.Called(ctx, tenantID)
@@ -620,6 +906,42 @@ func (_m *Service) DeleteTag(ctx context.Context, tenant string, tag string) err
 	return r0
 }
 
+// DeleteTunnel provides a mock function with given fields: ctx, uid, tenant, address
+func (_m *Service) DeleteTunnel(ctx context.Context, uid models.UID, tenant string, address string) error {
+	ret := _m.Called(ctx, uid, tenant, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTunnel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string) error); ok {
+		r0 = rf(ctx, uid, tenant, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteUpdatePolicy provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) DeleteUpdatePolicy(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteUpdatePolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceHeartbeat provides a mock function with given fields: ctx, uid
 func (_m *Service) DeviceHeartbeat(ctx context.Context, uid models.UID) error {
 	ret := _m.Called(ctx, uid)
@@ -668,6 +990,24 @@ func (_m *Service) EditNamespace(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// EditNamespaceEnrollment provides a mock function with given fields: ctx, tenantID, requireToken
+func (_m *Service) EditNamespaceEnrollment(ctx context.Context, tenantID string, requireToken bool) error {
+	ret := _m.Called(ctx, tenantID, requireToken)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EditNamespaceEnrollment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, tenantID, requireToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // EditNamespaceUser provides a mock function with given fields: ctx, tenantID, userID, memberID, memberNewRole
 func (_m *Service) EditNamespaceUser(ctx context.Context, tenantID string, userID string, memberID string, memberNewRole string) error {
 	ret := _m.Called(ctx, tenantID, userID, memberID, memberNewRole)
@@ -790,29 +1130,29 @@ func (_m *Service) GetDevice(ctx context.Context, uid models.UID) (*models.Devic
 	return r0, r1
 }
 
-// GetDeviceByPublicURLAddress provides a mock function with given fields: ctx, address
-func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
-	ret := _m.Called(ctx, address)
+// GetDeviceAcceptRule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetDeviceAcceptRule(ctx context.Context, tenant string, id string) (*models.DeviceAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetDeviceByPublicURLAddress")
+		panic("no return value specified for GetDeviceAcceptRule")
 	}
 
-	var r0 *models.Device
+	var r0 *models.DeviceAcceptRule
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
-		return rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.DeviceAcceptRule, error)); ok {
+		return rf(ctx, tenant, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
-		r0 = rf(ctx, address)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, tenant, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Device)
+			r0 = ret.Get(0).(*models.DeviceAcceptRule)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, address)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -820,11 +1160,191 @@ func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address stri
 	return r0, r1
 }
 
-// GetNamespace provides a mock function with given fields: ctx, tenantID
-func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
-	ret := _m.Called(ctx, tenantID)
-
-	if len(ret) == 0 {
+// GetDeviceByPublicURLAddress provides a mock function with given fields: ctx, address
+func (_m *Service) GetDeviceByPublicURLAddress(ctx context.Context, address string) (*models.Device, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceByPublicURLAddress")
+	}
+
+	var r0 *models.Device
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Device, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Device); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeviceUpdateTarget provides a mock function with given fields: ctx, uid
+func (_m *Service) GetDeviceUpdateTarget(ctx context.Context, uid models.UID) (*models.DeviceUpdateTarget, error) {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceUpdateTarget")
+	}
+
+	var r0 *models.DeviceUpdateTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) (*models.DeviceUpdateTarget, error)); ok {
+		return rf(ctx, uid)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) *models.DeviceUpdateTarget); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceUpdateTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID) error); ok {
+		r1 = rf(ctx, uid)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetEnrollmentToken provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetEnrollmentToken(ctx context.Context, tenant string, id string) (*models.EnrollmentToken, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnrollmentToken")
+	}
+
+	var r0 *models.EnrollmentToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.EnrollmentToken, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.EnrollmentToken); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrollmentToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFilePush provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetFilePush(ctx context.Context, tenant string, id string) (*models.FilePush, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFilePush")
+	}
+
+	var r0 *models.FilePush
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.FilePush, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.FilePush); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FilePush)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJob provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetJob(ctx context.Context, tenant string, id string) (*models.Job, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Job, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Job); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetJobSchedule provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetJobSchedule(ctx context.Context, tenant string, id string) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNamespace provides a mock function with given fields: ctx, tenantID
+func (_m *Service) GetNamespace(ctx context.Context, tenantID string) (*models.Namespace, error) {
+	ret := _m.Called(ctx, tenantID)
+
+	if len(ret) == 0 {
 		panic("no return value specified for GetNamespace")
 	}
 
@@ -951,53 +1471,383 @@ func (_m *Service) GetStats(ctx context.Context) (*models.Stats, error) {
 	if rf, ok := ret.Get(0).(func(context.Context) (*models.Stats, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context) *models.Stats); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx, tenant
+func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetUpdatePolicy provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) GetUpdatePolicy(ctx context.Context, tenant string, id string) (*models.UpdatePolicy, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdatePolicy")
+	}
+
+	var r0 *models.UpdatePolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.UpdatePolicy, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.UpdatePolicy); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantTunnel provides a mock function with given fields: ctx, uid, tenant, address, user, source, duration
+func (_m *Service) GrantTunnel(ctx context.Context, uid models.UID, tenant string, address string, user string, source string, duration time.Duration) (*models.TunnelGrant, error) {
+	ret := _m.Called(ctx, uid, tenant, address, user, source, duration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GrantTunnel")
+	}
+
+	var r0 *models.TunnelGrant
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, string, string, time.Duration) (*models.TunnelGrant, error)); ok {
+		return rf(ctx, uid, tenant, address, user, source, duration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, string, string, string, time.Duration) *models.TunnelGrant); ok {
+		r0 = rf(ctx, uid, tenant, address, user, source, duration)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TunnelGrant)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, string, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, uid, tenant, address, user, source, duration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// KeepAliveSession provides a mock function with given fields: ctx, uid
+func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
+	ret := _m.Called(ctx, uid)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KeepAliveSession")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
+		r0 = rf(ctx, uid)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDeviceAcceptRules provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListDeviceAcceptRules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceAcceptRules")
+	}
+
+	var r0 []models.DeviceAcceptRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceAcceptRule, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDeviceMetrics provides a mock function with given fields: ctx, uid, tenant, from, to
+func (_m *Service) ListDeviceMetrics(ctx context.Context, uid models.UID, tenant string, from time.Time, to time.Time) ([]models.DeviceMetrics, error) {
+	ret := _m.Called(ctx, uid, tenant, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceMetrics")
+	}
+
+	var r0 []models.DeviceMetrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, time.Time, time.Time) ([]models.DeviceMetrics, error)); ok {
+		return rf(ctx, uid, tenant, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, time.Time, time.Time) []models.DeviceMetrics); ok {
+		r0 = rf(ctx, uid, tenant, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceMetrics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, uid, tenant, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDeviceUpdates provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListDeviceUpdates(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceUpdateState, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeviceUpdates")
+	}
+
+	var r0 []models.DeviceUpdateState
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceUpdateState, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceUpdateState); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceUpdateState)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListDevices provides a mock function with given fields: ctx, tenant, pagination, filter, status, sort, order
+func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort string, order string) ([]models.Device, int, error) {
+	ret := _m.Called(ctx, tenant, pagination, filter, status, sort, order)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevices")
+	}
+
+	var r0 []models.Device
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) ([]models.Device, int, error)); ok {
+		return rf(ctx, tenant, pagination, filter, status, sort, order)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) []models.Device); ok {
+		r0 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Device)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) int); ok {
+		r1 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) error); ok {
+		r2 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListEnrollmentTokens provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListEnrollmentTokens(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListEnrollmentTokens")
+	}
+
+	var r0 []models.EnrollmentToken
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.EnrollmentToken, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.EnrollmentToken); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.EnrollmentToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListFilePushes provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListFilePushes(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListFilePushes")
+	}
+
+	var r0 []models.FilePush
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.FilePush, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.FilePush); ok {
+		r0 = rf(ctx, tenant, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.Stats)
+			r0 = ret.Get(0).([]models.FilePush)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetTags provides a mock function with given fields: ctx, tenant
-func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, error) {
-	ret := _m.Called(ctx, tenant)
+// ListJobScheduleJobs provides a mock function with given fields: ctx, tenant, id, pagination
+func (_m *Service) ListJobScheduleJobs(ctx context.Context, tenant string, id string, pagination paginator.Query) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, id, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTags")
+		panic("no return value specified for ListJobScheduleJobs")
 	}
 
-	var r0 []string
+	var r0 []models.Job
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
-		return rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, id, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, paginator.Query) []models.Job); ok {
+		r0 = rf(ctx, tenant, id, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.Job)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, id, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, id, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1005,54 +1855,73 @@ func (_m *Service) GetTags(ctx context.Context, tenant string) ([]string, int, e
 	return r0, r1, r2
 }
 
-// KeepAliveSession provides a mock function with given fields: ctx, uid
-func (_m *Service) KeepAliveSession(ctx context.Context, uid models.UID) error {
-	ret := _m.Called(ctx, uid)
+// ListJobSchedules provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListJobSchedules(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for KeepAliveSession")
+		panic("no return value specified for ListJobSchedules")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UID) error); ok {
-		r0 = rf(ctx, uid)
+	var r0 []models.JobSchedule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.JobSchedule, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, pagination)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// ListDevices provides a mock function with given fields: ctx, tenant, pagination, filter, status, sort, order
-func (_m *Service) ListDevices(ctx context.Context, tenant string, pagination paginator.Query, filter []models.Filter, status models.DeviceStatus, sort string, order string) ([]models.Device, int, error) {
-	ret := _m.Called(ctx, tenant, pagination, filter, status, sort, order)
+// ListJobs provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListJobs(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListDevices")
+		panic("no return value specified for ListJobs")
 	}
 
-	var r0 []models.Device
+	var r0 []models.Job
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) ([]models.Device, int, error)); ok {
-		return rf(ctx, tenant, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) []models.Device); ok {
-		r0 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Job); ok {
+		r0 = rf(ctx, tenant, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Device)
+			r0 = ret.Get(0).([]models.Job)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) int); ok {
-		r1 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query, []models.Filter, models.DeviceStatus, string, string) error); ok {
-		r2 = rf(ctx, tenant, pagination, filter, status, sort, order)
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1171,6 +2040,80 @@ func (_m *Service) ListSessions(ctx context.Context, pagination paginator.Query)
 	return r0, r1, r2
 }
 
+// ListTunnels provides a mock function with given fields: ctx, uid, tenant, pagination
+func (_m *Service) ListTunnels(ctx context.Context, uid models.UID, tenant string, pagination paginator.Query) ([]models.Tunnel, int, error) {
+	ret := _m.Called(ctx, uid, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTunnels")
+	}
+
+	var r0 []models.Tunnel
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, paginator.Query) ([]models.Tunnel, int, error)); ok {
+		return rf(ctx, uid, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, paginator.Query) []models.Tunnel); ok {
+		r0 = rf(ctx, uid, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, string, paginator.Query) int); ok {
+		r1 = rf(ctx, uid, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID, string, paginator.Query) error); ok {
+		r2 = rf(ctx, uid, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListUpdatePolicies provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Service) ListUpdatePolicies(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUpdatePolicies")
+	}
+
+	var r0 []models.UpdatePolicy
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.UpdatePolicy, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.UpdatePolicy); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // LookupDevice provides a mock function with given fields: ctx, namespace, name
 func (_m *Service) LookupDevice(ctx context.Context, namespace string, name string) (*models.Device, error) {
 	ret := _m.Called(ctx, namespace, name)
@@ -1201,6 +2144,66 @@ func (_m *Service) LookupDevice(ctx context.Context, namespace string, name stri
 	return r0, r1
 }
 
+// LookupTunnel provides a mock function with given fields: ctx, address, source
+func (_m *Service) LookupTunnel(ctx context.Context, address string, source string) (*models.Tunnel, error) {
+	ret := _m.Called(ctx, address, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupTunnel")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Tunnel, error)); ok {
+		return rf(ctx, address, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Tunnel); ok {
+		r0 = rf(ctx, address, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, address, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LookupTunnelByPort provides a mock function with given fields: ctx, port, source
+func (_m *Service) LookupTunnelByPort(ctx context.Context, port int, source string) (*models.Tunnel, error) {
+	ret := _m.Called(ctx, port, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupTunnelByPort")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) (*models.Tunnel, error)); ok {
+		return rf(ctx, port, source)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, string) *models.Tunnel); ok {
+		r0 = rf(ctx, port, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = rf(ctx, port, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // OffineDevice provides a mock function with given fields: ctx, uid, online
 func (_m *Service) OffineDevice(ctx context.Context, uid models.UID, online bool) error {
 	ret := _m.Called(ctx, uid, online)
@@ -1341,6 +2344,72 @@ func (_m *Service) RenameTag(ctx context.Context, tenant string, oldTag string,
 	return r0
 }
 
+// ReportDeviceUpdate provides a mock function with given fields: ctx, uid, report
+func (_m *Service) ReportDeviceUpdate(ctx context.Context, uid models.UID, report requests.DeviceUpdateReport) error {
+	ret := _m.Called(ctx, uid, report)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportDeviceUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, requests.DeviceUpdateReport) error); ok {
+		r0 = rf(ctx, uid, report)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryFilePush provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) RetryFilePush(ctx context.Context, tenant string, id string) (*models.FilePush, error) {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryFilePush")
+	}
+
+	var r0 *models.FilePush
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.FilePush, error)); ok {
+		return rf(ctx, tenant, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.FilePush); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FilePush)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeEnrollmentToken provides a mock function with given fields: ctx, tenant, id
+func (_m *Service) RevokeEnrollmentToken(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevokeEnrollmentToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDevicePosition provides a mock function with given fields: ctx, uid, ip
 func (_m *Service) SetDevicePosition(ctx context.Context, uid models.UID, ip string) error {
 	ret := _m.Called(ctx, uid, ip)
@@ -1512,6 +2581,36 @@ func (_m *Service) UpdateDevice(ctx context.Context, tenant string, uid models.U
 	return r0
 }
 
+// UpdateDeviceAcceptRule provides a mock function with given fields: ctx, tenant, id, data
+func (_m *Service) UpdateDeviceAcceptRule(ctx context.Context, tenant string, id string, data requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error) {
+	ret := _m.Called(ctx, tenant, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateDeviceAcceptRule")
+	}
+
+	var r0 *models.DeviceAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceAcceptRuleData) (*models.DeviceAcceptRule, error)); ok {
+		return rf(ctx, tenant, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.DeviceAcceptRuleData) *models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, tenant, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.DeviceAcceptRuleData) error); ok {
+		r1 = rf(ctx, tenant, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateDeviceStatus provides a mock function with given fields: ctx, tenant, uid, status
 func (_m *Service) UpdateDeviceStatus(ctx context.Context, tenant string, uid models.UID, status models.DeviceStatus) error {
 	ret := _m.Called(ctx, tenant, uid, status)
@@ -1548,6 +2647,36 @@ func (_m *Service) UpdateDeviceTag(ctx context.Context, uid models.UID, tags []s
 	return r0
 }
 
+// UpdateJobSchedule provides a mock function with given fields: ctx, tenant, id, data
+func (_m *Service) UpdateJobSchedule(ctx context.Context, tenant string, id string, data requests.JobScheduleData) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, tenant, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJobSchedule")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.JobScheduleData) (*models.JobSchedule, error)); ok {
+		return rf(ctx, tenant, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.JobScheduleData) *models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.JobScheduleData) error); ok {
+		r1 = rf(ctx, tenant, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdatePasswordUser provides a mock function with given fields: ctx, id, currentPassword, newPassword
 func (_m *Service) UpdatePasswordUser(ctx context.Context, id string, currentPassword string, newPassword string) error {
 	ret := _m.Called(ctx, id, currentPassword, newPassword)
@@ -1614,6 +2743,36 @@ func (_m *Service) UpdatePublicKeyTags(ctx context.Context, tenant string, finge
 	return r0
 }
 
+// UpdateUpdatePolicy provides a mock function with given fields: ctx, tenant, id, data
+func (_m *Service) UpdateUpdatePolicy(ctx context.Context, tenant string, id string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error) {
+	ret := _m.Called(ctx, tenant, id, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUpdatePolicy")
+	}
+
+	var r0 *models.UpdatePolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.UpdatePolicyData) (*models.UpdatePolicy, error)); ok {
+		return rf(ctx, tenant, id, data)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, requests.UpdatePolicyData) *models.UpdatePolicy); ok {
+		r0 = rf(ctx, tenant, id, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, requests.UpdatePolicyData) error); ok {
+		r1 = rf(ctx, tenant, id, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewService creates a new instance of Service. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewService(t interface {
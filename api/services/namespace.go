@@ -24,6 +24,7 @@ type NamespaceService interface {
 	RemoveNamespaceUser(ctx context.Context, tenantID, memberID, userID string) (*models.Namespace, error)
 	EditNamespaceUser(ctx context.Context, tenantID, userID, memberID, memberNewRole string) error
 	EditSessionRecordStatus(ctx context.Context, sessionRecord bool, tenantID string) error
+	EditNamespaceEnrollment(ctx context.Context, tenantID string, requireToken bool) error
 	GetSessionRecord(ctx context.Context, tenantID string) (bool, error)
 }
 
@@ -372,6 +373,16 @@ func (s *service) EditSessionRecordStatus(ctx context.Context, sessionRecord boo
 	return s.store.NamespaceSetSessionRecord(ctx, sessionRecord, tenantID)
 }
 
+// EditNamespaceEnrollment defines if only the devices presenting an enrollment token register into the namespace. The
+// devices already registered are kept.
+func (s *service) EditNamespaceEnrollment(ctx context.Context, tenantID string, requireToken bool) error {
+	if err := s.store.NamespaceSetRequireEnrollmentToken(ctx, tenantID, requireToken); err != nil {
+		return NewErrNamespaceNotFound(tenantID, err)
+	}
+
+	return nil
+}
+
 // GetSessionRecord gets the session record data.
 //
 // It receives a context, used to "control" the request flow, the tenant ID from models.Namespace.
@@ -2084,3 +2084,48 @@ func TestEditSessionRecord(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestEditNamespaceEnrollment(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		tenantID      string
+		requireToken  bool
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:  "fails when the namespace is not found",
+			tenantID:     "xxxx",
+			requireToken: true,
+			requiredMocks: func() {
+				mock.On("NamespaceSetRequireEnrollmentToken", ctx, "xxxx", true).Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrNamespaceNotFound("xxxx", store.ErrNoDocuments),
+		},
+		{
+			description:  "succeeds",
+			tenantID:     "xxxx",
+			requireToken: true,
+			requiredMocks: func() {
+				mock.On("NamespaceSetRequireEnrollmentToken", ctx, "xxxx", true).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.EditNamespaceEnrollment(ctx, tc.tenantID, tc.requireToken)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
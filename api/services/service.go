@@ -40,6 +40,15 @@ type Service interface {
 	StatsService
 	SetupService
 	SystemService
+	TunnelService
+	FilesService
+	MetricsService
+	JobService
+	JobScheduleService
+	FilePushService
+	UpdatePolicyService
+	DeviceAcceptRuleService
+	EnrollmentTokenService
 }
 
 func NewService(store store.Store, privKey *rsa.PrivateKey, pubKey *rsa.PublicKey, cache cache.Cache, c interface{}, l geoip.Locator) *APIService {
@@ -25,7 +25,9 @@ type SSHKeysService interface {
 	CreatePublicKey(ctx context.Context, req requests.PublicKeyCreate, tenant string) (*responses.PublicKeyCreate, error)
 	UpdatePublicKey(ctx context.Context, fingerprint, tenant string, key requests.PublicKeyUpdate) (*models.PublicKey, error)
 	DeletePublicKey(ctx context.Context, fingerprint, tenant string) error
-	CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error)
+	// CreatePrivateKey creates a private key to authenticate the SSH server on a device. When req identifies a public
+	// key created by a member of the namespace, the member is the identity of the private key.
+	CreatePrivateKey(ctx context.Context, req requests.PrivateKeyCreate) (*models.PrivateKey, error)
 }
 
 type Request struct {
@@ -112,6 +114,7 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		Fingerprint: req.Fingerprint,
 		CreatedAt:   clock.Now(),
 		TenantID:    req.TenantID,
+		CreatedBy:   req.UserID,
 		PublicKeyFields: models.PublicKeyFields{
 			Name:     req.Name,
 			Username: req.Username,
@@ -119,6 +122,7 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 				Hostname: req.Filter.Hostname,
 				Tags:     req.Filter.Tags,
 			},
+			Options: models.PublicKeyOptions(req.Options),
 		},
 	}
 
@@ -132,6 +136,7 @@ func (s *service) CreatePublicKey(ctx context.Context, req requests.PublicKeyCre
 		Filter:      responses.PublicKeyFilter(model.Filter),
 		Name:        model.Name,
 		Username:    model.Username,
+		Options:     responses.PublicKeyOptions(model.Options),
 		TenantID:    model.TenantID,
 		Fingerprint: model.Fingerprint,
 	}, nil
@@ -165,6 +170,7 @@ func (s *service) UpdatePublicKey(ctx context.Context, fingerprint, tenant strin
 				Hostname: key.Filter.Hostname,
 				Tags:     key.Filter.Tags,
 			},
+			Options: models.PublicKeyOptions(key.Options),
 		},
 	}
 
@@ -185,7 +191,12 @@ func (s *service) DeletePublicKey(ctx context.Context, fingerprint, tenant strin
 	return s.store.PublicKeyDelete(ctx, fingerprint, tenant)
 }
 
-func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, error) {
+func (s *service) CreatePrivateKey(ctx context.Context, req requests.PrivateKeyCreate) (*models.PrivateKey, error) {
+	identity, err := s.privateKeyIdentity(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	key, err := rsa.GenerateKey(rand.Reader, 4096)
 	if err != nil {
 		return nil, err
@@ -203,6 +214,7 @@ func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, err
 		}),
 		Fingerprint: ssh.FingerprintLegacyMD5(pubKey),
 		CreatedAt:   clock.Now(),
+		Identity:    identity,
 	}
 
 	if err := s.store.PrivateKeyCreate(ctx, privateKey); err != nil {
@@ -211,3 +223,41 @@ func (s *service) CreatePrivateKey(ctx context.Context) (*models.PrivateKey, err
 
 	return privateKey, nil
 }
+
+// privateKeyIdentity returns the member that created the public key identified by req, or nil when there is no such
+// public key or it wasn't created by a current member of the namespace.
+func (s *service) privateKeyIdentity(ctx context.Context, req requests.PrivateKeyCreate) (*models.PrivateKeyIdentity, error) {
+	if req.Fingerprint == "" || req.TenantID == "" {
+		return nil, nil
+	}
+
+	pubKey, err := s.store.PublicKeyGet(ctx, req.Fingerprint, req.TenantID)
+	switch {
+	case err == store.ErrNoDocuments:
+		return nil, nil
+	case err != nil:
+		return nil, err
+	case pubKey.CreatedBy == "":
+		return nil, nil
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, req.TenantID)
+	if err != nil {
+		return nil, NewErrNamespaceNotFound(req.TenantID, err)
+	}
+
+	member, ok := namespace.FindMember(pubKey.CreatedBy)
+	if !ok {
+		return nil, nil
+	}
+
+	user, _, err := s.store.UserGetByID(ctx, member.ID, false)
+	if err != nil {
+		return nil, NewErrUserNotFound(member.ID, err)
+	}
+
+	return &models.PrivateKeyIdentity{
+		Username: user.Username,
+		Role:     member.Role,
+	}, nil
+}
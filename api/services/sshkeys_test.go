@@ -14,6 +14,7 @@ import (
 	"github.com/shellhub-io/shellhub/pkg/errors"
 	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -432,6 +433,49 @@ func TestUpdatePublicKeys(t *testing.T) {
 				},
 			}, nil},
 		},
+		{
+			description: "Successful update the key with options",
+			fingerprint: "fingerprint",
+			tenantID:    "tenant",
+			keyUpdate: requests.PublicKeyUpdate{
+				Filter: requests.PublicKeyFilter{
+					Hostname: ".*",
+				},
+				Options: requests.PublicKeyOptions{
+					Command:     "/usr/local/bin/backup",
+					NoPTY:       true,
+					Environment: map[string]string{"TARGET": "s3"},
+				},
+			},
+			requiredMocks: func() {
+				model := models.PublicKeyUpdate{
+					PublicKeyFields: models.PublicKeyFields{
+						Filter: models.PublicKeyFilter{
+							Hostname: ".*",
+						},
+						Options: models.PublicKeyOptions{
+							Command:     "/usr/local/bin/backup",
+							NoPTY:       true,
+							Environment: map[string]string{"TARGET": "s3"},
+						},
+					},
+				}
+
+				mock.On("PublicKeyUpdate", ctx, "fingerprint", "tenant", &model).Return(&models.PublicKey{PublicKeyFields: model.PublicKeyFields}, nil).Once()
+			},
+			expected: Expected{&models.PublicKey{
+				PublicKeyFields: models.PublicKeyFields{
+					Filter: models.PublicKeyFilter{
+						Hostname: ".*",
+					},
+					Options: models.PublicKeyOptions{
+						Command:     "/usr/local/bin/backup",
+						NoPTY:       true,
+						Environment: map[string]string{"TARGET": "s3"},
+					},
+				},
+			}, nil},
+		},
 	}
 
 	for _, tc := range cases {
@@ -995,3 +1039,95 @@ func TestCreatePublicKeys(t *testing.T) {
 
 	mock.AssertExpectations(t)
 }
+
+func TestCreatePrivateKey(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	s := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+
+	type Expected struct {
+		identity *models.PrivateKeyIdentity
+		err      error
+	}
+
+	cases := []struct {
+		description   string
+		req           requests.PrivateKeyCreate
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "creates a private key without identity when no public key is set",
+			req:           requests.PrivateKeyCreate{},
+			requiredMocks: func() {},
+			expected:      Expected{nil, nil},
+		},
+		{
+			description: "creates a private key without identity when the public key does not exist",
+			req:         requests.PrivateKeyCreate{Fingerprint: "fingerprint", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, nil},
+		},
+		{
+			description: "creates a private key without identity when the creator is not a member",
+			req:         requests.PrivateKeyCreate{Fingerprint: "fingerprint", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").
+					Return(&models.PublicKey{Fingerprint: "fingerprint", TenantID: "tenant", CreatedBy: "id"}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").Return(&models.Namespace{TenantID: "tenant"}, nil).Once()
+			},
+			expected: Expected{nil, nil},
+		},
+		{
+			description: "fails when the public key cannot be retrieved",
+			req:         requests.PrivateKeyCreate{Fingerprint: "fingerprint", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").Return(nil, errors.New("error", "", 0)).Once()
+			},
+			expected: Expected{nil, errors.New("error", "", 0)},
+		},
+		{
+			description: "creates a private key to the member that created the public key",
+			req:         requests.PrivateKeyCreate{Fingerprint: "fingerprint", TenantID: "tenant"},
+			requiredMocks: func() {
+				mock.On("PublicKeyGet", ctx, "fingerprint", "tenant").
+					Return(&models.PublicKey{Fingerprint: "fingerprint", TenantID: "tenant", CreatedBy: "id"}, nil).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{
+						TenantID: "tenant",
+						Members:  []models.Member{{ID: "id", Role: "operator"}},
+					}, nil).Once()
+				mock.On("UserGetByID", ctx, "id", false).
+					Return(&models.User{ID: "id", UserData: models.UserData{Username: "john"}}, 0, nil).Once()
+			},
+			expected: Expected{&models.PrivateKeyIdentity{Username: "john", Role: "operator"}, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			if tc.expected.err == nil {
+				clockMock.On("Now").Return(now).Once()
+				mock.On("PrivateKeyCreate", ctx, testifymock.Anything).Return(nil).Once()
+			}
+
+			key, err := s.CreatePrivateKey(ctx, tc.req)
+			if tc.expected.err != nil {
+				assert.Equal(t, tc.expected, Expected{nil, err})
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.identity, key.Identity)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,221 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+const (
+	// TunnelModeSNI routes the tunnel's connections through TLS, with the tunnel's address as the server name.
+	TunnelModeSNI = "sni"
+	// TunnelModePort routes the tunnel's connections through plain TCP to a port allocated to the tunnel.
+	TunnelModePort = "port"
+)
+
+// TunnelGrantDuration is for how long the access to a tunnel is granted when no duration is requested.
+const TunnelGrantDuration = time.Hour
+
+type TunnelService interface {
+	ListTunnels(ctx context.Context, uid models.UID, tenant string, pagination paginator.Query) ([]models.Tunnel, int, error)
+	CreateTunnel(ctx context.Context, uid models.UID, tenant, host string, port int, mode string) (*models.Tunnel, error)
+	DeleteTunnel(ctx context.Context, uid models.UID, tenant, address string) error
+	GrantTunnel(ctx context.Context, uid models.UID, tenant, address, user, source string, duration time.Duration) (*models.TunnelGrant, error)
+	LookupTunnel(ctx context.Context, address, source string) (*models.Tunnel, error)
+	LookupTunnelByPort(ctx context.Context, port int, source string) (*models.Tunnel, error)
+}
+
+// ListTunnels lists the tunnels created to a device from a namespace.
+func (s *service) ListTunnels(ctx context.Context, uid models.UID, tenant string, pagination paginator.Query) ([]models.Tunnel, int, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return nil, 0, NewErrDeviceNotFound(uid, err)
+	}
+
+	return s.store.TunnelList(ctx, uid, pagination)
+}
+
+// CreateTunnel creates a raw TCP tunnel to the host and port reachable from the device, which must be accepted.
+//
+// The tunnel's address is generated randomly and it is used to route the incoming connections, through TLS SNI, to the
+// device. In the port mode, the tunnel also gets a port, from the SHELLHUB_TUNNELS_PORTS range, where its plain TCP
+// connections are received.
+func (s *service) CreateTunnel(ctx context.Context, uid models.UID, tenant, host string, port int, mode string) (*models.Tunnel, error) {
+	device, err := s.store.DeviceGetByUID(ctx, uid, tenant)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return nil, NewErrTunnelDeviceNotAccepted(uid, nil)
+	}
+
+	address, err := generateTunnelAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	tunnel := &models.Tunnel{
+		Address:   address,
+		TenantID:  device.TenantID,
+		Device:    device.UID,
+		Host:      host,
+		Port:      port,
+		Grants:    []models.TunnelGrant{},
+		CreatedAt: clock.Now(),
+	}
+
+	if mode != TunnelModePort {
+		if err := s.store.TunnelCreate(ctx, tunnel); err != nil {
+			return nil, err
+		}
+
+		return tunnel, nil
+	}
+
+	first, last, err := models.ParseTunnelPorts(envs.DefaultBackend.Get("SHELLHUB_TUNNELS_PORTS"))
+	if err != nil {
+		return nil, NewErrTunnelPortsUnavailable(0, nil)
+	}
+
+	// NOTICE: The listen port is unique in the store, so the first port not taken by another tunnel is allocated.
+	for listen := first; listen <= last; listen++ {
+		tunnel.ListenPort = listen
+
+		switch err := s.store.TunnelCreate(ctx, tunnel); err {
+		case nil:
+			return tunnel, nil
+		case store.ErrDuplicate:
+			continue
+		default:
+			return nil, err
+		}
+	}
+
+	return nil, NewErrTunnelPortsUnavailable(last-first+1, nil)
+}
+
+// DeleteTunnel deletes a tunnel from a device.
+func (s *service) DeleteTunnel(ctx context.Context, uid models.UID, tenant, address string) error {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if err := s.store.TunnelDelete(ctx, uid, address); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return NewErrTunnelNotFound(address, err)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GrantTunnel grants the member's access to a tunnel, from the source IP address, for a duration.
+func (s *service) GrantTunnel(ctx context.Context, uid models.UID, tenant, address, user, source string, duration time.Duration) (*models.TunnelGrant, error) {
+	if _, err := s.store.DeviceGetByUID(ctx, uid, tenant); err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	if duration <= 0 {
+		duration = TunnelGrantDuration
+	}
+
+	now := clock.Now()
+
+	grant := models.TunnelGrant{
+		Source:    source,
+		User:      user,
+		ExpiresAt: now.Add(duration),
+	}
+
+	if err := s.store.TunnelGrant(ctx, uid, address, grant, now); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return nil, NewErrTunnelNotFound(address, err)
+		default:
+			return nil, err
+		}
+	}
+
+	return &grant, nil
+}
+
+// LookupTunnel gets a tunnel by its address, to a connection coming from source.
+func (s *service) LookupTunnel(ctx context.Context, address, source string) (*models.Tunnel, error) {
+	tunnel, err := s.store.TunnelGet(ctx, address)
+	if err != nil {
+		return nil, NewErrTunnelNotFound(address, err)
+	}
+
+	if err := s.authorizeTunnel(ctx, tunnel, source); err != nil {
+		return nil, err
+	}
+
+	return tunnel, nil
+}
+
+// LookupTunnelByPort gets a tunnel by the port it listens on, to a connection coming from source.
+func (s *service) LookupTunnelByPort(ctx context.Context, port int, source string) (*models.Tunnel, error) {
+	tunnel, err := s.store.TunnelGetByPort(ctx, port)
+	if err != nil {
+		return nil, NewErrTunnelNotFound(strconv.Itoa(port), err)
+	}
+
+	if err := s.authorizeTunnel(ctx, tunnel, source); err != nil {
+		return nil, err
+	}
+
+	return tunnel, nil
+}
+
+// authorizeTunnel checks if a connection from source may go through the tunnel. The tunnel's device must still be
+// accepted, and the access must have been granted to source by a member who is still allowed to connect to it.
+func (s *service) authorizeTunnel(ctx context.Context, tunnel *models.Tunnel, source string) error {
+	device, err := s.store.DeviceGetByUID(ctx, models.UID(tunnel.Device), tunnel.TenantID)
+	if err != nil {
+		return NewErrDeviceNotFound(models.UID(tunnel.Device), err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return NewErrTunnelDeviceNotAccepted(models.UID(device.UID), nil)
+	}
+
+	grants := tunnel.ActiveGrants(source, clock.Now())
+	if len(grants) == 0 {
+		return NewErrTunnelForbidden(source, nil)
+	}
+
+	namespace, err := s.store.NamespaceGet(ctx, tunnel.TenantID)
+	if err != nil {
+		return NewErrNamespaceNotFound(tunnel.TenantID, err)
+	}
+
+	for _, grant := range grants {
+		if guard.EvaluateNamespace(namespace, grant.User, guard.Actions.Tunnel.Connect, func() error { return nil }) == nil {
+			return nil
+		}
+	}
+
+	return NewErrTunnelForbidden(source, nil)
+}
+
+// generateTunnelAddress generates a random hexadecimal address, valid as a DNS label, to a tunnel.
+func generateTunnelAddress() (string, error) {
+	buffer := make([]byte, 8)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(buffer), nil
+}
@@ -0,0 +1,553 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/guard"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	testifymock "github.com/stretchr/testify/mock"
+)
+
+func TestListTunnels(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		tunnels []models.Tunnel
+		count   int
+		err     error
+	}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		tenant        string
+		pagination    paginator.Query
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			pagination:  paginator.Query{Page: 1, PerPage: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				tunnels: nil,
+				count:   0,
+				err:     NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+			},
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			pagination:  paginator.Query{Page: 1, PerPage: 10},
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("TunnelList", ctx, models.UID("uid"), paginator.Query{Page: 1, PerPage: 10}).
+					Return([]models.Tunnel{{Address: "address", Device: "uid", TenantID: "tenant", Host: "localhost", Port: 80}}, 1, nil).Once()
+			},
+			expected: Expected{
+				tunnels: []models.Tunnel{{Address: "address", Device: "uid", TenantID: "tenant", Host: "localhost", Port: 80}},
+				count:   1,
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			tunnels, count, err := service.ListTunnels(ctx, tc.uid, tc.tenant, tc.pagination)
+			assert.Equal(t, tc.expected, Expected{tunnels, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestCreateTunnel(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	accepted := &models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		tenant        string
+		host          string
+		port          int
+		mode          string
+		requiredMocks func()
+		expected      error
+		listen        int
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+		},
+		{
+			description: "fails when the device is not accepted",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: NewErrTunnelDeviceNotAccepted(models.UID("uid"), nil),
+		},
+		{
+			description: "fails when the store tunnel create fails",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("TunnelCreate", ctx, testifymock.AnythingOfType("*models.Tunnel")).
+					Return(goerrors.New("error")).Once()
+			},
+			expected: goerrors.New("error"),
+		},
+		{
+			description: "fails when the tunnels ports are not configured",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			mode:        TunnelModePort,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_TUNNELS_PORTS").Return("").Once()
+			},
+			expected: NewErrTunnelPortsUnavailable(0, nil),
+		},
+		{
+			description: "fails when all the tunnels ports are taken",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			mode:        TunnelModePort,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_TUNNELS_PORTS").Return("40000-40001").Once()
+				mock.On("TunnelCreate", ctx, testifymock.AnythingOfType("*models.Tunnel")).
+					Return(store.ErrDuplicate).Twice()
+			},
+			expected: NewErrTunnelPortsUnavailable(2, nil),
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("TunnelCreate", ctx, testifymock.AnythingOfType("*models.Tunnel")).
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds when the mode is port",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			host:        "localhost",
+			port:        80,
+			mode:        TunnelModePort,
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(accepted, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_TUNNELS_PORTS").Return("40000-40099").Once()
+				mock.On("TunnelCreate", ctx, testifymock.AnythingOfType("*models.Tunnel")).
+					Return(store.ErrDuplicate).Once()
+				mock.On("TunnelCreate", ctx, testifymock.AnythingOfType("*models.Tunnel")).
+					Return(nil).Once()
+			},
+			expected: nil,
+			listen:   40001,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			tunnel, err := service.CreateTunnel(ctx, tc.uid, tc.tenant, tc.host, tc.port, tc.mode)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				assert.NotEmpty(t, tunnel.Address)
+				assert.Equal(t, &models.Tunnel{
+					Address:    tunnel.Address,
+					TenantID:   tc.tenant,
+					Device:     string(tc.uid),
+					Host:       tc.host,
+					Port:       tc.port,
+					ListenPort: tc.listen,
+					Grants:     []models.TunnelGrant{},
+					CreatedAt:  now,
+				}, tunnel)
+			}
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteTunnel(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		tenant        string
+		address       string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			address:     "address",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error")),
+		},
+		{
+			description: "fails when the tunnel is not found",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			address:     "address",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("TunnelDelete", ctx, models.UID("uid"), "address").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrTunnelNotFound("address", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			uid:         models.UID("uid"),
+			tenant:      "tenant",
+			address:     "address",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("TunnelDelete", ctx, models.UID("uid"), "address").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			err := service.DeleteTunnel(ctx, tc.uid, tc.tenant, tc.address)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGrantTunnel(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	grant := models.TunnelGrant{Source: "10.0.0.1", User: "user", ExpiresAt: now.Add(TunnelGrantDuration)}
+
+	type Expected struct {
+		grant *models.TunnelGrant
+		err   error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(nil, goerrors.New("error")).Once()
+			},
+			expected: Expected{nil, NewErrDeviceNotFound(models.UID("uid"), goerrors.New("error"))},
+		},
+		{
+			description: "fails when the tunnel is not found",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("TunnelGrant", ctx, models.UID("uid"), "address", grant, now).
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrTunnelNotFound("address", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("TunnelGrant", ctx, models.UID("uid"), "address", grant, now).
+					Return(nil).Once()
+			},
+			expected: Expected{&grant, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			grant, err := service.GrantTunnel(ctx, models.UID("uid"), "tenant", "address", "user", "10.0.0.1", 0)
+			assert.Equal(t, tc.expected, Expected{grant, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestLookupTunnel(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	tunnel := &models.Tunnel{
+		Address:  "address",
+		TenantID: "tenant",
+		Device:   "uid",
+		Host:     "localhost",
+		Port:     80,
+		Grants: []models.TunnelGrant{
+			{Source: "10.0.0.1", User: "observer", ExpiresAt: now.Add(time.Hour)},
+			{Source: "10.0.0.1", User: "operator", ExpiresAt: now.Add(time.Hour)},
+			{Source: "10.0.0.2", User: "operator", ExpiresAt: now.Add(-time.Hour)},
+		},
+	}
+
+	namespace := &models.Namespace{
+		TenantID: "tenant",
+		Members: []models.Member{
+			{ID: "observer", Role: guard.RoleObserver},
+			{ID: "operator", Role: guard.RoleOperator},
+		},
+	}
+
+	type Expected struct {
+		tunnel *models.Tunnel
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		source        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the tunnel is not found",
+			source:      "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("TunnelGet", ctx, "address").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				tunnel: nil,
+				err:    NewErrTunnelNotFound("address", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the device is not accepted",
+			source:      "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("TunnelGet", ctx, "address").
+					Return(tunnel, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusRejected}, nil).Once()
+			},
+			expected: Expected{
+				tunnel: nil,
+				err:    NewErrTunnelDeviceNotAccepted(models.UID("uid"), nil),
+			},
+		},
+		{
+			description: "fails when the grant to the source is expired",
+			source:      "10.0.0.2",
+			requiredMocks: func() {
+				mock.On("TunnelGet", ctx, "address").
+					Return(tunnel, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				tunnel: nil,
+				err:    NewErrTunnelForbidden("10.0.0.2", nil),
+			},
+		},
+		{
+			description: "fails when the member who granted the source is no longer allowed",
+			source:      "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("TunnelGet", ctx, "address").
+					Return(tunnel, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Members: namespace.Members[:1]}, nil).Once()
+			},
+			expected: Expected{
+				tunnel: nil,
+				err:    NewErrTunnelForbidden("10.0.0.1", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			source:      "10.0.0.1",
+			requiredMocks: func() {
+				mock.On("TunnelGet", ctx, "address").
+					Return(tunnel, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(namespace, nil).Once()
+			},
+			expected: Expected{
+				tunnel: tunnel,
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			tunnel, err := service.LookupTunnel(ctx, "address", tc.source)
+			assert.Equal(t, tc.expected, Expected{tunnel, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestLookupTunnelByPort(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	tunnel := &models.Tunnel{
+		Address:    "address",
+		TenantID:   "tenant",
+		Device:     "uid",
+		Host:       "localhost",
+		Port:       80,
+		ListenPort: 40000,
+		Grants: []models.TunnelGrant{
+			{Source: "10.0.0.1", User: "operator", ExpiresAt: now.Add(time.Hour)},
+		},
+	}
+
+	type Expected struct {
+		tunnel *models.Tunnel
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the tunnel is not found",
+			requiredMocks: func() {
+				mock.On("TunnelGetByPort", ctx, 40000).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{nil, NewErrTunnelNotFound("40000", store.ErrNoDocuments)},
+		},
+		{
+			description: "succeeds",
+			requiredMocks: func() {
+				mock.On("TunnelGetByPort", ctx, 40000).
+					Return(tunnel, nil).Once()
+				mock.On("DeviceGetByUID", ctx, models.UID("uid"), "tenant").
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("NamespaceGet", ctx, "tenant").
+					Return(&models.Namespace{TenantID: "tenant", Members: []models.Member{{ID: "operator", Role: guard.RoleOperator}}}, nil).Once()
+			},
+			expected: Expected{tunnel, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			tunnel, err := service.LookupTunnelByPort(ctx, 40000, "10.0.0.1")
+			assert.Equal(t, tc.expected, Expected{tunnel, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	"github.com/shellhub-io/shellhub/pkg/clock"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+// DefaultUpdatePolicyCanary is the percentage of the selected devices allowed to update when none is requested.
+const DefaultUpdatePolicyCanary = 100
+
+type UpdatePolicyService interface {
+	ListUpdatePolicies(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error)
+	GetUpdatePolicy(ctx context.Context, tenant, id string) (*models.UpdatePolicy, error)
+	CreateUpdatePolicy(ctx context.Context, tenant string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error)
+	UpdateUpdatePolicy(ctx context.Context, tenant, id string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error)
+	DeleteUpdatePolicy(ctx context.Context, tenant, id string) error
+	ListDeviceUpdates(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceUpdateState, int, error)
+	GetDeviceUpdateTarget(ctx context.Context, uid models.UID) (*models.DeviceUpdateTarget, error)
+	ReportDeviceUpdate(ctx context.Context, uid models.UID, report requests.DeviceUpdateReport) error
+}
+
+// ListUpdatePolicies lists the update policies from a namespace.
+func (s *service) ListUpdatePolicies(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error) {
+	return s.store.UpdatePolicyList(ctx, tenant, pagination)
+}
+
+// GetUpdatePolicy gets an update policy from a namespace.
+func (s *service) GetUpdatePolicy(ctx context.Context, tenant, id string) (*models.UpdatePolicy, error) {
+	policy, err := s.store.UpdatePolicyGet(ctx, id)
+	if err != nil {
+		return nil, NewErrUpdatePolicyNotFound(id, err)
+	}
+
+	if policy.TenantID != tenant {
+		return nil, NewErrUpdatePolicyNotFound(id, nil)
+	}
+
+	return policy, nil
+}
+
+// CreateUpdatePolicy creates an update policy to a namespace. The devices apply it the next time they poll their
+// update policy.
+func (s *service) CreateUpdatePolicy(ctx context.Context, tenant string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error) {
+	policy := new(models.UpdatePolicy)
+	if err := applyUpdatePolicyData(policy, data); err != nil {
+		return nil, err
+	}
+
+	policy.ID = uuid.Generate()
+	policy.TenantID = tenant
+	policy.CreatedAt = clock.Now()
+	policy.UpdatedAt = policy.CreatedAt
+
+	if err := s.store.UpdatePolicyCreate(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// UpdateUpdatePolicy replaces the data of an update policy from a namespace.
+func (s *service) UpdateUpdatePolicy(ctx context.Context, tenant, id string, data requests.UpdatePolicyData) (*models.UpdatePolicy, error) {
+	policy, err := s.GetUpdatePolicy(ctx, tenant, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyUpdatePolicyData(policy, data); err != nil {
+		return nil, err
+	}
+
+	policy.UpdatedAt = clock.Now()
+
+	if err := s.store.UpdatePolicyUpdate(ctx, policy); err != nil {
+		return nil, NewErrUpdatePolicyNotFound(id, err)
+	}
+
+	return policy, nil
+}
+
+// DeleteUpdatePolicy deletes an update policy from a namespace. Its devices keep the version they run.
+func (s *service) DeleteUpdatePolicy(ctx context.Context, tenant, id string) error {
+	if err := s.store.UpdatePolicyDelete(ctx, tenant, id); err != nil {
+		switch err {
+		case store.ErrNoDocuments:
+			return NewErrUpdatePolicyNotFound(id, err)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListDeviceUpdates lists the rollout's state of the accepted devices from a namespace.
+func (s *service) ListDeviceUpdates(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceUpdateState, int, error) {
+	policies, _, err := s.store.UpdatePolicyList(ctx, tenant, paginator.Query{Page: -1, PerPage: -1})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: tenant},
+		},
+	}
+
+	devices, count, err := s.store.DeviceList(ctx, pagination, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	now := clock.Now()
+
+	states := make([]models.DeviceUpdateState, 0, len(devices))
+	for i := range devices {
+		states = append(states, *deviceUpdateState(&devices[i], policies, now))
+	}
+
+	return states, count, nil
+}
+
+// GetDeviceUpdateTarget gets the agent's version the device should update to now, polled by the device's agent.
+//
+// A device without a policy follows the server's version, only updating when it is newer than the agent's version.
+func (s *service) GetDeviceUpdateTarget(ctx context.Context, uid models.UID) (*models.DeviceUpdateTarget, error) {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return nil, NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return nil, NewErrDeviceStatusInvalid(string(device.Status), nil)
+	}
+
+	policies, _, err := s.store.UpdatePolicyList(ctx, device.TenantID, paginator.Query{Page: -1, PerPage: -1})
+	if err != nil {
+		return nil, err
+	}
+
+	state := deviceUpdateState(device, policies, clock.Now())
+
+	target := new(models.DeviceUpdateTarget)
+	switch state.Status { //nolint:exhaustive
+	case models.DeviceUpdateStatusPending:
+		target.Version = state.Target
+	case models.DeviceUpdateStatusUnmanaged:
+		current, err := semver.NewVersion(state.Version)
+		if err != nil {
+			break
+		}
+
+		if next, err := semver.NewVersion(state.Target); err == nil && next.GreaterThan(current) {
+			target.Version = state.Target
+		}
+	}
+
+	return target, nil
+}
+
+// ReportDeviceUpdate stores the update attempt reported by the device's agent.
+func (s *service) ReportDeviceUpdate(ctx context.Context, uid models.UID, report requests.DeviceUpdateReport) error {
+	device, err := s.store.DeviceGet(ctx, uid)
+	if err != nil {
+		return NewErrDeviceNotFound(uid, err)
+	}
+
+	if device.Status != models.DeviceStatusAccepted {
+		return NewErrDeviceStatusInvalid(string(device.Status), nil)
+	}
+
+	return s.store.DeviceSetUpdate(ctx, uid, &models.DeviceUpdate{
+		Version:   report.Version,
+		Status:    models.DeviceUpdateStatus(report.Status),
+		Error:     report.Error,
+		UpdatedAt: clock.Now(),
+	})
+}
+
+// applyUpdatePolicyData sets the data sent by the user to the policy, applying the defaults to what wasn't sent.
+func applyUpdatePolicyData(policy *models.UpdatePolicy, data requests.UpdatePolicyData) error {
+	if _, err := semver.NewVersion(data.Version); err != nil {
+		return NewErrUpdatePolicyVersionInvalid(data.Version, err)
+	}
+
+	policy.Name = data.Name
+	policy.Tags = data.Tags
+	policy.Version = data.Version
+	policy.Canary = DefaultUpdatePolicyCanary
+	policy.Windows = make([]models.UpdateWindow, 0, len(data.Windows))
+
+	if policy.Tags == nil {
+		policy.Tags = []string{}
+	}
+
+	if data.Canary != nil {
+		policy.Canary = *data.Canary
+	}
+
+	for _, window := range data.Windows {
+		policy.Windows = append(policy.Windows, models.UpdateWindow{
+			Days:  window.Days,
+			Start: window.Start,
+			End:   window.End,
+		})
+	}
+
+	return nil
+}
+
+// selectUpdatePolicy selects, from the namespace's policies, the one that applies to a device with the tags. When more
+// than one matches, the one with more tags, what is the more specific, wins; on a tie, the last updated one.
+func selectUpdatePolicy(policies []models.UpdatePolicy, tags []string) *models.UpdatePolicy {
+	var selected *models.UpdatePolicy
+	for i := range policies {
+		policy := &policies[i]
+		if !policy.Matches(tags) {
+			continue
+		}
+
+		if selected == nil ||
+			len(policy.Tags) > len(selected.Tags) ||
+			(len(policy.Tags) == len(selected.Tags) && policy.UpdatedAt.After(selected.UpdatedAt)) {
+			selected = policy
+		}
+	}
+
+	return selected
+}
+
+// deviceUpdateState evaluates the rollout's state of the device at now.
+func deviceUpdateState(device *models.Device, policies []models.UpdatePolicy, now time.Time) *models.DeviceUpdateState {
+	state := &models.DeviceUpdateState{
+		UID:  device.UID,
+		Name: device.Name,
+	}
+
+	if device.Info != nil {
+		state.Version = device.Info.Version
+	}
+
+	if device.Update != nil {
+		state.UpdatedAt = &device.Update.UpdatedAt
+	}
+
+	policy := selectUpdatePolicy(policies, device.Tags)
+	if policy == nil {
+		state.Target = envs.DefaultBackend.Get("SHELLHUB_VERSION")
+		state.Status = models.DeviceUpdateStatusUnmanaged
+
+		return state
+	}
+
+	state.Policy = policy.ID
+	state.Target = policy.Version
+
+	// NOTICE: An attempt reported to another version than the policy's is outdated, so it is ignored.
+	attempt := device.Update
+	if attempt != nil && !sameVersion(attempt.Version, policy.Version) {
+		attempt = nil
+	}
+
+	switch {
+	case sameVersion(state.Version, policy.Version):
+		state.Status = models.DeviceUpdateStatusUpdated
+	case newerVersion(state.Version, policy.Version):
+		state.Status = models.DeviceUpdateStatusAhead
+	case attempt != nil && attempt.Status == models.DeviceUpdateStatusFailed:
+		state.Status = models.DeviceUpdateStatusFailed
+		state.Error = attempt.Error
+	case attempt != nil && now.Sub(attempt.UpdatedAt) > models.DeviceUpdateTimeout:
+		state.Status = models.DeviceUpdateStatusFailed
+		state.Error = "device did not come back with the version"
+	case attempt != nil:
+		state.Status = models.DeviceUpdateStatusUpdating
+	case !policy.InCanary(models.UID(device.UID)):
+		state.Status = models.DeviceUpdateStatusExcluded
+	case !policy.InWindow(now):
+		state.Status = models.DeviceUpdateStatusWaiting
+	default:
+		state.Status = models.DeviceUpdateStatusPending
+	}
+
+	return state
+}
+
+// newerVersion checks if a is a newer semantic version than b. Versions that aren't semantic are never newer.
+func newerVersion(a, b string) bool {
+	va, err := semver.NewVersion(a)
+	if err != nil {
+		return false
+	}
+
+	vb, err := semver.NewVersion(b)
+	if err != nil {
+		return false
+	}
+
+	return va.GreaterThan(vb)
+}
+
+// sameVersion checks if both versions are the same, what is done semantically, like "v0.15.0" and "0.15.0", when
+// they are semantic versions.
+func sameVersion(a, b string) bool {
+	va, err := semver.NewVersion(a)
+	if err != nil {
+		return a == b
+	}
+
+	vb, err := semver.NewVersion(b)
+	if err != nil {
+		return a == b
+	}
+
+	return va.Equal(vb)
+}
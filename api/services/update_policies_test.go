@@ -0,0 +1,595 @@
+package services
+
+import (
+	"context"
+	goerrors "errors"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mocks"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/api/requests"
+	storecache "github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	uuid_mocks "github.com/shellhub-io/shellhub/pkg/uuid/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateUpdatePolicy(t *testing.T) {
+	mock := new(mocks.Store)
+
+	uuidMock := &uuid_mocks.Uuid{}
+	uuid.DefaultBackend = uuidMock
+
+	ctx := context.TODO()
+
+	_, errVersion := semver.NewVersion("latest")
+
+	canary := 10
+
+	type Expected struct {
+		policy *models.UpdatePolicy
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		data          requests.UpdatePolicyData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when the version is invalid",
+			tenant:        "tenant",
+			data:          requests.UpdatePolicyData{Name: "fleet", Version: "latest"},
+			requiredMocks: func() {},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrUpdatePolicyVersionInvalid("latest", errVersion),
+			},
+		},
+		{
+			description: "fails when the store update policy create fails",
+			tenant:      "tenant",
+			data:        requests.UpdatePolicyData{Name: "fleet", Version: "v0.15.0"},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("UpdatePolicyCreate", ctx, &models.UpdatePolicy{
+					ID:        "id",
+					TenantID:  "tenant",
+					Name:      "fleet",
+					Tags:      []string{},
+					Version:   "v0.15.0",
+					Canary:    DefaultUpdatePolicyCanary,
+					Windows:   []models.UpdateWindow{},
+					CreatedAt: now,
+					UpdatedAt: now,
+				}).Return(goerrors.New("error")).Once()
+			},
+			expected: Expected{
+				policy: nil,
+				err:    goerrors.New("error"),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			data: requests.UpdatePolicyData{
+				Name:    "canary",
+				Tags:    []string{"production"},
+				Version: "v0.15.0",
+				Canary:  &canary,
+				Windows: []requests.UpdateWindowData{{Days: []int{6, 0}, Start: "22:00", End: "04:00"}},
+			},
+			requiredMocks: func() {
+				uuidMock.On("Generate").Return("id").Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("UpdatePolicyCreate", ctx, &models.UpdatePolicy{
+					ID:        "id",
+					TenantID:  "tenant",
+					Name:      "canary",
+					Tags:      []string{"production"},
+					Version:   "v0.15.0",
+					Canary:    10,
+					Windows:   []models.UpdateWindow{{Days: []int{6, 0}, Start: "22:00", End: "04:00"}},
+					CreatedAt: now,
+					UpdatedAt: now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				policy: &models.UpdatePolicy{
+					ID:        "id",
+					TenantID:  "tenant",
+					Name:      "canary",
+					Tags:      []string{"production"},
+					Version:   "v0.15.0",
+					Canary:    10,
+					Windows:   []models.UpdateWindow{{Days: []int{6, 0}, Start: "22:00", End: "04:00"}},
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			policy, err := service.CreateUpdatePolicy(ctx, tc.tenant, tc.data)
+			assert.Equal(t, tc.expected, Expected{policy, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestUpdateUpdatePolicy(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	type Expected struct {
+		policy *models.UpdatePolicy
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		data          requests.UpdatePolicyData
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the policy is not found",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.UpdatePolicyData{Name: "fleet", Version: "v0.15.0"},
+			requiredMocks: func() {
+				mock.On("UpdatePolicyGet", ctx, "id").
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrUpdatePolicyNotFound("id", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the policy belongs to another namespace",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.UpdatePolicyData{Name: "fleet", Version: "v0.15.0"},
+			requiredMocks: func() {
+				mock.On("UpdatePolicyGet", ctx, "id").
+					Return(&models.UpdatePolicy{ID: "id", TenantID: "other"}, nil).Once()
+			},
+			expected: Expected{
+				policy: nil,
+				err:    NewErrUpdatePolicyNotFound("id", nil),
+			},
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			data:        requests.UpdatePolicyData{Name: "fleet", Tags: []string{"staging"}, Version: "v0.16.0"},
+			requiredMocks: func() {
+				mock.On("UpdatePolicyGet", ctx, "id").
+					Return(&models.UpdatePolicy{ID: "id", TenantID: "tenant", Name: "old", Version: "v0.15.0", Canary: 10, CreatedAt: now}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("UpdatePolicyUpdate", ctx, &models.UpdatePolicy{
+					ID:        "id",
+					TenantID:  "tenant",
+					Name:      "fleet",
+					Tags:      []string{"staging"},
+					Version:   "v0.16.0",
+					Canary:    DefaultUpdatePolicyCanary,
+					Windows:   []models.UpdateWindow{},
+					CreatedAt: now,
+					UpdatedAt: now,
+				}).Return(nil).Once()
+			},
+			expected: Expected{
+				policy: &models.UpdatePolicy{
+					ID:        "id",
+					TenantID:  "tenant",
+					Name:      "fleet",
+					Tags:      []string{"staging"},
+					Version:   "v0.16.0",
+					Canary:    DefaultUpdatePolicyCanary,
+					Windows:   []models.UpdateWindow{},
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				err: nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			policy, err := service.UpdateUpdatePolicy(ctx, tc.tenant, tc.id, tc.data)
+			assert.Equal(t, tc.expected, Expected{policy, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDeleteUpdatePolicy(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		tenant        string
+		id            string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the policy is not found",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("UpdatePolicyDelete", ctx, "tenant", "id").
+					Return(store.ErrNoDocuments).Once()
+			},
+			expected: NewErrUpdatePolicyNotFound("id", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			id:          "id",
+			requiredMocks: func() {
+				mock.On("UpdatePolicyDelete", ctx, "tenant", "id").
+					Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.DeleteUpdatePolicy(ctx, tc.tenant, tc.id))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestGetDeviceUpdateTarget(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	all := paginator.Query{Page: -1, PerPage: -1}
+
+	// NOTICE: A window that opens two hours after now is always closed at now.
+	closed := models.UpdateWindow{
+		Start: now.UTC().Add(2 * time.Hour).Format("15:04"),
+		End:   now.UTC().Add(3 * time.Hour).Format("15:04"),
+	}
+
+	device := func(version string, tags []string, update *models.DeviceUpdate) *models.Device {
+		return &models.Device{
+			UID:      "uid",
+			TenantID: "tenant",
+			Status:   models.DeviceStatusAccepted,
+			Tags:     tags,
+			Info:     &models.DeviceInfo{Version: version},
+			Update:   update,
+		}
+	}
+
+	type Expected struct {
+		target *models.DeviceUpdateTarget
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: Expected{
+				target: nil,
+				err:    NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+			},
+		},
+		{
+			description: "fails when the device is not accepted",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusPending}, nil).Once()
+			},
+			expected: Expected{
+				target: nil,
+				err:    NewErrDeviceStatusInvalid(string(models.DeviceStatusPending), nil),
+			},
+		},
+		{
+			description: "succeeds with the server's version when no policy applies and it is newer",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{"production"}, Version: "v0.16.0", Canary: 100}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_VERSION").Return("v0.15.0").Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{Version: "v0.15.0"},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds without a version when no policy applies and the server's version is not newer",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.15.0", []string{}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{}, 0, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_VERSION").Return("v0.15.0").Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds with the version of the most specific policy",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{"production"}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{
+						{ID: "namespace", Tags: []string{}, Version: "v0.15.0", Canary: 100, UpdatedAt: now},
+						{ID: "production", Tags: []string{"production"}, Version: "v0.14.1", Canary: 100},
+					}, 2, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{Version: "v0.14.1"},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds without a version when the device runs the policy's version",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("0.15.0", []string{}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{}, Version: "v0.15.0", Canary: 100}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds without a version when the device is not among the canary devices",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{}, Version: "v0.15.0", Canary: 0}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds without a version when no maintenance window is open",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{}, nil), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{}, Version: "v0.15.0", Canary: 100, Windows: []models.UpdateWindow{closed}}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds without a version when the update to the policy's version has failed",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{}, &models.DeviceUpdate{Version: "v0.15.0", Status: models.DeviceUpdateStatusFailed, UpdatedAt: now}), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{}, Version: "v0.15.0", Canary: 100}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{},
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds with the version when the failed update was to another version",
+			uid:         "uid",
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(device("v0.14.0", []string{}, &models.DeviceUpdate{Version: "v0.14.1", Status: models.DeviceUpdateStatusFailed, UpdatedAt: now}), nil).Once()
+				mock.On("UpdatePolicyList", ctx, "tenant", all).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{}, Version: "v0.15.0", Canary: 100}}, 1, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{Version: "v0.15.0"},
+				err:    nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			target, err := service.GetDeviceUpdateTarget(ctx, tc.uid)
+			assert.Equal(t, tc.expected, Expected{target, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestReportDeviceUpdate(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	cases := []struct {
+		description   string
+		uid           models.UID
+		report        requests.DeviceUpdateReport
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         "uid",
+			report:      requests.DeviceUpdateReport{Version: "v0.15.0", Status: "updating"},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(nil, store.ErrNoDocuments).Once()
+			},
+			expected: NewErrDeviceNotFound("uid", store.ErrNoDocuments),
+		},
+		{
+			description: "succeeds",
+			uid:         "uid",
+			report:      requests.DeviceUpdateReport{Version: "v0.15.0", Status: "failed", Error: "signature does not match"},
+			requiredMocks: func() {
+				mock.On("DeviceGet", ctx, models.UID("uid")).
+					Return(&models.Device{UID: "uid", TenantID: "tenant", Status: models.DeviceStatusAccepted}, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				mock.On("DeviceSetUpdate", ctx, models.UID("uid"), &models.DeviceUpdate{
+					Version:   "v0.15.0",
+					Status:    models.DeviceUpdateStatusFailed,
+					Error:     "signature does not match",
+					UpdatedAt: now,
+				}).Return(nil).Once()
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			assert.Equal(t, tc.expected, service.ReportDeviceUpdate(ctx, tc.uid, tc.report))
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestListDeviceUpdates(t *testing.T) {
+	mock := new(mocks.Store)
+
+	ctx := context.TODO()
+
+	startedAt := now.Add(-2 * models.DeviceUpdateTimeout)
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: "tenant"},
+		},
+	}
+
+	type Expected struct {
+		states []models.DeviceUpdateState
+		count  int
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		tenant        string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "succeeds",
+			tenant:      "tenant",
+			requiredMocks: func() {
+				mock.On("UpdatePolicyList", ctx, "tenant", paginator.Query{Page: -1, PerPage: -1}).
+					Return([]models.UpdatePolicy{{ID: "policy", Tags: []string{"production"}, Version: "v0.15.0", Canary: 100}}, 1, nil).Once()
+				mock.On("DeviceList", ctx, paginator.Query{Page: 1, PerPage: 10}, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault).
+					Return([]models.Device{
+						{UID: "updated", Name: "updated", Tags: []string{"production"}, Info: &models.DeviceInfo{Version: "v0.15.0"}},
+						{UID: "ahead", Name: "ahead", Tags: []string{"production"}, Info: &models.DeviceInfo{Version: "v0.16.0"}},
+						{UID: "timeout", Name: "timeout", Tags: []string{"production"}, Info: &models.DeviceInfo{Version: "v0.14.0"}, Update: &models.DeviceUpdate{Version: "v0.15.0", Status: models.DeviceUpdateStatusUpdating, UpdatedAt: startedAt}},
+						{UID: "unmanaged", Name: "unmanaged", Tags: []string{}, Info: &models.DeviceInfo{Version: "v0.14.0"}},
+					}, 4, nil).Once()
+				clockMock.On("Now").Return(now).Once()
+				envMock.On("Get", "SHELLHUB_VERSION").Return("v0.15.0").Once()
+			},
+			expected: Expected{
+				states: []models.DeviceUpdateState{
+					{UID: "updated", Name: "updated", Version: "v0.15.0", Policy: "policy", Target: "v0.15.0", Status: models.DeviceUpdateStatusUpdated},
+					{UID: "ahead", Name: "ahead", Version: "v0.16.0", Policy: "policy", Target: "v0.15.0", Status: models.DeviceUpdateStatusAhead},
+					{UID: "timeout", Name: "timeout", Version: "v0.14.0", Policy: "policy", Target: "v0.15.0", Status: models.DeviceUpdateStatusFailed, Error: "device did not come back with the version", UpdatedAt: &startedAt},
+					{UID: "unmanaged", Name: "unmanaged", Version: "v0.14.0", Target: "v0.15.0", Status: models.DeviceUpdateStatusUnmanaged},
+				},
+				count: 4,
+				err:   nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			service := NewService(store.Store(mock), privateKey, publicKey, storecache.NewNullCache(), clientMock, nil)
+			states, count, err := service.ListDeviceUpdates(ctx, tc.tenant, paginator.Query{Page: 1, PerPage: 10})
+			assert.Equal(t, tc.expected, Expected{states, count, err})
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type DeviceAcceptRuleStore interface {
+	// DeviceAcceptRuleList lists the auto-accept rules from a namespace, oldest first.
+	DeviceAcceptRuleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error)
+	// DeviceAcceptRuleGet gets an auto-accept rule by its ID.
+	DeviceAcceptRuleGet(ctx context.Context, id string) (*models.DeviceAcceptRule, error)
+	// DeviceAcceptRuleCreate stores an auto-accept rule.
+	DeviceAcceptRuleCreate(ctx context.Context, rule *models.DeviceAcceptRule) error
+	// DeviceAcceptRuleUpdate replaces the auto-accept rule with the same ID.
+	DeviceAcceptRuleUpdate(ctx context.Context, rule *models.DeviceAcceptRule) error
+	// DeviceAcceptRuleDelete deletes an auto-accept rule from a namespace.
+	DeviceAcceptRuleDelete(ctx context.Context, tenant, id string) error
+}
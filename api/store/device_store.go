@@ -34,6 +34,10 @@ type DeviceStore interface {
 	DeviceGetByName(ctx context.Context, name string, tenantID string, status models.DeviceStatus) (*models.Device, error)
 	DeviceGetByUID(ctx context.Context, uid models.UID, tenantID string) (*models.Device, error)
 	DeviceSetPosition(ctx context.Context, uid models.UID, position models.DevicePosition) error
+	// DeviceSetUpdate sets the last update attempt reported by the device's agent.
+	DeviceSetUpdate(ctx context.Context, uid models.UID, update *models.DeviceUpdate) error
+	// DeviceSetAcceptance sets the rule that accepted the device automatically.
+	DeviceSetAcceptance(ctx context.Context, uid models.UID, acceptance *models.DeviceAcceptance) error
 	DeviceListByUsage(ctx context.Context, tenantID string) ([]models.UID, error)
 	DeviceChooser(ctx context.Context, tenantID string, chosen []string) error
 	DeviceRemovedCount(ctx context.Context, tenant string) (int64, error)
@@ -0,0 +1,25 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type EnrollmentTokenStore interface {
+	// EnrollmentTokenList lists the enrollment tokens from a namespace, newest first.
+	EnrollmentTokenList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error)
+	// EnrollmentTokenGet gets an enrollment token by its ID.
+	EnrollmentTokenGet(ctx context.Context, id string) (*models.EnrollmentToken, error)
+	// EnrollmentTokenGetByHash gets an enrollment token from a namespace by its secret's hash.
+	EnrollmentTokenGetByHash(ctx context.Context, tenant, hash string) (*models.EnrollmentToken, error)
+	// EnrollmentTokenCreate stores an enrollment token.
+	EnrollmentTokenCreate(ctx context.Context, token *models.EnrollmentToken) error
+	// EnrollmentTokenUse counts a device registered with the enrollment token at the given time. It fails with
+	// [ErrNoDocuments] when the token is no longer valid, so a single-use token is never used twice.
+	EnrollmentTokenUse(ctx context.Context, id string, at time.Time) error
+	// EnrollmentTokenRevoke revokes an enrollment token from a namespace at the given time.
+	EnrollmentTokenRevoke(ctx context.Context, tenant, id string, at time.Time) error
+}
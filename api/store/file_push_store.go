@@ -0,0 +1,30 @@
+package store
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type FilePushStore interface {
+	// FilePushList lists the file pushes from a namespace, newest first, without their results.
+	FilePushList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error)
+	// FilePushGet gets a file push, with its results, by its ID.
+	FilePushGet(ctx context.Context, id string) (*models.FilePush, error)
+	// FilePushCreate stores a file push.
+	FilePushCreate(ctx context.Context, push *models.FilePush) error
+	// FilePushSetStatus sets the file push's status and the time it has finished, if any. It returns [ErrNoDocuments]
+	// when the file push is not found or it already has the status, what allows a single caller to transition it.
+	FilePushSetStatus(ctx context.Context, id string, status models.FilePushStatus, finishedAt *time.Time) error
+	// FilePushSetResult replaces the file push's result from the result's device.
+	FilePushSetResult(ctx context.Context, id string, result *models.FilePushResult) error
+	// FilePushContentSave stores the content of the file push's file, read from r, returning its size.
+	FilePushContentSave(ctx context.Context, id string, r io.Reader) (int64, error)
+	// FilePushContentOpen opens the content of the file push's file, starting from offset.
+	FilePushContentOpen(ctx context.Context, id string, offset int64) (io.ReadCloser, error)
+	// FilePushContentDelete deletes the content of the file push's file.
+	FilePushContentDelete(ctx context.Context, id string) error
+}
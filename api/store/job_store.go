@@ -0,0 +1,44 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type JobStore interface {
+	// JobList lists the jobs from a namespace, newest first, without their results.
+	JobList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error)
+	// JobListBySchedule lists the jobs created by a schedule, newest first, without their results.
+	JobListBySchedule(ctx context.Context, schedule string, pagination paginator.Query) ([]models.Job, int, error)
+	// JobGet gets a job, with its results, by its ID.
+	JobGet(ctx context.Context, id string) (*models.Job, error)
+	// JobCreate stores a job.
+	JobCreate(ctx context.Context, job *models.Job) error
+	// JobSetStatus sets the job's status and the time it has finished, if any. It returns [ErrNoDocuments] when the job
+	// is not found or it already has the status, what allows a single caller to transition it.
+	JobSetStatus(ctx context.Context, id string, status models.JobStatus, finishedAt *time.Time) error
+	// JobSetResult replaces the job's result from the result's device.
+	JobSetResult(ctx context.Context, id string, result *models.JobResult) error
+}
+
+type JobScheduleStore interface {
+	// JobScheduleList lists the schedules from a namespace, oldest first.
+	JobScheduleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error)
+	// JobScheduleListEnabled lists the enabled schedules from all namespaces.
+	JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error)
+	// JobScheduleGet gets a schedule by its ID.
+	JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error)
+	// JobScheduleCreate stores a schedule.
+	JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error
+	// JobScheduleUpdate replaces the schedule with the same ID.
+	JobScheduleUpdate(ctx context.Context, schedule *models.JobSchedule) error
+	// JobScheduleClaimRun sets the time the schedule has last run when it hasn't run since before, what allows a single
+	// caller to claim the schedule's run. It returns [ErrNoDocuments] when the schedule is not found or it has already
+	// run since before.
+	JobScheduleClaimRun(ctx context.Context, id string, lastRunAt, before time.Time) error
+	// JobScheduleDelete deletes a schedule from a namespace.
+	JobScheduleDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type MetricsStore interface {
+	// MetricsCreate stores a device's metrics sample.
+	MetricsCreate(ctx context.Context, metrics *models.DeviceMetrics) error
+	// MetricsList lists the device's metrics samples collected between from and to, sorted by time.
+	MetricsList(ctx context.Context, uid models.UID, from, to time.Time) ([]models.DeviceMetrics, error)
+	// MetricsDeleteByDate deletes the metrics samples collected up to lte, returning how many were deleted.
+	MetricsDeleteByDate(ctx context.Context, lte time.Time) (int64, error)
+}
@@ -4,10 +4,12 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
-	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
+	models "github.com/shellhub-io/shellhub/pkg/models"
+
 	order "github.com/shellhub-io/shellhub/pkg/api/order"
 
 	paginator "github.com/shellhub-io/shellhub/pkg/api/paginator"
@@ -233,6 +235,127 @@ func (_m *Store) DeleteSecret(ctx context.Context, username string) error {
 	return r0
 }
 
+// DeviceAcceptRuleCreate provides a mock function with given fields: ctx, rule
+func (_m *Store) DeviceAcceptRuleCreate(ctx context.Context, rule *models.DeviceAcceptRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceAcceptRuleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceAcceptRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceAcceptRuleDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) DeviceAcceptRuleDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceAcceptRuleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeviceAcceptRuleGet provides a mock function with given fields: ctx, id
+func (_m *Store) DeviceAcceptRuleGet(ctx context.Context, id string) (*models.DeviceAcceptRule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceAcceptRuleGet")
+	}
+
+	var r0 *models.DeviceAcceptRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.DeviceAcceptRule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeviceAcceptRuleList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) DeviceAcceptRuleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceAcceptRuleList")
+	}
+
+	var r0 []models.DeviceAcceptRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.DeviceAcceptRule, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.DeviceAcceptRule); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceAcceptRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// DeviceAcceptRuleUpdate provides a mock function with given fields: ctx, rule
+func (_m *Store) DeviceAcceptRuleUpdate(ctx context.Context, rule *models.DeviceAcceptRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceAcceptRuleUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceAcceptRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceChooser provides a mock function with given fields: ctx, tenantID, chosen
 func (_m *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	ret := _m.Called(ctx, tenantID, chosen)
@@ -773,6 +896,24 @@ func (_m *Store) DeviceRenameTag(ctx context.Context, tenant string, oldTag stri
 	return r0
 }
 
+// DeviceSetAcceptance provides a mock function with given fields: ctx, uid, acceptance
+func (_m *Store) DeviceSetAcceptance(ctx context.Context, uid models.UID, acceptance *models.DeviceAcceptance) error {
+	ret := _m.Called(ctx, uid, acceptance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetAcceptance")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceAcceptance) error); ok {
+		r0 = rf(ctx, uid, acceptance)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceSetOnline provides a mock function with given fields: ctx, uid, timestamp, online
 func (_m *Store) DeviceSetOnline(ctx context.Context, uid models.UID, timestamp time.Time, online bool) error {
 	ret := _m.Called(ctx, uid, timestamp, online)
@@ -809,6 +950,24 @@ func (_m *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return r0
 }
 
+// DeviceSetUpdate provides a mock function with given fields: ctx, uid, update
+func (_m *Store) DeviceSetUpdate(ctx context.Context, uid models.UID, update *models.DeviceUpdate) error {
+	ret := _m.Called(ctx, uid, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceSetUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, *models.DeviceUpdate) error); ok {
+		r0 = rf(ctx, uid, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeviceUpdate provides a mock function with given fields: ctx, tenant, uid, name, publicURL
 func (_m *Store) DeviceUpdate(ctx context.Context, tenant string, uid models.UID, name *string, publicURL *bool) error {
 	ret := _m.Called(ctx, tenant, uid, name, publicURL)
@@ -899,35 +1058,17 @@ func (_m *Store) DeviceUpdateTag(ctx context.Context, uid models.UID, tags []str
 	return r0
 }
 
-// FirewallRuleAddTag provides a mock function with given fields: ctx, id, tag
-func (_m *Store) FirewallRuleAddTag(ctx context.Context, id string, tag string) error {
-	ret := _m.Called(ctx, id, tag)
-
-	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleAddTag")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, id, tag)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// FirewallRuleCreate provides a mock function with given fields: ctx, rule
-func (_m *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRule) error {
-	ret := _m.Called(ctx, rule)
+// EnrollmentTokenCreate provides a mock function with given fields: ctx, token
+func (_m *Store) EnrollmentTokenCreate(ctx context.Context, token *models.EnrollmentToken) error {
+	ret := _m.Called(ctx, token)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleCreate")
+		panic("no return value specified for EnrollmentTokenCreate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.FirewallRule) error); ok {
-		r0 = rf(ctx, rule)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.EnrollmentToken) error); ok {
+		r0 = rf(ctx, token)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -935,65 +1076,59 @@ func (_m *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRu
 	return r0
 }
 
-// FirewallRuleDelete provides a mock function with given fields: ctx, id
-func (_m *Store) FirewallRuleDelete(ctx context.Context, id string) error {
+// EnrollmentTokenGet provides a mock function with given fields: ctx, id
+func (_m *Store) EnrollmentTokenGet(ctx context.Context, id string) (*models.EnrollmentToken, error) {
 	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleDelete")
+		panic("no return value specified for EnrollmentTokenGet")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 *models.EnrollmentToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EnrollmentToken, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EnrollmentToken); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// FirewallRuleDeleteTag provides a mock function with given fields: ctx, tenant, tag
-func (_m *Store) FirewallRuleDeleteTag(ctx context.Context, tenant string, tag string) error {
-	ret := _m.Called(ctx, tenant, tag)
-
-	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleDeleteTag")
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrollmentToken)
+		}
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, tenant, tag)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
-// FirewallRuleGet provides a mock function with given fields: ctx, id
-func (_m *Store) FirewallRuleGet(ctx context.Context, id string) (*models.FirewallRule, error) {
-	ret := _m.Called(ctx, id)
+// EnrollmentTokenGetByHash provides a mock function with given fields: ctx, tenant, hash
+func (_m *Store) EnrollmentTokenGetByHash(ctx context.Context, tenant string, hash string) (*models.EnrollmentToken, error) {
+	ret := _m.Called(ctx, tenant, hash)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleGet")
+		panic("no return value specified for EnrollmentTokenGetByHash")
 	}
 
-	var r0 *models.FirewallRule
+	var r0 *models.EnrollmentToken
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.FirewallRule, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.EnrollmentToken, error)); ok {
+		return rf(ctx, tenant, hash)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *models.FirewallRule); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.EnrollmentToken); ok {
+		r0 = rf(ctx, tenant, hash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.FirewallRule)
+			r0 = ret.Get(0).(*models.EnrollmentToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, tenant, hash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1001,36 +1136,36 @@ func (_m *Store) FirewallRuleGet(ctx context.Context, id string) (*models.Firewa
 	return r0, r1
 }
 
-// FirewallRuleGetTags provides a mock function with given fields: ctx, tenant
-func (_m *Store) FirewallRuleGetTags(ctx context.Context, tenant string) ([]string, int, error) {
-	ret := _m.Called(ctx, tenant)
+// EnrollmentTokenList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) EnrollmentTokenList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleGetTags")
+		panic("no return value specified for EnrollmentTokenList")
 	}
 
-	var r0 []string
+	var r0 []models.EnrollmentToken
 	var r1 int
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
-		return rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.EnrollmentToken, int, error)); ok {
+		return rf(ctx, tenant, pagination)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
-		r0 = rf(ctx, tenant)
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.EnrollmentToken); ok {
+		r0 = rf(ctx, tenant, pagination)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]string)
+			r0 = ret.Get(0).([]models.EnrollmentToken)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
-		r1 = rf(ctx, tenant)
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
 	} else {
 		r1 = ret.Get(1).(int)
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
-		r2 = rf(ctx, tenant)
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -1038,54 +1173,35 @@ func (_m *Store) FirewallRuleGetTags(ctx context.Context, tenant string) ([]stri
 	return r0, r1, r2
 }
 
-// FirewallRuleList provides a mock function with given fields: ctx, pagination
-func (_m *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
-	ret := _m.Called(ctx, pagination)
+// EnrollmentTokenRevoke provides a mock function with given fields: ctx, tenant, id, at
+func (_m *Store) EnrollmentTokenRevoke(ctx context.Context, tenant string, id string, at time.Time) error {
+	ret := _m.Called(ctx, tenant, id, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleList")
-	}
-
-	var r0 []models.FirewallRule
-	var r1 int
-	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.FirewallRule, int, error)); ok {
-		return rf(ctx, pagination)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.FirewallRule); ok {
-		r0 = rf(ctx, pagination)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.FirewallRule)
-		}
+		panic("no return value specified for EnrollmentTokenRevoke")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
-		r1 = rf(ctx, pagination)
-	} else {
-		r1 = ret.Get(1).(int)
-	}
-
-	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
-		r2 = rf(ctx, pagination)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Time) error); ok {
+		r0 = rf(ctx, tenant, id, at)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1, r2
+	return r0
 }
 
-// FirewallRuleRemoveTag provides a mock function with given fields: ctx, id, tag
-func (_m *Store) FirewallRuleRemoveTag(ctx context.Context, id string, tag string) error {
-	ret := _m.Called(ctx, id, tag)
+// EnrollmentTokenUse provides a mock function with given fields: ctx, id, at
+func (_m *Store) EnrollmentTokenUse(ctx context.Context, id string, at time.Time) error {
+	ret := _m.Called(ctx, id, at)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleRemoveTag")
+		panic("no return value specified for EnrollmentTokenUse")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
-		r0 = rf(ctx, id, tag)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, id, at)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1093,17 +1209,17 @@ func (_m *Store) FirewallRuleRemoveTag(ctx context.Context, id string, tag strin
 	return r0
 }
 
-// FirewallRuleRenameTag provides a mock function with given fields: ctx, tenant, tagCurrent, tagNew
-func (_m *Store) FirewallRuleRenameTag(ctx context.Context, tenant string, tagCurrent string, tagNew string) error {
-	ret := _m.Called(ctx, tenant, tagCurrent, tagNew)
+// FilePushContentDelete provides a mock function with given fields: ctx, id
+func (_m *Store) FilePushContentDelete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleRenameTag")
+		panic("no return value specified for FilePushContentDelete")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
-		r0 = rf(ctx, tenant, tagCurrent, tagNew)
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1111,29 +1227,420 @@ func (_m *Store) FirewallRuleRenameTag(ctx context.Context, tenant string, tagCu
 	return r0
 }
 
-// FirewallRuleUpdate provides a mock function with given fields: ctx, id, rule
-func (_m *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
-	ret := _m.Called(ctx, id, rule)
+// FilePushContentOpen provides a mock function with given fields: ctx, id, offset
+func (_m *Store) FilePushContentOpen(ctx context.Context, id string, offset int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, id, offset)
 
 	if len(ret) == 0 {
-		panic("no return value specified for FirewallRuleUpdate")
+		panic("no return value specified for FilePushContentOpen")
 	}
 
-	var r0 *models.FirewallRule
+	var r0 io.ReadCloser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
-		return rf(ctx, id, rule)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, id, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) *models.FirewallRule); ok {
-		r0 = rf(ctx, id, rule)
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, id, offset)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.FirewallRule)
+			r0 = ret.Get(0).(io.ReadCloser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, models.FirewallRuleUpdate) error); ok {
-		r1 = rf(ctx, id, rule)
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, id, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilePushContentSave provides a mock function with given fields: ctx, id, r
+func (_m *Store) FilePushContentSave(ctx context.Context, id string, r io.Reader) (int64, error) {
+	ret := _m.Called(ctx, id, r)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushContentSave")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) (int64, error)); ok {
+		return rf(ctx, id, r)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader) int64); ok {
+		r0 = rf(ctx, id, r)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader) error); ok {
+		r1 = rf(ctx, id, r)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilePushCreate provides a mock function with given fields: ctx, push
+func (_m *Store) FilePushCreate(ctx context.Context, push *models.FilePush) error {
+	ret := _m.Called(ctx, push)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FilePush) error); ok {
+		r0 = rf(ctx, push)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilePushGet provides a mock function with given fields: ctx, id
+func (_m *Store) FilePushGet(ctx context.Context, id string) (*models.FilePush, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushGet")
+	}
+
+	var r0 *models.FilePush
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.FilePush, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.FilePush); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FilePush)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FilePushList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) FilePushList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushList")
+	}
+
+	var r0 []models.FilePush
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.FilePush, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.FilePush); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FilePush)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FilePushSetResult provides a mock function with given fields: ctx, id, result
+func (_m *Store) FilePushSetResult(ctx context.Context, id string, result *models.FilePushResult) error {
+	ret := _m.Called(ctx, id, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushSetResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.FilePushResult) error); ok {
+		r0 = rf(ctx, id, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FilePushSetStatus provides a mock function with given fields: ctx, id, status, finishedAt
+func (_m *Store) FilePushSetStatus(ctx context.Context, id string, status models.FilePushStatus, finishedAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, finishedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushSetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FilePushStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, finishedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleAddTag provides a mock function with given fields: ctx, id, tag
+func (_m *Store) FirewallRuleAddTag(ctx context.Context, id string, tag string) error {
+	ret := _m.Called(ctx, id, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleAddTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleCreate provides a mock function with given fields: ctx, rule
+func (_m *Store) FirewallRuleCreate(ctx context.Context, rule *models.FirewallRule) error {
+	ret := _m.Called(ctx, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.FirewallRule) error); ok {
+		r0 = rf(ctx, rule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleDelete provides a mock function with given fields: ctx, id
+func (_m *Store) FirewallRuleDelete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleDeleteTag provides a mock function with given fields: ctx, tenant, tag
+func (_m *Store) FirewallRuleDeleteTag(ctx context.Context, tenant string, tag string) error {
+	ret := _m.Called(ctx, tenant, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleDeleteTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleGet provides a mock function with given fields: ctx, id
+func (_m *Store) FirewallRuleGet(ctx context.Context, id string) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleGet")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.FirewallRule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.FirewallRule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FirewallRuleGetTags provides a mock function with given fields: ctx, tenant
+func (_m *Store) FirewallRuleGetTags(ctx context.Context, tenant string) ([]string, int, error) {
+	ret := _m.Called(ctx, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleGetTags")
+	}
+
+	var r0 []string
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, int, error)); ok {
+		return rf(ctx, tenant)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, tenant)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) int); ok {
+		r1 = rf(ctx, tenant)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, tenant)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FirewallRuleList provides a mock function with given fields: ctx, pagination
+func (_m *Store) FirewallRuleList(ctx context.Context, pagination paginator.Query) ([]models.FirewallRule, int, error) {
+	ret := _m.Called(ctx, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleList")
+	}
+
+	var r0 []models.FirewallRule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) ([]models.FirewallRule, int, error)); ok {
+		return rf(ctx, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, paginator.Query) []models.FirewallRule); ok {
+		r0 = rf(ctx, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, paginator.Query) int); ok {
+		r1 = rf(ctx, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, paginator.Query) error); ok {
+		r2 = rf(ctx, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// FirewallRuleRemoveTag provides a mock function with given fields: ctx, id, tag
+func (_m *Store) FirewallRuleRemoveTag(ctx context.Context, id string, tag string) error {
+	ret := _m.Called(ctx, id, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleRemoveTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, id, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleRenameTag provides a mock function with given fields: ctx, tenant, tagCurrent, tagNew
+func (_m *Store) FirewallRuleRenameTag(ctx context.Context, tenant string, tagCurrent string, tagNew string) error {
+	ret := _m.Called(ctx, tenant, tagCurrent, tagNew)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleRenameTag")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, tenant, tagCurrent, tagNew)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FirewallRuleUpdate provides a mock function with given fields: ctx, id, rule
+func (_m *Store) FirewallRuleUpdate(ctx context.Context, id string, rule models.FirewallRuleUpdate) (*models.FirewallRule, error) {
+	ret := _m.Called(ctx, id, rule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallRuleUpdate")
+	}
+
+	var r0 *models.FirewallRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) (*models.FirewallRule, error)); ok {
+		return rf(ctx, id, rule)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.FirewallRuleUpdate) *models.FirewallRule); ok {
+		r0 = rf(ctx, id, rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.FirewallRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.FirewallRuleUpdate) error); ok {
+		r1 = rf(ctx, id, rule)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1269,10 +1776,337 @@ func (_m *Store) GetStatusMFA(ctx context.Context, id string) (bool, error) {
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
 		r1 = rf(ctx, id)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobCreate provides a mock function with given fields: ctx, job
+func (_m *Store) JobCreate(ctx context.Context, job *models.Job) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Job) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobGet provides a mock function with given fields: ctx, id
+func (_m *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobGet")
+	}
+
+	var r0 *models.Job
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Job, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Job); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) JobList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobList")
+	}
+
+	var r0 []models.Job
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Job, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Job); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JobListBySchedule provides a mock function with given fields: ctx, schedule, pagination
+func (_m *Store) JobListBySchedule(ctx context.Context, schedule string, pagination paginator.Query) ([]models.Job, int, error) {
+	ret := _m.Called(ctx, schedule, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobListBySchedule")
+	}
+
+	var r0 []models.Job
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.Job, int, error)); ok {
+		return rf(ctx, schedule, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.Job); ok {
+		r0 = rf(ctx, schedule, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, schedule, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, schedule, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JobScheduleClaimRun provides a mock function with given fields: ctx, id, lastRunAt, before
+func (_m *Store) JobScheduleClaimRun(ctx context.Context, id string, lastRunAt time.Time, before time.Time) error {
+	ret := _m.Called(ctx, id, lastRunAt, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleClaimRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, time.Time) error); ok {
+		r0 = rf(ctx, id, lastRunAt, before)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleCreate provides a mock function with given fields: ctx, schedule
+func (_m *Store) JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JobSchedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) JobScheduleDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobScheduleGet provides a mock function with given fields: ctx, id
+func (_m *Store) JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleGet")
+	}
+
+	var r0 *models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.JobSchedule, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.JobSchedule); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobScheduleList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) JobScheduleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleList")
+	}
+
+	var r0 []models.JobSchedule
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.JobSchedule, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.JobSchedule); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// JobScheduleListEnabled provides a mock function with given fields: ctx
+func (_m *Store) JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleListEnabled")
+	}
+
+	var r0 []models.JobSchedule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.JobSchedule, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.JobSchedule); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.JobSchedule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobScheduleUpdate provides a mock function with given fields: ctx, schedule
+func (_m *Store) JobScheduleUpdate(ctx context.Context, schedule *models.JobSchedule) error {
+	ret := _m.Called(ctx, schedule)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobScheduleUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.JobSchedule) error); ok {
+		r0 = rf(ctx, schedule)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobSetResult provides a mock function with given fields: ctx, id, result
+func (_m *Store) JobSetResult(ctx context.Context, id string, result *models.JobResult) error {
+	ret := _m.Called(ctx, id, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobSetResult")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.JobResult) error); ok {
+		r0 = rf(ctx, id, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// JobSetStatus provides a mock function with given fields: ctx, id, status, finishedAt
+func (_m *Store) JobSetStatus(ctx context.Context, id string, status models.JobStatus, finishedAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, finishedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobSetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.JobStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, finishedAt)
+	} else {
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
 // LicenseLoad provides a mock function with given fields: ctx
@@ -1323,6 +2157,82 @@ func (_m *Store) LicenseSave(ctx context.Context, license *models.License) error
 	return r0
 }
 
+// MetricsCreate provides a mock function with given fields: ctx, metrics
+func (_m *Store) MetricsCreate(ctx context.Context, metrics *models.DeviceMetrics) error {
+	ret := _m.Called(ctx, metrics)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MetricsCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.DeviceMetrics) error); ok {
+		r0 = rf(ctx, metrics)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MetricsDeleteByDate provides a mock function with given fields: ctx, lte
+func (_m *Store) MetricsDeleteByDate(ctx context.Context, lte time.Time) (int64, error) {
+	ret := _m.Called(ctx, lte)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MetricsDeleteByDate")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, lte)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, lte)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, lte)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MetricsList provides a mock function with given fields: ctx, uid, from, to
+func (_m *Store) MetricsList(ctx context.Context, uid models.UID, from time.Time, to time.Time) ([]models.DeviceMetrics, error) {
+	ret := _m.Called(ctx, uid, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MetricsList")
+	}
+
+	var r0 []models.DeviceMetrics
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, time.Time) ([]models.DeviceMetrics, error)); ok {
+		return rf(ctx, uid, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, time.Time, time.Time) []models.DeviceMetrics); ok {
+		r0 = rf(ctx, uid, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DeviceMetrics)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, uid, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NamespaceAddMember provides a mock function with given fields: ctx, tenantID, memberID, memberRole
 func (_m *Store) NamespaceAddMember(ctx context.Context, tenantID string, memberID string, memberRole string) (*models.Namespace, error) {
 	ret := _m.Called(ctx, tenantID, memberID, memberRole)
@@ -1634,6 +2544,24 @@ func (_m *Store) NamespaceRename(ctx context.Context, tenantID string, name stri
 	return r0, r1
 }
 
+// NamespaceSetRequireEnrollmentToken provides a mock function with given fields: ctx, tenantID, required
+func (_m *Store) NamespaceSetRequireEnrollmentToken(ctx context.Context, tenantID string, required bool) error {
+	ret := _m.Called(ctx, tenantID, required)
+
+	if len(ret) == 0 {
+		panic("no return value specified for NamespaceSetRequireEnrollmentToken")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = rf(ctx, tenantID, required)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NamespaceSetSessionRecord provides a mock function with given fields: ctx, sessionRecord, tenantID
 func (_m *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error {
 	ret := _m.Called(ctx, sessionRecord, tenantID)
@@ -2346,6 +3274,157 @@ func (_m *Store) TagsGet(ctx context.Context, tenant string) ([]string, int, err
 	return r0, r1, r2
 }
 
+// TunnelCreate provides a mock function with given fields: ctx, tunnel
+func (_m *Store) TunnelCreate(ctx context.Context, tunnel *models.Tunnel) error {
+	ret := _m.Called(ctx, tunnel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Tunnel) error); ok {
+		r0 = rf(ctx, tunnel)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TunnelDelete provides a mock function with given fields: ctx, uid, address
+func (_m *Store) TunnelDelete(ctx context.Context, uid models.UID, address string) error {
+	ret := _m.Called(ctx, uid, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string) error); ok {
+		r0 = rf(ctx, uid, address)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TunnelGet provides a mock function with given fields: ctx, address
+func (_m *Store) TunnelGet(ctx context.Context, address string) (*models.Tunnel, error) {
+	ret := _m.Called(ctx, address)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelGet")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Tunnel, error)); ok {
+		return rf(ctx, address)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Tunnel); ok {
+		r0 = rf(ctx, address)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, address)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TunnelGetByPort provides a mock function with given fields: ctx, port
+func (_m *Store) TunnelGetByPort(ctx context.Context, port int) (*models.Tunnel, error) {
+	ret := _m.Called(ctx, port)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelGetByPort")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*models.Tunnel, error)); ok {
+		return rf(ctx, port)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *models.Tunnel); ok {
+		r0 = rf(ctx, port)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, port)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TunnelGrant provides a mock function with given fields: ctx, uid, address, grant, now
+func (_m *Store) TunnelGrant(ctx context.Context, uid models.UID, address string, grant models.TunnelGrant, now time.Time) error {
+	ret := _m.Called(ctx, uid, address, grant, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelGrant")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, string, models.TunnelGrant, time.Time) error); ok {
+		r0 = rf(ctx, uid, address, grant, now)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TunnelList provides a mock function with given fields: ctx, uid, pagination
+func (_m *Store) TunnelList(ctx context.Context, uid models.UID, pagination paginator.Query) ([]models.Tunnel, int, error) {
+	ret := _m.Called(ctx, uid, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TunnelList")
+	}
+
+	var r0 []models.Tunnel
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, paginator.Query) ([]models.Tunnel, int, error)); ok {
+		return rf(ctx, uid, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UID, paginator.Query) []models.Tunnel); ok {
+		r0 = rf(ctx, uid, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UID, paginator.Query) int); ok {
+		r1 = rf(ctx, uid, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, models.UID, paginator.Query) error); ok {
+		r2 = rf(ctx, uid, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // UpdateCodes provides a mock function with given fields: ctx, id, codes
 func (_m *Store) UpdateCodes(ctx context.Context, id string, codes []string) error {
 	ret := _m.Called(ctx, id, codes)
@@ -2364,6 +3443,127 @@ func (_m *Store) UpdateCodes(ctx context.Context, id string, codes []string) err
 	return r0
 }
 
+// UpdatePolicyCreate provides a mock function with given fields: ctx, policy
+func (_m *Store) UpdatePolicyCreate(ctx context.Context, policy *models.UpdatePolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyCreate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdatePolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePolicyDelete provides a mock function with given fields: ctx, tenant, id
+func (_m *Store) UpdatePolicyDelete(ctx context.Context, tenant string, id string) error {
+	ret := _m.Called(ctx, tenant, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, tenant, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePolicyGet provides a mock function with given fields: ctx, id
+func (_m *Store) UpdatePolicyGet(ctx context.Context, id string) (*models.UpdatePolicy, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyGet")
+	}
+
+	var r0 *models.UpdatePolicy
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.UpdatePolicy, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.UpdatePolicy); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePolicyList provides a mock function with given fields: ctx, tenant, pagination
+func (_m *Store) UpdatePolicyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error) {
+	ret := _m.Called(ctx, tenant, pagination)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyList")
+	}
+
+	var r0 []models.UpdatePolicy
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) ([]models.UpdatePolicy, int, error)); ok {
+		return rf(ctx, tenant, pagination)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, paginator.Query) []models.UpdatePolicy); ok {
+		r0 = rf(ctx, tenant, pagination)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.UpdatePolicy)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, paginator.Query) int); ok {
+		r1 = rf(ctx, tenant, pagination)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, paginator.Query) error); ok {
+		r2 = rf(ctx, tenant, pagination)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// UpdatePolicyUpdate provides a mock function with given fields: ctx, policy
+func (_m *Store) UpdatePolicyUpdate(ctx context.Context, policy *models.UpdatePolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePolicyUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.UpdatePolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UserCreate provides a mock function with given fields: ctx, user
 func (_m *Store) UserCreate(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
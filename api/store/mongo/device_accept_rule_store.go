@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) DeviceAcceptRuleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.DeviceAcceptRule, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("device_accept_rules"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	rules := make([]models.DeviceAcceptRule, 0)
+	cursor, err := s.db.Collection("device_accept_rules").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		rule := new(models.DeviceAcceptRule)
+		if err := cursor.Decode(rule); err != nil {
+			return rules, count, FromMongoError(err)
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	return rules, count, nil
+}
+
+func (s *Store) DeviceAcceptRuleGet(ctx context.Context, id string) (*models.DeviceAcceptRule, error) {
+	rule := new(models.DeviceAcceptRule)
+	if err := s.db.Collection("device_accept_rules").FindOne(ctx, bson.M{"id": id}).Decode(rule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return rule, nil
+}
+
+func (s *Store) DeviceAcceptRuleCreate(ctx context.Context, rule *models.DeviceAcceptRule) error {
+	_, err := s.db.Collection("device_accept_rules").InsertOne(ctx, rule)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) DeviceAcceptRuleUpdate(ctx context.Context, rule *models.DeviceAcceptRule) error {
+	res, err := s.db.Collection("device_accept_rules").ReplaceOne(ctx, bson.M{"id": rule.ID}, rule)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceAcceptRuleDelete(ctx context.Context, tenant, id string) error {
+	res, err := s.db.Collection("device_accept_rules").DeleteOne(ctx, bson.M{"tenant_id": tenant, "id": id})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
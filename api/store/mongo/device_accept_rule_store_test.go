@@ -0,0 +1,252 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeviceAcceptRuleList(t *testing.T) {
+	type Expected struct {
+		rules []models.DeviceAcceptRule
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no rules",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected: Expected{
+				rules: []models.DeviceAcceptRule{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has rules",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected: Expected{
+				rules: []models.DeviceAcceptRule{
+					{
+						ID:          "d9e4f5a6-0000-4000-8000-000000000001",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "office",
+						MACPrefixes: []string{"00:1a:2b"},
+						Info:        map[string]string{},
+						Sources:     []string{"10.0.0.0/8"},
+						CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						UpdatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:          "d9e4f5a6-0000-4000-8000-000000000002",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "containers",
+						MACPrefixes: []string{},
+						Hostname:    "web-*",
+						Info:        map[string]string{"platform": "docker"},
+						Sources:     []string{},
+						TokenHash:   "e6a7194f7c495689643e2f94f4ae6a6125cae531c113227f2c7d56c50a4c853b",
+						CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+						UpdatedAt:   time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			rules, count, err := mongostore.DeviceAcceptRuleList(context.TODO(), tc.tenant, tc.page)
+			assert.Equal(t, tc.expected, Expected{rules: rules, count: count, err: err})
+		})
+	}
+}
+
+func TestDeviceAcceptRuleGet(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when rule is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when rule is found",
+			id:          "d9e4f5a6-0000-4000-8000-000000000002",
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			rule, err := mongostore.DeviceAcceptRuleGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, tc.id, rule.ID)
+			}
+		})
+	}
+}
+
+func TestDeviceAcceptRuleCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	defer fixtures.Teardown() // nolint: errcheck
+
+	err := mongostore.DeviceAcceptRuleCreate(context.TODO(), &models.DeviceAcceptRule{
+		ID:          "d9e4f5a6-0000-4000-8000-000000000003",
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Name:        "arm",
+		MACPrefixes: []string{},
+		Info:        map[string]string{"arch": "arm*"},
+		Sources:     []string{},
+		CreatedAt:   time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+}
+
+func TestDeviceAcceptRuleUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		rule        *models.DeviceAcceptRule
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when rule is not found",
+			rule:        &models.DeviceAcceptRule{ID: "nonexistent"},
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when rule is found",
+			rule: &models.DeviceAcceptRule{
+				ID:          "d9e4f5a6-0000-4000-8000-000000000002",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "containers",
+				MACPrefixes: []string{},
+				Hostname:    "api-*",
+				Info:        map[string]string{"platform": "docker"},
+				Sources:     []string{"192.168.0.10"},
+				TokenHash:   "e6a7194f7c495689643e2f94f4ae6a6125cae531c113227f2c7d56c50a4c853b",
+				CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+				UpdatedAt:   time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureDeviceAcceptRules},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceAcceptRuleUpdate(context.TODO(), tc.rule)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				rule, err := mongostore.DeviceAcceptRuleGet(context.TODO(), tc.rule.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.rule, rule)
+			}
+		})
+	}
+}
+
+func TestDeviceAcceptRuleDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when rule belongs to another namespace",
+			tenant:      "nonexistent",
+			id:          "d9e4f5a6-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when rule is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "d9e4f5a6-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureDeviceAcceptRules},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceAcceptRuleDelete(context.TODO(), tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
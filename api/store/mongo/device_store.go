@@ -270,6 +270,10 @@ func (s *Store) DeviceDelete(ctx context.Context, uid models.UID) error {
 			return nil, FromMongoError(err)
 		}
 
+		if _, err := s.db.Collection("tunnels").DeleteMany(ctx, bson.M{"device": uid}); err != nil {
+			return nil, FromMongoError(err)
+		}
+
 		return nil, nil
 	})
 
@@ -526,6 +530,32 @@ func (s *Store) DeviceSetPosition(ctx context.Context, uid models.UID, position
 	return nil
 }
 
+func (s *Store) DeviceSetUpdate(ctx context.Context, uid models.UID, update *models.DeviceUpdate) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"update": update}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) DeviceSetAcceptance(ctx context.Context, uid models.UID, acceptance *models.DeviceAcceptance) error {
+	res, err := s.db.Collection("devices").UpdateOne(ctx, bson.M{"uid": uid}, bson.M{"$set": bson.M{"acceptance": acceptance}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
 func (s *Store) DeviceChooser(ctx context.Context, tenantID string, chosen []string) error {
 	filter := bson.M{
 		"status":    "accepted",
@@ -1344,6 +1344,117 @@ func TestDeviceSetPosition(t *testing.T) {
 	}
 }
 
+func TestDeviceSetUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		update      *models.DeviceUpdate
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			update: &models.DeviceUpdate{
+				Version:   "v0.15.0",
+				Status:    models.DeviceUpdateStatusUpdating,
+				UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			update: &models.DeviceUpdate{
+				Version:   "v0.15.0",
+				Status:    models.DeviceUpdateStatusFailed,
+				Error:     "signature does not match the downloaded binary",
+				UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetUpdate(context.TODO(), tc.uid, tc.update)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGet(context.TODO(), tc.uid)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.update, device.Update)
+			}
+		})
+	}
+}
+
+func TestDeviceSetAcceptance(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		acceptance  *models.DeviceAcceptance
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when the device is not found",
+			uid:         models.UID("nonexistent"),
+			acceptance: &models.DeviceAcceptance{
+				Rule:       "d9e4f5a6-0000-4000-8000-000000000001",
+				Name:       "office",
+				AcceptedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when the device is found",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			acceptance: &models.DeviceAcceptance{
+				Rule:       "d9e4f5a6-0000-4000-8000-000000000001",
+				Name:       "office",
+				AcceptedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureDevices},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.DeviceSetAcceptance(context.TODO(), tc.uid, tc.acceptance)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				device, err := mongostore.DeviceGet(context.TODO(), tc.uid)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.acceptance, device.Acceptance)
+			}
+		})
+	}
+}
+
 func TestDeviceChooser(t *testing.T) {
 	cases := []struct {
 		description string
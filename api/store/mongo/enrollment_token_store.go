@@ -0,0 +1,113 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) EnrollmentTokenList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.EnrollmentToken, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("enrollment_tokens"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	tokens := make([]models.EnrollmentToken, 0)
+	cursor, err := s.db.Collection("enrollment_tokens").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		token := new(models.EnrollmentToken)
+		if err := cursor.Decode(token); err != nil {
+			return tokens, count, FromMongoError(err)
+		}
+
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, count, nil
+}
+
+func (s *Store) EnrollmentTokenGet(ctx context.Context, id string) (*models.EnrollmentToken, error) {
+	token := new(models.EnrollmentToken)
+	if err := s.db.Collection("enrollment_tokens").FindOne(ctx, bson.M{"id": id}).Decode(token); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return token, nil
+}
+
+func (s *Store) EnrollmentTokenGetByHash(ctx context.Context, tenant, hash string) (*models.EnrollmentToken, error) {
+	token := new(models.EnrollmentToken)
+	if err := s.db.Collection("enrollment_tokens").FindOne(ctx, bson.M{"tenant_id": tenant, "hash": hash}).Decode(token); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return token, nil
+}
+
+func (s *Store) EnrollmentTokenCreate(ctx context.Context, token *models.EnrollmentToken) error {
+	_, err := s.db.Collection("enrollment_tokens").InsertOne(ctx, token)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) EnrollmentTokenUse(ctx context.Context, id string, at time.Time) error {
+	filter := bson.M{
+		"id":         id,
+		"revoked_at": nil,
+		"$and": []bson.M{
+			{"$or": []bson.M{{"expires_at": nil}, {"expires_at": bson.M{"$gt": at}}}},
+			{"$or": []bson.M{{"single_use": false}, {"uses": 0}}},
+		},
+	}
+
+	res, err := s.db.Collection("enrollment_tokens").UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"uses": 1}, "$set": bson.M{"last_used_at": at}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) EnrollmentTokenRevoke(ctx context.Context, tenant, id string, at time.Time) error {
+	res, err := s.db.Collection("enrollment_tokens").UpdateOne(ctx, bson.M{"tenant_id": tenant, "id": id, "revoked_at": nil}, bson.M{"$set": bson.M{"revoked_at": at}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,316 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrollmentTokenList(t *testing.T) {
+	type Expected struct {
+		ids   []string
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no tokens",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected: Expected{
+				ids:   []string{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has tokens",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected: Expected{
+				ids: []string{
+					"e1a2b3c4-0000-4000-8000-000000000002",
+					"e1a2b3c4-0000-4000-8000-000000000001",
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			tokens, count, err := mongostore.EnrollmentTokenList(context.TODO(), tc.tenant, tc.page)
+
+			ids := make([]string, 0)
+			for _, token := range tokens {
+				ids = append(ids, token.ID)
+			}
+
+			assert.Equal(t, tc.expected, Expected{ids: ids, count: count, err: err})
+		})
+	}
+}
+
+func TestEnrollmentTokenGet(t *testing.T) {
+	lastUsedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	type Expected struct {
+		token *models.EnrollmentToken
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when token is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected: Expected{
+				token: nil,
+				err:   store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when token is found",
+			id:          "e1a2b3c4-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected: Expected{
+				token: &models.EnrollmentToken{
+					ID:         "e1a2b3c4-0000-4000-8000-000000000001",
+					TenantID:   "00000000-0000-4000-0000-000000000000",
+					Name:       "fleet",
+					Hash:       "fbe9c27f25fd400d7777ed36022cbb808cc64d312350d5db7946723693d57f1c",
+					Tags:       []string{"production"},
+					Status:     models.DeviceStatusAccepted,
+					SingleUse:  false,
+					Uses:       2,
+					LastUsedAt: &lastUsedAt,
+					CreatedAt:  time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			token, err := mongostore.EnrollmentTokenGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, Expected{token: token, err: err})
+		})
+	}
+}
+
+func TestEnrollmentTokenGetByHash(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		hash        string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when token belongs to another namespace",
+			tenant:      "nonexistent",
+			hash:        models.HashEnrollmentToken("f1e2d3c4b5"),
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when token is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			hash:        models.HashEnrollmentToken("f1e2d3c4b5"),
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			token, err := mongostore.EnrollmentTokenGetByHash(context.TODO(), tc.tenant, tc.hash)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, "e1a2b3c4-0000-4000-8000-000000000001", token.ID)
+			}
+		})
+	}
+}
+
+func TestEnrollmentTokenCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	defer fixtures.Teardown() // nolint: errcheck
+
+	err := mongostore.EnrollmentTokenCreate(context.TODO(), &models.EnrollmentToken{
+		ID:        "e1a2b3c4-0000-4000-8000-000000000003",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Name:      "kiosk",
+		Hash:      models.HashEnrollmentToken("c1d2e3f4a5"),
+		Tags:      []string{},
+		Status:    models.DeviceStatusPending,
+		CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+}
+
+func TestEnrollmentTokenUse(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		at          time.Time
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when token is not found",
+			id:          "nonexistent",
+			at:          time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when token is expired",
+			id:          "e1a2b3c4-0000-4000-8000-000000000002",
+			at:          time.Date(2031, 1, 1, 12, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when token is valid",
+			id:          "e1a2b3c4-0000-4000-8000-000000000001",
+			at:          time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.EnrollmentTokenUse(context.TODO(), tc.id, tc.at)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestEnrollmentTokenUseSingleUse(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureEnrollmentTokens))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	at := time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, mongostore.EnrollmentTokenUse(context.TODO(), "e1a2b3c4-0000-4000-8000-000000000002", at))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.EnrollmentTokenUse(context.TODO(), "e1a2b3c4-0000-4000-8000-000000000002", at))
+
+	token, err := mongostore.EnrollmentTokenGet(context.TODO(), "e1a2b3c4-0000-4000-8000-000000000002")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, token.Uses)
+	assert.Equal(t, &at, token.LastUsedAt)
+}
+
+func TestEnrollmentTokenRevoke(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when token belongs to another namespace",
+			tenant:      "nonexistent",
+			id:          "e1a2b3c4-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when token is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "e1a2b3c4-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureEnrollmentTokens},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.EnrollmentTokenRevoke(context.TODO(), tc.tenant, tc.id, time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC))
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// filePushContentBucket is the GridFS bucket where the content of the pushed files is stored, identified by the file
+// push's ID.
+const filePushContentBucket = "file_push_contents"
+
+func (s *Store) FilePushList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.FilePush, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{"tenant_id": tenant},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("file_pushes"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+	query = append(query, bson.M{"$project": bson.M{"results": 0}})
+
+	pushes := make([]models.FilePush, 0)
+	cursor, err := s.db.Collection("file_pushes").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		push := new(models.FilePush)
+		if err := cursor.Decode(push); err != nil {
+			return pushes, count, FromMongoError(err)
+		}
+
+		pushes = append(pushes, *push)
+	}
+
+	return pushes, count, nil
+}
+
+func (s *Store) FilePushGet(ctx context.Context, id string) (*models.FilePush, error) {
+	push := new(models.FilePush)
+	if err := s.db.Collection("file_pushes").FindOne(ctx, bson.M{"id": id}).Decode(push); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return push, nil
+}
+
+func (s *Store) FilePushCreate(ctx context.Context, push *models.FilePush) error {
+	_, err := s.db.Collection("file_pushes").InsertOne(ctx, push)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) FilePushSetStatus(ctx context.Context, id string, status models.FilePushStatus, finishedAt *time.Time) error {
+	res, err := s.db.Collection("file_pushes").UpdateOne(
+		ctx,
+		bson.M{"id": id, "status": bson.M{"$ne": status}},
+		bson.M{"$set": bson.M{"status": status, "finished_at": finishedAt}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) FilePushSetResult(ctx context.Context, id string, result *models.FilePushResult) error {
+	res, err := s.db.Collection("file_pushes").UpdateOne(
+		ctx,
+		bson.M{"id": id, "results.device": result.Device},
+		bson.M{"$set": bson.M{"results.$": result}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) filePushContentBucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(s.db, options.GridFSBucket().SetName(filePushContentBucket))
+}
+
+func (s *Store) FilePushContentSave(ctx context.Context, id string, r io.Reader) (int64, error) {
+	bucket, err := s.filePushContentBucket()
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		bucket.SetWriteDeadline(deadline) //nolint:errcheck
+	}
+
+	stream, err := bucket.OpenUploadStreamWithID(id, id)
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+
+	size, err := io.Copy(stream, r)
+	if err != nil {
+		stream.Abort() //nolint:errcheck
+
+		return 0, FromMongoError(err)
+	}
+
+	return size, FromMongoError(stream.Close())
+}
+
+func (s *Store) FilePushContentOpen(ctx context.Context, id string, offset int64) (io.ReadCloser, error) {
+	bucket, err := s.filePushContentBucket()
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	stream, err := bucket.OpenDownloadStream(id)
+	if err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return nil, store.ErrNoDocuments
+		}
+
+		return nil, FromMongoError(err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		stream.SetReadDeadline(deadline) //nolint:errcheck
+	}
+
+	if _, err := stream.Skip(offset); err != nil {
+		stream.Close()
+
+		return nil, FromMongoError(err)
+	}
+
+	return stream, nil
+}
+
+func (s *Store) FilePushContentDelete(ctx context.Context, id string) error {
+	bucket, err := s.filePushContentBucket()
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if err := bucket.DeleteContext(ctx, id); err != nil {
+		if errors.Is(err, gridfs.ErrFileNotFound) {
+			return store.ErrNoDocuments
+		}
+
+		return FromMongoError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,311 @@
+package mongo
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilePushList(t *testing.T) {
+	type Expected struct {
+		pushes []models.FilePush
+		count  int
+		err    error
+	}
+
+	finishedAt := time.Date(2023, 1, 1, 12, 0, 5, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no file pushes",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected: Expected{
+				pushes: []models.FilePush{},
+				count:  0,
+				err:    nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has file pushes",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected: Expected{
+				pushes: []models.FilePush{
+					{
+						ID:          "c8d3e4f5-0000-4000-8000-000000000002",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "motd",
+						Path:        "/etc/motd",
+						Mode:        "0644",
+						Size:        0,
+						SHA256:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+						Tags:        []string{"tag-1"},
+						Concurrency: 1,
+						Status:      models.FilePushStatusPending,
+						CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:          "c8d3e4f5-0000-4000-8000-000000000001",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "app.conf",
+						Path:        "/etc/app/app.conf",
+						Owner:       "root",
+						Mode:        "0644",
+						Size:        10,
+						SHA256:      "7d8a1b2e3cd6f4bb2d9c4a30fd88ef1aa0bf4c5bff3d54beb9bd1a4b1e5a6f40",
+						Tags:        []string{"tag-1"},
+						Concurrency: 10,
+						Status:      models.FilePushStatusFinished,
+						CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						FinishedAt:  &finishedAt,
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			pushes, count, err := mongostore.FilePushList(context.TODO(), tc.tenant, tc.page)
+			assert.Equal(t, tc.expected, Expected{pushes: pushes, count: count, err: err})
+		})
+	}
+}
+
+func TestFilePushGet(t *testing.T) {
+	type Expected struct {
+		push *models.FilePush
+		err  error
+	}
+
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when file push is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected: Expected{
+				push: nil,
+				err:  store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when file push is found",
+			id:          "c8d3e4f5-0000-4000-8000-000000000002",
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected: Expected{
+				push: &models.FilePush{
+					ID:          "c8d3e4f5-0000-4000-8000-000000000002",
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					Name:        "motd",
+					Path:        "/etc/motd",
+					Mode:        "0644",
+					Size:        0,
+					SHA256:      "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+					Tags:        []string{"tag-1"},
+					Concurrency: 1,
+					Status:      models.FilePushStatusPending,
+					CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					Results: []models.FilePushResult{
+						{
+							Device: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Status: models.FilePushResultStatusPending,
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			push, err := mongostore.FilePushGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, Expected{push: push, err: err})
+		})
+	}
+}
+
+func TestFilePushSetStatus(t *testing.T) {
+	finishedAt := time.Date(2023, 1, 2, 12, 1, 0, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		id          string
+		status      models.FilePushStatus
+		finishedAt  *time.Time
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when file push is not found",
+			id:          "nonexistent",
+			status:      models.FilePushStatusRunning,
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when file push already has the status",
+			id:          "c8d3e4f5-0000-4000-8000-000000000001",
+			status:      models.FilePushStatusFinished,
+			finishedAt:  &finishedAt,
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when file push is found",
+			id:          "c8d3e4f5-0000-4000-8000-000000000002",
+			status:      models.FilePushStatusFinished,
+			finishedAt:  &finishedAt,
+			fixtures:    []string{fixtures.FixtureFilePushes},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.FilePushSetStatus(context.TODO(), tc.id, tc.status, tc.finishedAt)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				push, err := mongostore.FilePushGet(context.TODO(), tc.id)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.status, push.Status)
+				assert.Equal(t, tc.finishedAt, push.FinishedAt)
+			}
+		})
+	}
+}
+
+func TestFilePushSetResult(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		result      *models.FilePushResult
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device is not part of the file push",
+			id:          "c8d3e4f5-0000-4000-8000-000000000002",
+			result: &models.FilePushResult{
+				Device: "nonexistent",
+				Status: models.FilePushResultStatusSucceeded,
+			},
+			fixtures: []string{fixtures.FixtureFilePushes},
+			expected: store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device is part of the file push",
+			id:          "c8d3e4f5-0000-4000-8000-000000000002",
+			result: &models.FilePushResult{
+				Device: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+				Status: models.FilePushResultStatusFailed,
+				Error:  "checksum does not match the pushed file",
+			},
+			fixtures: []string{fixtures.FixtureFilePushes},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.FilePushSetResult(context.TODO(), tc.id, tc.result)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				push, err := mongostore.FilePushGet(context.TODO(), tc.id)
+				assert.NoError(t, err)
+				assert.Equal(t, []models.FilePushResult{*tc.result}, push.Results)
+			}
+		})
+	}
+}
+
+func TestFilePushContent(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	ctx := context.TODO()
+
+	_, err := mongostore.FilePushContentOpen(ctx, "c8d3e4f5-0000-4000-8000-000000000003", 0)
+	assert.Equal(t, store.ErrNoDocuments, err)
+
+	size, err := mongostore.FilePushContentSave(ctx, "c8d3e4f5-0000-4000-8000-000000000003", strings.NewReader("key=value\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), size)
+
+	content, err := mongostore.FilePushContentOpen(ctx, "c8d3e4f5-0000-4000-8000-000000000003", 4)
+	require.NoError(t, err)
+
+	read, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.NoError(t, content.Close())
+	assert.Equal(t, "value\n", string(read))
+
+	assert.NoError(t, mongostore.FilePushContentDelete(ctx, "c8d3e4f5-0000-4000-8000-000000000003"))
+	assert.Equal(t, store.ErrNoDocuments, mongostore.FilePushContentDelete(ctx, "c8d3e4f5-0000-4000-8000-000000000003"))
+}
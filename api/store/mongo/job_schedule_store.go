@@ -0,0 +1,136 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) JobScheduleList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.JobSchedule, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("job_schedules"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	schedules := make([]models.JobSchedule, 0)
+	cursor, err := s.db.Collection("job_schedules").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		schedule := new(models.JobSchedule)
+		if err := cursor.Decode(schedule); err != nil {
+			return schedules, count, FromMongoError(err)
+		}
+
+		schedules = append(schedules, *schedule)
+	}
+
+	return schedules, count, nil
+}
+
+func (s *Store) JobScheduleListEnabled(ctx context.Context) ([]models.JobSchedule, error) {
+	cursor, err := s.db.Collection("job_schedules").Find(ctx, bson.M{"enabled": true})
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	schedules := make([]models.JobSchedule, 0)
+	for cursor.Next(ctx) {
+		schedule := new(models.JobSchedule)
+		if err := cursor.Decode(schedule); err != nil {
+			return schedules, FromMongoError(err)
+		}
+
+		schedules = append(schedules, *schedule)
+	}
+
+	return schedules, nil
+}
+
+func (s *Store) JobScheduleGet(ctx context.Context, id string) (*models.JobSchedule, error) {
+	schedule := new(models.JobSchedule)
+	if err := s.db.Collection("job_schedules").FindOne(ctx, bson.M{"id": id}).Decode(schedule); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return schedule, nil
+}
+
+func (s *Store) JobScheduleCreate(ctx context.Context, schedule *models.JobSchedule) error {
+	_, err := s.db.Collection("job_schedules").InsertOne(ctx, schedule)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) JobScheduleUpdate(ctx context.Context, schedule *models.JobSchedule) error {
+	res, err := s.db.Collection("job_schedules").ReplaceOne(ctx, bson.M{"id": schedule.ID}, schedule)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobScheduleClaimRun(ctx context.Context, id string, lastRunAt, before time.Time) error {
+	filter := bson.M{
+		"id": id,
+		"$or": []bson.M{
+			{"last_run_at": nil},
+			{"last_run_at": bson.M{"$lte": before}},
+		},
+	}
+
+	res, err := s.db.Collection("job_schedules").UpdateOne(ctx, filter, bson.M{"$set": bson.M{"last_run_at": lastRunAt}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobScheduleDelete(ctx context.Context, tenant, id string) error {
+	res, err := s.db.Collection("job_schedules").DeleteOne(ctx, bson.M{"tenant_id": tenant, "id": id})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,339 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobScheduleList(t *testing.T) {
+	type Expected struct {
+		schedules []models.JobSchedule
+		count     int
+		err       error
+	}
+
+	lastRunAt := time.Date(2023, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no schedules",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected: Expected{
+				schedules: []models.JobSchedule{},
+				count:     0,
+				err:       nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has schedules",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected: Expected{
+				schedules: []models.JobSchedule{
+					{
+						ID:          "b7c2d3e4-0000-4000-8000-000000000001",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "reboot",
+						Cron:        "0 3 * * *",
+						Command:     "reboot",
+						User:        "root",
+						Tags:        []string{"tag-1"},
+						Concurrency: 1,
+						Timeout:     30,
+						Offline:     models.JobScheduleOfflineSkip,
+						Enabled:     true,
+						CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						LastRunAt:   &lastRunAt,
+					},
+					{
+						ID:          "b7c2d3e4-0000-4000-8000-000000000002",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Name:        "cleanup",
+						Cron:        "@daily",
+						Command:     "rm -rf /tmp/cache",
+						User:        "root",
+						Tags:        []string{"tag-1", "tag-2"},
+						Concurrency: 10,
+						Timeout:     60,
+						Offline:     models.JobScheduleOfflineQueue,
+						NotifyURL:   "https://example.com/notify",
+						Enabled:     false,
+						CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			schedules, count, err := mongostore.JobScheduleList(context.TODO(), tc.tenant, tc.page)
+			assert.Equal(t, tc.expected, Expected{schedules: schedules, count: count, err: err})
+		})
+	}
+}
+
+func TestJobScheduleListEnabled(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureJobSchedules))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	schedules, err := mongostore.JobScheduleListEnabled(context.TODO())
+	assert.NoError(t, err)
+	assert.Len(t, schedules, 1)
+	assert.Equal(t, "b7c2d3e4-0000-4000-8000-000000000001", schedules[0].ID)
+}
+
+func TestJobScheduleGet(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when schedule is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when schedule is found",
+			id:          "b7c2d3e4-0000-4000-8000-000000000002",
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			schedule, err := mongostore.JobScheduleGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, tc.id, schedule.ID)
+			}
+		})
+	}
+}
+
+func TestJobScheduleCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	defer fixtures.Teardown() // nolint: errcheck
+
+	err := mongostore.JobScheduleCreate(context.TODO(), &models.JobSchedule{
+		ID:          "b7c2d3e4-0000-4000-8000-000000000003",
+		TenantID:    "00000000-0000-4000-0000-000000000000",
+		Name:        "uptime",
+		Cron:        "@hourly",
+		Command:     "uptime",
+		User:        "root",
+		Tags:        []string{"tag-1"},
+		Concurrency: 10,
+		Timeout:     60,
+		Offline:     models.JobScheduleOfflineSkip,
+		Enabled:     true,
+		CreatedAt:   time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+}
+
+func TestJobScheduleUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		schedule    *models.JobSchedule
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when schedule is not found",
+			schedule:    &models.JobSchedule{ID: "nonexistent"},
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when schedule is found",
+			schedule: &models.JobSchedule{
+				ID:          "b7c2d3e4-0000-4000-8000-000000000002",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Name:        "cleanup",
+				Cron:        "@weekly",
+				Command:     "rm -rf /tmp/cache",
+				User:        "root",
+				Tags:        []string{"tag-2"},
+				Concurrency: 5,
+				Timeout:     60,
+				Offline:     models.JobScheduleOfflineSkip,
+				Enabled:     true,
+				CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureJobSchedules},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobScheduleUpdate(context.TODO(), tc.schedule)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				schedule, err := mongostore.JobScheduleGet(context.TODO(), tc.schedule.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.schedule, schedule)
+			}
+		})
+	}
+}
+
+func TestJobScheduleClaimRun(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		before      time.Time
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when schedule is not found",
+			id:          "nonexistent",
+			before:      time.Date(2023, 1, 3, 2, 30, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when schedule has already run since before",
+			id:          "b7c2d3e4-0000-4000-8000-000000000001",
+			before:      time.Date(2023, 1, 2, 2, 30, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when schedule hasn't run since before",
+			id:          "b7c2d3e4-0000-4000-8000-000000000001",
+			before:      time.Date(2023, 1, 3, 2, 30, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    nil,
+		},
+		{
+			description: "succeeds when schedule has never run",
+			id:          "b7c2d3e4-0000-4000-8000-000000000002",
+			before:      time.Date(2023, 1, 3, 2, 30, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobScheduleClaimRun(context.TODO(), tc.id, time.Date(2023, 1, 3, 3, 0, 0, 0, time.UTC), tc.before)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestJobScheduleDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when schedule belongs to another namespace",
+			tenant:      "nonexistent",
+			id:          "b7c2d3e4-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when schedule is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "b7c2d3e4-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureJobSchedules},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobScheduleDelete(context.TODO(), tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) JobList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.Job, int, error) {
+	return s.jobList(ctx, bson.M{"tenant_id": tenant}, pagination)
+}
+
+func (s *Store) JobListBySchedule(ctx context.Context, schedule string, pagination paginator.Query) ([]models.Job, int, error) {
+	return s.jobList(ctx, bson.M{"schedule": schedule}, pagination)
+}
+
+// jobList lists the jobs matching the filter, newest first, without their results.
+func (s *Store) jobList(ctx context.Context, filter bson.M, pagination paginator.Query) ([]models.Job, int, error) {
+	query := []bson.M{
+		{
+			"$match": filter,
+		},
+		{
+			"$sort": bson.M{
+				"created_at": -1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("jobs"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	jobs := make([]models.Job, 0)
+	cursor, err := s.db.Collection("jobs").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		job := new(models.Job)
+		if err := cursor.Decode(job); err != nil {
+			return jobs, count, FromMongoError(err)
+		}
+
+		jobs = append(jobs, *job)
+	}
+
+	return jobs, count, nil
+}
+
+// jobResult is the document of a job's result from a device, stored apart from the job.
+type jobResult struct {
+	Job              string `bson:"job"`
+	models.JobResult `bson:",inline"`
+}
+
+func (s *Store) JobGet(ctx context.Context, id string) (*models.Job, error) {
+	job := new(models.Job)
+	if err := s.db.Collection("jobs").FindOne(ctx, bson.M{"id": id}).Decode(job); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	cursor, err := s.db.Collection("job_results").Find(ctx, bson.M{"job": id}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	job.Results = make([]models.JobResult, 0)
+	for cursor.Next(ctx) {
+		result := new(jobResult)
+		if err := cursor.Decode(result); err != nil {
+			return nil, FromMongoError(err)
+		}
+
+		job.Results = append(job.Results, result.JobResult)
+	}
+
+	return job, nil
+}
+
+func (s *Store) JobCreate(ctx context.Context, job *models.Job) error {
+	if _, err := s.db.Collection("jobs").InsertOne(ctx, job); err != nil {
+		return FromMongoError(err)
+	}
+
+	if len(job.Results) == 0 {
+		return nil
+	}
+
+	results := make([]interface{}, 0, len(job.Results))
+	for _, result := range job.Results {
+		results = append(results, &jobResult{Job: job.ID, JobResult: result})
+	}
+
+	_, err := s.db.Collection("job_results").InsertMany(ctx, results)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) JobSetStatus(ctx context.Context, id string, status models.JobStatus, finishedAt *time.Time) error {
+	res, err := s.db.Collection("jobs").UpdateOne(
+		ctx,
+		bson.M{"id": id, "status": bson.M{"$ne": status}},
+		bson.M{"$set": bson.M{"status": status, "finished_at": finishedAt}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) JobSetResult(ctx context.Context, id string, result *models.JobResult) error {
+	res, err := s.db.Collection("job_results").UpdateOne(
+		ctx,
+		bson.M{"job": id, "device": result.Device},
+		bson.M{"$set": &jobResult{Job: id, JobResult: *result}},
+	)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,400 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobList(t *testing.T) {
+	type Expected struct {
+		jobs  []models.Job
+		count int
+		err   error
+	}
+
+	finishedAt := time.Date(2023, 1, 1, 12, 0, 5, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no jobs",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected: Expected{
+				jobs:  []models.Job{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has jobs",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected: Expected{
+				jobs: []models.Job{
+					{
+						ID:          "a6b1c2d3-0000-4000-8000-000000000002",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Schedule:    "b7c2d3e4-0000-4000-8000-000000000001",
+						Command:     "reboot",
+						User:        "root",
+						Tags:        []string{"tag-1"},
+						Concurrency: 1,
+						Timeout:     30,
+						Status:      models.JobStatusPending,
+						CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:          "a6b1c2d3-0000-4000-8000-000000000001",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Command:     "uptime",
+						User:        "root",
+						Concurrency: 10,
+						Timeout:     60,
+						Status:      models.JobStatusFinished,
+						CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						FinishedAt:  &finishedAt,
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			jobs, count, err := mongostore.JobList(context.TODO(), tc.tenant, tc.page)
+			assert.Equal(t, tc.expected, Expected{jobs: jobs, count: count, err: err})
+		})
+	}
+}
+
+func TestJobListBySchedule(t *testing.T) {
+	type Expected struct {
+		jobs  []models.Job
+		count int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		schedule    string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when schedule has no jobs",
+			schedule:    "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected: Expected{
+				jobs:  []models.Job{},
+				count: 0,
+				err:   nil,
+			},
+		},
+		{
+			description: "succeeds when schedule has jobs",
+			schedule:    "b7c2d3e4-0000-4000-8000-000000000001",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected: Expected{
+				jobs: []models.Job{
+					{
+						ID:          "a6b1c2d3-0000-4000-8000-000000000002",
+						TenantID:    "00000000-0000-4000-0000-000000000000",
+						Schedule:    "b7c2d3e4-0000-4000-8000-000000000001",
+						Command:     "reboot",
+						User:        "root",
+						Tags:        []string{"tag-1"},
+						Concurrency: 1,
+						Timeout:     30,
+						Status:      models.JobStatusPending,
+						CreatedAt:   time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					},
+				},
+				count: 1,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			jobs, count, err := mongostore.JobListBySchedule(context.TODO(), tc.schedule, tc.page)
+			assert.Equal(t, tc.expected, Expected{jobs: jobs, count: count, err: err})
+		})
+	}
+}
+
+func TestJobGet(t *testing.T) {
+	type Expected struct {
+		job *models.Job
+		err error
+	}
+
+	finishedAt := time.Date(2023, 1, 1, 12, 0, 5, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when job is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureJobs, fixtures.FixtureJobResults},
+			expected: Expected{
+				job: nil,
+				err: store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when job is found",
+			id:          "a6b1c2d3-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureJobs, fixtures.FixtureJobResults},
+			expected: Expected{
+				job: &models.Job{
+					ID:          "a6b1c2d3-0000-4000-8000-000000000001",
+					TenantID:    "00000000-0000-4000-0000-000000000000",
+					Command:     "uptime",
+					User:        "root",
+					Concurrency: 10,
+					Timeout:     60,
+					Status:      models.JobStatusFinished,
+					CreatedAt:   time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					FinishedAt:  &finishedAt,
+					Results: []models.JobResult{
+						{
+							Device: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+							Status: models.JobResultStatusSucceeded,
+							Stdout: "up 1 day\n",
+						},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			job, err := mongostore.JobGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, Expected{job: job, err: err})
+		})
+	}
+}
+
+func TestJobCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		job         *models.Job
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "succeeds when data is valid",
+			job: &models.Job{
+				ID:          "a6b1c2d3-0000-4000-8000-000000000003",
+				TenantID:    "00000000-0000-4000-0000-000000000000",
+				Command:     "uptime",
+				User:        "root",
+				Concurrency: 10,
+				Timeout:     60,
+				Status:      models.JobStatusPending,
+				CreatedAt:   time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+				Results: []models.JobResult{
+					{
+						Device: "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+						Status: models.JobResultStatusPending,
+					},
+				},
+			},
+			fixtures: []string{},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobCreate(context.TODO(), tc.job)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				job, err := mongostore.JobGet(context.TODO(), tc.job.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.job.Results, job.Results)
+			}
+		})
+	}
+}
+
+func TestJobSetStatus(t *testing.T) {
+	finishedAt := time.Date(2023, 1, 2, 12, 1, 0, 0, time.UTC)
+
+	cases := []struct {
+		description string
+		id          string
+		status      models.JobStatus
+		finishedAt  *time.Time
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when job is not found",
+			id:          "nonexistent",
+			status:      models.JobStatusRunning,
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when job already has the status",
+			id:          "a6b1c2d3-0000-4000-8000-000000000001",
+			status:      models.JobStatusFinished,
+			finishedAt:  &finishedAt,
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when job is found",
+			id:          "a6b1c2d3-0000-4000-8000-000000000002",
+			status:      models.JobStatusFinished,
+			finishedAt:  &finishedAt,
+			fixtures:    []string{fixtures.FixtureJobs},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobSetStatus(context.TODO(), tc.id, tc.status, tc.finishedAt)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				job, err := mongostore.JobGet(context.TODO(), tc.id)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.status, job.Status)
+				assert.Equal(t, tc.finishedAt, job.FinishedAt)
+			}
+		})
+	}
+}
+
+func TestJobSetResult(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		result      *models.JobResult
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when device is not part of the job",
+			id:          "a6b1c2d3-0000-4000-8000-000000000002",
+			result: &models.JobResult{
+				Device: "nonexistent",
+				Status: models.JobResultStatusSucceeded,
+			},
+			fixtures: []string{fixtures.FixtureJobs, fixtures.FixtureJobResults},
+			expected: store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when device is part of the job",
+			id:          "a6b1c2d3-0000-4000-8000-000000000002",
+			result: &models.JobResult{
+				Device:   "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+				Status:   models.JobResultStatusFailed,
+				ExitCode: 1,
+				Stderr:   "permission denied\n",
+			},
+			fixtures: []string{fixtures.FixtureJobs, fixtures.FixtureJobResults},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.JobSetResult(context.TODO(), tc.id, tc.result)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				job, err := mongostore.JobGet(context.TODO(), tc.id)
+				assert.NoError(t, err)
+				assert.Equal(t, []models.JobResult{*tc.result}, job.Results)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (s *Store) MetricsCreate(ctx context.Context, metrics *models.DeviceMetrics) error {
+	_, err := s.db.Collection("device_metrics").InsertOne(ctx, metrics)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) MetricsList(ctx context.Context, uid models.UID, from, to time.Time) ([]models.DeviceMetrics, error) {
+	filter := bson.M{
+		"uid": uid,
+		"time": bson.M{
+			"$gte": from,
+			"$lte": to,
+		},
+	}
+
+	cursor, err := s.db.Collection("device_metrics").Find(ctx, filter, options.Find().SetSort(bson.M{"time": 1}))
+	if err != nil {
+		return nil, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	metrics := make([]models.DeviceMetrics, 0)
+	for cursor.Next(ctx) {
+		sample := new(models.DeviceMetrics)
+		if err := cursor.Decode(sample); err != nil {
+			return metrics, FromMongoError(err)
+		}
+
+		metrics = append(metrics, *sample)
+	}
+
+	return metrics, nil
+}
+
+func (s *Store) MetricsDeleteByDate(ctx context.Context, lte time.Time) (int64, error) {
+	res, err := s.db.Collection("device_metrics").DeleteMany(ctx, bson.M{"time": bson.M{"$lte": lte}})
+	if err != nil {
+		return 0, FromMongoError(err)
+	}
+
+	return res.DeletedCount, nil
+}
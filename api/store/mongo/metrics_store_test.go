@@ -0,0 +1,172 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		metrics     *models.DeviceMetrics
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "succeeds when data is valid",
+			metrics: &models.DeviceMetrics{
+				UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+				TenantID: "00000000-0000-4000-0000-000000000000",
+				Time:     time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+				CPU:      10,
+				Load:     []float64{0.1, 0.1, 0.1},
+			},
+			fixtures: []string{},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.MetricsCreate(context.TODO(), tc.metrics)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestMetricsList(t *testing.T) {
+	type Expected struct {
+		metrics []models.DeviceMetrics
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		from        time.Time
+		to          time.Time
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when device has no metrics",
+			uid:         models.UID("nonexistent"),
+			from:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2023, 1, 3, 0, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDeviceMetrics},
+			expected: Expected{
+				metrics: []models.DeviceMetrics{},
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds when device has metrics in the range",
+			uid:         models.UID("2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c"),
+			from:        time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+			to:          time.Date(2023, 1, 1, 23, 59, 59, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDeviceMetrics},
+			expected: Expected{
+				metrics: []models.DeviceMetrics{
+					{
+						UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Time:     time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						CPU:      12.5,
+						Memory:   models.DeviceMetricsUsage{Total: 2048, Used: 1024},
+						Disk:     models.DeviceMetricsUsage{Total: 8192, Used: 4096},
+						Load:     []float64{0.5, 0.4, 0.3},
+						Uptime:   3600,
+						Network:  models.DeviceMetricsNetwork{RxBytes: 100, TxBytes: 200},
+					},
+					{
+						UID:      "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Time:     time.Date(2023, 1, 1, 12, 1, 0, 0, time.UTC),
+						CPU:      25,
+						Memory:   models.DeviceMetricsUsage{Total: 2048, Used: 1536},
+						Disk:     models.DeviceMetricsUsage{Total: 8192, Used: 4096},
+						Load:     []float64{0.6, 0.4, 0.3},
+						Uptime:   3660,
+						Network:  models.DeviceMetricsNetwork{RxBytes: 300, TxBytes: 400},
+					},
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			metrics, err := mongostore.MetricsList(context.TODO(), tc.uid, tc.from, tc.to)
+			assert.Equal(t, tc.expected, Expected{metrics: metrics, err: err})
+		})
+	}
+}
+
+func TestMetricsDeleteByDate(t *testing.T) {
+	type Expected struct {
+		deleted int64
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		lte         time.Time
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when there are no metrics to delete",
+			lte:         time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDeviceMetrics},
+			expected:    Expected{deleted: 0, err: nil},
+		},
+		{
+			description: "succeeds when there are metrics to delete",
+			lte:         time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+			fixtures:    []string{fixtures.FixtureDeviceMetrics},
+			expected:    Expected{deleted: 2, err: nil},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			deleted, err := mongostore.MetricsDeleteByDate(context.TODO(), tc.lte)
+			assert.Equal(t, tc.expected, Expected{deleted: deleted, err: err})
+		})
+	}
+}
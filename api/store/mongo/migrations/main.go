@@ -73,6 +73,15 @@ func GenerateMigrations() []migrate.Migration {
 		migration61,
 		migration62,
 		migration63,
+		migration64,
+		migration65,
+		migration66,
+		migration67,
+		migration68,
+		migration69,
+		migration70,
+		migration71,
+		migration72,
 	}
 }
 
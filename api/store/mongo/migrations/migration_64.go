@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration64 = migrate.Migration{
+	Version:     64,
+	Description: "create address and device indexes in tunnels collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{"address", 1}},
+				Options: options.Index().SetName("address").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{"device", 1}},
+				Options: options.Index().SetName("device").SetUnique(false),
+			},
+		}
+
+		_, err := db.Collection("tunnels").Indexes().CreateMany(context.TODO(), indexes)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   64,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("tunnels").Indexes().DropOne(context.TODO(), "address"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("tunnels").Indexes().DropOne(context.TODO(), "device")
+
+		return err
+	},
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration64(t *testing.T) {
+	logrus.Info("Testing Migration 64 - Test whether the tunnel's address is unique")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[:64]...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(64), version)
+
+	tunnel := models.Tunnel{
+		Address: "a582b47a42d",
+		Device:  "2300230e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809c",
+		Host:    "localhost",
+		Port:    5432,
+	}
+
+	_, err = db.Client().Database("test").Collection("tunnels").InsertOne(context.TODO(), tunnel)
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("tunnels").InsertOne(context.TODO(), tunnel)
+	assert.Error(t, err)
+
+	err = migrates.Down(migrate.AllAvailable)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration65 = migrate.Migration{
+	Version:     65,
+	Description: "create uid_time and time indexes in device_metrics collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{"uid", 1}, {"time", 1}},
+				Options: options.Index().SetName("uid_time").SetUnique(false),
+			},
+			{
+				Keys:    bson.D{{"time", 1}},
+				Options: options.Index().SetName("time").SetUnique(false),
+			},
+		}
+
+		_, err := db.Collection("device_metrics").Indexes().CreateMany(context.TODO(), indexes)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   65,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("device_metrics").Indexes().DropOne(context.TODO(), "uid_time"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("device_metrics").Indexes().DropOne(context.TODO(), "time")
+
+		return err
+	},
+}
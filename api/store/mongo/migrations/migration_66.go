@@ -0,0 +1,66 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration66 = migrate.Migration{
+	Version:     66,
+	Description: "create id and tenant_id_created_at indexes in jobs collection and job_device index in job_results collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{"id", 1}},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{"tenant_id", 1}, {"created_at", -1}},
+				Options: options.Index().SetName("tenant_id_created_at").SetUnique(false),
+			},
+		}
+
+		if _, err := db.Collection("jobs").Indexes().CreateMany(context.TODO(), indexes); err != nil {
+			return err
+		}
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{"job", 1}, {"device", 1}},
+			Options: options.Index().SetName("job_device").SetUnique(true),
+		}
+
+		_, err := db.Collection("job_results").Indexes().CreateOne(context.TODO(), index)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   66,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("jobs").Indexes().DropOne(context.TODO(), "id"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("jobs").Indexes().DropOne(context.TODO(), "tenant_id_created_at"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("job_results").Indexes().DropOne(context.TODO(), "job_device")
+
+		return err
+	},
+}
@@ -0,0 +1,50 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration66(t *testing.T) {
+	logrus.Info("Testing Migration 66 - Test whether the jobs and job results indexes are created")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[:66]...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(66), version)
+
+	cursor, err := db.Client().Database("test").Collection("jobs").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	names := make([]string, 0)
+	for cursor.Next(context.TODO()) {
+		var index bson.M
+		assert.NoError(t, cursor.Decode(&index))
+
+		names = append(names, index["name"].(string))
+	}
+
+	assert.Contains(t, names, "id")
+	assert.Contains(t, names, "tenant_id_created_at")
+
+	_, err = db.Client().Database("test").Collection("job_results").InsertOne(context.TODO(), bson.M{"job": "job", "device": "device"})
+	assert.NoError(t, err)
+
+	_, err = db.Client().Database("test").Collection("job_results").InsertOne(context.TODO(), bson.M{"job": "job", "device": "device"})
+	assert.Error(t, err)
+
+	err = migrates.Down(migrate.AllAvailable)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,64 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration67 = migrate.Migration{
+	Version:     67,
+	Description: "create id and tenant_id_created_at indexes in job_schedules collection and schedule index in jobs collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   67,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{"id", 1}},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{"tenant_id", 1}, {"created_at", 1}},
+				Options: options.Index().SetName("tenant_id_created_at").SetUnique(false),
+			},
+		}
+
+		if _, err := db.Collection("job_schedules").Indexes().CreateMany(context.TODO(), indexes); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("jobs").Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+			Keys:    bson.D{{"schedule", 1}, {"created_at", -1}},
+			Options: options.Index().SetName("schedule_created_at").SetUnique(false),
+		})
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   67,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("jobs").Indexes().DropOne(context.TODO(), "schedule_created_at"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("job_schedules").Indexes().DropOne(context.TODO(), "id"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("job_schedules").Indexes().DropOne(context.TODO(), "tenant_id_created_at")
+
+		return err
+	},
+}
@@ -0,0 +1,49 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration67(t *testing.T) {
+	logrus.Info("Testing Migration 67 - Test whether the job schedules indexes are created")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[:67]...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(67), version)
+
+	indexes := func(collection string) []string {
+		cursor, err := db.Client().Database("test").Collection(collection).Indexes().List(context.TODO())
+		assert.NoError(t, err)
+
+		names := make([]string, 0)
+		for cursor.Next(context.TODO()) {
+			var index bson.M
+			assert.NoError(t, cursor.Decode(&index))
+
+			names = append(names, index["name"].(string))
+		}
+
+		return names
+	}
+
+	assert.Contains(t, indexes("job_schedules"), "id")
+	assert.Contains(t, indexes("job_schedules"), "tenant_id_created_at")
+	assert.Contains(t, indexes("jobs"), "schedule_created_at")
+
+	err = migrates.Down(migrate.AllAvailable)
+	assert.NoError(t, err)
+}
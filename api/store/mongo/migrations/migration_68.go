@@ -0,0 +1,53 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration68 = migrate.Migration{
+	Version:     68,
+	Description: "create id and tenant_id_created_at indexes in file_pushes collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{"id", 1}},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{"tenant_id", 1}, {"created_at", 1}},
+				Options: options.Index().SetName("tenant_id_created_at").SetUnique(false),
+			},
+		}
+
+		_, err := db.Collection("file_pushes").Indexes().CreateMany(context.TODO(), indexes)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   68,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("file_pushes").Indexes().DropOne(context.TODO(), "id"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("file_pushes").Indexes().DropOne(context.TODO(), "tenant_id_created_at")
+
+		return err
+	},
+}
@@ -0,0 +1,88 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration70 = migrate.Migration{
+	Version:     70,
+	Description: "create id and tenant_id_created_at indexes in device_accept_rules collection and hash their tokens",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "id", Value: 1}},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("tenant_id_created_at").SetUnique(false),
+			},
+		}
+
+		if _, err := db.Collection("device_accept_rules").Indexes().CreateMany(context.TODO(), indexes); err != nil {
+			return err
+		}
+
+		// NOTICE: The rules' tokens are stored hashed as token_hash, so the ones stored as they are, by the rules created
+		// before, are hashed.
+		cursor, err := db.Collection("device_accept_rules").Find(context.TODO(), bson.M{"token": bson.M{"$exists": true}})
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(context.TODO())
+
+		for cursor.Next(context.TODO()) {
+			rule := new(struct {
+				ID    string `bson:"id"`
+				Token string `bson:"token"`
+			})
+
+			if err := cursor.Decode(rule); err != nil {
+				return err
+			}
+
+			hash := ""
+			if rule.Token != "" {
+				hash = models.HashEnrollmentToken(rule.Token)
+			}
+
+			if _, err := db.Collection("device_accept_rules").UpdateOne(context.TODO(), bson.M{"id": rule.ID}, bson.M{
+				"$set":   bson.M{"token_hash": hash},
+				"$unset": bson.M{"token": ""},
+			}); err != nil {
+				return err
+			}
+		}
+
+		return cursor.Err()
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   70,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		// NOTICE: The hashed tokens can't be reverted, so only the indexes are dropped.
+		if _, err := db.Collection("device_accept_rules").Indexes().DropOne(context.TODO(), "id"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("device_accept_rules").Indexes().DropOne(context.TODO(), "tenant_id_created_at")
+
+		return err
+	},
+}
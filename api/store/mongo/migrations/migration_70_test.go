@@ -0,0 +1,63 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMigration70(t *testing.T) {
+	logrus.Info("Testing Migration 70 - Test whether the device accept rules indexes are created and their tokens are hashed")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	_, err := db.Client().Database("test").Collection("device_accept_rules").InsertMany(context.TODO(), []interface{}{
+		bson.M{"id": "token", "token": "b3f1c2d4e5"},
+		bson.M{"id": "empty", "token": ""},
+	})
+	assert.NoError(t, err)
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[:70]...)
+	err = migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(70), version)
+
+	cursor, err := db.Client().Database("test").Collection("device_accept_rules").Indexes().List(context.TODO())
+	assert.NoError(t, err)
+
+	names := make([]string, 0)
+	for cursor.Next(context.TODO()) {
+		var index bson.M
+		assert.NoError(t, cursor.Decode(&index))
+
+		names = append(names, index["name"].(string))
+	}
+
+	assert.Contains(t, names, "id")
+	assert.Contains(t, names, "tenant_id_created_at")
+
+	rule := make(bson.M)
+	err = db.Client().Database("test").Collection("device_accept_rules").FindOne(context.TODO(), bson.M{"id": "token"}).Decode(&rule)
+	assert.NoError(t, err)
+	assert.NotContains(t, rule, "token")
+	assert.Equal(t, models.HashEnrollmentToken("b3f1c2d4e5"), rule["token_hash"])
+
+	rule = make(bson.M)
+	err = db.Client().Database("test").Collection("device_accept_rules").FindOne(context.TODO(), bson.M{"id": "empty"}).Decode(&rule)
+	assert.NoError(t, err)
+	assert.NotContains(t, rule, "token")
+	assert.Equal(t, "", rule["token_hash"])
+
+	err = migrates.Down(migrate.AllAvailable)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration71 = migrate.Migration{
+	Version:     71,
+	Description: "create id, hash and tenant_id_created_at indexes in enrollment_tokens collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		indexes := []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "id", Value: 1}},
+				Options: options.Index().SetName("id").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "hash", Value: 1}},
+				Options: options.Index().SetName("hash").SetUnique(true),
+			},
+			{
+				Keys:    bson.D{{Key: "tenant_id", Value: 1}, {Key: "created_at", Value: 1}},
+				Options: options.Index().SetName("tenant_id_created_at").SetUnique(false),
+			},
+		}
+
+		_, err := db.Collection("enrollment_tokens").Indexes().CreateMany(context.TODO(), indexes)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   71,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		if _, err := db.Collection("enrollment_tokens").Indexes().DropOne(context.TODO(), "id"); err != nil {
+			return err
+		}
+
+		if _, err := db.Collection("enrollment_tokens").Indexes().DropOne(context.TODO(), "hash"); err != nil {
+			return err
+		}
+
+		_, err := db.Collection("enrollment_tokens").Indexes().DropOne(context.TODO(), "tenant_id_created_at")
+
+		return err
+	},
+}
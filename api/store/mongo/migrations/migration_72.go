@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	migrate "github.com/xakep666/mongo-migrate"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var migration72 = migrate.Migration{
+	Version:     72,
+	Description: "create listen_port index in tunnels collection",
+	Up: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Up",
+		}).Info("Applying migration")
+
+		index := mongo.IndexModel{
+			Keys:    bson.D{{Key: "listen_port", Value: 1}},
+			Options: options.Index().SetName("listen_port").SetUnique(true).SetSparse(true),
+		}
+
+		_, err := db.Collection("tunnels").Indexes().CreateOne(context.TODO(), index)
+
+		return err
+	},
+	Down: func(db *mongo.Database) error {
+		logrus.WithFields(logrus.Fields{
+			"component": "migration",
+			"version":   72,
+			"action":    "Down",
+		}).Info("Reverting migration")
+
+		_, err := db.Collection("tunnels").Indexes().DropOne(context.TODO(), "listen_port")
+
+		return err
+	},
+}
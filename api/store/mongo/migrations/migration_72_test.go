@@ -0,0 +1,44 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	migrate "github.com/xakep666/mongo-migrate"
+)
+
+func TestMigration72(t *testing.T) {
+	logrus.Info("Testing Migration 72 - Test whether the tunnel's listen port is unique")
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	migrates := migrate.NewMigrate(db.Client().Database("test"), GenerateMigrations()[:72]...)
+	err := migrates.Up(migrate.AllAvailable)
+	assert.NoError(t, err)
+
+	version, _, err := migrates.Version()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(72), version)
+
+	collection := db.Client().Database("test").Collection("tunnels")
+
+	_, err = collection.InsertOne(context.TODO(), models.Tunnel{Address: "a582b47a42d", Host: "localhost", Port: 5432})
+	assert.NoError(t, err)
+
+	_, err = collection.InsertOne(context.TODO(), models.Tunnel{Address: "a582b47a42e", Host: "localhost", Port: 5432})
+	assert.NoError(t, err)
+
+	_, err = collection.InsertOne(context.TODO(), models.Tunnel{Address: "a582b47a42f", Host: "localhost", Port: 502, ListenPort: 40000})
+	assert.NoError(t, err)
+
+	_, err = collection.InsertOne(context.TODO(), models.Tunnel{Address: "a582b47a430", Host: "localhost", Port: 502, ListenPort: 40000})
+	assert.Error(t, err)
+
+	err = migrates.Down(migrate.AllAvailable)
+	assert.NoError(t, err)
+}
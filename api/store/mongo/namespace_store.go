@@ -361,6 +361,23 @@ func (s *Store) NamespaceSetSessionRecord(ctx context.Context, sessionRecord boo
 	return nil
 }
 
+func (s *Store) NamespaceSetRequireEnrollmentToken(ctx context.Context, tenantID string, required bool) error {
+	ns, err := s.db.Collection("namespaces").UpdateOne(ctx, bson.M{"tenant_id": tenantID}, bson.M{"$set": bson.M{"settings.require_enrollment_token": required}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if ns.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	if err := s.cache.Delete(ctx, strings.Join([]string{"namespace", tenantID}, "/")); err != nil {
+		logrus.Error(err)
+	}
+
+	return nil
+}
+
 func (s *Store) NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error) {
 	var settings struct {
 		Settings *models.NamespaceSettings `json:"settings" bson:"settings"`
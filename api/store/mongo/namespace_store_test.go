@@ -806,6 +806,53 @@ func TestNamespaceSetSessionRecord(t *testing.T) {
 	}
 }
 
+func TestNamespaceSetRequireEnrollmentToken(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		required    bool
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when tenant is not found",
+			tenant:      "nonexistent",
+			required:    true,
+			fixtures:    []string{fixtures.FixtureNamespaces},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when tenant is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			required:    true,
+			fixtures:    []string{fixtures.FixtureNamespaces},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.NamespaceSetRequireEnrollmentToken(context.TODO(), tc.tenant, tc.required)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				ns, err := mongostore.NamespaceGet(context.TODO(), tc.tenant)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.required, ns.Settings.RequireEnrollmentToken)
+			}
+		})
+	}
+}
+
 func TestNamespaceGetSessionRecord(t *testing.T) {
 	type Expected struct {
 		set bool
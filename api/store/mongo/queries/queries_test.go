@@ -211,6 +211,40 @@ func TestBuildQuery(t *testing.T) {
 				err:  nil,
 			},
 		},
+		{
+			description: "Success when filtering by the device's inventory",
+			filters: []models.Filter{
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "info.memory",
+						Operator: "gt",
+						Value:    "1073741824",
+					},
+				},
+				{
+					Type: "property",
+					Params: &models.PropertyParams{
+						Name:     "info.interfaces.ips",
+						Operator: "contains",
+						Value:    "192.168.0.",
+					},
+				},
+				{
+					Type: "operator",
+					Params: &models.OperatorParams{
+						Name: "and",
+					},
+				},
+			},
+			expected: Expected{
+				data: []bson.M{{"$match": bson.M{"$and": []bson.M{
+					{"info.memory": bson.M{"$gt": 1073741824}},
+					{"info.interfaces.ips": bson.M{"$regex": "192.168.0.", "$options": "i"}},
+				}}}},
+				err: nil,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,110 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) TunnelList(ctx context.Context, uid models.UID, pagination paginator.Query) ([]models.Tunnel, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"device": uid,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("tunnels"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	tunnels := make([]models.Tunnel, 0)
+	cursor, err := s.db.Collection("tunnels").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		tunnel := new(models.Tunnel)
+		if err := cursor.Decode(tunnel); err != nil {
+			return tunnels, count, FromMongoError(err)
+		}
+
+		tunnels = append(tunnels, *tunnel)
+	}
+
+	return tunnels, count, nil
+}
+
+func (s *Store) TunnelGet(ctx context.Context, address string) (*models.Tunnel, error) {
+	tunnel := new(models.Tunnel)
+	if err := s.db.Collection("tunnels").FindOne(ctx, bson.M{"address": address}).Decode(tunnel); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return tunnel, nil
+}
+
+func (s *Store) TunnelGetByPort(ctx context.Context, port int) (*models.Tunnel, error) {
+	tunnel := new(models.Tunnel)
+	if err := s.db.Collection("tunnels").FindOne(ctx, bson.M{"listen_port": port}).Decode(tunnel); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return tunnel, nil
+}
+
+func (s *Store) TunnelCreate(ctx context.Context, tunnel *models.Tunnel) error {
+	_, err := s.db.Collection("tunnels").InsertOne(ctx, tunnel)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) TunnelDelete(ctx context.Context, uid models.UID, address string) error {
+	res, err := s.db.Collection("tunnels").DeleteOne(ctx, bson.M{"device": uid, "address": address})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) TunnelGrant(ctx context.Context, uid models.UID, address string, grant models.TunnelGrant, now time.Time) error {
+	filter := bson.M{"device": uid, "address": address}
+
+	if _, err := s.db.Collection("tunnels").UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"grants": bson.M{"expires_at": bson.M{"$lte": now}}}}); err != nil {
+		return FromMongoError(err)
+	}
+
+	res, err := s.db.Collection("tunnels").UpdateOne(ctx, filter, bson.M{"$push": bson.M{"grants": grant}})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,297 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunnelList(t *testing.T) {
+	type Expected struct {
+		tunnels []models.Tunnel
+		count   int
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		uid         models.UID
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when device has no tunnels",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected: Expected{
+				tunnels: []models.Tunnel{},
+				count:   0,
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds when device has tunnels",
+			uid:         models.UID("5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f"),
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected: Expected{
+				tunnels: []models.Tunnel{
+					{
+						Address:   "a582b47a42d",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Device:    "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+						Host:      "localhost",
+						Port:      5432,
+						CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						Address:    "a582b47a42e",
+						TenantID:   "00000000-0000-4000-0000-000000000000",
+						Device:     "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+						Host:       "127.0.0.1",
+						Port:       502,
+						ListenPort: 40000,
+						CreatedAt:  time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			tunnels, count, err := mongostore.TunnelList(context.TODO(), tc.uid, tc.page)
+			assert.Equal(t, tc.expected, Expected{tunnels: tunnels, count: count, err: err})
+		})
+	}
+}
+
+func TestTunnelGet(t *testing.T) {
+	type Expected struct {
+		tunnel *models.Tunnel
+		err    error
+	}
+
+	cases := []struct {
+		description string
+		address     string
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "fails when tunnel is not found",
+			address:     "nonexistent",
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected: Expected{
+				tunnel: nil,
+				err:    store.ErrNoDocuments,
+			},
+		},
+		{
+			description: "succeeds when tunnel is found",
+			address:     "a582b47a42d",
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected: Expected{
+				tunnel: &models.Tunnel{
+					Address:   "a582b47a42d",
+					TenantID:  "00000000-0000-4000-0000-000000000000",
+					Device:    "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+					Host:      "localhost",
+					Port:      5432,
+					CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+				},
+				err: nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			tunnel, err := mongostore.TunnelGet(context.TODO(), tc.address)
+			assert.Equal(t, tc.expected, Expected{tunnel: tunnel, err: err})
+		})
+	}
+}
+
+func TestTunnelGetByPort(t *testing.T) {
+	cases := []struct {
+		description string
+		port        int
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when tunnel is not found",
+			port:        40001,
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when tunnel is found",
+			port:        40000,
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			tunnel, err := mongostore.TunnelGetByPort(context.TODO(), tc.port)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, "a582b47a42e", tunnel.Address)
+			}
+		})
+	}
+}
+
+func TestTunnelCreate(t *testing.T) {
+	cases := []struct {
+		description string
+		tunnel      *models.Tunnel
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "succeeds when data is valid",
+			tunnel: &models.Tunnel{
+				Address:   "a582b47a42f",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Device:    "5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f",
+				Host:      "localhost",
+				Port:      3306,
+				CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.TunnelCreate(context.TODO(), tc.tunnel)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestTunnelDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		uid         models.UID
+		address     string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when tunnel is not found",
+			uid:         models.UID("5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f"),
+			address:     "nonexistent",
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "fails when tunnel belongs to another device",
+			uid:         models.UID("4300430e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809e"),
+			address:     "a582b47a42d",
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when tunnel is found",
+			uid:         models.UID("5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f"),
+			address:     "a582b47a42d",
+			fixtures:    []string{fixtures.FixtureTunnels},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.TunnelDelete(context.TODO(), tc.uid, tc.address)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
+
+func TestTunnelGrant(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	assert.NoError(t, fixtures.Apply(fixtures.FixtureTunnels))
+	defer fixtures.Teardown() // nolint: errcheck
+
+	uid := models.UID("5300530e3ca2f637636b4d025d2235269014865db5204b6d115386cbee89809f")
+	now := time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC)
+
+	expired := models.TunnelGrant{Source: "10.0.0.1", User: "507f1f77bcf86cd799439011", ExpiresAt: now.Add(-time.Minute)}
+	granted := models.TunnelGrant{Source: "10.0.0.2", User: "507f1f77bcf86cd799439011", ExpiresAt: now.Add(time.Hour)}
+
+	assert.Equal(t, store.ErrNoDocuments, mongostore.TunnelGrant(context.TODO(), uid, "nonexistent", granted, now))
+	assert.NoError(t, mongostore.TunnelGrant(context.TODO(), uid, "a582b47a42d", expired, now.Add(-time.Hour)))
+	assert.NoError(t, mongostore.TunnelGrant(context.TODO(), uid, "a582b47a42d", granted, now))
+
+	tunnel, err := mongostore.TunnelGet(context.TODO(), "a582b47a42d")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.TunnelGrant{granted}, tunnel.Grants)
+}
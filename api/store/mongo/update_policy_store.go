@@ -0,0 +1,94 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/api/store/mongo/queries"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func (s *Store) UpdatePolicyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error) {
+	query := []bson.M{
+		{
+			"$match": bson.M{
+				"tenant_id": tenant,
+			},
+		},
+		{
+			"$sort": bson.M{
+				"created_at": 1,
+			},
+		},
+	}
+
+	queryCount := query
+	queryCount = append(queryCount, bson.M{"$count": "count"})
+	count, err := AggregateCount(ctx, s.db.Collection("update_policies"), queryCount)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+
+	query = append(query, queries.BuildPaginationQuery(pagination)...)
+
+	policies := make([]models.UpdatePolicy, 0)
+	cursor, err := s.db.Collection("update_policies").Aggregate(ctx, query)
+	if err != nil {
+		return nil, 0, FromMongoError(err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		policy := new(models.UpdatePolicy)
+		if err := cursor.Decode(policy); err != nil {
+			return policies, count, FromMongoError(err)
+		}
+
+		policies = append(policies, *policy)
+	}
+
+	return policies, count, nil
+}
+
+func (s *Store) UpdatePolicyGet(ctx context.Context, id string) (*models.UpdatePolicy, error) {
+	policy := new(models.UpdatePolicy)
+	if err := s.db.Collection("update_policies").FindOne(ctx, bson.M{"id": id}).Decode(policy); err != nil {
+		return nil, FromMongoError(err)
+	}
+
+	return policy, nil
+}
+
+func (s *Store) UpdatePolicyCreate(ctx context.Context, policy *models.UpdatePolicy) error {
+	_, err := s.db.Collection("update_policies").InsertOne(ctx, policy)
+
+	return FromMongoError(err)
+}
+
+func (s *Store) UpdatePolicyUpdate(ctx context.Context, policy *models.UpdatePolicy) error {
+	res, err := s.db.Collection("update_policies").ReplaceOne(ctx, bson.M{"id": policy.ID}, policy)
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.MatchedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (s *Store) UpdatePolicyDelete(ctx context.Context, tenant, id string) error {
+	res, err := s.db.Collection("update_policies").DeleteOne(ctx, bson.M{"tenant_id": tenant, "id": id})
+	if err != nil {
+		return FromMongoError(err)
+	}
+
+	if res.DeletedCount < 1 {
+		return store.ErrNoDocuments
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/api/pkg/dbtest"
+	"github.com/shellhub-io/shellhub/api/pkg/fixtures"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/cache"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdatePolicyList(t *testing.T) {
+	type Expected struct {
+		policies []models.UpdatePolicy
+		count    int
+		err      error
+	}
+
+	cases := []struct {
+		description string
+		tenant      string
+		page        paginator.Query
+		fixtures    []string
+		expected    Expected
+	}{
+		{
+			description: "succeeds when namespace has no policies",
+			tenant:      "nonexistent",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected: Expected{
+				policies: []models.UpdatePolicy{},
+				count:    0,
+				err:      nil,
+			},
+		},
+		{
+			description: "succeeds when namespace has policies",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			page:        paginator.Query{Page: -1, PerPage: -1},
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected: Expected{
+				policies: []models.UpdatePolicy{
+					{
+						ID:        "c8d3e4f5-0000-4000-8000-000000000001",
+						TenantID:  "00000000-0000-4000-0000-000000000000",
+						Name:      "namespace",
+						Tags:      []string{},
+						Version:   "v0.14.0",
+						Canary:    100,
+						Windows:   []models.UpdateWindow{},
+						CreatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC),
+					},
+					{
+						ID:       "c8d3e4f5-0000-4000-8000-000000000002",
+						TenantID: "00000000-0000-4000-0000-000000000000",
+						Name:     "canary",
+						Tags:     []string{"tag-1"},
+						Version:  "v0.15.0",
+						Canary:   10,
+						Windows: []models.UpdateWindow{
+							{Days: []int{6, 0}, Start: "22:00", End: "04:00"},
+						},
+						CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+						UpdatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+					},
+				},
+				count: 2,
+				err:   nil,
+			},
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			policies, count, err := mongostore.UpdatePolicyList(context.TODO(), tc.tenant, tc.page)
+			assert.Equal(t, tc.expected, Expected{policies: policies, count: count, err: err})
+		})
+	}
+}
+
+func TestUpdatePolicyGet(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when policy is not found",
+			id:          "nonexistent",
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when policy is found",
+			id:          "c8d3e4f5-0000-4000-8000-000000000002",
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			policy, err := mongostore.UpdatePolicyGet(context.TODO(), tc.id)
+			assert.Equal(t, tc.expected, err)
+			if err == nil {
+				assert.Equal(t, tc.id, policy.ID)
+			}
+		})
+	}
+}
+
+func TestUpdatePolicyCreate(t *testing.T) {
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	defer fixtures.Teardown() // nolint: errcheck
+
+	err := mongostore.UpdatePolicyCreate(context.TODO(), &models.UpdatePolicy{
+		ID:        "c8d3e4f5-0000-4000-8000-000000000003",
+		TenantID:  "00000000-0000-4000-0000-000000000000",
+		Name:      "production",
+		Tags:      []string{"tag-2"},
+		Version:   "v0.15.0",
+		Canary:    100,
+		Windows:   []models.UpdateWindow{},
+		CreatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2023, 1, 3, 12, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdatePolicyUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		policy      *models.UpdatePolicy
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when policy is not found",
+			policy:      &models.UpdatePolicy{ID: "nonexistent"},
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when policy is found",
+			policy: &models.UpdatePolicy{
+				ID:        "c8d3e4f5-0000-4000-8000-000000000002",
+				TenantID:  "00000000-0000-4000-0000-000000000000",
+				Name:      "canary",
+				Tags:      []string{"tag-1"},
+				Version:   "v0.15.0",
+				Canary:    50,
+				Windows:   []models.UpdateWindow{},
+				CreatedAt: time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC),
+				UpdatedAt: time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC),
+			},
+			fixtures: []string{fixtures.FixtureUpdatePolicies},
+			expected: nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.UpdatePolicyUpdate(context.TODO(), tc.policy)
+			assert.Equal(t, tc.expected, err)
+
+			if err == nil {
+				policy, err := mongostore.UpdatePolicyGet(context.TODO(), tc.policy.ID)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.policy, policy)
+			}
+		})
+	}
+}
+
+func TestUpdatePolicyDelete(t *testing.T) {
+	cases := []struct {
+		description string
+		tenant      string
+		id          string
+		fixtures    []string
+		expected    error
+	}{
+		{
+			description: "fails when policy belongs to another namespace",
+			tenant:      "nonexistent",
+			id:          "c8d3e4f5-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected:    store.ErrNoDocuments,
+		},
+		{
+			description: "succeeds when policy is found",
+			tenant:      "00000000-0000-4000-0000-000000000000",
+			id:          "c8d3e4f5-0000-4000-8000-000000000001",
+			fixtures:    []string{fixtures.FixtureUpdatePolicies},
+			expected:    nil,
+		},
+	}
+
+	db := dbtest.DBServer{}
+	defer db.Stop()
+
+	mongostore := NewStore(db.Client().Database("test"), cache.NewNullCache())
+	fixtures.Init(db.Host, "test")
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.NoError(t, fixtures.Apply(tc.fixtures...))
+			defer fixtures.Teardown() // nolint: errcheck
+
+			err := mongostore.UpdatePolicyDelete(context.TODO(), tc.tenant, tc.id)
+			assert.Equal(t, tc.expected, err)
+		})
+	}
+}
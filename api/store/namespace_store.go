@@ -21,4 +21,7 @@ type NamespaceStore interface {
 	NamespaceGetFirst(ctx context.Context, id string) (*models.Namespace, error)
 	NamespaceSetSessionRecord(ctx context.Context, sessionRecord bool, tenantID string) error
 	NamespaceGetSessionRecord(ctx context.Context, tenantID string) (bool, error)
+	// NamespaceSetRequireEnrollmentToken sets if only the devices presenting an enrollment token register into the
+	// namespace.
+	NamespaceSetRequireEnrollmentToken(ctx context.Context, tenantID string, required bool) error
 }
@@ -17,4 +17,12 @@ type Store interface {
 	LicenseStore
 	StatsStore
 	MFAStore
+	TunnelStore
+	MetricsStore
+	JobStore
+	JobScheduleStore
+	FilePushStore
+	UpdatePolicyStore
+	DeviceAcceptRuleStore
+	EnrollmentTokenStore
 }
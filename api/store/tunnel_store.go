@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type TunnelStore interface {
+	TunnelList(ctx context.Context, uid models.UID, pagination paginator.Query) ([]models.Tunnel, int, error)
+	TunnelGet(ctx context.Context, address string) (*models.Tunnel, error)
+	// TunnelGetByPort gets the tunnel listening on port.
+	TunnelGetByPort(ctx context.Context, port int) (*models.Tunnel, error)
+	TunnelCreate(ctx context.Context, tunnel *models.Tunnel) error
+	TunnelDelete(ctx context.Context, uid models.UID, address string) error
+	// TunnelGrant adds a grant to the device's tunnel, removing the grants expired at now.
+	TunnelGrant(ctx context.Context, uid models.UID, address string, grant models.TunnelGrant, now time.Time) error
+}
@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+type UpdatePolicyStore interface {
+	// UpdatePolicyList lists the update policies from a namespace, oldest first.
+	UpdatePolicyList(ctx context.Context, tenant string, pagination paginator.Query) ([]models.UpdatePolicy, int, error)
+	// UpdatePolicyGet gets an update policy by its ID.
+	UpdatePolicyGet(ctx context.Context, id string) (*models.UpdatePolicy, error)
+	// UpdatePolicyCreate stores an update policy.
+	UpdatePolicyCreate(ctx context.Context, policy *models.UpdatePolicy) error
+	// UpdatePolicyUpdate replaces the update policy with the same ID.
+	UpdatePolicyUpdate(ctx context.Context, policy *models.UpdatePolicy) error
+	// UpdatePolicyDelete deletes an update policy from a namespace.
+	UpdatePolicyDelete(ctx context.Context, tenant, id string) error
+}
@@ -0,0 +1,246 @@
+package workers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// FilePushAddress is the address of the SSH server, what proxies the transfer of the pushed files to the devices
+// through their tunnels.
+var FilePushAddress = "http://ssh:8080"
+
+// filePushAttempts is the number of times the transfer to a device is resumed, from the bytes it has already received,
+// before giving up.
+const filePushAttempts = 3
+
+// filePushRefusedError is returned when the device refuses the pushed file, what is not fixed by retrying.
+type filePushRefusedError struct {
+	status  int
+	message string
+}
+
+func (e *filePushRefusedError) Error() string {
+	return fmt.Sprintf("device refused the file (%d): %s", e.status, e.message)
+}
+
+// registerFilePushRunner worker delivers the pushed files to their pending devices, with at most the push's
+// concurrency receiving the file at the same time. The result from each one of the devices is stored as soon as it is
+// known.
+func (w *Workers) registerFilePushRunner() {
+	w.mux.HandleFunc(TaskFilePushRun, func(ctx context.Context, task *asynq.Task) error {
+		id := string(task.Payload())
+
+		logger := log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskFilePushRun,
+				"push":      id,
+			})
+
+		logger.Trace("Executing file push runner worker.")
+
+		push, err := w.store.FilePushGet(ctx, id)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get the file push")
+
+			return err
+		}
+
+		if err := w.store.FilePushSetStatus(ctx, id, models.FilePushStatusRunning, nil); err != nil && err != store.ErrNoDocuments {
+			logger.WithError(err).Error("Failed to set the file push as running")
+
+			return err
+		}
+
+		concurrency := push.Concurrency
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		semaphore := make(chan struct{}, concurrency)
+		wg := new(sync.WaitGroup)
+
+		for _, result := range push.Results {
+			if result.Status != models.FilePushResultStatusPending {
+				continue
+			}
+
+			semaphore <- struct{}{}
+			wg.Add(1)
+
+			go func(result models.FilePushResult) {
+				defer func() {
+					<-semaphore
+					wg.Done()
+				}()
+
+				// NOTICE: The result is stored even when the task's deadline is exceeded.
+				if err := w.store.FilePushSetResult(context.Background(), id, w.deliverFile(ctx, push, result)); err != nil {
+					logger.WithError(err).WithField("device", result.Device).Error("Failed to store the file push's result")
+				}
+			}(result)
+		}
+
+		wg.Wait()
+
+		finishedAt := time.Now().UTC()
+		if err := w.store.FilePushSetStatus(context.Background(), id, models.FilePushStatusFinished, &finishedAt); err != nil && err != store.ErrNoDocuments {
+			logger.WithError(err).Error("Failed to set the file push as finished")
+
+			return err
+		}
+
+		logger.Trace("Finishing file push runner worker.")
+
+		return nil
+	})
+}
+
+// deliverFile transfers the pushed file to the device and commits it, returning the device's result.
+func (w *Workers) deliverFile(ctx context.Context, push *models.FilePush, result models.FilePushResult) *models.FilePushResult {
+	ctx, cancel := context.WithTimeout(ctx, models.FilePushGracePeriod)
+	defer cancel()
+
+	startedAt := time.Now().UTC()
+
+	result.Status = models.FilePushResultStatusRunning
+	result.Error = ""
+	result.StartedAt = &startedAt
+	result.FinishedAt = nil
+
+	w.store.FilePushSetResult(ctx, push.ID, &result) //nolint:errcheck
+
+	var err error
+	for attempt := 0; attempt < filePushAttempts; attempt++ {
+		if err = w.uploadFile(ctx, push, &result); err == nil {
+			break
+		}
+
+		var refused *filePushRefusedError
+		if errors.As(err, &refused) && refused.status != http.StatusConflict {
+			break
+		}
+	}
+
+	if err == nil {
+		err = commitFile(ctx, push, result.Device)
+	}
+
+	finishedAt := time.Now().UTC()
+	result.FinishedAt = &finishedAt
+
+	var refused *filePushRefusedError
+	switch {
+	case err == nil:
+		result.Status = models.FilePushResultStatusSucceeded
+	case errors.As(err, &refused):
+		result.Status = models.FilePushResultStatusFailed
+		result.Error = err.Error()
+	default:
+		result.Status = models.FilePushResultStatusError
+		result.Error = err.Error()
+	}
+
+	return &result
+}
+
+// uploadFile sends to the device the part of the pushed file it has not received yet.
+func (w *Workers) uploadFile(ctx context.Context, push *models.FilePush, result *models.FilePushResult) error {
+	offset := new(models.FilePushOffset)
+	if err := filePushRequest(ctx, http.MethodGet, push, result.Device, "status", nil, nil, offset); err != nil {
+		return err
+	}
+
+	result.Transferred = offset.Offset
+
+	// NOTICE: An empty file is still uploaded, so the device creates it.
+	if offset.Offset >= push.Size && push.Size > 0 {
+		return nil
+	}
+
+	content, err := w.store.FilePushContentOpen(ctx, push.ID, offset.Offset)
+	if err != nil {
+		return err
+	}
+
+	defer content.Close()
+
+	query := url.Values{"offset": []string{strconv.FormatInt(offset.Offset, 10)}}
+	if err := filePushRequest(ctx, http.MethodPut, push, result.Device, "upload", query, content, offset); err != nil {
+		return err
+	}
+
+	result.Transferred = offset.Offset
+
+	return nil
+}
+
+// commitFile requests the device to verify the received file and write it to the push's path.
+func commitFile(ctx context.Context, push *models.FilePush, device models.UID) error {
+	return filePushRequest(ctx, http.MethodPost, push, device, "commit", nil, nil, nil)
+}
+
+// filePushRequest requests the file push's action to the device's agent, through the SSH server, decoding the
+// response's body into response, when defined.
+func filePushRequest(ctx context.Context, method string, push *models.FilePush, device models.UID, action string, query url.Values, body io.Reader, response interface{}) error {
+	values := url.Values{
+		"id":     []string{push.ID},
+		"path":   []string{push.Path},
+		"size":   []string{strconv.FormatInt(push.Size, 10)},
+		"sha256": []string{push.SHA256},
+		"owner":  []string{push.Owner},
+		"group":  []string{push.Group},
+		"mode":   []string{push.Mode},
+	}
+
+	for key, value := range query {
+		values[key] = value
+	}
+
+	address := fmt.Sprintf("%s/push/%s/%s?%s", FilePushAddress, device, action, values.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, method, address, body)
+	if err != nil {
+		return err
+	}
+
+	res, err := sshClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusBadGateway:
+		return errors.New("device is not reachable")
+	case res.StatusCode == http.StatusConflict && response != nil:
+		// NOTICE: The device replies with the bytes it has received when the upload does not continue from them.
+		json.NewDecoder(res.Body).Decode(response) //nolint:errcheck
+
+		return &filePushRefusedError{status: res.StatusCode, message: "offset does not match the received bytes"}
+	case res.StatusCode != http.StatusOK:
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+		return &filePushRefusedError{status: res.StatusCode, message: string(message)}
+	}
+
+	if response == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(response)
+}
@@ -0,0 +1,235 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// JobExecAddress is the address of the SSH server, what executes the jobs' commands on the devices as exec sessions.
+var JobExecAddress = "http://ssh:8080"
+
+// sshClient is the HTTP client used to reach the SSH server, what never follows redirects.
+var sshClient = &http.Client{
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// registerJobRunner worker executes the jobs' commands on their pending devices. The devices are reached through the
+// SSH server, with at most the job's concurrency executing the command at the same time, and the result from each one
+// of them is stored as soon as it is received. Jobs are never retried, as their commands could have been executed on
+// some of the devices.
+func (w *Workers) registerJobRunner() {
+	w.mux.HandleFunc(TaskJobRun, func(ctx context.Context, task *asynq.Task) error {
+		id := string(task.Payload())
+
+		logger := log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskJobRun,
+				"job":       id,
+			})
+
+		logger.Trace("Executing job runner worker.")
+
+		job, err := w.store.JobGet(ctx, id)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get the job")
+
+			return err
+		}
+
+		if err := w.store.JobSetStatus(ctx, id, models.JobStatusRunning, nil); err != nil && err != store.ErrNoDocuments {
+			logger.WithError(err).Error("Failed to set the job as running")
+
+			return err
+		}
+
+		concurrency := job.Concurrency
+		if concurrency < 1 {
+			concurrency = 1
+		}
+
+		semaphore := make(chan struct{}, concurrency)
+		wg := new(sync.WaitGroup)
+
+		for _, result := range job.Results {
+			if result.Status != models.JobResultStatusPending {
+				continue
+			}
+
+			semaphore <- struct{}{}
+			wg.Add(1)
+
+			go func(device models.UID) {
+				defer func() {
+					<-semaphore
+					wg.Done()
+				}()
+
+				result, err := w.runJob(ctx, job, device)
+				if err != nil {
+					logger.WithError(err).WithField("device", device).Error("Failed to start the job on the device")
+
+					return
+				}
+
+				// NOTICE: The result is stored even when the task's deadline is exceeded.
+				if err := w.store.JobSetResult(context.Background(), id, result); err != nil {
+					logger.WithError(err).WithField("device", device).Error("Failed to store the job's result")
+				}
+			}(result.Device)
+		}
+
+		wg.Wait()
+
+		if err := w.finishJob(context.Background(), id); err != nil {
+			logger.WithError(err).Error("Failed to finish the job")
+
+			return err
+		}
+
+		logger.Trace("Finishing job runner worker.")
+
+		return nil
+	})
+}
+
+// finishJob sets the job as finished when all its results are final. When the job was created by a schedule and any of
+// its devices failed, the schedule's notification URL, if any, receives the job.
+func (w *Workers) finishJob(ctx context.Context, id string) error {
+	job, err := w.store.JobGet(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	failed := false
+	for _, result := range job.Results {
+		if !result.IsTerminal() {
+			return nil
+		}
+
+		if result.IsFailure() {
+			failed = true
+		}
+	}
+
+	finishedAt := time.Now().UTC()
+	if err := w.store.JobSetStatus(ctx, id, models.JobStatusFinished, &finishedAt); err != nil {
+		if err == store.ErrNoDocuments {
+			// NOTICE: The job was already finished by another worker.
+			return nil
+		}
+
+		return err
+	}
+
+	if failed && job.Schedule != "" {
+		job.Status = models.JobStatusFinished
+		job.FinishedAt = &finishedAt
+
+		w.notifyJobFailure(ctx, job)
+	}
+
+	return nil
+}
+
+// runJob executes the job's command on the device, returning its result. The command is not executed when the result
+// can't be set as running, what keeps a job whose results can't be stored from running on the devices.
+func (w *Workers) runJob(ctx context.Context, job *models.Job, device models.UID) (*models.JobResult, error) {
+	startedAt := time.Now().UTC()
+
+	result := &models.JobResult{
+		Device:    device,
+		Status:    models.JobResultStatusRunning,
+		StartedAt: &startedAt,
+	}
+
+	if err := w.store.JobSetResult(ctx, job.ID, result); err != nil {
+		return nil, err
+	}
+
+	response, err := execJob(ctx, job, device)
+
+	finishedAt := time.Now().UTC()
+	result.FinishedAt = &finishedAt
+
+	switch {
+	case err != nil:
+		result.Status = models.JobResultStatusError
+		result.Error = err.Error()
+	case response.TimedOut:
+		result.Status = models.JobResultStatusTimeout
+	case response.ExitCode == 0:
+		result.Status = models.JobResultStatusSucceeded
+	default:
+		result.Status = models.JobResultStatusFailed
+	}
+
+	if response != nil {
+		result.ExitCode = response.ExitCode
+		result.Stdout = response.Stdout
+		result.Stderr = response.Stderr
+	}
+
+	return result, nil
+}
+
+// execJob requests the SSH server to execute the job's command on the device, through an exec session.
+func execJob(ctx context.Context, job *models.Job, device models.UID) (*models.JobExecResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(job.Timeout)*time.Second+models.JobGracePeriod)
+	defer cancel()
+
+	body, err := json.Marshal(&models.JobExecRequest{
+		Job:     job.ID,
+		User:    job.User,
+		Command: job.Command,
+		Timeout: job.Timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/exec/%s", JobExecAddress, device), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := sshClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusBadGateway {
+		return nil, errors.New("device is not reachable")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+
+		return nil, fmt.Errorf("device refused to execute the command: %s", bytes.TrimSpace(message))
+	}
+
+	response := new(models.JobExecResponse)
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,475 @@
+package workers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/robfig/cron/v3"
+	"github.com/shellhub-io/shellhub/api/store"
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// jobQueuedRetryInterval is the interval a queued job waits before checking again if its device is online.
+const jobQueuedRetryInterval = time.Minute
+
+// jobNotifyTimeout is the maximum time to deliver a failed scheduled job to the schedule's notification URL.
+const jobNotifyTimeout = 10 * time.Second
+
+// ErrJobNotifyAddress is returned when the schedule's notification URL resolves to an address that isn't public.
+var ErrJobNotifyAddress = errors.New("notification URL must resolve to a public address")
+
+// notifyClient delivers the failed scheduled jobs to the notification URLs set by the users. As these URLs aren't
+// trusted, it only connects to public addresses, checked after the name resolution, and doesn't follow redirects,
+// keeping the notifications from reaching the internal network.
+var notifyClient = &http.Client{
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: jobNotifyTimeout,
+			Control: func(_, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+
+				if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+					return ErrJobNotifyAddress
+				}
+
+				return nil
+			},
+		}).DialContext,
+	},
+	CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	},
+}
+
+// sharedAddressSpace is the IPv4 range used by the carrier-grade NAT, what isn't reachable from the internet.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP checks if the IP is reachable from the internet.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsPrivate() &&
+		!ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// jobQueuedPayload is the payload of a [TaskJobQueuedRun] task.
+type jobQueuedPayload struct {
+	Job    string     `json:"job"`
+	Device models.UID `json:"device"`
+	// Deadline is when the job stops waiting for the device to be online.
+	Deadline time.Time `json:"deadline"`
+}
+
+// registerJobScheduler worker creates the jobs from the schedules, when they are due, and executes the commands of the
+// queued devices when they are online again.
+func (w *Workers) registerJobScheduler() {
+	w.mux.HandleFunc(TaskJobScheduleRun, func(ctx context.Context, task *asynq.Task) error {
+		id := string(task.Payload())
+
+		logger := log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskJobScheduleRun,
+				"schedule":  id,
+			})
+
+		logger.Trace("Executing job schedule worker.")
+
+		schedule, err := w.store.JobScheduleGet(ctx, id)
+		if err != nil {
+			if err == store.ErrNoDocuments {
+				logger.Info("Schedule was removed")
+
+				return nil
+			}
+
+			logger.WithError(err).Error("Failed to get the schedule")
+
+			return err
+		}
+
+		if !schedule.Enabled {
+			logger.Info("Schedule is disabled")
+
+			return nil
+		}
+
+		// NOTICE: Every API instance enqueues the schedule's runs, so the run is claimed on the store, by the first
+		// instance to execute it, before creating the job. A run is only claimed when the schedule hasn't run in the last
+		// half of its interval, what tolerates the delay between the instances without skipping the next run.
+		sched, err := cron.ParseStandard(schedule.Cron)
+		if err != nil {
+			logger.WithError(err).Error("Failed to parse the schedule's cron")
+
+			return nil
+		}
+
+		now := time.Now().UTC()
+		next := sched.Next(now)
+
+		if err := w.store.JobScheduleClaimRun(ctx, schedule.ID, now, now.Add(-sched.Next(next).Sub(next)/2)); err != nil {
+			if err == store.ErrNoDocuments {
+				logger.Info("Schedule's run was already claimed")
+
+				return nil
+			}
+
+			logger.WithError(err).Error("Failed to claim the schedule's run")
+
+			return err
+		}
+
+		job, err := w.createScheduledJob(ctx, schedule)
+		if err != nil {
+			logger.WithError(err).Error("Failed to create the scheduled job")
+
+			return err
+		}
+
+		if err := w.enqueueScheduledJob(ctx, job); err != nil {
+			logger.WithError(err).WithField("job", job.ID).Error("Failed to enqueue the scheduled job")
+
+			return err
+		}
+
+		logger.WithField("job", job.ID).Info("Scheduled job created")
+
+		return nil
+	})
+
+	w.mux.HandleFunc(TaskJobQueuedRun, func(ctx context.Context, task *asynq.Task) error {
+		payload := new(jobQueuedPayload)
+		if err := json.Unmarshal(task.Payload(), payload); err != nil {
+			return err
+		}
+
+		logger := log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskJobQueuedRun,
+				"job":       payload.Job,
+				"device":    payload.Device,
+			})
+
+		logger.Trace("Executing queued job worker.")
+
+		job, err := w.store.JobGet(ctx, payload.Job)
+		if err != nil {
+			logger.WithError(err).Error("Failed to get the job")
+
+			return err
+		}
+
+		device, err := w.store.DeviceGet(ctx, payload.Device)
+		if err != nil && err != store.ErrNoDocuments {
+			logger.WithError(err).Error("Failed to get the device")
+
+			return err
+		}
+
+		var result *models.JobResult
+		switch {
+		case device != nil && device.Online && job.Full():
+			logger.Debug("Job is running on as many devices as its concurrency")
+
+			return w.enqueueQueuedJob(ctx, payload)
+		case device != nil && device.Online:
+			if result, err = w.runJob(ctx, job, payload.Device); err != nil {
+				logger.WithError(err).Error("Failed to start the job on the device")
+
+				return err
+			}
+		case device == nil || time.Now().After(payload.Deadline):
+			finishedAt := time.Now().UTC()
+
+			result = &models.JobResult{
+				Device:     payload.Device,
+				Status:     models.JobResultStatusError,
+				Error:      "device remained offline",
+				FinishedAt: &finishedAt,
+			}
+		default:
+			return w.enqueueQueuedJob(ctx, payload)
+		}
+
+		if err := w.store.JobSetResult(context.Background(), job.ID, result); err != nil {
+			logger.WithError(err).Error("Failed to store the job's result")
+
+			return err
+		}
+
+		if err := w.finishJob(context.Background(), job.ID); err != nil {
+			logger.WithError(err).Error("Failed to finish the job")
+
+			return err
+		}
+
+		logger.Trace("Finishing queued job worker.")
+
+		return nil
+	})
+}
+
+// createScheduledJob creates a job from the schedule to its namespace's accepted devices having all the schedule's
+// tags. The devices offline at this moment are skipped or queued, according to the schedule.
+func (w *Workers) createScheduledJob(ctx context.Context, schedule *models.JobSchedule) (*models.Job, error) {
+	values := make([]interface{}, 0, len(schedule.Tags))
+	for _, tag := range schedule.Tags {
+		values = append(values, tag)
+	}
+
+	filters := []models.Filter{
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tenant_id", Operator: "eq", Value: schedule.TenantID},
+		},
+		{
+			Type:   "property",
+			Params: &models.PropertyParams{Name: "tags", Operator: "contains", Value: values},
+		},
+		{
+			Type:   "operator",
+			Params: &models.OperatorParams{Name: "and"},
+		},
+	}
+
+	devices, _, err := w.store.DeviceList(ctx, paginator.Query{Page: -1, PerPage: -1}, filters, models.DeviceStatusAccepted, "", "", store.DeviceListModeDefault)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	job := &models.Job{
+		ID:          uuid.Generate(),
+		TenantID:    schedule.TenantID,
+		Schedule:    schedule.ID,
+		Command:     schedule.Command,
+		User:        schedule.User,
+		Tags:        schedule.Tags,
+		Concurrency: schedule.Concurrency,
+		Timeout:     schedule.Timeout,
+		Status:      models.JobStatusPending,
+		CreatedAt:   now,
+		Results:     make([]models.JobResult, 0, len(devices)),
+	}
+
+	for _, device := range devices {
+		result := models.JobResult{Device: models.UID(device.UID), Status: models.JobResultStatusPending}
+
+		if !device.Online {
+			switch schedule.Offline {
+			case models.JobScheduleOfflineQueue:
+				result.Status = models.JobResultStatusQueued
+			default:
+				result.Status = models.JobResultStatusSkipped
+				result.FinishedAt = &now
+			}
+		}
+
+		job.Results = append(job.Results, result)
+	}
+
+	if err := w.store.JobCreate(ctx, job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// enqueueScheduledJob enqueues the execution of the scheduled job on its online devices and the waiting for its queued
+// ones. A job without devices to wait for is finished right away.
+func (w *Workers) enqueueScheduledJob(ctx context.Context, job *models.Job) error {
+	pending := 0
+	for _, result := range job.Results {
+		switch result.Status { //nolint:exhaustive
+		case models.JobResultStatusPending:
+			pending++
+		case models.JobResultStatusQueued:
+			payload := &jobQueuedPayload{
+				Job:      job.ID,
+				Device:   result.Device,
+				Deadline: job.CreatedAt.Add(time.Duration(w.env.JobQueueTTL) * time.Hour),
+			}
+
+			if err := w.enqueueQueuedJob(ctx, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	if pending == 0 {
+		return w.finishJob(ctx, job.ID)
+	}
+
+	_, err := w.client.EnqueueContext(
+		ctx,
+		asynq.NewTask(TaskJobRun, []byte(job.ID)),
+		asynq.Queue("jobs"),
+		asynq.TaskID(job.ID),
+		asynq.MaxRetry(0),
+		asynq.Timeout(job.Duration(pending)),
+	)
+
+	return err
+}
+
+// enqueueQueuedJob enqueues a new check, after [jobQueuedRetryInterval], of a queued device.
+func (w *Workers) enqueueQueuedJob(ctx context.Context, payload *jobQueuedPayload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.client.EnqueueContext(
+		ctx,
+		asynq.NewTask(TaskJobQueuedRun, data),
+		asynq.Queue("jobs"),
+		asynq.ProcessIn(jobQueuedRetryInterval),
+		asynq.MaxRetry(3),
+	)
+
+	return err
+}
+
+// notifyJobFailure sends the finished job to its schedule's notification URL, if any. Failures to notify are only
+// logged, as the job itself has already finished.
+func (w *Workers) notifyJobFailure(ctx context.Context, job *models.Job) {
+	logger := log.WithFields(log.Fields{"component": "worker", "job": job.ID, "schedule": job.Schedule})
+
+	schedule, err := w.store.JobScheduleGet(ctx, job.Schedule)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to get the job's schedule to notify its failure")
+
+		return
+	}
+
+	if schedule.NotifyURL == "" {
+		return
+	}
+
+	body, err := json.Marshal(job)
+	if err != nil {
+		logger.WithError(err).Error("Failed to encode the job to notify its failure")
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, jobNotifyTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, schedule.NotifyURL, bytes.NewReader(body))
+	if err != nil {
+		logger.WithError(err).Error("Failed to create the job's failure notification")
+
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := notifyClient.Do(req)
+	if err != nil {
+		logger.WithError(err).Warn("Failed to notify the job's failure")
+
+		return
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		logger.WithField("status", res.StatusCode).Warn("Job's failure notification was refused")
+	}
+}
+
+// jobScheduleEntry is a schedule registered on the worker's scheduler.
+type jobScheduleEntry struct {
+	entry string
+	cron  string
+}
+
+// syncJobSchedules keeps the worker's scheduler in sync with the enabled schedules stored in the database, reloading
+// them each [Envs.JobSchedulesSyncInterval] seconds. Every API instance registers the schedules on its own scheduler;
+// the run claimed on the store keeps a schedule from creating more than one job for the same run.
+func (w *Workers) syncJobSchedules() {
+	logger := log.WithFields(log.Fields{"component": "worker", "task": TaskJobScheduleRun})
+
+	entries := make(map[string]jobScheduleEntry)
+
+	sync := func() {
+		schedules, err := w.store.JobScheduleListEnabled(context.Background())
+		if err != nil {
+			logger.WithError(err).Error("Failed to list the enabled schedules")
+
+			return
+		}
+
+		seen := make(map[string]bool, len(schedules))
+		for _, schedule := range schedules {
+			seen[schedule.ID] = true
+
+			if current, ok := entries[schedule.ID]; ok {
+				if current.cron == schedule.Cron {
+					continue
+				}
+
+				if err := w.scheduler.Unregister(current.entry); err != nil {
+					logger.WithError(err).WithField("schedule", schedule.ID).Error("Failed to unregister the schedule")
+				}
+
+				delete(entries, schedule.ID)
+			}
+
+			entry, err := w.scheduler.Register(
+				schedule.Cron,
+				asynq.NewTask(TaskJobScheduleRun, []byte(schedule.ID)),
+				asynq.Queue("jobs"),
+				asynq.MaxRetry(0),
+			)
+			if err != nil {
+				logger.WithError(err).WithField("schedule", schedule.ID).Error("Failed to register the schedule")
+
+				continue
+			}
+
+			entries[schedule.ID] = jobScheduleEntry{entry: entry, cron: schedule.Cron}
+		}
+
+		for id, current := range entries {
+			if seen[id] {
+				continue
+			}
+
+			if err := w.scheduler.Unregister(current.entry); err != nil {
+				logger.WithError(err).WithField("schedule", id).Error("Failed to unregister the schedule")
+			}
+
+			delete(entries, id)
+		}
+	}
+
+	sync()
+
+	ticker := time.NewTicker(time.Duration(w.env.JobSchedulesSyncInterval) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		sync()
+	}
+}
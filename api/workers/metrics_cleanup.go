@@ -0,0 +1,73 @@
+package workers
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	log "github.com/sirupsen/logrus"
+)
+
+// registerMetricsCleanup worker is designed to delete devices' metrics older than a specified number of days. The
+// retention period is determined by the value of the `SHELLHUB_METRICS_RETENTION` environment variable, 30 days by
+// default. To disable this worker, keeping the metrics forever, set `SHELLHUB_METRICS_RETENTION` to 0. It uses a cron
+// expression from `SHELLHUB_METRICS_CLEANUP_SCHEDULE` to schedule its periodic execution.
+func (w *Workers) registerMetricsCleanup() {
+	if w.env.MetricsCleanupRetention < 1 {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskMetricsCleanup,
+			}).
+			Warnf("Aborting cleanup worker due to SHELLHUB_METRICS_RETENTION equal to %d.", w.env.MetricsCleanupRetention)
+
+		return
+	}
+
+	w.mux.HandleFunc(TaskMetricsCleanup, func(ctx context.Context, _ *asynq.Task) error {
+		log.WithFields(
+			log.Fields{
+				"component":       "worker",
+				"cron_expression": w.env.MetricsCleanupSchedule,
+				"task":            TaskMetricsCleanup,
+			}).
+			Trace("Executing cleanup worker.")
+
+		lte := time.Now().UTC().AddDate(0, 0, w.env.MetricsCleanupRetention*(-1))
+		deletedCount, err := w.store.MetricsDeleteByDate(ctx, lte)
+		if err != nil {
+			log.WithFields(
+				log.Fields{
+					"component": "worker",
+					"task":      TaskMetricsCleanup,
+				}).
+				WithError(err).
+				Error("Failed to delete devices' metrics")
+
+			return err
+		}
+
+		log.WithFields(
+			log.Fields{
+				"component":       "worker",
+				"cron_expression": w.env.MetricsCleanupSchedule,
+				"task":            TaskMetricsCleanup,
+				"lte":             lte.String(),
+				"deleted_count":   deletedCount,
+			}).
+			Trace("Finishing cleanup worker.")
+
+		return nil
+	})
+
+	task := asynq.NewTask(TaskMetricsCleanup, nil, asynq.TaskID(TaskMetricsCleanup), asynq.Queue("metrics"))
+	if _, err := w.scheduler.Register(w.env.MetricsCleanupSchedule, task); err != nil {
+		log.WithFields(
+			log.Fields{
+				"component": "worker",
+				"task":      TaskMetricsCleanup,
+			}).
+			WithError(err).
+			Error("Failed to register the scheduler.")
+	}
+}
@@ -3,4 +3,9 @@ package workers
 const (
 	TaskSessionCleanup = "session_record:cleanup"
 	TaskHeartbeat      = "api:heartbeat"
+	TaskMetricsCleanup = "metrics:cleanup"
+	TaskJobRun         = "job:run"
+	TaskJobQueuedRun   = "job:queued_run"
+	TaskJobScheduleRun = "job_schedule:run"
+	TaskFilePushRun    = "file_push:run"
 )
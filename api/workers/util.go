@@ -10,6 +10,13 @@ type Envs struct {
 	RedisURI                      string `env:"REDIS_URI,default=redis://redis:6379"`
 	SessionRecordCleanupSchedule  string `env:"SESSION_RECORD_CLEANUP_SCHEDULE,default=@daily"`
 	SessionRecordCleanupRetention int    `env:"RECORD_RETENTION,default=0"`
+	MetricsCleanupSchedule        string `env:"METRICS_CLEANUP_SCHEDULE,default=@daily"`
+	// MetricsCleanupRetention is the number of days the devices' metrics are kept. Set it to 0 to keep them forever.
+	MetricsCleanupRetention int `env:"METRICS_RETENTION,default=30"`
+	// JobSchedulesSyncInterval is the interval, in seconds, the jobs' schedules are reloaded from the database.
+	JobSchedulesSyncInterval int `env:"JOB_SCHEDULES_SYNC_INTERVAL,default=60"`
+	// JobQueueTTL is the number of hours a scheduled job waits for an offline device to be online.
+	JobQueueTTL int `env:"JOB_QUEUE_TTL,default=24"`
 	// AsynqGroupMaxDelay is the maximum duration to wait before processing a group of tasks.
 	//
 	// Its time unit is second.
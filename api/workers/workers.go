@@ -15,6 +15,7 @@ type Workers struct {
 	store store.Store
 
 	addr      asynq.RedisConnOpt
+	client    *asynq.Client
 	srv       *asynq.Server
 	mux       *asynq.ServeMux
 	env       *Envs
@@ -52,6 +53,8 @@ func New(store store.Store) (*Workers, error) {
 			Queues: map[string]int{
 				"api":            1,
 				"session_record": 1,
+				"metrics":        1,
+				"jobs":           1,
 			},
 			GroupAggregator: asynq.GroupAggregatorFunc(
 				func(group string, tasks []*asynq.Task) *asynq.Task {
@@ -74,6 +77,7 @@ func New(store store.Store) (*Workers, error) {
 
 	w := &Workers{
 		addr:      addr,
+		client:    asynq.NewClient(addr),
 		env:       env,
 		srv:       srv,
 		mux:       mux,
@@ -107,11 +111,17 @@ func (w *Workers) Start() {
 				Error("Unable to run the scheduler.")
 		}
 	}()
+
+	go w.syncJobSchedules()
 }
 
 // setupHandlers is responsible for registering all the handlers of the server. It needs
 // to be called before any initialization.
 func (w *Workers) setupHandlers() {
 	w.registerSessionCleanup()
+	w.registerMetricsCleanup()
 	w.registerHeartbeat()
+	w.registerJobRunner()
+	w.registerJobScheduler()
+	w.registerFilePushRunner()
 }
@@ -0,0 +1,355 @@
+// Package containerd implements the connector to containerd, apart from the other runtimes to keep the containerd's
+// client, and its dependencies, out of the agent.
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/containerd/containerd"
+	apievents "github.com/containerd/containerd/api/events"
+	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl/v2"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/shellhub-io/shellhub/pkg/agent/connector"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+)
+
+const (
+	// DefaultSocket is the default address of the containerd's API socket.
+	DefaultSocket = "/run/containerd/containerd.sock"
+	// DefaultNamespace is the containerd's namespace used when none is set. The containers created by the Kubernetes'
+	// CRI plugin are in the `k8s.io` namespace.
+	DefaultNamespace = "default"
+)
+
+// Labels set by the containerd's clients to the containers, used to name the devices.
+const (
+	// labelCRIKind is the kind of the container created by the CRI plugin, what is `sandbox` to the pods' pause
+	// containers.
+	labelCRIKind = "io.cri-containerd.kind"
+	// labelCRIPodName is the name of the pod of the container created by the CRI plugin.
+	labelCRIPodName = "io.kubernetes.pod.name"
+	// labelCRIContainerName is the name of the container created by the CRI plugin.
+	labelCRIContainerName = "io.kubernetes.container.name"
+	// labelNerdctlName is the name of the container created by nerdctl.
+	labelNerdctlName = "nerdctl/name"
+)
+
+var _ connector.Connector = new(Connector)
+
+// Connector is a struct that represents a connector that uses containerd as the container runtime, turning the
+// containers of one of its namespaces into devices.
+type Connector struct {
+	// cli is the containerd client, bound to the namespace.
+	cli *containerd.Client
+	// namespace is the containerd's namespace of the containers.
+	namespace string
+	// agents starts and stops the agents for the containers.
+	agents *connector.Agents
+}
+
+// NewConnector creates a new [connector.Connector] that uses containerd, through its API served at socket, as the
+// container runtime. Only the containers in the containerd's namespace, and selected by the selector, are turned into
+// devices; when the selector is nil, every container in the namespace is.
+//
+// NOTICE: The users are authenticated through the files read from the container's root filesystem at the process'
+// `/proc/<pid>/root`, so the connector must run in the host's PID namespace.
+func NewConnector(server string, tenant string, privateKey string, socket string, namespace string, selector *connector.Selector) (connector.Connector, error) {
+	if socket == "" {
+		socket = DefaultSocket
+	}
+
+	if namespace == "" {
+		namespace = DefaultNamespace
+	}
+
+	cli, err := containerd.New(socket, containerd.WithDefaultNamespace(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connector{
+		cli:       cli,
+		namespace: namespace,
+		agents:    connector.NewAgents(server, tenant, privateKey, &runtime{cli: cli}, selector),
+	}, nil
+}
+
+func (c *Connector) List(ctx context.Context) ([]connector.Container, error) {
+	containers, err := c.cli.Containers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]connector.Container, 0, len(containers))
+	for _, container := range containers {
+		selected, ok, err := c.getContainer(ctx, container)
+		if err != nil {
+			return nil, err
+		}
+
+		if !ok {
+			continue
+		}
+
+		task, err := container.Task(ctx, nil)
+		if errdefs.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := task.Status(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Status != containerd.Running {
+			continue
+		}
+
+		list = append(list, selected)
+	}
+
+	return list, nil
+}
+
+// Start starts the agent for the container.
+func (c *Connector) Start(ctx context.Context, container connector.Container) {
+	c.agents.Start(ctx, container)
+}
+
+// Stop stops the agent for the container with the given ID.
+func (c *Connector) Stop(_ context.Context, id string) {
+	c.agents.Stop(id)
+}
+
+// getContainer gets the container turned into a device. It also reports if the container should be turned into a
+// device, what isn't the case of the containers not selected nor of a pod's sandbox container.
+func (c *Connector) getContainer(ctx context.Context, container containerd.Container) (connector.Container, bool, error) {
+	info, err := container.Info(ctx)
+	if err != nil {
+		return connector.Container{}, false, err
+	}
+
+	name, ok := containerName(container.ID(), info.Labels)
+	if !ok {
+		return connector.Container{}, false, nil
+	}
+
+	selected, ok := c.agents.Container(container.ID(), name, info.Image, info.Labels)
+
+	return selected, ok, nil
+}
+
+// containerName names the device of the container from the labels set by the containerd's clients, falling
+// back to the container's ID.
+func containerName(id string, labels map[string]string) (string, bool) {
+	if labels[labelCRIKind] == "sandbox" {
+		return "", false
+	}
+
+	if name, ok := labels[labelCRIContainerName]; ok {
+		return connector.PodDeviceName(labels[labelCRIPodName], name), true
+	}
+
+	if name, ok := labels[labelNerdctlName]; ok {
+		return name, true
+	}
+
+	if len(id) > 12 {
+		id = id[:12]
+	}
+
+	return id, true
+}
+
+// Listen listens for events and starts or stops the agent for the containers.
+func (c *Connector) Listen(ctx context.Context) error {
+	containers, err := c.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, container := range containers {
+		c.Start(ctx, container)
+	}
+
+	events, errs := c.cli.Subscribe(ctx,
+		fmt.Sprintf(`namespace==%q,topic=="/tasks/start"`, c.namespace),
+		fmt.Sprintf(`namespace==%q,topic=="/tasks/exit"`, c.namespace),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			return err
+		case envelope := <-events:
+			event, err := typeurl.UnmarshalAny(envelope.Event)
+			if err != nil {
+				return err
+			}
+
+			switch event := event.(type) {
+			case *apievents.TaskStart:
+				container, err := c.cli.LoadContainer(ctx, event.ContainerID)
+				if err != nil {
+					return err
+				}
+
+				selected, ok, err := c.getContainer(ctx, container)
+				if err != nil {
+					return err
+				}
+
+				if ok {
+					c.Start(ctx, selected)
+				}
+			case *apievents.TaskExit:
+				// NOTICE: The exit of the processes executed inside the container, like the agent's sessions, are
+				// also published, but only the exit of the container's init process has the container's ID.
+				if event.ID == event.ContainerID {
+					c.Stop(ctx, event.ContainerID)
+				}
+			}
+		}
+	}
+}
+
+// NOTICE: Ensures the Runtime interface is implemented.
+var _ connectormode.Runtime = (*runtime)(nil)
+
+// runtime implements the connector mode's Runtime interface through the containerd's API.
+type runtime struct {
+	cli *containerd.Client
+}
+
+func (r *runtime) Name() string {
+	return "containerd"
+}
+
+func (r *runtime) Image(ctx context.Context, id string) (string, error) {
+	container, err := r.cli.LoadContainer(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := container.Info(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return info.Image, nil
+}
+
+// ReadFile reads the file at path from the container's root filesystem, through its init process.
+func (r *runtime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	container, err := r.cli.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return os.Open(filepath.Join("/proc", strconv.FormatUint(uint64(task.Pid()), 10), "root", path))
+}
+
+func (r *runtime) Exec(ctx context.Context, id string, user *osauth.User, command []string, tty bool, size [2]uint) (connectormode.Process, error) {
+	container, err := r.cli.LoadContainer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := container.Task(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := container.Spec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTICE: The process inherits the environment and the capabilities of the container's init process.
+	process := *spec.Process
+	process.Args = command
+	process.Terminal = tty
+	process.User = specs.User{UID: user.UID, GID: user.GID}
+
+	// NOTICE: The runtime keeps the capabilities across the switch to the user, so, like an unprivileged login, the
+	// processes of users other than root have none, but the container's bounding set, what limits the set-user-ID
+	// programs, like `sudo`.
+	if user.UID != 0 && process.Capabilities != nil {
+		process.Capabilities = &specs.LinuxCapabilities{Bounding: process.Capabilities.Bounding}
+	}
+	process.Cwd = user.HomeDir
+	if process.Cwd == "" {
+		process.Cwd = "/"
+	}
+
+	if tty {
+		process.ConsoleSize = &specs.Box{Height: size[0], Width: size[1]}
+	}
+
+	stdin, stdinWriter := io.Pipe()
+	stdout, stdoutWriter := io.Pipe()
+	stderr, stderrWriter := io.Pipe()
+
+	opts := []cio.Opt{cio.WithStreams(stdin, stdoutWriter, stderrWriter)}
+	if tty {
+		opts = append(opts, cio.WithTerminal)
+	}
+
+	exec, err := task.Exec(ctx, uuid.Generate(), &process, cio.NewCreator(opts...))
+	if err != nil {
+		return nil, err
+	}
+
+	exited, err := exec.Wait(ctx)
+	if err != nil {
+		exec.Delete(context.Background()) //nolint:errcheck
+
+		return nil, err
+	}
+
+	if err := exec.Start(ctx); err != nil {
+		exec.Delete(context.Background()) //nolint:errcheck
+
+		return nil, err
+	}
+
+	p := connector.NewPipedProcess(stdinWriter, stdout, stderr, func() error {
+		_, err := exec.Delete(context.Background(), containerd.WithProcessKill)
+
+		return err
+	})
+
+	go func() {
+		status := <-exited
+
+		// NOTICE: The output is only closed after it is fully copied, so it is completely read by the session.
+		exec.IO().Wait()
+		stdoutWriter.Close()
+		stderrWriter.Close()
+
+		code, _, err := status.Result()
+		p.Exit(int(code), err)
+	}()
+
+	return p, nil
+}
@@ -0,0 +1,61 @@
+package containerd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainerName(t *testing.T) {
+	cases := []struct {
+		description string
+		id          string
+		labels      map[string]string
+		name        string
+		ok          bool
+	}{
+		{
+			description: "skips the pod's sandbox container",
+			id:          "3f1e2d",
+			labels:      map[string]string{labelCRIKind: "sandbox", labelCRIPodName: "web"},
+			ok:          false,
+		},
+		{
+			description: "names the CRI container after its pod",
+			id:          "3f1e2d",
+			labels:      map[string]string{labelCRIKind: "container", labelCRIPodName: "web", labelCRIContainerName: "nginx"},
+			name:        "web-nginx",
+			ok:          true,
+		},
+		{
+			description: "keeps the CRI container's name when it starts with its pod's",
+			id:          "3f1e2d",
+			labels:      map[string]string{labelCRIPodName: "web", labelCRIContainerName: "web-nginx"},
+			name:        "web-nginx",
+			ok:          true,
+		},
+		{
+			description: "uses the nerdctl's container name",
+			id:          "3f1e2d",
+			labels:      map[string]string{labelNerdctlName: "nginx"},
+			name:        "nginx",
+			ok:          true,
+		},
+		{
+			description: "falls back to the container's short ID",
+			id:          "3f1e2d4c5b6a7980",
+			labels:      map[string]string{},
+			name:        "3f1e2d4c5b6a",
+			ok:          true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			name, ok := containerName(tc.id, tc.labels)
+
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.name, name)
+		})
+	}
+}
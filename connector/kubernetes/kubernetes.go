@@ -0,0 +1,504 @@
+// Package kubernetes implements the connector to Kubernetes, apart from the other runtimes to keep the Kubernetes'
+// client, and its dependencies, out of the agent.
+package kubernetes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/connector"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// annotationPrefix is the prefix of the pods' annotations read as the containers' labels, what, unlike the Kubernetes'
+// labels, can hold the comma-separated tags.
+const annotationPrefix = "io.shellhub."
+
+// ErrSwitchUser is returned when a session to a user other than root is opened on a container that doesn't run as root
+// or doesn't have `su`, through what the session's command is executed as the user.
+var ErrSwitchUser = errors.New("the container must run as root and have su to open sessions to other users")
+
+// Options are the options of the pods watched by the Kubernetes connector.
+type Options struct {
+	// Namespace is the Kubernetes' namespace of the pods. When empty, the pods of every namespace are watched.
+	Namespace string
+	// LabelSelector selects the pods by their labels, in the Kubernetes' label selector format, like `app=web`.
+	LabelSelector string
+	// TagLabels are the keys of the labels, of the pods or of their namespaces, whose values are applied as the tags
+	// of the containers' devices. The pod's label takes precedence over its namespace's one.
+	TagLabels []string
+}
+
+// Config gets the configuration to reach the Kubernetes' API from the kubeconfig file at path or, when it is empty,
+// from the pod's service account, when the connector runs inside the cluster.
+func Config(path string) (*rest.Config, error) {
+	if path == "" {
+		return rest.InClusterConfig()
+	}
+
+	return clientcmd.BuildConfigFromFlags("", path)
+}
+
+var _ connector.Connector = new(Connector)
+
+// Connector is a struct that represents a connector that turns the containers of the Kubernetes' pods into devices,
+// executing the sessions inside them through the Kubernetes' API.
+type Connector struct {
+	client  kubernetes.Interface
+	options Options
+	// agents starts and stops the agents for the containers.
+	agents agents
+}
+
+// agents starts and stops the agents for the containers, implemented by [connector.Agents].
+type agents interface {
+	Container(id string, name string, image string, labels map[string]string) (connector.Container, bool)
+	Start(ctx context.Context, container connector.Container)
+	Stop(id string)
+	Started() []string
+}
+
+// NewConnector creates a new [connector.Connector] that watches the pods through the Kubernetes' API reached with
+// config. Each running container of the pods, selected by the selector, is turned into a device named after its pod;
+// when the selector is nil, every container is.
+//
+// NOTICE: The Kubernetes' exec runs the commands as the container's user, so the sessions of the other users are
+// started through `su`, what requires the container to run as root.
+func NewConnector(server string, tenant string, privateKey string, config *rest.Config, options Options, selector *connector.Selector) (connector.Connector, error) {
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newConnector(client, &runtime{client: client, config: config}, server, tenant, privateKey, options, selector), nil
+}
+
+func newConnector(client kubernetes.Interface, runtime connectormode.Runtime, server string, tenant string, privateKey string, options Options, selector *connector.Selector) *Connector {
+	return &Connector{
+		client:  client,
+		options: options,
+		agents:  connector.NewAgents(server, tenant, privateKey, runtime, selector),
+	}
+}
+
+// containerID identifies the pod's container. The Kubernetes' names don't contain underscores, so they separate the
+// names unambiguously.
+func containerID(namespace string, pod string, container string) string {
+	return strings.Join([]string{namespace, pod, container}, "_")
+}
+
+// parseContainerID gets the namespace, the pod and the container's names from the container's ID.
+func parseContainerID(id string) (string, string, string, error) {
+	parts := strings.SplitN(id, "_", 3)
+	if len(parts) != 3 {
+		return "", "", "", fmt.Errorf("invalid kubernetes container %q", id)
+	}
+
+	return parts[0], parts[1], parts[2], nil
+}
+
+func (k *Connector) List(ctx context.Context) ([]connector.Container, error) {
+	pods, err := k.client.CoreV1().Pods(k.options.Namespace).List(ctx, metav1.ListOptions{LabelSelector: k.options.LabelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	list := []connector.Container{}
+	for i := range pods.Items {
+		containers, err := k.podContainers(ctx, &pods.Items[i])
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, containers...)
+	}
+
+	return list, nil
+}
+
+// Start starts the agent for the container.
+func (k *Connector) Start(ctx context.Context, container connector.Container) {
+	k.agents.Start(ctx, container)
+}
+
+// Stop stops the agent for the container with the given ID.
+func (k *Connector) Stop(_ context.Context, id string) {
+	k.agents.Stop(id)
+}
+
+// podContainers gets the pod's running containers selected to be turned into devices.
+func (k *Connector) podContainers(ctx context.Context, pod *corev1.Pod) ([]connector.Container, error) {
+	if pod.DeletionTimestamp != nil || pod.Status.Phase != corev1.PodRunning {
+		return nil, nil
+	}
+
+	labels := make(map[string]string, len(pod.Labels))
+	for key, value := range pod.Labels {
+		labels[key] = value
+	}
+
+	for key, value := range pod.Annotations {
+		if strings.HasPrefix(key, annotationPrefix) {
+			labels[key] = value
+		}
+	}
+
+	// NOTICE: The name set to the pod names the devices of all of its containers, so it replaces only the pod's name.
+	name := pod.Name
+	if label, ok := labels[connector.LabelName]; ok && label != "" {
+		name = label
+		delete(labels, connector.LabelName)
+	}
+
+	tags, err := k.podTags(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	images := make(map[string]string, len(pod.Spec.Containers))
+	for _, container := range pod.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+
+	containers := []connector.Container{}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Running == nil {
+			continue
+		}
+
+		id := containerID(pod.Namespace, pod.Name, status.Name)
+
+		container, ok := k.agents.Container(id, connector.PodDeviceName(name, status.Name), images[status.Name], labels)
+		if !ok {
+			continue
+		}
+
+		container.Tags = connector.ValidTags(id, append(container.Tags, tags...))
+
+		containers = append(containers, container)
+	}
+
+	return containers, nil
+}
+
+// podTags gets the values of the pod's tag labels, falling back to the labels of its namespace.
+func (k *Connector) podTags(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	var namespace *corev1.Namespace
+
+	tags := []string{}
+	for _, key := range k.options.TagLabels {
+		if value, ok := pod.Labels[key]; ok {
+			tags = append(tags, value)
+
+			continue
+		}
+
+		if namespace == nil {
+			var err error
+			if namespace, err = k.client.CoreV1().Namespaces().Get(ctx, pod.Namespace, metav1.GetOptions{}); err != nil {
+				return nil, err
+			}
+		}
+
+		if value, ok := namespace.Labels[key]; ok {
+			tags = append(tags, value)
+		}
+	}
+
+	return tags, nil
+}
+
+// update starts the agents for the pod's running containers and stops the ones for its containers not running.
+func (k *Connector) update(ctx context.Context, pod *corev1.Pod) error {
+	containers, err := k.podContainers(ctx, pod)
+	if err != nil {
+		return err
+	}
+
+	running := make(map[string]bool, len(containers))
+	for _, container := range containers {
+		running[container.ID] = true
+
+		k.Start(ctx, container)
+	}
+
+	for _, container := range pod.Spec.Containers {
+		if id := containerID(pod.Namespace, pod.Name, container.Name); !running[id] {
+			k.Stop(ctx, id)
+		}
+	}
+
+	return nil
+}
+
+// sync starts the agents for the pods' running containers and stops the agents of the containers no longer running,
+// returning the pods' resource version to watch their changes from.
+func (k *Connector) sync(ctx context.Context) (string, error) {
+	pods, err := k.client.CoreV1().Pods(k.options.Namespace).List(ctx, metav1.ListOptions{LabelSelector: k.options.LabelSelector})
+	if err != nil {
+		return "", err
+	}
+
+	running := map[string]bool{}
+	for i := range pods.Items {
+		containers, err := k.podContainers(ctx, &pods.Items[i])
+		if err != nil {
+			return "", err
+		}
+
+		for _, container := range containers {
+			running[container.ID] = true
+
+			k.Start(ctx, container)
+		}
+	}
+
+	for _, id := range k.agents.Started() {
+		if !running[id] {
+			k.Stop(ctx, id)
+		}
+	}
+
+	return pods.ResourceVersion, nil
+}
+
+// Listen listens for the pods' changes and starts or stops the agent for their containers.
+func (k *Connector) Listen(ctx context.Context) error {
+	for {
+		version, err := k.sync(ctx)
+		if err != nil {
+			return err
+		}
+
+		watcher, err := k.client.CoreV1().Pods(k.options.Namespace).Watch(ctx, metav1.ListOptions{
+			LabelSelector:   k.options.LabelSelector,
+			ResourceVersion: version,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := k.watch(ctx, watcher); err != nil {
+			return err
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		// NOTICE: The watch is closed by the Kubernetes' API after a while, or when the resource version is too old,
+		// so the pods are listed again to not miss any change.
+	}
+}
+
+// watch handles the pods' changes until the watch is closed.
+func (k *Connector) watch(ctx context.Context, watcher watch.Interface) error {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+
+			switch event.Type {
+			case watch.Added, watch.Modified:
+				pod, ok := event.Object.(*corev1.Pod)
+				if !ok {
+					continue
+				}
+
+				if err := k.update(ctx, pod); err != nil {
+					return err
+				}
+			case watch.Deleted:
+				pod, ok := event.Object.(*corev1.Pod)
+				if !ok {
+					continue
+				}
+
+				for _, container := range pod.Spec.Containers {
+					k.Stop(ctx, containerID(pod.Namespace, pod.Name, container.Name))
+				}
+			case watch.Error:
+				return nil
+			}
+		}
+	}
+}
+
+// NOTICE: Ensures the Runtime interface is implemented.
+var _ connectormode.Runtime = (*runtime)(nil)
+
+// runtime implements the connector mode's Runtime interface through the Kubernetes' API.
+type runtime struct {
+	client kubernetes.Interface
+	config *rest.Config
+}
+
+func (r *runtime) Name() string {
+	return "kubernetes"
+}
+
+func (r *runtime) Image(ctx context.Context, id string) (string, error) {
+	namespace, name, container, err := parseContainerID(id)
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := r.client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return "", err
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			return c.Image, nil
+		}
+	}
+
+	return "", fmt.Errorf("container %s not found in the pod %s", container, name)
+}
+
+// ReadFile reads the file at path from the container's filesystem, executing `cat` inside it.
+func (r *runtime) ReadFile(ctx context.Context, id string, path string) (io.ReadCloser, error) {
+	var stdout, stderr bytes.Buffer
+	if err := r.stream(ctx, id, []string{"cat", path}, nil, &stdout, &stderr, false, [2]uint{}); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w: %s", path, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return io.NopCloser(&stdout), nil
+}
+
+func (r *runtime) Exec(ctx context.Context, id string, user *osauth.User, command []string, tty bool, size [2]uint) (connectormode.Process, error) {
+	// NOTICE: The command is executed as the container's user, expected to be root, so it is switched to the user
+	// through `su`, passing the command's arguments as they are.
+	if user.UID != 0 {
+		if err := r.switchable(ctx, id); err != nil {
+			return nil, err
+		}
+
+		command = append([]string{"su", "-s", "/bin/sh", user.Username, "-c", `exec "$0" "$@"`}, command...)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stdin, stdinWriter := io.Pipe()
+	stdout, stdoutWriter := io.Pipe()
+	stderr, stderrWriter := io.Pipe()
+
+	p := connector.NewPipedProcess(stdinWriter, stdout, stderr, func() error {
+		cancel()
+
+		return nil
+	})
+
+	go func() {
+		err := r.stream(ctx, id, command, stdin, stdoutWriter, stderrWriter, tty, size)
+
+		stdoutWriter.Close()
+		stderrWriter.Close()
+
+		var exit utilexec.ExitError
+		switch {
+		case errors.As(err, &exit):
+			p.Exit(exit.ExitStatus(), nil)
+		case err != nil:
+			p.Exit(-1, err)
+		default:
+			p.Exit(0, nil)
+		}
+	}()
+
+	return p, nil
+}
+
+// switchable checks whether the container runs as root and has `su`, what are required to execute a command as another
+// user, refusing the session up front instead of failing it with the `su`'s error.
+func (r *runtime) switchable(ctx context.Context, id string) error {
+	var stdout, stderr bytes.Buffer
+	if err := r.stream(ctx, id, []string{"sh", "-c", `[ "$(id -u)" = 0 ] && command -v su`}, nil, &stdout, &stderr, false, [2]uint{}); err != nil {
+		var exit utilexec.ExitError
+		if errors.As(err, &exit) {
+			return ErrSwitchUser
+		}
+
+		return fmt.Errorf("%w: %w: %s", ErrSwitchUser, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
+
+// stream executes the command inside the container, streaming its input and output until it exits.
+func (r *runtime) stream(ctx context.Context, id string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer, tty bool, size [2]uint) error {
+	namespace, pod, container, err := parseContainerID(id)
+	if err != nil {
+		return err
+	}
+
+	req := r.client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    true,
+			Stderr:    !tty,
+			TTY:       tty,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(r.config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	options := remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Tty:    tty,
+	}
+
+	if tty {
+		sizes := make(chan remotecommand.TerminalSize, 1)
+		sizes <- remotecommand.TerminalSize{Height: uint16(size[0]), Width: uint16(size[1])}
+		close(sizes)
+
+		options.TerminalSizeQueue = terminalSizeQueue(sizes)
+	} else {
+		options.Stderr = stderr
+	}
+
+	return executor.StreamWithContext(ctx, options)
+}
+
+// terminalSizeQueue sends the sizes of the terminal to the Kubernetes' exec.
+type terminalSizeQueue chan remotecommand.TerminalSize
+
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	size, ok := <-q
+	if !ok {
+		return nil
+	}
+
+	return &size
+}
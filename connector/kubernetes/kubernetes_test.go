@@ -0,0 +1,151 @@
+package kubernetes
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/connector"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newPod(namespace string, name string, labels map[string]string, annotations map[string]string, phase corev1.PodPhase, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   namespace,
+			Name:        name,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Status: corev1.PodStatus{Phase: phase},
+	}
+
+	for _, container := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: container, Image: container + ":latest"})
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  container,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		})
+	}
+
+	return pod
+}
+
+// startedAgents records the agents started and stopped by the connector, instead of running them.
+type startedAgents struct {
+	*connector.Agents
+
+	mu      sync.Mutex
+	started map[string]bool
+}
+
+func (a *startedAgents) Start(_ context.Context, container connector.Container) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.started[container.ID] = true
+}
+
+func (a *startedAgents) Stop(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.started, id)
+}
+
+func (a *startedAgents) Started() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ids := make([]string, 0, len(a.started))
+	for id := range a.started {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+func (a *startedAgents) isStarted(id string) func() bool {
+	return func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+
+		return a.started[id]
+	}
+}
+
+func TestConnectorList(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "production", Labels: map[string]string{"team": "payments"}}},
+		newPod("production", "web-7d9f", map[string]string{"app": "web", "env": "production"}, map[string]string{
+			connector.LabelName: "frontend",
+			connector.LabelTags: "edge",
+		}, corev1.PodRunning, "nginx", "sidecar"),
+		newPod("production", "worker-5c2a", map[string]string{"app": "worker"}, nil, corev1.PodRunning, "worker"),
+		newPod("production", "job-1b3e", map[string]string{"app": "web"}, nil, corev1.PodSucceeded, "job"),
+	)
+
+	k := newConnector(client, nil, "http://localhost", "tenant", "/tmp", Options{
+		TagLabels: []string{"env", "team"},
+	}, &connector.Selector{ExcludeImages: []string{"sidecar:*"}})
+
+	containers, err := k.List(context.Background())
+	assert.NoError(t, err)
+
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i].ID < containers[j].ID
+	})
+
+	assert.Equal(t, []connector.Container{
+		{ID: "production_web-7d9f_nginx", Name: "frontend-nginx", Tags: []string{"edge", "production", "payments"}},
+		{ID: "production_worker-5c2a_worker", Name: "worker-5c2a-worker", Tags: []string{"payments"}},
+	}, containers)
+}
+
+func TestConnectorListen(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		newPod("default", "web-7d9f", nil, nil, corev1.PodRunning, "nginx"),
+	)
+
+	agents := &startedAgents{
+		Agents:  connector.NewAgents("http://localhost", "tenant", "/tmp", nil, nil),
+		started: map[string]bool{},
+	}
+
+	k := newConnector(client, nil, "http://localhost", "tenant", "/tmp", Options{}, nil)
+	k.agents = agents
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go k.Listen(ctx) //nolint:errcheck
+
+	assert.Eventually(t, agents.isStarted("default_web-7d9f_nginx"), time.Second, 10*time.Millisecond)
+
+	pods := client.CoreV1().Pods("default")
+
+	_, err := pods.Create(ctx, newPod("default", "api-4e8b", nil, nil, corev1.PodRunning, "api"), metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, agents.isStarted("default_api-4e8b_api"), time.Second, 10*time.Millisecond)
+
+	assert.NoError(t, pods.Delete(ctx, "web-7d9f", metav1.DeleteOptions{}))
+	assert.Eventually(t, func() bool { return !agents.isStarted("default_web-7d9f_nginx")() }, time.Second, 10*time.Millisecond)
+
+	_, err = pods.Update(ctx, newPod("default", "api-4e8b", nil, nil, corev1.PodFailed, "api"), metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return !agents.isStarted("default_api-4e8b_api")() }, time.Second, 10*time.Millisecond)
+}
+
+func TestParseContainerID(t *testing.T) {
+	namespace, pod, container, err := parseContainerID(containerID("default", "web-7d9f", "nginx"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default", "web-7d9f", "nginx"}, []string{namespace, pod, container})
+
+	_, _, _, err = parseContainerID("3f1e2d")
+	assert.Error(t, err)
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
 	"path"
 
+	"github.com/shellhub-io/shellhub/connector/containerd"
+	"github.com/shellhub-io/shellhub/connector/kubernetes"
 	"github.com/shellhub-io/shellhub/pkg/agent/connector"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	log "github.com/sirupsen/logrus"
@@ -29,6 +32,89 @@ type Config struct {
 	// has a direct impact of the bandwidth used by the device when in idle
 	// state. Default is 30 seconds.
 	KeepAliveInterval int `env:"KEEPALIVE_INTERVAL,default=30"`
+
+	// Set the container runtime whose containers are turned into devices. It can be `docker`, `podman`,
+	// `containerd` or `kubernetes`. Default is `docker`.
+	Runtime string `env:"RUNTIME,default=docker"`
+
+	// Set the address of the container runtime's API socket. If not provided, the runtime's default socket is used.
+	// Docker's address is read from the DOCKER_HOST environment variable instead.
+	RuntimeSocket string `env:"RUNTIME_SOCKET"`
+
+	// Set the containerd's namespace whose containers are turned into devices, like `k8s.io` to the containers created
+	// by Kubernetes. Only used by the `containerd` runtime. Default is `default`.
+	ContainerdNamespace string `env:"CONTAINERD_NAMESPACE,default=default"`
+
+	// Set the path to the kubeconfig file used to reach the Kubernetes' API. If not provided, the connector's service
+	// account is used, when running inside the cluster. Only used by the `kubernetes` runtime.
+	Kubeconfig string `env:"KUBECONFIG"`
+
+	// Set the Kubernetes' namespace whose pods' containers are turned into devices. If not provided, the pods of every
+	// namespace are. Only used by the `kubernetes` runtime.
+	KubernetesNamespace string `env:"KUBERNETES_NAMESPACE"`
+
+	// Set the Kubernetes' label selector, like `app=web`, of the pods whose containers are turned into devices. Only
+	// used by the `kubernetes` runtime.
+	KubernetesLabelSelector string `env:"KUBERNETES_LABEL_SELECTOR"`
+
+	// Set the comma-separated keys of the pods', or of their namespaces', labels whose values are applied as the tags
+	// of the containers' devices. Only used by the `kubernetes` runtime.
+	KubernetesTagLabels []string `env:"KUBERNETES_TAG_LABELS"`
+
+	// Set the comma-separated labels, as `key` or `key=value`, of the containers turned into devices. If not provided,
+	// the containers aren't selected by their labels.
+	IncludeLabels []string `env:"INCLUDE_LABELS"`
+
+	// Set the comma-separated labels, as `key` or `key=value`, of the containers not turned into devices.
+	ExcludeLabels []string `env:"EXCLUDE_LABELS"`
+
+	// Set the comma-separated patterns, like `nginx:*`, of the images of the containers turned into devices. If not
+	// provided, the containers aren't selected by their images.
+	IncludeImages []string `env:"INCLUDE_IMAGES"`
+
+	// Set the comma-separated patterns of the images of the containers not turned into devices.
+	ExcludeImages []string `env:"EXCLUDE_IMAGES"`
+
+	// Set the comma-separated Docker Compose's projects of the containers turned into devices. If not provided, the
+	// containers aren't selected by their projects.
+	IncludeProjects []string `env:"INCLUDE_PROJECTS"`
+
+	// Set the comma-separated Docker Compose's projects of the containers not turned into devices.
+	ExcludeProjects []string `env:"EXCLUDE_PROJECTS"`
+}
+
+// newConnector creates the connector to the container runtime set in the configuration.
+func newConnector(cfg *Config) (connector.Connector, error) {
+	selector := &connector.Selector{
+		IncludeLabels:   cfg.IncludeLabels,
+		ExcludeLabels:   cfg.ExcludeLabels,
+		IncludeImages:   cfg.IncludeImages,
+		ExcludeImages:   cfg.ExcludeImages,
+		IncludeProjects: cfg.IncludeProjects,
+		ExcludeProjects: cfg.ExcludeProjects,
+	}
+
+	switch cfg.Runtime {
+	case "docker":
+		return connector.NewDockerConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys, selector)
+	case "podman":
+		return connector.NewPodmanConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys, cfg.RuntimeSocket, selector)
+	case "containerd":
+		return containerd.NewConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys, cfg.RuntimeSocket, cfg.ContainerdNamespace, selector)
+	case "kubernetes":
+		config, err := kubernetes.Config(cfg.Kubeconfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return kubernetes.NewConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys, config, kubernetes.Options{
+			Namespace:     cfg.KubernetesNamespace,
+			LabelSelector: cfg.KubernetesLabelSelector,
+			TagLabels:     cfg.KubernetesTagLabels,
+		}, selector)
+	default:
+		return nil, fmt.Errorf("unsupported container runtime %q", cfg.Runtime)
+	}
 }
 
 // ConnectorVersion store the version to be embed inside the binary. This is
@@ -39,8 +125,8 @@ var ConnectorVersion string
 func main() {
 	rootCmd := &cobra.Command{ // nolint: exhaustruct
 		Use:   "docker",
-		Short: "Starts the Docker Connector",
-		Long:  "Starts the Docker Connector, a service what turns all containers in a docker engine into a ShelHub device",
+		Short: "Starts the Connector",
+		Long:  "Starts the Connector, a service what turns all containers in a docker engine, or in another container runtime, into a ShelHub device",
 		Run: func(cmd *cobra.Command, args []string) {
 			cfg, err := envs.ParseWithPrefix[Config]("SHELLHUB_")
 			if err != nil {
@@ -53,17 +139,19 @@ func main() {
 				"address":      cfg.ServerAddress,
 				"tenant_id":    cfg.TenantID,
 				"private_keys": cfg.PrivateKeys,
+				"runtime":      cfg.Runtime,
 				"version":      ConnectorVersion,
-			}).Info("Starting ShellHub Docker Connector")
+			}).Info("Starting ShellHub Connector")
 
 			connector.ConnectorVersion = ConnectorVersion
-			connector, err := connector.NewDockerConnector(cfg.ServerAddress, cfg.TenantID, cfg.PrivateKeys)
+			connector, err := newConnector(cfg)
 			if err != nil {
 				log.WithError(err).WithFields(log.Fields{
 					"address":   cfg.ServerAddress,
 					"tenant_id": cfg.TenantID,
+					"runtime":   cfg.Runtime,
 					"version":   ConnectorVersion,
-				}).Fatal("Failed to create ShellHub Docker Connector")
+				}).Fatal("Failed to create ShellHub Connector")
 			}
 
 			if err := connector.Listen(cmd.Context()); err != nil {
@@ -77,8 +165,9 @@ func main() {
 			log.WithFields(log.Fields{
 				"address":   cfg.ServerAddress,
 				"tenant_id": cfg.TenantID,
+				"runtime":   cfg.Runtime,
 				"version":   ConnectorVersion,
-			}).Info("ShellHub Docker Connector stopped")
+			}).Info("ShellHub Connector stopped")
 		},
 	}
 
@@ -0,0 +1,63 @@
+package agent
+
+import (
+	"math/rand"
+	"time"
+)
+
+// reconnectStableInterval is how long a connection to the server must stay up for the backoff to restart from the
+// minimum delay.
+const reconnectStableInterval = time.Minute
+
+// backoff computes the exponentially growing delay between the agent's attempts to connect to the server, spread by a
+// random jitter, so the agents disconnected at once, like when the server restarts, don't reconnect at once too.
+type backoff struct {
+	min     time.Duration
+	max     time.Duration
+	attempt int
+	// random returns a random number in [0.0, 1.0).
+	random func() float64
+}
+
+func newBackoff(min, max time.Duration) *backoff {
+	if min <= 0 {
+		min = time.Second
+	}
+
+	if max <= 0 {
+		max = 2 * time.Minute
+	}
+
+	if max < min {
+		max = min
+	}
+
+	return &backoff{min: min, max: max, random: rand.Float64} //nolint:gosec
+}
+
+// Next gets the delay before the next attempt, doubling it from the minimum on each call up to the maximum. When the
+// server has hinted a longer delay, the hint, up to the maximum, is used instead. The delay is increased by a random
+// jitter of up to half of it.
+func (b *backoff) Next(hint time.Duration) time.Duration {
+	delay := b.max
+	// NOTICE: The attempts are not counted above the maximum delay to avoid overflowing the shift.
+	if shift := b.attempt; shift < 32 && b.min<<shift < b.max {
+		delay = b.min << shift
+		b.attempt++
+	}
+
+	if hint > b.max {
+		hint = b.max
+	}
+
+	if hint > delay {
+		delay = hint
+	}
+
+	return delay + time.Duration(b.random()*float64(delay)/2)
+}
+
+// Reset restarts the delay from the minimum.
+func (b *backoff) Reset() {
+	b.attempt = 0
+}
@@ -0,0 +1,85 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoff(t *testing.T) {
+	cases := []struct {
+		description string
+		min         time.Duration
+		max         time.Duration
+		random      float64
+		hints       []time.Duration
+		expected    []time.Duration
+	}{
+		{
+			description: "doubles the delay up to the maximum",
+			min:         time.Second,
+			max:         10 * time.Second,
+			random:      0,
+			hints:       []time.Duration{0, 0, 0, 0, 0, 0},
+			expected:    []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second},
+		},
+		{
+			description: "spreads the delay by up to half of it",
+			min:         time.Second,
+			max:         10 * time.Second,
+			random:      0.5,
+			hints:       []time.Duration{0, 0, 0},
+			expected:    []time.Duration{1250 * time.Millisecond, 2500 * time.Millisecond, 5 * time.Second},
+		},
+		{
+			description: "honors the server's hint when it is longer than the delay",
+			min:         time.Second,
+			max:         10 * time.Second,
+			random:      0,
+			hints:       []time.Duration{5 * time.Second, 0, time.Second},
+			expected:    []time.Duration{5 * time.Second, 2 * time.Second, 4 * time.Second},
+		},
+		{
+			description: "caps the server's hint at the maximum",
+			min:         time.Second,
+			max:         10 * time.Second,
+			random:      0,
+			hints:       []time.Duration{time.Hour},
+			expected:    []time.Duration{10 * time.Second},
+		},
+		{
+			description: "uses the defaults when the intervals are not set",
+			min:         0,
+			max:         0,
+			random:      0,
+			hints:       []time.Duration{0, 0},
+			expected:    []time.Duration{time.Second, 2 * time.Second},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			b := newBackoff(tc.min, tc.max)
+			b.random = func() float64 { return tc.random }
+
+			delays := make([]time.Duration, 0, len(tc.hints))
+			for _, hint := range tc.hints {
+				delays = append(delays, b.Next(hint))
+			}
+
+			assert.Equal(t, tc.expected, delays)
+		})
+	}
+}
+
+func TestBackoffReset(t *testing.T) {
+	b := newBackoff(time.Second, time.Minute)
+	b.random = func() float64 { return 0 }
+
+	b.Next(0)
+	b.Next(0)
+	b.Reset()
+
+	assert.Equal(t, time.Second, b.Next(0))
+}
@@ -0,0 +1,271 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/sethvargo/go-envconfig"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigPrefix is the prefix of the environment variables that configure the agent.
+const ConfigPrefix = "SHELLHUB_"
+
+// configPolicyKey is the key of the configuration file's section with the device-local authorization policy.
+const configPolicyKey = "policy"
+
+// reloadableSettings are the settings applied to the running agent when its configuration is reloaded. The others are
+// only applied when the agent restarts.
+var reloadableSettings = []string{
+	"PREFERRED_HOSTNAME",
+	"POLICY_FILE",
+	"DISABLE_JOBS",
+	"FILE_PUSH_PATHS",
+}
+
+// LoadConfig loads the agent's configuration from the environment variables and, when filename is not empty, from the
+// YAML configuration file, validating it. The environment variables take precedence over the file.
+//
+// The file's keys are the environment variables' names, without the prefix, in lower case, like:
+//
+//	server_address: https://cloud.shellhub.io
+//	tenant_id: 00000000-0000-4000-0000-000000000000
+//	private_key: /var/lib/shellhub/agent.key
+//	disable_jobs: true
+//	policy:
+//	  deny_users: ["root"]
+//	  sessions: ["shell", "sftp"]
+//
+// The policy section is the device-local authorization policy, in the same format of the policy file, what is
+// enforced instead of it.
+func LoadConfig(filename string) (*Config, error) {
+	settings := map[string]string{}
+	config := new(Config)
+
+	if filename != "" {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if settings, config.inlinePolicy, err = parseConfigFile(data); err != nil {
+			return nil, fmt.Errorf("invalid configuration file %s: %w", filename, err)
+		}
+	}
+
+	if err := envconfig.ProcessWith(context.Background(), config, envconfig.MultiLookuper(
+		envconfig.PrefixLookuper(ConfigPrefix, envconfig.OsLookuper()),
+		envconfig.OsLookuper(),
+		envconfig.MapLookuper(settings),
+	)); err != nil {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// parseConfigFile parses the YAML configuration file into the settings, keyed by their environment variables' names,
+// and the device-local authorization policy, if any.
+func parseConfigFile(data []byte) (map[string]string, *policy.Policy, error) {
+	var document map[string]interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, nil, err
+	}
+
+	known := configKeys()
+
+	settings := make(map[string]string, len(document))
+
+	var inline *policy.Policy
+	for key, value := range document {
+		if key == configPolicyKey {
+			var err error
+			if inline, err = parseConfigPolicy(value); err != nil {
+				return nil, nil, fmt.Errorf("invalid policy: %w", err)
+			}
+
+			continue
+		}
+
+		name := strings.ToUpper(key)
+		if _, ok := known[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown setting %q", key)
+		}
+
+		switch value := value.(type) {
+		case string, bool, int, float64:
+			settings[name] = fmt.Sprint(value)
+		case []interface{}:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+
+			settings[name] = strings.Join(items, ",")
+		case nil:
+		default:
+			return nil, nil, fmt.Errorf("setting %q must be a scalar value or a list", key)
+		}
+	}
+
+	return settings, inline, nil
+}
+
+// parseConfigPolicy parses the configuration file's policy section, rejecting the fields unknown to the policy.
+func parseConfigPolicy(value interface{}) (*policy.Policy, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	p := new(policy.Policy)
+	if err := decoder.Decode(p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// configKeys gets the names of the environment variables, without the prefix, that configure the agent, mapped to the
+// names of their fields.
+func configKeys() map[string]string {
+	keys := map[string]string{}
+
+	kind := reflect.TypeOf(Config{})
+	for i := 0; i < kind.NumField(); i++ {
+		tag, ok := kind.Field(i).Tag.Lookup("env")
+		if !ok {
+			continue
+		}
+
+		keys[strings.Split(tag, ",")[0]] = kind.Field(i).Name
+	}
+
+	return keys
+}
+
+// validate checks the configuration's values the agent cannot start with.
+func (c *Config) validate() error {
+	address, err := url.Parse(c.ServerAddress)
+	if err != nil || (address.Scheme != "http" && address.Scheme != "https") || address.Host == "" {
+		return fmt.Errorf("server address %q must be a HTTP or HTTPS URL", c.ServerAddress)
+	}
+
+	intervals := map[string]int{
+		"KEEPALIVE_INTERVAL":     c.KeepAliveInterval,
+		"INVENTORY_INTERVAL":     c.InventoryInterval,
+		"METRICS_INTERVAL":       c.MetricsInterval,
+		"UPDATE_DEADLINE":        c.UpdateDeadline,
+		"UPDATE_CHECK_INTERVAL":  c.UpdateCheckInterval,
+		"RECONNECT_MIN_INTERVAL": c.ReconnectMinInterval,
+		"RECONNECT_MAX_INTERVAL": c.ReconnectMaxInterval,
+	}
+
+	for name, interval := range intervals {
+		if interval < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+
+	if c.KeepAliveInterval == 0 || c.UpdateCheckInterval == 0 {
+		return fmt.Errorf("KEEPALIVE_INTERVAL and UPDATE_CHECK_INTERVAL must be greater than zero")
+	}
+
+	if c.inlinePolicy != nil && c.PolicyFile != "" {
+		return fmt.Errorf("policy must be set either in the configuration file or in POLICY_FILE, not both")
+	}
+
+	for _, path := range c.FilePushPaths {
+		if !filepath.IsAbs(path) || filepath.Clean(path) != path || path == "/" {
+			return fmt.Errorf("FILE_PUSH_PATHS must be absolute and clean directory paths, other than the root")
+		}
+	}
+
+	if c.PolicyFile != "" {
+		if _, err := policy.Load(c.PolicyFile); err != nil {
+			return fmt.Errorf("invalid policy file %s: %w", c.PolicyFile, err)
+		}
+	}
+
+	return nil
+}
+
+// settings gets a copy of the agent's configuration, safe to read while it is reloaded.
+func (a *Agent) settings() Config {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+
+	return *a.config
+}
+
+// jobsDisabled reports whether the exec sessions that run the jobs' commands are denied on the device.
+func (a *Agent) jobsDisabled() bool {
+	return a.settings().DisableJobs
+}
+
+// loadPolicy loads the device-local authorization policy, either set in the configuration file or read from the
+// policy file. It is nil when no policy is configured.
+func (a *Agent) loadPolicy() (*policy.Policy, error) {
+	config := a.settings()
+
+	switch {
+	case config.inlinePolicy != nil:
+		return config.inlinePolicy, nil
+	case config.PolicyFile != "":
+		return policy.Load(config.PolicyFile)
+	default:
+		return nil, nil
+	}
+}
+
+// Reload applies the reloaded configuration to the running agent, without dropping its connection to the server. Only
+// the device-local authorization policy, the preferred hostname and the jobs' and file pushes' switches are applied;
+// it returns the names of the other settings that have changed, what are only applied when the agent restarts.
+func (a *Agent) Reload(config *Config) []string {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+
+	current := reflect.ValueOf(a.config).Elem()
+	next := reflect.ValueOf(config).Elem()
+
+	var pending []string
+	for name, field := range configKeys() {
+		if reflect.DeepEqual(current.FieldByName(field).Interface(), next.FieldByName(field).Interface()) {
+			continue
+		}
+
+		reloadable := false
+		for _, setting := range reloadableSettings {
+			if setting == name {
+				reloadable = true
+
+				break
+			}
+		}
+
+		if reloadable {
+			current.FieldByName(field).Set(next.FieldByName(field))
+		} else {
+			pending = append(pending, name)
+		}
+	}
+
+	a.config.inlinePolicy = config.inlinePolicy
+
+	return pending
+}
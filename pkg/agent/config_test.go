@@ -0,0 +1,184 @@
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	cases := []struct {
+		description string
+		file        string
+		envs        map[string]string
+		check       func(t *testing.T, config *Config)
+		fails       bool
+	}{
+		{
+			description: "loads the settings from the file",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+keepalive_interval: 45
+disable_jobs: true
+file_push_paths: ["/etc/app", "/opt/app"]
+`,
+			check: func(t *testing.T, config *Config) {
+				assert.Equal(t, "https://cloud.shellhub.io", config.ServerAddress)
+				assert.Equal(t, "00000000-0000-4000-0000-000000000000", config.TenantID)
+				assert.Equal(t, 45, config.KeepAliveInterval)
+				assert.Equal(t, 300, config.InventoryInterval)
+				assert.True(t, config.DisableJobs)
+				assert.Equal(t, []string{"/etc/app", "/opt/app"}, config.FilePushPaths)
+				assert.Nil(t, config.inlinePolicy)
+			},
+		},
+		{
+			description: "prefers the environment variables over the file",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+preferred_hostname: file
+`,
+			envs: map[string]string{"SHELLHUB_PREFERRED_HOSTNAME": "env"},
+			check: func(t *testing.T, config *Config) {
+				assert.Equal(t, "env", config.PreferredHostname)
+			},
+		},
+		{
+			description: "loads the policy from the file",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+policy:
+  deny_users: ["root"]
+  sessions: ["shell"]
+`,
+			check: func(t *testing.T, config *Config) {
+				require.NotNil(t, config.inlinePolicy)
+				assert.Equal(t, []string{"root"}, config.inlinePolicy.DenyUsers)
+				assert.Equal(t, []policy.Session{policy.SessionShell}, config.inlinePolicy.Sessions)
+			},
+		},
+		{
+			description: "fails when the file has an unknown setting",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+unknown: value
+`,
+			fails: true,
+		},
+		{
+			description: "fails when the policy has an unknown field",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+policy:
+  deny: ["root"]
+`,
+			fails: true,
+		},
+		{
+			description: "fails when the server address is not a HTTP URL",
+			file: `
+server_address: cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+`,
+			fails: true,
+		},
+		{
+			description: "fails when an interval is negative",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+metrics_interval: -1
+`,
+			fails: true,
+		},
+		{
+			description: "fails when a file push path is relative",
+			file: `
+server_address: https://cloud.shellhub.io
+tenant_id: 00000000-0000-4000-0000-000000000000
+private_key: /tmp/shellhub.key
+file_push_paths: ["etc/app"]
+`,
+			fails: true,
+		},
+		{
+			description: "fails when a required setting is missing",
+			file: `
+server_address: https://cloud.shellhub.io
+private_key: /tmp/shellhub.key
+`,
+			fails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			for _, name := range []string{"SHELLHUB_TENANT_ID", "TENANT_ID", "SHELLHUB_SERVER_ADDRESS", "SERVER_ADDRESS"} {
+				t.Setenv(name, "")
+				os.Unsetenv(name)
+			}
+
+			for name, value := range tc.envs {
+				t.Setenv(name, value)
+			}
+
+			file := filepath.Join(t.TempDir(), "agent.yaml")
+			require.NoError(t, os.WriteFile(file, []byte(tc.file), 0o600))
+
+			config, err := LoadConfig(file)
+			if tc.fails {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			tc.check(t, config)
+		})
+	}
+}
+
+func TestReload(t *testing.T) {
+	a := &Agent{
+		config: &Config{
+			ServerAddress:     "https://cloud.shellhub.io",
+			TenantID:          "00000000-0000-4000-0000-000000000000",
+			PreferredHostname: "device",
+			KeepAliveInterval: 30,
+		},
+	}
+
+	pending := a.Reload(&Config{
+		ServerAddress:     "https://other.shellhub.io",
+		TenantID:          "00000000-0000-4000-0000-000000000000",
+		PreferredHostname: "renamed",
+		KeepAliveInterval: 30,
+		DisableJobs:       true,
+		inlinePolicy:      &policy.Policy{DenyUsers: []string{"root"}},
+	})
+
+	assert.Equal(t, []string{"SERVER_ADDRESS"}, pending)
+	assert.Equal(t, "https://cloud.shellhub.io", a.config.ServerAddress)
+	assert.Equal(t, "renamed", a.config.PreferredHostname)
+	assert.True(t, a.config.DisableJobs)
+
+	p, err := a.loadPolicy()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root"}, p.DenyUsers)
+}
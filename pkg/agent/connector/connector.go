@@ -2,6 +2,13 @@ package connector
 
 import (
 	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent"
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+	log "github.com/sirupsen/logrus"
 )
 
 // ConnectorVersion stores the version of the ShellHub Instane that is running the connector.
@@ -14,6 +21,8 @@ type Container struct {
 	ID string
 	// Name is the container name.
 	Name string
+	// Tags are the tags applied to the container's device when it is registered.
+	Tags []string
 	// ServerAddress is the ShellHub address of the server that the agent will connect to.
 	ServerAddress string
 	// Tenant is the tenant ID of the namespace that the agent belongs to.
@@ -29,10 +38,225 @@ type Container struct {
 type Connector interface {
 	// List lists all containers running on the host.
 	List(ctx context.Context) ([]Container, error)
-	// Start starts the agent for the container with the given ID.
-	Start(ctx context.Context, id string, name string)
+	// Start starts the agent for the container.
+	Start(ctx context.Context, container Container)
 	// Stop stops the agent for the container with the given ID.
 	Stop(ctx context.Context, id string)
 	// Listen listens for events and starts or stops the agent for the container that was created or removed.
 	Listen(ctx context.Context) error
 }
+
+// Agents starts and stops the agents of the containers turned into devices by a connector, independently of the
+// container runtime.
+type Agents struct {
+	mu sync.Mutex
+	// server is the ShellHub address of the server that the agent will connect to.
+	server string
+	// tenant is the tenant ID of the namespace that the agent belongs to.
+	tenant string
+	// privateKeys is the path to the directory that contains the private keys for the containers.
+	privateKeys string
+	// runtime is the container runtime where the containers run.
+	runtime connectormode.Runtime
+	// selector selects the containers turned into devices.
+	selector *Selector
+	// cancels is a map that contains the cancel functions for each container.
+	// This is used to stop the agent for a container, marking as done its context and closing the agent.
+	cancels map[string]context.CancelFunc
+	// run runs the agent for a container until its context is done.
+	run func(ctx context.Context, runtime connectormode.Runtime, container Container)
+}
+
+// NewAgents creates the [Agents] of the containers, run by the runtime, turned into devices by a connector. When the
+// selector is nil, every container is.
+func NewAgents(server string, tenant string, privateKeys string, runtime connectormode.Runtime, selector *Selector) *Agents {
+	if selector == nil {
+		selector = new(Selector)
+	}
+
+	return &Agents{
+		server:      server,
+		tenant:      tenant,
+		privateKeys: privateKeys,
+		runtime:     runtime,
+		selector:    selector,
+		cancels:     make(map[string]context.CancelFunc),
+		run:         initContainerAgent,
+	}
+}
+
+// Container gets the container, from its ID, name, image and labels, when it is selected to be turned into a device.
+// The device's name and tags are read from the container's labels.
+func (a *Agents) Container(id string, name string, image string, labels map[string]string) (Container, bool) {
+	if !a.selector.Selects(image, labels) {
+		return Container{}, false
+	}
+
+	if label, ok := labels[LabelName]; ok && label != "" {
+		name = label
+	}
+
+	return Container{
+		ID:   id,
+		Name: name,
+		Tags: containerTags(id, labels),
+	}, true
+}
+
+// Start starts the agent for the container, when it isn't started yet.
+func (a *Agents) Start(ctx context.Context, container Container) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.cancels[container.ID]; ok {
+		return
+	}
+
+	ctx, a.cancels[container.ID] = context.WithCancel(ctx)
+
+	container.ServerAddress = a.server
+	container.Tenant = a.tenant
+	container.PrivateKey = fmt.Sprintf("%s/%s.key", a.privateKeys, container.ID)
+	container.Cancel = a.cancels[container.ID]
+
+	go a.run(ctx, a.runtime, container)
+}
+
+// Stop stops the agent for the container with the given ID.
+func (a *Agents) Stop(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	cancel, ok := a.cancels[id]
+	if ok {
+		cancel()
+		delete(a.cancels, id)
+	}
+}
+
+// Started gets the IDs of the containers whose agents are started.
+func (a *Agents) Started() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	ids := make([]string, 0, len(a.cancels))
+	for id := range a.cancels {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// initContainerAgent initializes the agent for a container.
+func initContainerAgent(ctx context.Context, runtime connectormode.Runtime, container Container) {
+	agent.AgentPlatform = "connector"
+	agent.AgentVersion = ConnectorVersion
+
+	cfg := &agent.Config{
+		ServerAddress:     container.ServerAddress,
+		TenantID:          container.Tenant,
+		PrivateKey:        container.PrivateKey,
+		PreferredIdentity: container.ID,
+		PreferredHostname: container.Name,
+		Tags:              container.Tags,
+		KeepAliveInterval: 30,
+	}
+
+	log.WithFields(log.Fields{
+		"id":             container.ID,
+		"identity":       cfg.PreferredIdentity,
+		"hostname":       cfg.PreferredHostname,
+		"tenant_id":      cfg.TenantID,
+		"server_address": cfg.ServerAddress,
+		"timestamp":      time.Now(),
+		"version":        agent.AgentVersion,
+	}).Info("Connector container started")
+
+	mode, err := agent.NewConnectorMode(runtime, container.ID)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"id":             container.ID,
+			"identity":       cfg.PreferredIdentity,
+			"hostname":       cfg.PreferredHostname,
+			"tenant_id":      cfg.TenantID,
+			"server_address": cfg.ServerAddress,
+			"timestamp":      time.Now(),
+			"version":        agent.AgentVersion,
+		}).Fatal("Failed to create connector mode")
+	}
+
+	ag, err := agent.NewAgentWithConfig(cfg, mode)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"id":            container.ID,
+			"configuration": cfg,
+			"version":       agent.AgentVersion,
+		}).Fatal("Failed to create agent")
+	}
+
+	if err := ag.Initialize(); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"id":            container.ID,
+			"configuration": cfg,
+			"version":       agent.AgentVersion,
+		}).Fatal("Failed to initialize agent")
+	}
+
+	go func() {
+		if err := ag.Ping(ctx, 0); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"id":             container.ID,
+				"identity":       cfg.PreferredIdentity,
+				"hostname":       cfg.PreferredHostname,
+				"tenant_id":      cfg.TenantID,
+				"server_address": cfg.ServerAddress,
+				"timestamp":      time.Now(),
+				"version":        agent.AgentVersion,
+			}).Fatal("Failed to ping server")
+		}
+
+		log.WithFields(log.Fields{
+			"id":             container.ID,
+			"identity":       cfg.PreferredIdentity,
+			"hostname":       cfg.PreferredHostname,
+			"tenant_id":      cfg.TenantID,
+			"server_address": cfg.ServerAddress,
+			"timestamp":      time.Now(),
+			"version":        agent.AgentVersion,
+		}).Info("Stopped pinging server")
+	}()
+
+	log.WithFields(log.Fields{
+		"id":             container.ID,
+		"identity":       cfg.PreferredIdentity,
+		"hostname":       cfg.PreferredHostname,
+		"tenant_id":      cfg.TenantID,
+		"server_address": cfg.ServerAddress,
+		"timestamp":      time.Now(),
+		"version":        agent.AgentVersion,
+	}).Info("Listening for connections")
+
+	// NOTICE(r): listing for connection and wait for a channel message to close the agent. It will receives
+	// this mensagem when something out of this goroutine send a `done`, what will cause the agent closes
+	// and no more connection to be allowed until it be started again.
+	if err := ag.Listen(ctx); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"id":             container.ID,
+			"identity":       cfg.PreferredIdentity,
+			"hostname":       cfg.PreferredHostname,
+			"tenant_id":      cfg.TenantID,
+			"server_address": cfg.ServerAddress,
+			"timestamp":      time.Now(),
+			"version":        agent.AgentVersion,
+		}).Fatal("Failed to listen for connections")
+	}
+
+	log.WithFields(log.Fields{
+		"id":             container.ID,
+		"identity":       cfg.PreferredIdentity,
+		"hostname":       cfg.PreferredHostname,
+		"tenant_id":      cfg.TenantID,
+		"server_address": cfg.ServerAddress,
+		"version":        agent.AgentVersion,
+	}).Info("Connector container done")
+}
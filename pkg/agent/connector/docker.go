@@ -2,48 +2,36 @@ package connector
 
 import (
 	"context"
-	"fmt"
-	"sync"
-	"time"
 
 	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/events"
 	dockerclient "github.com/docker/docker/client"
-	"github.com/shellhub-io/shellhub/pkg/agent"
-	log "github.com/sirupsen/logrus"
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
 )
 
 var _ Connector = new(DockerConnector)
 
 // DockerConnector is a struct that represents a connector that uses Docker as the container runtime.
 type DockerConnector struct {
-	mu sync.Mutex
-	// server is the ShellHub address of the server that the agent will connect to.
-	server string
-	// tenant is the tenant ID of the namespace that the agent belongs to.
-	tenant string
 	// cli is the Docker client.
 	cli *dockerclient.Client
-	// privateKeys is the path to the directory that contains the private keys for the containers.
-	privateKeys string
-	// cancels is a map that contains the cancel functions for each container.
-	// This is used to stop the agent for a container, marking as done its context and closing the agent.
-	cancels map[string]context.CancelFunc
+	// agents starts and stops the agents for the containers.
+	agents *Agents
+	// pods defines if the containers are grouped in Podman's pods, naming the devices after them.
+	pods bool
 }
 
-// NewDockerConnector creates a new [Connector] that uses Docker as the container runtime.
-func NewDockerConnector(server string, tenant string, privateKey string) (Connector, error) {
+// NewDockerConnector creates a new [Connector] that uses Docker as the container runtime. Only the containers selected
+// by the selector are turned into devices; when it is nil, every container is.
+func NewDockerConnector(server string, tenant string, privateKey string, selector *Selector) (Connector, error) {
 	cli, err := dockerclient.NewClientWithOpts(dockerclient.FromEnv, dockerclient.WithAPIVersionNegotiation())
 	if err != nil {
 		return nil, err
 	}
 
 	return &DockerConnector{
-		server:      server,
-		tenant:      tenant,
-		cli:         cli,
-		privateKeys: privateKey,
-		cancels:     make(map[string]context.CancelFunc),
+		cli:    cli,
+		agents: NewAgents(server, tenant, privateKey, connectormode.NewDockerRuntime("docker", cli), selector),
 	}, nil
 }
 
@@ -58,62 +46,56 @@ func (d *DockerConnector) List(ctx context.Context) ([]Container, error) {
 		return nil, err
 	}
 
-	list := make([]Container, len(containers))
-	for i, container := range containers {
-		list[i].ID = container.ID
-
-		name, err := d.getContainerNameFromID(ctx, container.ID)
+	list := make([]Container, 0, len(containers))
+	for _, c := range containers {
+		container, ok, err := d.getContainerFromID(ctx, c.ID)
 		if err != nil {
 			return nil, err
 		}
 
-		list[i].Name = name
+		if !ok {
+			continue
+		}
+
+		list = append(list, container)
 	}
 
 	return list, nil
 }
 
-// Start starts the agent for the container with the given ID.
-func (d *DockerConnector) Start(ctx context.Context, id string, name string) {
-	id = id[:12]
+// Start starts the agent for the container.
+func (d *DockerConnector) Start(ctx context.Context, container Container) {
+	container.ID = container.ID[:12]
 
-	d.mu.Lock()
-	ctx, d.cancels[id] = context.WithCancel(ctx)
-	d.mu.Unlock()
-
-	privateKey := fmt.Sprintf("%s/%s.key", d.privateKeys, id)
-	go initContainerAgent(ctx, d.cli, Container{
-		ID:            id,
-		Name:          name,
-		ServerAddress: d.server,
-		Tenant:        d.tenant,
-		PrivateKey:    privateKey,
-		Cancel:        d.cancels[id],
-	})
+	d.agents.Start(ctx, container)
 }
 
 // Stop stops the agent for the container with the given ID.
 func (d *DockerConnector) Stop(_ context.Context, id string) {
-	id = id[:12]
-
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
-	cancel, ok := d.cancels[id]
-	if ok {
-		cancel()
-		delete(d.cancels, id)
-	}
+	d.agents.Stop(id[:12])
 }
 
-func (d *DockerConnector) getContainerNameFromID(ctx context.Context, id string) (string, error) {
+// getContainerFromID gets the container with the given ID. It also reports if the container should be turned into a
+// device, what isn't the case of the containers not selected nor of a Podman's pod infra container.
+func (d *DockerConnector) getContainerFromID(ctx context.Context, id string) (Container, bool, error) {
 	container, err := d.cli.ContainerInspect(ctx, id)
 	if err != nil {
-		return "", err
+		return Container{}, false, err
 	}
 
 	// NOTICE: It removes the first character on container's name that is a `/`.
-	return container.Name[1:], nil
+	name := container.Name[1:]
+
+	if d.pods {
+		var ok bool
+		if name, ok, err = podContainerName(ctx, d.cli, id, name); err != nil || !ok {
+			return Container{}, false, err
+		}
+	}
+
+	selected, ok := d.agents.Container(id, name, container.Config.Image, container.Config.Labels)
+
+	return selected, ok, nil
 }
 
 // Listen listens for events and starts or stops the agent for the containers.
@@ -124,7 +106,7 @@ func (d *DockerConnector) Listen(ctx context.Context) error {
 	}
 
 	for _, container := range containers {
-		d.Start(ctx, container.ID, container.Name)
+		d.Start(ctx, container)
 	}
 
 	events, errs := d.events(ctx)
@@ -141,128 +123,17 @@ func (d *DockerConnector) Listen(ctx context.Context) error {
 			// the "start" event will be called too. The same happens with the "die" event.
 			switch container.Action {
 			case "start":
-				name, err := d.getContainerNameFromID(ctx, container.ID)
+				selected, ok, err := d.getContainerFromID(ctx, container.ID)
 				if err != nil {
 					return err
 				}
 
-				d.Start(ctx, container.ID, name)
+				if ok {
+					d.Start(ctx, selected)
+				}
 			case "die":
 				d.Stop(ctx, container.ID)
 			}
 		}
 	}
 }
-
-// initContainerAgent initializes the agent for a container.
-func initContainerAgent(ctx context.Context, cli *dockerclient.Client, container Container) {
-	agent.AgentPlatform = "connector"
-	agent.AgentVersion = ConnectorVersion
-
-	cfg := &agent.Config{
-		ServerAddress:     container.ServerAddress,
-		TenantID:          container.Tenant,
-		PrivateKey:        container.PrivateKey,
-		PreferredIdentity: container.ID,
-		PreferredHostname: container.Name,
-		KeepAliveInterval: 30,
-	}
-
-	log.WithFields(log.Fields{
-		"id":             container.ID,
-		"identity":       cfg.PreferredIdentity,
-		"hostname":       cfg.PreferredHostname,
-		"tenant_id":      cfg.TenantID,
-		"server_address": cfg.ServerAddress,
-		"timestamp":      time.Now(),
-		"version":        agent.AgentVersion,
-	}).Info("Connector container started")
-
-	mode, err := agent.NewConnectorMode(cli, container.ID)
-	if err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"id":             container.ID,
-			"identity":       cfg.PreferredIdentity,
-			"hostname":       cfg.PreferredHostname,
-			"tenant_id":      cfg.TenantID,
-			"server_address": cfg.ServerAddress,
-			"timestamp":      time.Now(),
-			"version":        agent.AgentVersion,
-		}).Fatal("Failed to create connector mode")
-	}
-
-	ag, err := agent.NewAgentWithConfig(cfg, mode)
-	if err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"id":            container.ID,
-			"configuration": cfg,
-			"version":       agent.AgentVersion,
-		}).Fatal("Failed to create agent")
-	}
-
-	if err := ag.Initialize(); err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"id":            container.ID,
-			"configuration": cfg,
-			"version":       agent.AgentVersion,
-		}).Fatal("Failed to initialize agent")
-	}
-
-	go func() {
-		if err := ag.Ping(ctx, 0); err != nil {
-			log.WithError(err).WithFields(log.Fields{
-				"id":             container.ID,
-				"identity":       cfg.PreferredIdentity,
-				"hostname":       cfg.PreferredHostname,
-				"tenant_id":      cfg.TenantID,
-				"server_address": cfg.ServerAddress,
-				"timestamp":      time.Now(),
-				"version":        agent.AgentVersion,
-			}).Fatal("Failed to ping server")
-		}
-
-		log.WithFields(log.Fields{
-			"id":             container.ID,
-			"identity":       cfg.PreferredIdentity,
-			"hostname":       cfg.PreferredHostname,
-			"tenant_id":      cfg.TenantID,
-			"server_address": cfg.ServerAddress,
-			"timestamp":      time.Now(),
-			"version":        agent.AgentVersion,
-		}).Info("Stopped pinging server")
-	}()
-
-	log.WithFields(log.Fields{
-		"id":             container.ID,
-		"identity":       cfg.PreferredIdentity,
-		"hostname":       cfg.PreferredHostname,
-		"tenant_id":      cfg.TenantID,
-		"server_address": cfg.ServerAddress,
-		"timestamp":      time.Now(),
-		"version":        agent.AgentVersion,
-	}).Info("Listening for connections")
-
-	// NOTICE(r): listing for connection and wait for a channel message to close the agent. It will receives
-	// this mensagem when something out of this goroutine send a `done`, what will cause the agent closes
-	// and no more connection to be allowed until it be started again.
-	if err := ag.Listen(ctx); err != nil {
-		log.WithError(err).WithFields(log.Fields{
-			"id":             container.ID,
-			"identity":       cfg.PreferredIdentity,
-			"hostname":       cfg.PreferredHostname,
-			"tenant_id":      cfg.TenantID,
-			"server_address": cfg.ServerAddress,
-			"timestamp":      time.Now(),
-			"version":        agent.AgentVersion,
-		}).Fatal("Failed to listen for connections")
-	}
-
-	log.WithFields(log.Fields{
-		"id":             container.ID,
-		"identity":       cfg.PreferredIdentity,
-		"hostname":       cfg.PreferredHostname,
-		"tenant_id":      cfg.TenantID,
-		"server_address": cfg.ServerAddress,
-		"version":        agent.AgentVersion,
-	}).Info("Connector container done")
-}
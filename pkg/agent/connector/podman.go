@@ -0,0 +1,113 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	dockerclient "github.com/docker/docker/client"
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+)
+
+// DefaultPodmanSocket is the default address of the rootful Podman's API socket.
+const DefaultPodmanSocket = "unix:///run/podman/podman.sock"
+
+// libpodVersion is the version of the Podman's own API, served alongside the Docker-compatible one.
+const libpodVersion = "v4.0.0"
+
+// NewPodmanConnector creates a new [Connector] that uses Podman as the container runtime, through its
+// Docker-compatible API served at socket. The pods' infra containers aren't turned into devices, and the devices of
+// the containers inside a pod are named after it. Only the containers selected by the selector are turned into
+// devices; when it is nil, every container is.
+func NewPodmanConnector(server string, tenant string, privateKey string, socket string, selector *Selector) (Connector, error) {
+	if socket == "" {
+		socket = DefaultPodmanSocket
+	}
+
+	cli, err := dockerclient.NewClientWithOpts(dockerclient.WithHost(socket), dockerclient.WithAPIVersionNegotiation())
+	if err != nil {
+		return nil, err
+	}
+
+	return &DockerConnector{
+		cli:    cli,
+		agents: NewAgents(server, tenant, privateKey, connectormode.NewDockerRuntime("podman", cli), selector),
+		pods:   true,
+	}, nil
+}
+
+// libpodGet gets the resource at path from the Podman's own API, decoding it into v.
+func libpodGet(ctx context.Context, cli *dockerclient.Client, path string, v interface{}) error {
+	host, err := dockerclient.ParseHostURL(cli.DaemonHost())
+	if err != nil {
+		return err
+	}
+
+	base := url.URL{Scheme: "http", Host: host.Host}
+	// NOTICE: When the API is served through a socket, the host is ignored by the client's dialer.
+	if host.Scheme == "unix" || host.Scheme == "npipe" {
+		base.Host = "podman"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base.String()+"/"+libpodVersion+"/libpod"+path, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := cli.HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("podman's API replied with %s to %s", res.Status, path)
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
+
+// podContainerName gets the name of the device for the Podman's container with the given ID and name. When the
+// container is inside a pod, the device is named after the pod, unless the container's name already starts with it.
+// The pod's infra container, what only holds the pod's namespaces, isn't turned into a device.
+func podContainerName(ctx context.Context, cli *dockerclient.Client, id string, name string) (string, bool, error) {
+	container := new(struct {
+		Pod     string `json:"Pod"`
+		IsInfra bool   `json:"IsInfra"`
+	})
+
+	if err := libpodGet(ctx, cli, "/containers/"+id+"/json", container); err != nil {
+		return "", false, err
+	}
+
+	if container.IsInfra {
+		return "", false, nil
+	}
+
+	if container.Pod == "" {
+		return name, true, nil
+	}
+
+	pod := new(struct {
+		Name string `json:"Name"`
+	})
+
+	if err := libpodGet(ctx, cli, "/pods/"+container.Pod+"/json", pod); err != nil {
+		return "", false, err
+	}
+
+	return PodDeviceName(pod.Name, name), true, nil
+}
+
+// PodDeviceName names the device of the container inside the pod.
+func PodDeviceName(pod string, container string) string {
+	if pod == "" || strings.HasPrefix(container, pod+"-") {
+		return container
+	}
+
+	return pod + "-" + container
+}
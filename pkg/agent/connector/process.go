@@ -0,0 +1,67 @@
+package connector
+
+import (
+	"io"
+
+	connectormode "github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
+)
+
+// NOTICE: Ensures the Process interface is implemented.
+var _ connectormode.Process = (*PipedProcess)(nil)
+
+// PipedProcess is a command started inside a container whose streams are piped by the connector to the runtime.
+type PipedProcess struct {
+	stdin  io.WriteCloser
+	stdout io.Reader
+	stderr io.Reader
+	// done is closed when the process exits, after its output is written.
+	done chan struct{}
+	code int
+	err  error
+	// release releases the process' resources on the runtime.
+	release func() error
+}
+
+// NewPipedProcess creates a [PipedProcess] whose input is written to stdin, and whose output is read from stdout and
+// stderr. The release function is called when the process is closed.
+func NewPipedProcess(stdin io.WriteCloser, stdout io.Reader, stderr io.Reader, release func() error) *PipedProcess {
+	return &PipedProcess{
+		stdin:   stdin,
+		stdout:  stdout,
+		stderr:  stderr,
+		done:    make(chan struct{}),
+		release: release,
+	}
+}
+
+// Exit sets the process' exit code and error, releasing who waits for it. It must be called once, after the process'
+// output is written.
+func (p *PipedProcess) Exit(code int, err error) {
+	p.code, p.err = code, err
+
+	close(p.done)
+}
+
+func (p *PipedProcess) Stdin() io.WriteCloser {
+	return p.stdin
+}
+
+func (p *PipedProcess) Stdout() io.Reader {
+	return p.stdout
+}
+
+func (p *PipedProcess) Stderr() io.Reader {
+	return p.stderr
+}
+
+func (p *PipedProcess) Wait() (int, error) {
+	<-p.done
+
+	return p.code, p.err
+}
+
+func (p *PipedProcess) Close() error {
+	p.stdin.Close()
+
+	return p.release()
+}
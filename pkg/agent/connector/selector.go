@@ -0,0 +1,141 @@
+package connector
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Labels read from the containers by the connector.
+const (
+	// LabelEnable excludes the container from the connector when it is `false`.
+	LabelEnable = "io.shellhub.enable"
+	// LabelName is the name of the container's device, used instead of the container's name.
+	LabelName = "io.shellhub.name"
+	// LabelTags are the comma-separated tags applied to the container's device when it is registered.
+	LabelTags = "io.shellhub.tags"
+	// LabelComposeProject is the Docker Compose's project of the container.
+	LabelComposeProject = "com.docker.compose.project"
+)
+
+// maxTags is the maximum number of tags of a device.
+const maxTags = 3
+
+// tagPattern is the format of a device's tag.
+var tagPattern = regexp.MustCompile(`^[a-zA-Z0-9]{3,255}$`)
+
+// Selector selects the containers turned into devices by the connector. A container is selected when it matches no
+// exclude rule and, for each kind of include rule that is set, at least one of them. The containers with the
+// [LabelEnable] label set to `false` are never selected.
+type Selector struct {
+	// IncludeLabels are the labels, as `key` or `key=value`, of the selected containers.
+	IncludeLabels []string
+	// ExcludeLabels are the labels, as `key` or `key=value`, of the containers not selected.
+	ExcludeLabels []string
+	// IncludeImages are the patterns, like `nginx:*` or `registry.example.com/*`, of the selected containers' images.
+	IncludeImages []string
+	// ExcludeImages are the patterns of the images of the containers not selected.
+	ExcludeImages []string
+	// IncludeProjects are the Docker Compose's projects of the selected containers.
+	IncludeProjects []string
+	// ExcludeProjects are the Docker Compose's projects of the containers not selected.
+	ExcludeProjects []string
+}
+
+// Selects checks if the container with the image and labels is selected.
+func (s *Selector) Selects(image string, labels map[string]string) bool {
+	if labels[LabelEnable] == "false" {
+		return false
+	}
+
+	project, inProject := labels[LabelComposeProject]
+
+	if matchLabels(s.ExcludeLabels, labels) || matchImages(s.ExcludeImages, image) || (inProject && contains(s.ExcludeProjects, project)) {
+		return false
+	}
+
+	if len(s.IncludeLabels) > 0 && !matchLabels(s.IncludeLabels, labels) {
+		return false
+	}
+
+	if len(s.IncludeImages) > 0 && !matchImages(s.IncludeImages, image) {
+		return false
+	}
+
+	if len(s.IncludeProjects) > 0 && (!inProject || !contains(s.IncludeProjects, project)) {
+		return false
+	}
+
+	return true
+}
+
+// matchLabels checks if any of the rules, as `key` or `key=value`, matches the labels.
+func matchLabels(rules []string, labels map[string]string) bool {
+	for _, rule := range rules {
+		key, value, hasValue := strings.Cut(rule, "=")
+
+		if current, ok := labels[key]; ok && (!hasValue || current == value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchImages checks if any of the patterns matches the image.
+func matchImages(patterns []string, image string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, image); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(list []string, item string) bool {
+	for _, i := range list {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
+}
+
+// containerTags gets the tags of the container's device from its [LabelTags] label, dropping the invalid ones and
+// the ones above the device's limit.
+func containerTags(id string, labels map[string]string) []string {
+	value, ok := labels[LabelTags]
+	if !ok {
+		return nil
+	}
+
+	return ValidTags(id, strings.Split(value, ","))
+}
+
+// ValidTags drops the invalid and duplicated tags of the container's device, and the ones above the device's limit.
+func ValidTags(id string, candidates []string) []string {
+	tags := []string{}
+	for _, tag := range candidates {
+		tag = strings.TrimSpace(tag)
+
+		switch {
+		case tag == "" || contains(tags, tag):
+			continue
+		case !tagPattern.MatchString(tag) || len(tags) == maxTags:
+			log.WithFields(log.Fields{
+				"id":  id,
+				"tag": tag,
+			}).Warn("Ignoring the container's tag, what is invalid or above the device's limit")
+
+			continue
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
@@ -0,0 +1,120 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectorSelects(t *testing.T) {
+	cases := []struct {
+		description string
+		selector    Selector
+		image       string
+		labels      map[string]string
+		expected    bool
+	}{
+		{
+			description: "selects every container when no rule is set",
+			selector:    Selector{},
+			image:       "nginx:latest",
+			labels:      map[string]string{},
+			expected:    true,
+		},
+		{
+			description: "does not select the container disabled by its label",
+			selector:    Selector{},
+			image:       "nginx:latest",
+			labels:      map[string]string{LabelEnable: "false"},
+			expected:    false,
+		},
+		{
+			description: "selects the container with the included label's key",
+			selector:    Selector{IncludeLabels: []string{"shellhub"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{"shellhub": "yes"},
+			expected:    true,
+		},
+		{
+			description: "does not select the container with another value of the included label",
+			selector:    Selector{IncludeLabels: []string{"env=production"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{"env": "staging"},
+			expected:    false,
+		},
+		{
+			description: "does not select the container with an excluded label",
+			selector:    Selector{ExcludeLabels: []string{"env=staging"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{"env": "staging"},
+			expected:    false,
+		},
+		{
+			description: "selects the container whose image matches the included pattern",
+			selector:    Selector{IncludeImages: []string{"nginx:*"}},
+			image:       "nginx:1.25",
+			labels:      map[string]string{},
+			expected:    true,
+		},
+		{
+			description: "does not select the container whose image matches the excluded pattern",
+			selector:    Selector{ExcludeImages: []string{"registry.example.com/*"}},
+			image:       "registry.example.com/database",
+			labels:      map[string]string{},
+			expected:    false,
+		},
+		{
+			description: "selects the container of the included project",
+			selector:    Selector{IncludeProjects: []string{"web"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{LabelComposeProject: "web"},
+			expected:    true,
+		},
+		{
+			description: "does not select the container out of any project when projects are included",
+			selector:    Selector{IncludeProjects: []string{"web"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{},
+			expected:    false,
+		},
+		{
+			description: "does not select the container of the excluded project",
+			selector:    Selector{ExcludeProjects: []string{"web"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{LabelComposeProject: "web"},
+			expected:    false,
+		},
+		{
+			description: "requires every kind of included rule to match",
+			selector:    Selector{IncludeLabels: []string{"shellhub"}, IncludeImages: []string{"redis:*"}},
+			image:       "nginx:latest",
+			labels:      map[string]string{"shellhub": "yes"},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.selector.Selects(tc.image, tc.labels))
+		})
+	}
+}
+
+func TestAgentsContainer(t *testing.T) {
+	a := NewAgents("http://localhost", "tenant", "/tmp", nil, &Selector{ExcludeImages: []string{"redis:*"}})
+
+	t.Run("does not get the container not selected", func(t *testing.T) {
+		_, ok := a.Container("3f1e2d", "cache", "redis:7", map[string]string{})
+		assert.False(t, ok)
+	})
+
+	t.Run("gets the device's name and tags from the container's labels", func(t *testing.T) {
+		container, ok := a.Container("3f1e2d", "web", "nginx:latest", map[string]string{
+			LabelName: "frontend",
+			LabelTags: "web, production,web,a,invalid-tag,edge,extra",
+		})
+
+		assert.True(t, ok)
+		assert.Equal(t, Container{ID: "3f1e2d", Name: "frontend", Tags: []string{"web", "production", "edge"}}, container)
+	})
+}
@@ -0,0 +1,243 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultControlSocket is the default path to the agent's control socket.
+const DefaultControlSocket = "/run/shellhub-agent.sock"
+
+// Paths served by the agent's control socket.
+const (
+	ControlStatusPath    = "/status"
+	ControlInfoPath      = "/info"
+	ControlReconnectPath = "/reconnect"
+)
+
+// Status is the state of the agent's connection to the server, served by the control socket.
+type Status struct {
+	Connected bool `json:"connected"`
+	// ConnectedAt is when the current connection to the server was established.
+	ConnectedAt *time.Time `json:"connected_at"`
+	// LastHeartbeat is when the agent was last authorized by the server, what it does periodically.
+	LastHeartbeat *time.Time `json:"last_heartbeat"`
+	// ReconnectAttempts is the number of attempts the agent has made to reconnect to the server since it started.
+	ReconnectAttempts uint64 `json:"reconnect_attempts"`
+	// Sessions are the IDs of the sessions opened on the device.
+	Sessions []string `json:"sessions"`
+}
+
+// AgentInfo is the identity and configuration of the agent, served by the control socket.
+type AgentInfo struct {
+	Version   string       `json:"version"`
+	UID       string       `json:"uid"`
+	Name      string       `json:"name"`
+	Namespace string       `json:"namespace"`
+	TenantID  string       `json:"tenant_id"`
+	Server    *models.Info `json:"server"`
+	// Config is the agent's configuration, without its secrets.
+	Config Config `json:"config"`
+}
+
+// Status gets the state of the agent's connection to the server.
+func (a *Agent) Status() *Status {
+	a.mux.RLock()
+	defer a.mux.RUnlock()
+
+	status := &Status{
+		Connected:         a.listener != nil,
+		ReconnectAttempts: a.ReconnectAttempts(),
+		Sessions:          []string{},
+	}
+
+	if a.listener != nil {
+		connectedAt := a.connectedAt
+		status.ConnectedAt = &connectedAt
+	}
+
+	if !a.heartbeat.IsZero() {
+		heartbeat := a.heartbeat
+		status.LastHeartbeat = &heartbeat
+	}
+
+	if a.server != nil {
+		status.Sessions = a.activeSessions()
+	}
+
+	return status
+}
+
+// GetAgentInfo gets the identity and configuration of the agent.
+func (a *Agent) GetAgentInfo() *AgentInfo {
+	info := &AgentInfo{
+		Version: AgentVersion,
+		Config:  a.settings(),
+	}
+
+	info.TenantID = info.Config.TenantID
+
+	a.mux.RLock()
+	info.Server = a.serverInfo
+
+	if auth := a.authData; auth != nil {
+		info.UID = auth.UID
+		info.Name = auth.Name
+		info.Namespace = auth.Namespace
+	}
+	a.mux.RUnlock()
+
+	if info.Config.SingleUserPassword != "" {
+		info.Config.SingleUserPassword = "[redacted]"
+	}
+
+	if info.Config.EnrollmentToken != "" {
+		info.Config.EnrollmentToken = "[redacted]"
+	}
+
+	if proxy, err := url.Parse(info.Config.Proxy); err == nil {
+		info.Config.Proxy = proxy.Redacted()
+	}
+
+	return info
+}
+
+// ServeControl serves the agent's control socket at path, a Unix socket through which the local tools inspect and
+// control the running agent, until the context is done. The socket is only accessible by the agent's user.
+func (a *Agent) ServeControl(ctx context.Context, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	// NOTICE: The socket is left behind when the agent isn't stopped gracefully, what keeps it from listening again.
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	// NOTICE: The socket is created only accessible by the agent's user, through the umask, so no one else can connect
+	// to it before its mode is set. The umask is process-wide, so it is restored right after.
+	umask := syscall.Umask(0o177)
+	listener, err := net.Listen("unix", path)
+	syscall.Umask(umask)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+
+		return err
+	}
+
+	server := &http.Server{Handler: a.controlHandler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+
+		server.Close() // nolint:errcheck
+	}()
+
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func (a *Agent) controlHandler() http.Handler {
+	reply := func(w http.ResponseWriter, data interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(data); err != nil {
+			log.WithError(err).Error("Failed to reply to the control socket")
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(ControlStatusPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		reply(w, a.Status())
+	})
+
+	mux.HandleFunc(ControlInfoPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		reply(w, a.GetAgentInfo())
+	})
+
+	mux.HandleFunc(ControlReconnectPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		log.WithFields(log.Fields{
+			"version": AgentVersion,
+		}).Info("Reconnecting to the server as requested through the control socket")
+
+		a.Reconnect()
+
+		reply(w, a.Status())
+	})
+
+	return mux
+}
+
+// RequestControl requests the path to the agent's control socket at socket, returning the response's body.
+func RequestControl(socket, method, path string) ([]byte, error) {
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return new(net.Dialer).DialContext(ctx, "unix", socket)
+			},
+		},
+		Timeout: 10 * time.Second,
+	}
+
+	req, err := http.NewRequest(method, "http://agent"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the agent's control socket, is the agent running? %w", err)
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("agent's control socket replied with %s", res.Status)
+	}
+
+	return body, nil
+}
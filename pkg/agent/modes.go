@@ -4,24 +4,28 @@ import (
 	"context"
 	"os/exec"
 
-	dockerclient "github.com/docker/docker/client"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/provisioner"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/sysinfo"
 	"github.com/shellhub-io/shellhub/pkg/agent/server"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/connector"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
 )
 
 type Info struct {
 	ID   string
 	Name string
+	// Inventory is the device's hardware and software inventory, when the mode is able to collect it.
+	Inventory *models.DeviceInventory
 }
 
 // Mode is the Agent execution mode.
 //
 // The Agent can be executed in two different modes: `Host` and `Connector`.
 // The `Host` mode is the default one, where the agent will listen for incoming connections and use the host device as
-// source of any information needed to start itself. When running in `Connector` mode, it uses the Docker engine as this
-// source.
+// source of any information needed to start itself. When running in `Connector` mode, it uses the container runtime, like
+// Docker, Podman or containerd, as this source.
 //
 // Check [HostMode] and [ConnectorMode] for more information.
 type Mode interface {
@@ -30,7 +34,7 @@ type Mode interface {
 	// GetInfo gets information about Agent according to Agent's mode.
 	//
 	// When Agent is running on [HostMode], the info got is from the system where the Agent is running, but when running
-	// in [ConnectorMode], the data is retrieved from Docker Engine.
+	// in [ConnectorMode], the data is retrieved from the container runtime.
 	GetInfo() (*Info, error)
 }
 
@@ -43,6 +47,16 @@ type HostMode struct{}
 var _ Mode = new(HostMode)
 
 func (m *HostMode) Serve(agent *Agent) {
+	var prov *provisioner.Provisioner
+	if agent.config.ProvisioningFile != "" {
+		var err error
+		if prov, err = provisioner.Load(agent.config.ProvisioningFile); err != nil {
+			log.WithError(err).Error("Failed to load the provisioning configuration, the provisioning is disabled")
+		} else {
+			go prov.Run(context.Background())
+		}
+	}
+
 	agent.server = server.NewServer(
 		agent.cli,
 		agent.authData,
@@ -50,8 +64,8 @@ func (m *HostMode) Serve(agent *Agent) {
 		agent.config.KeepAliveInterval,
 		agent.config.SingleUserPassword,
 		&host.Mode{
-			Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name),
-			Sessioner:     *host.NewSessioner(&agent.authData.Name, make(map[string]*exec.Cmd)),
+			Authenticator: *host.NewAuthenticator(agent.cli, agent.authData, agent.config.SingleUserPassword, &agent.authData.Name, prov),
+			Sessioner:     *host.NewSessioner(&agent.authData.Name, make(map[string]*exec.Cmd), agent.loadPolicy, prov, agent.jobsDisabled),
 		},
 	)
 
@@ -65,8 +79,9 @@ func (m *HostMode) GetInfo() (*Info, error) {
 	}
 
 	return &Info{
-		ID:   osrelease.ID,
-		Name: osrelease.Name,
+		ID:        osrelease.ID,
+		Name:      osrelease.Name,
+		Inventory: sysinfo.GetInventory(),
 	}, nil
 }
 
@@ -76,13 +91,15 @@ func (m *HostMode) GetInfo() (*Info, error) {
 // responsible for the SSH server, but the authentication and authorization is made by either the conainer
 // internals, `passwd` or `shadow`, or by the ShellHub API.
 type ConnectorMode struct {
-	cli      *dockerclient.Client
+	runtime  connector.Runtime
 	identity string
 }
 
-func NewConnectorMode(cli *dockerclient.Client, identity string) (Mode, error) {
+// NewConnectorMode creates the connector mode to the container identified by identity, reached through the container
+// runtime.
+func NewConnectorMode(runtime connector.Runtime, identity string) (Mode, error) {
 	return &ConnectorMode{
-		cli:      cli,
+		runtime:  runtime,
 		identity: identity,
 	}, nil
 }
@@ -101,8 +118,8 @@ func (m *ConnectorMode) Serve(agent *Agent) {
 		agent.config.KeepAliveInterval,
 		agent.config.SingleUserPassword,
 		&connector.Mode{
-			Authenticator: *connector.NewAuthenticator(agent.cli, m.cli, agent.authData, &agent.Identity.MAC),
-			Sessioner:     *connector.NewSessioner(&agent.Identity.MAC, m.cli),
+			Authenticator: *connector.NewAuthenticator(agent.cli, m.runtime, agent.authData, &agent.Identity.MAC),
+			Sessioner:     *connector.NewSessioner(&agent.Identity.MAC, m.runtime),
 		},
 	)
 
@@ -111,13 +128,13 @@ func (m *ConnectorMode) Serve(agent *Agent) {
 }
 
 func (m *ConnectorMode) GetInfo() (*Info, error) {
-	info, err := m.cli.ContainerInspect(context.Background(), m.identity)
+	image, err := m.runtime.Image(context.Background(), m.identity)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Info{
-		ID:   "docker",
-		Name: info.Config.Image,
+		ID:   m.runtime.Name(),
+		Name: image,
 	}, nil
 }
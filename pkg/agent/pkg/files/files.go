@@ -0,0 +1,221 @@
+// Package files implements the agent's file browser, what allows the files on the device to be managed through a REST
+// API served over the tunnel.
+//
+// The operations are performed by the agent's SFTP server, started as the impersonated user, so the file browser is
+// subject to the same permissions of a SFTP session.
+package files
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/sftp"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Actions supported by the file browser.
+const (
+	ActionList     = "list"
+	ActionStat     = "stat"
+	ActionDownload = "download"
+	ActionUpload   = "upload"
+	ActionMkdir    = "mkdir"
+	ActionDelete   = "delete"
+)
+
+// ErrInvalidAction is returned when the action, or the method used to request it, is not supported.
+var ErrInvalidAction = errors.New("invalid file browser action")
+
+// Browser performs the file operations as a user of the device.
+type Browser struct {
+	cmd    *exec.Cmd
+	client *sftp.Client
+}
+
+// Open starts the agent's SFTP server as the user and connects the browser to it.
+func Open(user *osauth.User) (*Browser, error) {
+	cmd := exec.Command("/proc/self/exe", "sftp")
+	cmd.Env = []string{
+		fmt.Sprintf("HOME=%s", user.HomeDir),
+		fmt.Sprintf("GID=%d", user.GID),
+		fmt.Sprintf("UID=%d", user.UID),
+	}
+
+	input, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	client, err := sftp.NewClientPipe(output, input)
+	if err != nil {
+		cmd.Process.Kill() // nolint:errcheck
+		cmd.Wait()         // nolint:errcheck
+
+		return nil, err
+	}
+
+	return &Browser{cmd: cmd, client: client}, nil
+}
+
+// Close disconnects the browser, stopping the SFTP server.
+func (b *Browser) Close() error {
+	err := b.client.Close()
+	if b.cmd != nil {
+		b.cmd.Wait() // nolint:errcheck
+	}
+
+	return err
+}
+
+// List lists the entries of the directory at name.
+func (b *Browser) List(name string) ([]models.FileInfo, error) {
+	entries, err := b.client.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]models.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		infos = append(infos, fileInfo(entry))
+	}
+
+	return infos, nil
+}
+
+// Stat describes the file at name.
+func (b *Browser) Stat(name string) (*models.FileInfo, error) {
+	info, err := b.client.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+
+	described := fileInfo(info)
+
+	return &described, nil
+}
+
+// Download copies the file at name to w.
+func (b *Browser) Download(name string, w io.Writer) error {
+	file, err := b.client.Open(name)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = file.WriteTo(w)
+
+	return err
+}
+
+// Upload writes the content read from r to the file at name, creating or truncating it.
+func (b *Browser) Upload(name string, r io.Reader) error {
+	file, err := b.client.Create(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.ReadFrom(r); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// Mkdir creates the directory at name, and its parents when needed.
+func (b *Browser) Mkdir(name string) error {
+	return b.client.MkdirAll(name)
+}
+
+// Delete removes the file, or empty directory, at name.
+func (b *Browser) Delete(name string) error {
+	return b.client.Remove(name)
+}
+
+func fileInfo(info os.FileInfo) models.FileInfo {
+	return models.FileInfo{
+		Name:    info.Name(),
+		Size:    info.Size(),
+		Mode:    info.Mode().String(),
+		Dir:     info.IsDir(),
+		ModTime: info.ModTime(),
+	}
+}
+
+// Serve handles the file browser's request, performing the action, got from the route's `action` parameter, on the
+// file at the `path` query parameter.
+func (b *Browser) Serve(c echo.Context) error {
+	name := c.QueryParam("path")
+	if name == "" {
+		return c.String(http.StatusBadRequest, "path is required")
+	}
+
+	action, method := c.Param("action"), c.Request().Method
+
+	var err error
+
+	switch {
+	case action == ActionList && method == http.MethodGet:
+		var infos []models.FileInfo
+		if infos, err = b.List(name); err == nil {
+			return c.JSON(http.StatusOK, infos)
+		}
+	case action == ActionStat && method == http.MethodGet:
+		var info *models.FileInfo
+		if info, err = b.Stat(name); err == nil {
+			return c.JSON(http.StatusOK, info)
+		}
+	case action == ActionDownload && method == http.MethodGet:
+		// NOTICE: The file is stated before writing the response, as any error after it cannot change the status.
+		if _, err = b.Stat(name); err == nil {
+			c.Response().Header().Set(echo.HeaderContentType, echo.MIMEOctetStream)
+			c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", path.Base(name)))
+			c.Response().WriteHeader(http.StatusOK)
+
+			return b.Download(name, c.Response())
+		}
+	case action == ActionUpload && method == http.MethodPut:
+		if err = b.Upload(name, c.Request().Body); err == nil {
+			return c.NoContent(http.StatusOK)
+		}
+	case action == ActionMkdir && method == http.MethodPost:
+		if err = b.Mkdir(name); err == nil {
+			return c.NoContent(http.StatusOK)
+		}
+	case action == ActionDelete && method == http.MethodDelete:
+		if err = b.Delete(name); err == nil {
+			return c.NoContent(http.StatusOK)
+		}
+	default:
+		err = ErrInvalidAction
+	}
+
+	switch {
+	case errors.Is(err, ErrInvalidAction):
+		return c.String(http.StatusMethodNotAllowed, err.Error())
+	case errors.Is(err, os.ErrNotExist):
+		return c.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, os.ErrPermission):
+		return c.String(http.StatusForbidden, err.Error())
+	default:
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,98 @@
+package files
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBrowser(t *testing.T) *Browser {
+	t.Helper()
+
+	server, client := net.Pipe()
+
+	srv, err := sftp.NewServer(server)
+	require.NoError(t, err)
+
+	go srv.Serve() // nolint:errcheck
+
+	cli, err := sftp.NewClientPipe(client, client)
+	require.NoError(t, err)
+
+	browser := &Browser{client: cli}
+	t.Cleanup(func() {
+		browser.Close()
+		srv.Close()
+	})
+
+	return browser
+}
+
+func serve(t *testing.T, browser *Browser, method, action, name, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	e := echo.New()
+
+	req := httptest.NewRequest(method, "/ssh/files/"+action+"?path="+name, strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	c := e.NewContext(req, rec)
+	c.SetParamNames("action")
+	c.SetParamValues(action)
+
+	require.NoError(t, browser.Serve(c))
+
+	return rec
+}
+
+func TestServe(t *testing.T) {
+	browser := newTestBrowser(t)
+	dir := t.TempDir()
+
+	rec := serve(t, browser, http.MethodPost, ActionMkdir, filepath.Join(dir, "a", "b"), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.DirExists(t, filepath.Join(dir, "a", "b"))
+
+	rec = serve(t, browser, http.MethodPut, ActionUpload, filepath.Join(dir, "a", "file.txt"), "content")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	data, err := os.ReadFile(filepath.Join(dir, "a", "file.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+
+	rec = serve(t, browser, http.MethodGet, ActionList, filepath.Join(dir, "a"), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"name":"b","size":`)
+	assert.Contains(t, rec.Body.String(), `"name":"file.txt","size":7`)
+
+	rec = serve(t, browser, http.MethodGet, ActionStat, filepath.Join(dir, "a", "file.txt"), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"dir":false`)
+
+	rec = serve(t, browser, http.MethodGet, ActionDownload, filepath.Join(dir, "a", "file.txt"), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "content", rec.Body.String())
+	assert.Equal(t, `attachment; filename="file.txt"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+	rec = serve(t, browser, http.MethodDelete, ActionDelete, filepath.Join(dir, "a", "file.txt"), "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NoFileExists(t, filepath.Join(dir, "a", "file.txt"))
+
+	rec = serve(t, browser, http.MethodGet, ActionDownload, filepath.Join(dir, "a", "file.txt"), "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serve(t, browser, http.MethodGet, ActionDelete, filepath.Join(dir, "a"), "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = serve(t, browser, http.MethodGet, ActionList, "", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
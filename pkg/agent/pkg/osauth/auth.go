@@ -28,7 +28,30 @@ type OSAuther interface {
 
 type OSAuth struct{}
 
+var (
+	// DefaultPAMService is the PAM service used to authenticate the users and open their sessions.
+	DefaultPAMService = "sshd"
+	// DefaultPAMConfigDir is the directory where the PAM services are configured.
+	DefaultPAMConfigDir = "/etc/pam.d"
+)
+
+// ErrPAMUnavailable is returned when the agent wasn't built with the `pam` tag or the PAM service isn't configured on
+// the device.
+var ErrPAMUnavailable = errors.New("PAM is unavailable")
+
+// ErrPAMAccount is returned when the user's account is refused by the PAM account modules, like when it is expired or
+// locked.
+var ErrPAMAccount = errors.New("account is not valid to PAM")
+
+// AuthUser checks if the username and password are valid. When the agent is built with the `pam` tag, the
+// authentication is done through the PAM service, honoring the modules configured to it, like LDAP, SSSD and the
+// account expiration; otherwise, or if PAM is unavailable on the device, the password is checked against the shadow
+// file.
 func (l *OSAuth) AuthUser(username, password string) bool {
+	if ok, err := authUserPAM(username, password); !errors.Is(err, ErrPAMUnavailable) {
+		return ok
+	}
+
 	shadow, err := os.Open(DefaultShadowFilename)
 	if err != nil {
 		logrus.WithError(err).Error("Could not open /etc/shadow")
@@ -103,6 +126,19 @@ func (l *OSAuth) VerifyPasswordHash(hash, password string) bool {
 	return true
 }
 
+// OpenSession opens a PAM session to the user, running its session modules, and calls start to start the session's
+// process with the environment variables defined by them. The limits set by the session modules, like pam_limits, are
+// inherited by the process started. The returned function closes the session and must be called when the process
+// exits.
+//
+// Before the session is opened, the user's account is checked by the account modules, whatever the method the user was
+// authenticated by, and ErrPAMAccount is returned when it is refused.
+//
+// When PAM is unavailable, start is called without any variable and the returned function does nothing.
+func OpenSession(username string, start func(env []string) error) (func(), error) {
+	return openSession(username, start)
+}
+
 // ErrUserNotFound is returned when the user is not found in the passwd file.
 var ErrUserNotFound = errors.New("user not found")
 
@@ -32,3 +32,22 @@ func TestVerifyPasswordHashMD5Pass(t *testing.T) {
 
 	assert.True(t, result)
 }
+
+func TestOpenSessionWithoutPAM(t *testing.T) {
+	dir := DefaultPAMConfigDir
+	DefaultPAMConfigDir = t.TempDir()
+	t.Cleanup(func() { DefaultPAMConfigDir = dir })
+
+	started := false
+	closeSession, err := OpenSession("root", func(env []string) error {
+		started = true
+
+		assert.Empty(t, env)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, started)
+	assert.NotNil(t, closeSession)
+}
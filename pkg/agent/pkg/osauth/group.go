@@ -0,0 +1,58 @@
+package osauth
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+var DefaultGroupFilename = "/etc/group"
+
+// LookupGroupsFromGroup reads the group file from the given reader and returns the names of the groups the user is
+// member of, including its primary group.
+func (l *OSAuth) LookupGroupsFromGroup(user *User, group io.Reader) ([]string, error) {
+	groups := []string{}
+
+	lines := bufio.NewScanner(group)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// The group's line format is `name:password:gid:member,member`.
+		parts := strings.Split(line, ":")
+		if len(parts) != 4 {
+			continue
+		}
+
+		if gid, err := strconv.Atoi(parts[2]); err == nil && uint32(gid) == user.GID {
+			groups = append(groups, parts[0])
+
+			continue
+		}
+
+		for _, member := range strings.Split(parts[3], ",") {
+			if strings.TrimSpace(member) == user.Username {
+				groups = append(groups, parts[0])
+
+				break
+			}
+		}
+	}
+
+	return groups, lines.Err()
+}
+
+// LookupGroups returns the names of the groups the user is member of.
+func (l *OSAuth) LookupGroups(user *User) ([]string, error) {
+	group, err := os.Open(DefaultGroupFilename)
+	if err != nil {
+		return nil, err
+	}
+	defer group.Close()
+
+	return l.LookupGroupsFromGroup(user, group)
+}
@@ -0,0 +1,48 @@
+package osauth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupGroupsFromGroup(t *testing.T) {
+	group := `# comment
+root:x:0:
+wheel:x:10:root,user
+users:x:100:
+docker:x:998:other, user
+invalid
+`
+
+	cases := []struct {
+		description string
+		user        *User
+		expected    []string
+	}{
+		{
+			description: "returns the primary group",
+			user:        &User{Username: "root", GID: 0},
+			expected:    []string{"root", "wheel"},
+		},
+		{
+			description: "returns the primary and supplementary groups",
+			user:        &User{Username: "user", GID: 100},
+			expected:    []string{"wheel", "users", "docker"},
+		},
+		{
+			description: "returns no groups when user is not member of any",
+			user:        &User{Username: "nobody", GID: 65534},
+			expected:    []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			groups, err := new(OSAuth).LookupGroupsFromGroup(tc.user, strings.NewReader(group))
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, groups)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+//go:build pam
+// +build pam
+
+package osauth
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/msteinert/pam/v2"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// limits are the resources whose limits could be changed by the session modules, like pam_limits.
+var limits = []int{
+	unix.RLIMIT_AS,
+	unix.RLIMIT_CORE,
+	unix.RLIMIT_CPU,
+	unix.RLIMIT_DATA,
+	unix.RLIMIT_FSIZE,
+	unix.RLIMIT_MEMLOCK,
+	unix.RLIMIT_MSGQUEUE,
+	unix.RLIMIT_NICE,
+	unix.RLIMIT_NOFILE,
+	unix.RLIMIT_NPROC,
+	unix.RLIMIT_RTPRIO,
+	unix.RLIMIT_SIGPENDING,
+	unix.RLIMIT_STACK,
+}
+
+// sessionMu serializes the sessions' opening, as the limits set by the session modules are applied to the agent's
+// process until the session's process is started.
+var sessionMu sync.Mutex
+
+// startPAM starts a PAM transaction to the user, answering the password prompts with password. The transaction must be
+// ended when it is no longer used.
+func startPAM(username, password string) (*pam.Transaction, error) {
+	if _, err := os.Stat(filepath.Join(DefaultPAMConfigDir, DefaultPAMService)); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPAMUnavailable, err)
+	}
+
+	tx, err := pam.StartFunc(DefaultPAMService, username, func(style pam.Style, msg string) (string, error) {
+		switch style {
+		case pam.PromptEchoOff, pam.PromptEchoOn:
+			return password, nil
+		case pam.ErrorMsg, pam.TextInfo:
+			logrus.WithFields(logrus.Fields{
+				"username": username,
+				"message":  msg,
+			}).Debug("PAM message")
+
+			return "", nil
+		default:
+			return "", errors.New("unsupported PAM conversation style")
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPAMUnavailable, err)
+	}
+
+	return tx, nil
+}
+
+func authUserPAM(username, password string) (bool, error) {
+	tx, err := startPAM(username, password)
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.End() //nolint:errcheck
+
+	if err := tx.Authenticate(pam.DisallowNullAuthtok); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"username": username,
+		}).Debug("Failed to authenticate user through PAM")
+
+		return false, nil
+	}
+
+	// NOTICE: The account management checks if the account is valid, e.g. not expired or locked.
+	if err := tx.AcctMgmt(0); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"username": username,
+		}).Debug("User's account is not valid to PAM")
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func openSession(username string, start func(env []string) error) (func(), error) {
+	tx, err := startPAM(username, "")
+	if err != nil {
+		return func() {}, start(nil)
+	}
+
+	// NOTICE: The transaction is ended when the session fails to be opened or, after it has been opened, when it is
+	// closed.
+	opened := false
+	defer func() {
+		if !opened {
+			tx.End() //nolint:errcheck
+		}
+	}()
+
+	// NOTICE: The account management is checked to every session, whatever the method the user was authenticated by,
+	// what keeps the users authenticated through public keys, or by ShellHub, from opening sessions to expired or
+	// locked accounts.
+	if err := tx.AcctMgmt(0); err != nil {
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"username": username,
+		}).Debug("User's account is not valid to PAM")
+
+		return nil, fmt.Errorf("%w: %w", ErrPAMAccount, err)
+	}
+
+	sessionMu.Lock()
+	defer sessionMu.Unlock()
+
+	saved := make(map[int]unix.Rlimit, len(limits))
+	for _, resource := range limits {
+		var limit unix.Rlimit
+		if err := unix.Getrlimit(resource, &limit); err == nil {
+			saved[resource] = limit
+		}
+	}
+
+	// NOTICE: The agent's limits are restored after the session's process is started, what inherits the ones set by
+	// the session modules.
+	defer func() {
+		for resource, limit := range saved {
+			limit := limit
+			if err := unix.Setrlimit(resource, &limit); err != nil {
+				logrus.WithError(err).Warn("Failed to restore the agent's resource limit")
+			}
+		}
+	}()
+
+	if err := tx.SetItem(pam.Tty, "ssh"); err != nil {
+		return nil, err
+	}
+
+	if err := tx.OpenSession(0); err != nil {
+		return nil, err
+	}
+
+	closer := func() {
+		if err := tx.CloseSession(0); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"username": username,
+			}).Warn("Failed to close the PAM session")
+		}
+
+		if err := tx.End(); err != nil {
+			logrus.WithError(err).WithFields(logrus.Fields{
+				"username": username,
+			}).Warn("Failed to end the PAM transaction")
+		}
+	}
+
+	vars, err := tx.GetEnvList()
+	if err != nil {
+		closer()
+
+		return nil, err
+	}
+
+	env := make([]string, 0, len(vars))
+	for name, value := range vars {
+		env = append(env, name+"="+value)
+	}
+
+	if err := start(env); err != nil {
+		closer()
+
+		return nil, err
+	}
+
+	opened = true
+
+	return closer, nil
+}
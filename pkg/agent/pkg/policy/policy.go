@@ -0,0 +1,150 @@
+// Package policy implements the device-local authorization policy, what allows the device's owner to restrict the
+// access to the device independently of the permissions granted by the ShellHub's server.
+//
+// The policy is a JSON file like:
+//
+//	{
+//	  "deny_users": ["root"],
+//	  "allow_groups": ["wheel", "deploy"],
+//	  "sessions": ["shell", "exec", "sftp"],
+//	  "rules": [
+//	    {"groups": ["deploy"], "sessions": ["exec"], "force_command": "/usr/local/bin/deploy"}
+//	  ]
+//	}
+//
+// Users and groups are matched using the patterns accepted by [path.Match].
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+)
+
+// Session is the type of SSH session controlled by the policy.
+type Session string
+
+const (
+	SessionShell   Session = "shell"
+	SessionHeredoc Session = "heredoc"
+	SessionExec    Session = "exec"
+	SessionSFTP    Session = "sftp"
+)
+
+var (
+	// ErrUserDenied is returned when the user, or one of its groups, is not allowed by the policy.
+	ErrUserDenied = errors.New("user is not allowed by the device's policy")
+	// ErrSessionDenied is returned when the session's type is not allowed to the user by the policy.
+	ErrSessionDenied = errors.New("session type is not allowed by the device's policy")
+)
+
+// Rule overrides the allowed sessions' types and the forced command to the users and groups it matches.
+type Rule struct {
+	Users        []string  `json:"users"`
+	Groups       []string  `json:"groups"`
+	Sessions     []Session `json:"sessions"`
+	ForceCommand string    `json:"force_command"`
+}
+
+// matches checks if the rule matches the user or one of its groups.
+func (r *Rule) matches(user string, groups []string) bool {
+	return match(r.Users, user) || matchAny(r.Groups, groups)
+}
+
+// Policy is the device-local authorization policy.
+type Policy struct {
+	// AllowUsers, when not empty, are the only users allowed to log in.
+	AllowUsers []string `json:"allow_users"`
+	// DenyUsers are the users not allowed to log in.
+	DenyUsers []string `json:"deny_users"`
+	// AllowGroups, when not empty, restricts the log in to the members of these groups.
+	AllowGroups []string `json:"allow_groups"`
+	// DenyGroups denies the log in to the members of these groups.
+	DenyGroups []string `json:"deny_groups"`
+	// Sessions, when not empty, are the sessions' types allowed.
+	Sessions []Session `json:"sessions"`
+	// ForceCommand, when defined, is executed instead of the shell or the command requested by the user.
+	ForceCommand string `json:"force_command"`
+	// Rules override Sessions and ForceCommand to the users and groups they match. Only the first matching rule is
+	// applied.
+	Rules []Rule `json:"rules"`
+}
+
+// Load reads the policy from the JSON file at filename.
+func Load(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := new(Policy)
+	if err := json.Unmarshal(data, policy); err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+// Authorize checks if user, member of groups, is allowed to open a session of the given type. It returns the command
+// forced to the user, or an empty string when the user is free to run what was requested.
+func (p *Policy) Authorize(user string, groups []string, session Session) (string, error) {
+	if match(p.DenyUsers, user) || matchAny(p.DenyGroups, groups) {
+		return "", ErrUserDenied
+	}
+
+	if len(p.AllowUsers) > 0 && !match(p.AllowUsers, user) {
+		return "", ErrUserDenied
+	}
+
+	if len(p.AllowGroups) > 0 && !matchAny(p.AllowGroups, groups) {
+		return "", ErrUserDenied
+	}
+
+	sessions, command := p.Sessions, p.ForceCommand
+	for _, rule := range p.Rules {
+		if rule.matches(user, groups) {
+			sessions, command = rule.Sessions, rule.ForceCommand
+
+			break
+		}
+	}
+
+	if len(sessions) > 0 && !contains(sessions, session) {
+		return "", ErrSessionDenied
+	}
+
+	return command, nil
+}
+
+// match checks if value matches any of the patterns.
+func match(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, value); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchAny checks if any of the values matches any of the patterns.
+func matchAny(patterns []string, values []string) bool {
+	for _, value := range values {
+		if match(patterns, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func contains(sessions []Session, session Session) bool {
+	for _, s := range sessions {
+		if s == session {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,154 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"deny_users":["root"],"rules":[{"groups":["deploy"],"force_command":"deploy"}]}`), 0o600))
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"deny_users":`), 0o600))
+
+	policy, err := Load(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, &Policy{
+		DenyUsers: []string{"root"},
+		Rules:     []Rule{{Groups: []string{"deploy"}, ForceCommand: "deploy"}},
+	}, policy)
+
+	_, err = Load(invalid)
+	assert.Error(t, err)
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestAuthorize(t *testing.T) {
+	type Expected struct {
+		command string
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		policy      *Policy
+		user        string
+		groups      []string
+		session     Session
+		expected    Expected
+	}{
+		{
+			description: "succeeds when policy is empty",
+			policy:      &Policy{},
+			user:        "root",
+			groups:      []string{"root"},
+			session:     SessionShell,
+			expected:    Expected{"", nil},
+		},
+		{
+			description: "fails when user is denied",
+			policy:      &Policy{DenyUsers: []string{"root"}},
+			user:        "root",
+			groups:      []string{"root"},
+			session:     SessionShell,
+			expected:    Expected{"", ErrUserDenied},
+		},
+		{
+			description: "fails when user matches a denied pattern",
+			policy:      &Policy{DenyUsers: []string{"guest*"}},
+			user:        "guest01",
+			groups:      []string{"users"},
+			session:     SessionShell,
+			expected:    Expected{"", ErrUserDenied},
+		},
+		{
+			description: "fails when user is not allowed",
+			policy:      &Policy{AllowUsers: []string{"admin"}},
+			user:        "user",
+			groups:      []string{"users"},
+			session:     SessionShell,
+			expected:    Expected{"", ErrUserDenied},
+		},
+		{
+			description: "fails when group is denied",
+			policy:      &Policy{DenyGroups: []string{"guests"}},
+			user:        "user",
+			groups:      []string{"users", "guests"},
+			session:     SessionShell,
+			expected:    Expected{"", ErrUserDenied},
+		},
+		{
+			description: "fails when no group is allowed",
+			policy:      &Policy{AllowGroups: []string{"wheel"}},
+			user:        "user",
+			groups:      []string{"users"},
+			session:     SessionShell,
+			expected:    Expected{"", ErrUserDenied},
+		},
+		{
+			description: "fails when session type is not allowed",
+			policy:      &Policy{Sessions: []Session{SessionExec}},
+			user:        "user",
+			groups:      []string{"users"},
+			session:     SessionSFTP,
+			expected:    Expected{"", ErrSessionDenied},
+		},
+		{
+			description: "fails when session type is not allowed by the matching rule",
+			policy: &Policy{
+				Rules: []Rule{{Groups: []string{"deploy"}, Sessions: []Session{SessionExec}}},
+			},
+			user:     "ci",
+			groups:   []string{"deploy"},
+			session:  SessionShell,
+			expected: Expected{"", ErrSessionDenied},
+		},
+		{
+			description: "succeeds when user and group are allowed",
+			policy:      &Policy{AllowUsers: []string{"user"}, AllowGroups: []string{"users"}},
+			user:        "user",
+			groups:      []string{"users"},
+			session:     SessionShell,
+			expected:    Expected{"", nil},
+		},
+		{
+			description: "succeeds returning the forced command",
+			policy:      &Policy{ForceCommand: "/usr/bin/true"},
+			user:        "user",
+			groups:      []string{"users"},
+			session:     SessionShell,
+			expected:    Expected{"/usr/bin/true", nil},
+		},
+		{
+			description: "succeeds returning the forced command of the first matching rule",
+			policy: &Policy{
+				ForceCommand: "/usr/bin/false",
+				Rules: []Rule{
+					{Users: []string{"other"}, ForceCommand: "/usr/bin/other"},
+					{Users: []string{"ci"}, ForceCommand: "/usr/local/bin/deploy"},
+					{Groups: []string{"deploy"}, ForceCommand: "/usr/bin/deploy"},
+				},
+			},
+			user:     "ci",
+			groups:   []string{"deploy"},
+			session:  SessionExec,
+			expected: Expected{"/usr/local/bin/deploy", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			command, err := tc.policy.Authorize(tc.user, tc.groups, tc.session)
+			assert.Equal(t, tc.expected, Expected{command, err})
+		})
+	}
+}
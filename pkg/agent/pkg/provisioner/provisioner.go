@@ -0,0 +1,360 @@
+// Package provisioner creates ephemeral local accounts to the ShellHub's members that log in the device as users that
+// don't exist on it, removing them after a period of inactivity.
+//
+// Only the logins authenticated by ShellHub on behalf of a member, through the public keys signed by its API, are
+// provisioned, and only to the account named after the member's ShellHub username. The provisioning is configured by a
+// JSON file like:
+//
+//	{
+//	  "shell": "/bin/bash",
+//	  "home": "/home",
+//	  "inactivity": "24h",
+//	  "groups": {
+//	    "*": ["users"],
+//	    "owner": ["sudo"]
+//	  },
+//	  "members": {
+//	    "john": ["docker"]
+//	  }
+//	}
+//
+// The groups' keys are the members' roles in the namespace, or "*" to every role, and the members' keys are their
+// ShellHub usernames.
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultShell is the shell of the provisioned accounts when none is configured.
+	DefaultShell = "/bin/sh"
+	// DefaultHome is the directory where the provisioned accounts' homes are created when none is configured.
+	DefaultHome = "/home"
+	// DefaultInactivity is the period of inactivity after what a provisioned account is removed when none is configured.
+	DefaultInactivity = 24 * time.Hour
+	// DefaultStateFilename is the file where the provisioned accounts are tracked when none is configured.
+	DefaultStateFilename = "/var/lib/shellhub/provisioned.json"
+)
+
+// SweepInterval is the interval between the checks of inactive accounts.
+var SweepInterval = time.Minute
+
+// comment identifies the accounts created by the provisioner.
+const comment = "ShellHub ephemeral user"
+
+// ErrInvalidUsername is returned when the username can't be used to create a local account.
+var ErrInvalidUsername = errors.New("invalid username to provision")
+
+// username is the pattern of the usernames accepted to provision, what is the portable subset used by useradd.
+var username = regexp.MustCompile(`^[a-z_][a-z0-9_-]{0,31}$`)
+
+// Config is the provisioning configuration.
+type Config struct {
+	// Shell is the login shell of the provisioned accounts.
+	Shell string `json:"shell"`
+	// Home is the directory where the accounts' homes are created.
+	Home string `json:"home"`
+	// Inactivity is the period, as accepted by [time.ParseDuration], after what an account without processes is
+	// removed.
+	Inactivity string `json:"inactivity"`
+	// Groups maps the members' roles, or "*" to every role, to the supplementary groups of the accounts.
+	Groups map[string][]string `json:"groups"`
+	// Members maps the members' ShellHub usernames to the supplementary groups of their accounts.
+	Members map[string][]string `json:"members"`
+	// State is the file where the provisioned accounts are tracked across the agent's restarts.
+	State string `json:"state"`
+}
+
+// Provisioner creates and removes the ephemeral accounts.
+type Provisioner struct {
+	config     Config
+	inactivity time.Duration
+
+	mu sync.Mutex
+	// accounts maps the provisioned accounts to their last activity.
+	accounts map[string]time.Time
+
+	// run runs the commands used to manage the accounts.
+	run func(name string, args ...string) error
+	// active checks if the account has running processes.
+	active func(username string) bool
+	// exists checks if the account exists on the device.
+	exists func(username string) bool
+}
+
+// Load reads the provisioning configuration from the JSON file at filename, restoring the accounts provisioned before.
+func Load(filename string) (*Provisioner, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	return New(config)
+}
+
+// New creates a new Provisioner from config, restoring the accounts provisioned before.
+func New(config Config) (*Provisioner, error) {
+	if config.Shell == "" {
+		config.Shell = DefaultShell
+	}
+
+	if config.Home == "" {
+		config.Home = DefaultHome
+	}
+
+	if config.State == "" {
+		config.State = DefaultStateFilename
+	}
+
+	inactivity := DefaultInactivity
+	if config.Inactivity != "" {
+		var err error
+		if inactivity, err = time.ParseDuration(config.Inactivity); err != nil {
+			return nil, err
+		}
+	}
+
+	p := &Provisioner{
+		config:     config,
+		inactivity: inactivity,
+		accounts:   make(map[string]time.Time),
+		run:        run,
+		active:     active,
+		exists:     exists,
+	}
+
+	data, err := os.ReadFile(config.State)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &p.accounts); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+// Groups returns the supplementary groups of the account to the member identified by identity.
+func (p *Provisioner) Groups(identity *models.PrivateKeyIdentity) []string {
+	set := make(map[string]struct{})
+	for _, groups := range [][]string{p.config.Groups["*"], p.config.Groups[identity.Role], p.config.Members[identity.Username]} {
+		for _, group := range groups {
+			set[group] = struct{}{}
+		}
+	}
+
+	groups := make([]string, 0, len(set))
+	for group := range set {
+		groups = append(groups, group)
+	}
+
+	sort.Strings(groups)
+
+	return groups
+}
+
+// Provision creates the local account to the member identified by identity, named after its ShellHub username. It
+// does nothing when the account was already provisioned and still exists.
+func (p *Provisioner) Provision(identity *models.PrivateKeyIdentity) error {
+	username := identity.Username
+	if !valid(username) {
+		return ErrInvalidUsername
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.accounts[username]; ok && p.exists(username) {
+		p.accounts[username] = time.Now()
+
+		return p.save()
+	}
+
+	args := []string{
+		"--create-home",
+		"--home-dir", filepath.Join(p.config.Home, username),
+		"--shell", p.config.Shell,
+		"--comment", comment,
+	}
+
+	if groups := p.Groups(identity); len(groups) > 0 {
+		args = append(args, "--groups", strings.Join(groups, ","))
+	}
+
+	if err := p.run("useradd", append(args, username)...); err != nil {
+		return err
+	}
+
+	p.accounts[username] = time.Now()
+
+	log.WithFields(log.Fields{
+		"username": username,
+	}).Info("Ephemeral account provisioned")
+
+	return p.save()
+}
+
+// Managed checks if the account to username was provisioned.
+func (p *Provisioner) Managed(username string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, ok := p.accounts[username]
+
+	return ok
+}
+
+// Touch updates the last activity of the account to username, if it was provisioned.
+func (p *Provisioner) Touch(username string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.accounts[username]; !ok {
+		return
+	}
+
+	p.accounts[username] = time.Now()
+
+	if err := p.save(); err != nil {
+		log.WithError(err).Warn("Failed to save the provisioned accounts")
+	}
+}
+
+// Run removes the inactive accounts every [SweepInterval] until ctx is done.
+func (p *Provisioner) Run(ctx context.Context) {
+	ticker := time.NewTicker(SweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			p.Sweep(now)
+		}
+	}
+}
+
+// Sweep removes the accounts without running processes and whose last activity is older than the inactivity period.
+// The accounts with running processes are considered active at now.
+func (p *Provisioner) Sweep(now time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for username, last := range p.accounts {
+		if p.active(username) {
+			p.accounts[username] = now
+
+			continue
+		}
+
+		if now.Sub(last) < p.inactivity {
+			continue
+		}
+
+		if err := p.run("userdel", "--remove", username); err != nil {
+			log.WithError(err).WithFields(log.Fields{
+				"username": username,
+			}).Error("Failed to remove the ephemeral account")
+
+			continue
+		}
+
+		delete(p.accounts, username)
+
+		log.WithFields(log.Fields{
+			"username": username,
+		}).Info("Ephemeral account removed after inactivity")
+	}
+
+	if err := p.save(); err != nil {
+		log.WithError(err).Warn("Failed to save the provisioned accounts")
+	}
+}
+
+// save writes the provisioned accounts to the state file.
+func (p *Provisioner) save() error {
+	data, err := json.Marshal(p.accounts)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p.config.State), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(p.config.State, data, 0o600)
+}
+
+func valid(name string) bool {
+	return username.MatchString(name)
+}
+
+func run(name string, args ...string) error {
+	if output, err := exec.Command(name, args...).CombinedOutput(); err != nil { //nolint:gosec
+		return fmt.Errorf("%s: %w: %s", name, err, output)
+	}
+
+	return nil
+}
+
+// exists checks if the account to username exists on the device.
+func exists(username string) bool {
+	return new(osauth.OSAuth).LookupUser(username) != nil
+}
+
+// active checks, through the procfs, if there is any process running as the user.
+func active(username string) bool {
+	user := new(osauth.OSAuth).LookupUser(username)
+	if user == nil {
+		return false
+	}
+
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return false
+	}
+
+	for _, entry := range entries {
+		if _, err := strconv.Atoi(entry.Name()); err != nil {
+			continue
+		}
+
+		info, err := os.Stat(filepath.Join("/proc", entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok && stat.Uid == user.UID {
+			return true
+		}
+	}
+
+	return false
+}
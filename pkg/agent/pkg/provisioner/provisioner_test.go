@@ -0,0 +1,179 @@
+package provisioner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type command struct {
+	name string
+	args []string
+}
+
+func newTestProvisioner(t *testing.T, config Config, active map[string]bool) (*Provisioner, *[]command) {
+	t.Helper()
+
+	config.State = filepath.Join(t.TempDir(), "state", "provisioned.json")
+
+	p, err := New(config)
+	require.NoError(t, err)
+
+	commands := []command{}
+	p.run = func(name string, args ...string) error {
+		commands = append(commands, command{name, args})
+
+		return nil
+	}
+	p.active = func(username string) bool {
+		return active[username]
+	}
+	p.exists = func(username string) bool {
+		for _, command := range commands {
+			if command.name == "useradd" && command.args[len(command.args)-1] == username {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return p, &commands
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.json")
+	require.NoError(t, os.WriteFile(valid, []byte(`{"shell":"/bin/bash","inactivity":"1h","groups":{"*":["users"]},"state":"`+filepath.Join(dir, "state.json")+`"}`), 0o600))
+
+	invalid := filepath.Join(dir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalid, []byte(`{"inactivity":"forever"}`), 0o600))
+
+	p, err := Load(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, "/bin/bash", p.config.Shell)
+	assert.Equal(t, DefaultHome, p.config.Home)
+	assert.Equal(t, time.Hour, p.inactivity)
+
+	_, err = Load(invalid)
+	assert.Error(t, err)
+
+	_, err = Load(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestGroups(t *testing.T) {
+	p, _ := newTestProvisioner(t, Config{
+		Groups: map[string][]string{
+			"*":     {"users"},
+			"owner": {"sudo", "users"},
+		},
+		Members: map[string][]string{
+			"john": {"docker"},
+		},
+	}, nil)
+
+	assert.Equal(t, []string{"users"}, p.Groups(&models.PrivateKeyIdentity{Username: "jane", Role: "operator"}))
+	assert.Equal(t, []string{"docker", "users"}, p.Groups(&models.PrivateKeyIdentity{Username: "john", Role: "operator"}))
+	assert.Equal(t, []string{"sudo", "users"}, p.Groups(&models.PrivateKeyIdentity{Username: "admin-john", Role: "owner"}))
+}
+
+func TestProvision(t *testing.T) {
+	cases := []struct {
+		description string
+		identity    *models.PrivateKeyIdentity
+		expected    []command
+		err         error
+	}{
+		{
+			description: "fails when username is invalid",
+			identity:    &models.PrivateKeyIdentity{Username: "john;rm -rf /", Role: "owner"},
+			expected:    []command{},
+			err:         ErrInvalidUsername,
+		},
+		{
+			description: "does not grant the role's groups by the username",
+			identity:    &models.PrivateKeyIdentity{Username: "admin-john", Role: "operator"},
+			expected: []command{
+				{
+					name: "useradd",
+					args: []string{
+						"--create-home",
+						"--home-dir", "/home/admin-john",
+						"--shell", "/bin/bash",
+						"--comment", "ShellHub ephemeral user",
+						"admin-john",
+					},
+				},
+			},
+		},
+		{
+			description: "succeeds to create the account",
+			identity:    &models.PrivateKeyIdentity{Username: "john", Role: "owner"},
+			expected: []command{
+				{
+					name: "useradd",
+					args: []string{
+						"--create-home",
+						"--home-dir", "/home/john",
+						"--shell", "/bin/bash",
+						"--comment", "ShellHub ephemeral user",
+						"--groups", "sudo",
+						"john",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			p, commands := newTestProvisioner(t, Config{
+				Shell:  "/bin/bash",
+				Groups: map[string][]string{"owner": {"sudo"}},
+			}, nil)
+
+			err := p.Provision(tc.identity)
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, *commands)
+			assert.Equal(t, tc.err == nil, p.Managed(tc.identity.Username))
+
+			if tc.err == nil {
+				// NOTICE: The account already provisioned isn't created again.
+				assert.NoError(t, p.Provision(tc.identity))
+				assert.Equal(t, tc.expected, *commands)
+			}
+		})
+	}
+}
+
+func TestSweep(t *testing.T) {
+	p, commands := newTestProvisioner(t, Config{Inactivity: "1h"}, map[string]bool{"active": true})
+
+	for _, username := range []string{"active", "recent", "inactive"} {
+		require.NoError(t, p.Provision(&models.PrivateKeyIdentity{Username: username, Role: "operator"}))
+	}
+
+	now := time.Now()
+	p.accounts["active"] = now.Add(-2 * time.Hour)
+	p.accounts["recent"] = now.Add(-30 * time.Minute)
+	p.accounts["inactive"] = now.Add(-2 * time.Hour)
+	*commands = []command{}
+
+	p.Sweep(now)
+
+	assert.Equal(t, []command{{name: "userdel", args: []string{"--remove", "inactive"}}}, *commands)
+	assert.Equal(t, map[string]time.Time{"active": now, "recent": now.Add(-30 * time.Minute)}, p.accounts)
+
+	restored, err := New(p.config)
+	require.NoError(t, err)
+	assert.True(t, restored.Managed("active"))
+	assert.True(t, restored.Managed("recent"))
+	assert.False(t, restored.Managed("inactive"))
+}
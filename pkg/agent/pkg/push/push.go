@@ -0,0 +1,356 @@
+// Package push receives the files pushed from ShellHub's server to a set of devices.
+//
+// A pushed file is written, in as many requests as needed, to a partial file beside its destination, so an interrupted
+// transfer is resumed from the bytes already received. Only when the whole file is received, and its checksum matches,
+// the partial file gets its ownership and mode and is renamed to the destination, replacing it atomically.
+//
+// The files are only written inside the directories allowed by the device's owner, what should be writable only by
+// the agent's user, as the partial files are created there.
+package push
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/user"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+// Actions supported by the file push.
+const (
+	ActionStatus = "status"
+	ActionUpload = "upload"
+	ActionCommit = "commit"
+)
+
+// DefaultMode is the mode of a pushed file when none is requested.
+const DefaultMode os.FileMode = 0o644
+
+var (
+	// ErrInvalidAction is returned when the action, or the method used to request it, is not supported.
+	ErrInvalidAction = errors.New("invalid file push action")
+	// ErrInvalidTransfer is returned when the transfer's parameters are missing or invalid.
+	ErrInvalidTransfer = errors.New("invalid file push transfer")
+	// ErrOffsetMismatch is returned when a chunk does not continue from the bytes already received.
+	ErrOffsetMismatch = errors.New("offset does not match the received bytes")
+	// ErrChecksumMismatch is returned when the received file's size or checksum does not match the pushed file.
+	ErrChecksumMismatch = errors.New("checksum does not match the pushed file")
+	// ErrPathNotAllowed is returned when the transfer's path is outside of the directories allowed to receive files.
+	ErrPathNotAllowed = errors.New("path is not allowed to receive pushed files")
+)
+
+var (
+	idRegexp     = regexp.MustCompile(`^[a-zA-Z0-9-]+$`)
+	sha256Regexp = regexp.MustCompile(`^[a-f0-9]{64}$`)
+)
+
+// Transfer is a file pushed from the ShellHub server to the device.
+type Transfer struct {
+	// ID identifies the file push, what keeps the partial files from different pushes to the same path apart.
+	ID     string
+	Path   string
+	Size   int64
+	SHA256 string
+	Owner  string
+	Group  string
+	Mode   os.FileMode
+}
+
+// ParseTransfer parses the transfer from the request's query parameters.
+func ParseTransfer(values url.Values) (*Transfer, error) {
+	t := &Transfer{
+		ID:     values.Get("id"),
+		Path:   values.Get("path"),
+		SHA256: values.Get("sha256"),
+		Owner:  values.Get("owner"),
+		Group:  values.Get("group"),
+		Mode:   DefaultMode,
+	}
+
+	if !idRegexp.MatchString(t.ID) {
+		return nil, fmt.Errorf("%w: id is invalid", ErrInvalidTransfer)
+	}
+
+	if !filepath.IsAbs(t.Path) || filepath.Clean(t.Path) != t.Path || t.Path == "/" {
+		return nil, fmt.Errorf("%w: path must be an absolute and clean file path", ErrInvalidTransfer)
+	}
+
+	if !sha256Regexp.MatchString(t.SHA256) {
+		return nil, fmt.Errorf("%w: sha256 is invalid", ErrInvalidTransfer)
+	}
+
+	size, err := strconv.ParseInt(values.Get("size"), 10, 64)
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("%w: size is invalid", ErrInvalidTransfer)
+	}
+
+	t.Size = size
+
+	if mode := values.Get("mode"); mode != "" {
+		parsed, err := strconv.ParseUint(mode, 8, 32)
+		// NOTICE: The setuid, setgid and sticky bits are refused, as they aren't kept by [os.FileMode] and shouldn't be
+		// set to the pushed files anyway.
+		if err != nil || parsed > 0o777 {
+			return nil, fmt.Errorf("%w: mode is invalid", ErrInvalidTransfer)
+		}
+
+		t.Mode = os.FileMode(parsed)
+	}
+
+	return t, nil
+}
+
+// Allowed checks if the transfer's path is inside one of the directories, also when the symbolic links on its way are
+// followed.
+func Allowed(t *Transfer, dirs []string) error {
+	parent, err := resolve(filepath.Dir(t.Path))
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		if !within(dir, t.Path) {
+			continue
+		}
+
+		real, err := filepath.EvalSymlinks(dir)
+		if err != nil {
+			continue
+		}
+
+		if parent == real || within(real, parent) {
+			return nil
+		}
+	}
+
+	return ErrPathNotAllowed
+}
+
+// resolve follows the symbolic links on the path's existing directories, keeping the ones still to be created.
+func resolve(path string) (string, error) {
+	real, err := filepath.EvalSymlinks(path)
+	switch {
+	case err == nil:
+		return real, nil
+	case !errors.Is(err, os.ErrNotExist) || filepath.Dir(path) == path:
+		return "", err
+	}
+
+	parent, err := resolve(filepath.Dir(path))
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(parent, filepath.Base(path)), nil
+}
+
+// within checks if the path is inside the directory.
+func within(dir, path string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+// partial is the path of the file receiving the transfer, a hidden file beside its destination.
+func (t *Transfer) partial() string {
+	return filepath.Join(filepath.Dir(t.Path), fmt.Sprintf(".%s.%s.part", filepath.Base(t.Path), t.ID))
+}
+
+// Offset gets the number of bytes already received from the transfer.
+func Offset(t *Transfer) (int64, error) {
+	info, err := os.Lstat(t.partial())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return 0, nil
+	case err != nil:
+		return 0, err
+	}
+
+	// NOTICE: A partial file larger than the transfer cannot be resumed, and what isn't a regular file, like a symbolic
+	// link, or is linked elsewhere wasn't created by the agent; both are discarded to start over.
+	if stat, ok := info.Sys().(*syscall.Stat_t); !info.Mode().IsRegular() || !ok || stat.Nlink > 1 || info.Size() > t.Size {
+		if err := os.Remove(t.partial()); err != nil {
+			return 0, err
+		}
+
+		return 0, nil
+	}
+
+	return info.Size(), nil
+}
+
+// Write writes the content read from r to the transfer, starting at offset, what must be the number of bytes already
+// received. It returns the number of bytes received after writing, what is the current offset when it does not match.
+func Write(t *Transfer, offset int64, r io.Reader) (int64, error) {
+	current, err := Offset(t)
+	if err != nil {
+		return 0, err
+	}
+
+	if offset != current {
+		return current, ErrOffsetMismatch
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0o755); err != nil {
+		return current, err
+	}
+
+	// NOTICE: The partial file is created by the agent, never opened through a symbolic link, at the first write, and
+	// only appended to while the transfer is resumed.
+	flags := os.O_WRONLY | os.O_APPEND | syscall.O_NOFOLLOW
+	if current == 0 {
+		if err := os.Remove(t.partial()); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return current, err
+		}
+
+		flags |= os.O_CREATE | os.O_EXCL
+	}
+
+	file, err := os.OpenFile(t.partial(), flags, 0o600)
+	if err != nil {
+		return current, err
+	}
+
+	written, err := io.Copy(file, io.LimitReader(r, t.Size-offset))
+	if err != nil {
+		file.Close()
+
+		return offset + written, err
+	}
+
+	return offset + written, file.Close()
+}
+
+// Commit verifies the received file against the transfer's size and checksum and, when they match, sets its ownership
+// and mode and moves it to the transfer's path. A received file that does not match is discarded.
+func Commit(t *Transfer) error {
+	file, err := os.OpenFile(t.partial(), os.O_RDONLY|syscall.O_NOFOLLOW, 0)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return err
+	}
+
+	if size != t.Size || hex.EncodeToString(hash.Sum(nil)) != t.SHA256 {
+		os.Remove(t.partial()) //nolint:errcheck
+
+		return ErrChecksumMismatch
+	}
+
+	// NOTICE: The ownership and mode are set through the verified file's descriptor, not its path.
+	if t.Owner != "" {
+		uid, gid, err := lookupOwner(t.Owner, t.Group)
+		if err != nil {
+			return err
+		}
+
+		if err := file.Chown(uid, gid); err != nil {
+			return err
+		}
+	}
+
+	if err := file.Chmod(t.Mode); err != nil {
+		return err
+	}
+
+	return os.Rename(t.partial(), t.Path)
+}
+
+// lookupOwner resolves the IDs of the file's owner and group, using the owner's primary group when no group is set.
+func lookupOwner(owner, group string) (int, int, error) {
+	u := new(osauth.OSAuth).LookupUser(owner)
+	if u == nil {
+		return 0, 0, fmt.Errorf("%w: user %s not found", ErrInvalidTransfer, owner)
+	}
+
+	gid := int(u.GID)
+	if group != "" {
+		g, err := user.LookupGroup(group)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: group %s not found", ErrInvalidTransfer, group)
+		}
+
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return 0, 0, fmt.Errorf("%w: group %s not found", ErrInvalidTransfer, group)
+		}
+	}
+
+	return int(u.UID), gid, nil
+}
+
+// Serve handles the file push's request, performing the action, got from the route's `action` parameter, on the
+// transfer described by the query parameters, what must be to a path inside one of the allowed directories.
+func Serve(c echo.Context, allowed []string) error {
+	t, err := ParseTransfer(c.QueryParams())
+	if err != nil {
+		return c.String(http.StatusBadRequest, err.Error())
+	}
+
+	if err := Allowed(t, allowed); err != nil {
+		return c.String(http.StatusForbidden, ErrPathNotAllowed.Error())
+	}
+
+	action, method := c.Param("action"), c.Request().Method
+
+	switch {
+	case action == ActionStatus && method == http.MethodGet:
+		var offset int64
+		if offset, err = Offset(t); err == nil {
+			return c.JSON(http.StatusOK, &models.FilePushOffset{Offset: offset})
+		}
+	case action == ActionUpload && method == http.MethodPut:
+		var offset int64
+		offset, err = strconv.ParseInt(c.QueryParam("offset"), 10, 64)
+		if err != nil {
+			return c.String(http.StatusBadRequest, "offset is invalid")
+		}
+
+		if offset, err = Write(t, offset, c.Request().Body); err == nil {
+			return c.JSON(http.StatusOK, &models.FilePushOffset{Offset: offset})
+		}
+
+		if errors.Is(err, ErrOffsetMismatch) {
+			return c.JSON(http.StatusConflict, &models.FilePushOffset{Offset: offset})
+		}
+	case action == ActionCommit && method == http.MethodPost:
+		if err = Commit(t); err == nil {
+			return c.NoContent(http.StatusOK)
+		}
+	default:
+		err = ErrInvalidAction
+	}
+
+	switch {
+	case errors.Is(err, ErrInvalidAction):
+		return c.String(http.StatusMethodNotAllowed, err.Error())
+	case errors.Is(err, ErrInvalidTransfer):
+		return c.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, ErrChecksumMismatch):
+		return c.String(http.StatusUnprocessableEntity, err.Error())
+	case errors.Is(err, os.ErrNotExist):
+		return c.String(http.StatusNotFound, err.Error())
+	case errors.Is(err, os.ErrPermission):
+		return c.String(http.StatusForbidden, err.Error())
+	default:
+		return c.String(http.StatusInternalServerError, err.Error())
+	}
+}
@@ -0,0 +1,247 @@
+package push
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func checksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func transferQuery(path, content string) url.Values {
+	return url.Values{
+		"id":     []string{"push-1"},
+		"path":   []string{path},
+		"size":   []string{strconv.Itoa(len(content))},
+		"sha256": []string{checksum(content)},
+		"mode":   []string{"0600"},
+	}
+}
+
+func serve(t *testing.T, method, action string, query url.Values, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	return serveAllowed(t, method, action, query, body, []string{filepath.Dir(filepath.Dir(query.Get("path")))})
+}
+
+func serveAllowed(t *testing.T, method, action string, query url.Values, body string, allowed []string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/ssh/push/"+action+"?"+query.Encode(), strings.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	c := echo.New().NewContext(req, rec)
+	c.SetParamNames("action")
+	c.SetParamValues(action)
+
+	require.NoError(t, Serve(c, allowed))
+
+	return rec
+}
+
+func TestParseTransfer(t *testing.T) {
+	valid := transferQuery("/etc/app.conf", "content")
+
+	cases := []struct {
+		description string
+		key         string
+		value       string
+	}{
+		{description: "fails when id has a path separator", key: "id", value: "../push"},
+		{description: "fails when path is relative", key: "path", value: "etc/app.conf"},
+		{description: "fails when path is not clean", key: "path", value: "/etc/../app.conf"},
+		{description: "fails when path is the root", key: "path", value: "/"},
+		{description: "fails when sha256 is invalid", key: "sha256", value: "abc"},
+		{description: "fails when size is negative", key: "size", value: "-1"},
+		{description: "fails when mode is not octal", key: "mode", value: "0999"},
+		{description: "fails when mode has the setuid bit", key: "mode", value: "4755"},
+		{description: "fails when mode has the sticky bit", key: "mode", value: "1777"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			query := url.Values{}
+			for k, v := range valid {
+				query[k] = v
+			}
+
+			query.Set(tc.key, tc.value)
+
+			_, err := ParseTransfer(query)
+			assert.ErrorIs(t, err, ErrInvalidTransfer)
+		})
+	}
+
+	transfer, err := ParseTransfer(valid)
+	require.NoError(t, err)
+	assert.Equal(t, &Transfer{
+		ID:     "push-1",
+		Path:   "/etc/app.conf",
+		Size:   7,
+		SHA256: checksum("content"),
+		Mode:   0o600,
+	}, transfer)
+}
+
+func TestResumeAndCommit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "conf", "app.conf")
+	content := "key=value\n"
+	query := transferQuery(path, content)
+
+	rec := serve(t, http.MethodGet, ActionStatus, query, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"offset":0}`, rec.Body.String())
+
+	query.Set("offset", "0")
+	rec = serve(t, http.MethodPut, ActionUpload, query, content[:4])
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"offset":4}`, rec.Body.String())
+
+	// A chunk that does not continue from the received bytes is refused with the current offset.
+	rec = serve(t, http.MethodPut, ActionUpload, query, content)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{"offset":4}`, rec.Body.String())
+
+	rec = serve(t, http.MethodGet, ActionStatus, query, "")
+	assert.JSONEq(t, `{"offset":4}`, rec.Body.String())
+
+	query.Set("offset", "4")
+	rec = serve(t, http.MethodPut, ActionUpload, query, content[4:])
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"offset":10}`, rec.Body.String())
+
+	rec = serve(t, http.MethodPost, ActionCommit, query, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	written, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(written))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestCommitChecksumMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.conf")
+	query := transferQuery(path, "expected")
+
+	query.Set("offset", "0")
+	rec := serve(t, http.MethodPut, ActionUpload, query, "modified")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(t, http.MethodPost, ActionCommit, query, "")
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	_, err := os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	// The mismatching file is discarded, so the transfer starts over.
+	rec = serve(t, http.MethodGet, ActionStatus, query, "")
+	assert.JSONEq(t, `{"offset":0}`, rec.Body.String())
+}
+
+func TestServeInvalidAction(t *testing.T) {
+	query := transferQuery(filepath.Join(t.TempDir(), "app.conf"), "content")
+
+	rec := serve(t, http.MethodDelete, ActionCommit, query, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestServeNotAllowed(t *testing.T) {
+	dir := t.TempDir()
+	outside := t.TempDir()
+
+	require.NoError(t, os.Symlink(outside, filepath.Join(dir, "link")))
+
+	cases := []struct {
+		description string
+		path        string
+		allowed     []string
+	}{
+		{
+			description: "fails when no directory is allowed",
+			path:        filepath.Join(dir, "app.conf"),
+			allowed:     nil,
+		},
+		{
+			description: "fails when path is outside of the allowed directories",
+			path:        filepath.Join(outside, "app.conf"),
+			allowed:     []string{dir},
+		},
+		{
+			description: "fails when path is the allowed directory",
+			path:        dir,
+			allowed:     []string{dir},
+		},
+		{
+			description: "fails when path follows a symbolic link outside of the allowed directories",
+			path:        filepath.Join(dir, "link", "conf", "app.conf"),
+			allowed:     []string{dir},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			query := transferQuery(tc.path, "content")
+			query.Set("offset", "0")
+
+			rec := serveAllowed(t, http.MethodPut, ActionUpload, query, "content", tc.allowed)
+			assert.Equal(t, http.StatusForbidden, rec.Code)
+
+			entries, err := os.ReadDir(outside)
+			require.NoError(t, err)
+			assert.Empty(t, entries)
+		})
+	}
+}
+
+func TestWriteDiscardsSymlink(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(t.TempDir(), "target")
+	require.NoError(t, os.WriteFile(target, []byte("target"), 0o600))
+
+	path := filepath.Join(dir, "app.conf")
+	query := transferQuery(path, "content")
+	require.NoError(t, os.Symlink(target, filepath.Join(dir, ".app.conf.push-1.part")))
+
+	// The symbolic link planted as the partial file is discarded, instead of being written through.
+	rec := serve(t, http.MethodGet, ActionStatus, query, "")
+	assert.JSONEq(t, `{"offset":0}`, rec.Body.String())
+
+	require.NoError(t, os.Symlink(target, filepath.Join(dir, ".app.conf.push-1.part")))
+
+	query.Set("offset", "0")
+	rec = serve(t, http.MethodPut, ActionUpload, query, "content")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(t, http.MethodPost, ActionCommit, query, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	written, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Equal(t, "target", string(written))
+
+	written, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(written))
+}
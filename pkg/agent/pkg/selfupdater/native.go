@@ -0,0 +1,588 @@
+package selfupdater
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/shellhub-io/shellhub/pkg/api/client"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxBinarySize is the maximum size of the agent's binary downloaded by the update.
+const maxBinarySize = 256 << 20
+
+var (
+	// ErrUpdateDisabled is returned when the update is applied without a public key to verify the binary's signature.
+	ErrUpdateDisabled = errors.New("self-update is disabled as no public key is configured")
+	// ErrChecksumMismatch is returned when the downloaded binary does not match its checksum.
+	ErrChecksumMismatch = errors.New("checksum does not match the downloaded binary")
+	// ErrSignatureMismatch is returned when the downloaded binary's signature is not valid to the public key.
+	ErrSignatureMismatch = errors.New("signature does not match the downloaded binary")
+	// ErrUpdateRolledBack is returned by the update's completion when the update is rolled back to the previous binary.
+	ErrUpdateRolledBack = errors.New("update was rolled back to the previous binary")
+)
+
+// WatchdogCommand is the agent's command, run from the previous binary, that rolls back an update not confirmed before
+// its deadline.
+const WatchdogCommand = "update-watchdog"
+
+// pendingUpdate is the state of an update applied to the binary, but not confirmed yet, kept beside the binary.
+type pendingUpdate struct {
+	// Version is the version of the updated binary.
+	Version string `json:"version"`
+	// Previous is the version of the binary kept as backup.
+	Previous string `json:"previous"`
+	// Deadline is when the update is rolled back if it was not confirmed.
+	Deadline time.Time `json:"deadline"`
+	// Unit is the systemd's service unit running the agent, if any.
+	Unit string `json:"unit,omitempty"`
+	// PID is the agent's process, kept by the restart when the agent is not a systemd's service.
+	PID int `json:"pid"`
+	// Args are the agent's command line arguments, what starts the previous binary when the agent is not a systemd's
+	// service.
+	Args []string `json:"args"`
+}
+
+// nativeUpdater updates the agent's binary in place. The new binary is downloaded to a staging file beside the
+// current one, verified against its checksum and detached signature, and renamed over it, keeping the current binary
+// as a backup until the updated agent connects to the server.
+//
+// The rollback doesn't depend on the updated binary, what could fail to even start: before the update, a watchdog is
+// started from the previous binary, apart from the agent, to roll it back when it isn't confirmed in time.
+type nativeUpdater struct {
+	version    string
+	config     *Config
+	executable string
+	client     *http.Client
+	// restart restarts the agent to run the binary in the executable's path.
+	restart func() error
+	// watchdog starts the watchdog that rolls back the pending update when it isn't confirmed before its deadline.
+	watchdog func(pending *pendingUpdate) error
+	// takeover restarts the agent, from the watchdog, to run the previous binary restored to the executable's path.
+	takeover func(pending *pendingUpdate) error
+
+	mu      sync.Mutex
+	pending *pendingUpdate
+}
+
+func newNativeUpdater(version string, config *Config) (*nativeUpdater, error) {
+	if config == nil {
+		config = new(Config)
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	if executable, err = filepath.EvalSymlinks(executable); err != nil {
+		return nil, err
+	}
+
+	// NOTICE: The binary is downloaded through the same proxy used to connect to the server, when it is set, or the
+	// one from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables otherwise.
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if config.Proxy != "" {
+		proxy, err := client.ParseProxy(config.Proxy)
+		if err != nil {
+			return nil, err
+		}
+
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	n := &nativeUpdater{
+		version:    version,
+		config:     config,
+		executable: executable,
+		client:     &http.Client{Transport: transport},
+	}
+
+	n.restart = n.restartService
+	n.watchdog = n.startWatchdog
+	n.takeover = n.takeoverAgent
+
+	return n, nil
+}
+
+func (n *nativeUpdater) CurrentVersion() (*semver.Version, error) {
+	return semver.NewVersion(n.version)
+}
+
+func (n *nativeUpdater) staging() string {
+	return n.executable + ".new"
+}
+
+func (n *nativeUpdater) backup() string {
+	return n.executable + ".bak"
+}
+
+func (n *nativeUpdater) marker() string {
+	return n.executable + ".update"
+}
+
+func (n *nativeUpdater) ApplyUpdate(v *semver.Version) error {
+	if n.config.PublicKey == "" {
+		return ErrUpdateDisabled
+	}
+
+	key, err := base64.StdEncoding.DecodeString(n.config.PublicKey)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return errors.New("public key is not a base64 encoded Ed25519 key")
+	}
+
+	url := strings.NewReplacer("{version}", v.Original(), "{arch}", arch()).Replace(n.config.URL)
+
+	binary, err := n.download(url, maxBinarySize)
+	if err != nil {
+		return err
+	}
+
+	checksum, err := n.download(url+".sha256", 1024)
+	if err != nil {
+		return err
+	}
+
+	signature, err := n.download(url+".sig", 1024)
+	if err != nil {
+		return err
+	}
+
+	if err := verify(v.Original(), binary, checksum, signature, ed25519.PublicKey(key)); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(n.executable)
+	if err != nil {
+		return err
+	}
+
+	if err := writeFile(n.staging(), binary, info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	defer os.Remove(n.staging()) //nolint:errcheck
+
+	if err := copyFile(n.executable, n.backup(), info.Mode().Perm()); err != nil {
+		return err
+	}
+
+	pending := &pendingUpdate{
+		Version:  v.Original(),
+		Previous: n.version,
+		Deadline: time.Now().Add(n.config.Deadline),
+		Unit:     systemdUnit(),
+		PID:      os.Getpid(),
+		Args:     os.Args,
+	}
+
+	if err := n.writeMarker(pending); err != nil {
+		return err
+	}
+
+	if err := n.watchdog(pending); err != nil {
+		os.Remove(n.marker()) //nolint:errcheck
+
+		return err
+	}
+
+	if err := os.Rename(n.staging(), n.executable); err != nil {
+		os.Remove(n.marker()) //nolint:errcheck
+
+		return err
+	}
+
+	if err := syncDir(filepath.Dir(n.executable)); err != nil {
+		return err
+	}
+
+	return n.restart()
+}
+
+// CompleteUpdate checks if the running binary comes from an update not confirmed yet. When it does, the update is
+// rolled back if its deadline has passed; otherwise, it is kept until [nativeUpdater.ConfirmUpdate] is called, or
+// rolled back by the watchdog when its deadline passes before.
+func (n *nativeUpdater) CompleteUpdate() error {
+	pending, err := n.readMarker()
+	if err != nil || pending == nil {
+		return err
+	}
+
+	// NOTICE: The running binary is not the updated one, as when the update was already rolled back, so there is
+	// nothing to confirm.
+	current, err := semver.NewVersion(n.version)
+	if err != nil {
+		return n.cleanup(pending)
+	}
+
+	if updated, err := semver.NewVersion(pending.Version); err != nil || !current.Equal(updated) {
+		return n.cleanup(pending)
+	}
+
+	if time.Now().After(pending.Deadline) {
+		if err := n.rollback(); err != nil {
+			return err
+		}
+
+		return ErrUpdateRolledBack
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.pending = pending
+
+	return nil
+}
+
+func (n *nativeUpdater) ConfirmUpdate() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.pending == nil {
+		return nil
+	}
+
+	pending := n.pending
+	n.pending = nil
+
+	return n.cleanup(pending)
+}
+
+// Watchdog waits for the deadline of the executable's pending update and, when the update is not confirmed until then,
+// rolls it back, restarting the agent with the previous binary. It is run, from the previous binary, by the
+// [WatchdogCommand].
+func Watchdog(executable string) error {
+	n := &nativeUpdater{executable: executable}
+	n.takeover = n.takeoverAgent
+
+	return n.watch()
+}
+
+func (n *nativeUpdater) watch() error {
+	pending, err := n.readMarker()
+	if err != nil || pending == nil {
+		return err
+	}
+
+	time.Sleep(time.Until(pending.Deadline))
+
+	// NOTICE: The pending update's state is removed when the update is confirmed.
+	if pending, err = n.readMarker(); err != nil || pending == nil {
+		return err
+	}
+
+	log.WithFields(log.Fields{
+		"version":  pending.Version,
+		"previous": pending.Previous,
+	}).Warn("Updated agent did not connect to the server in time, rolling back the update")
+
+	if err := n.restore(); err != nil {
+		return err
+	}
+
+	return n.takeover(pending)
+}
+
+// rollback restores the backup of the previous binary and restarts the agent.
+func (n *nativeUpdater) rollback() error {
+	if err := n.restore(); err != nil {
+		return err
+	}
+
+	return n.restart()
+}
+
+// restore moves the backup of the previous binary to the executable's path, removing the pending update's state.
+func (n *nativeUpdater) restore() error {
+	if err := os.Rename(n.backup(), n.executable); err != nil {
+		return err
+	}
+
+	if err := syncDir(filepath.Dir(n.executable)); err != nil {
+		return err
+	}
+
+	if err := os.Remove(n.marker()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// cleanup removes the pending update's state, its watchdog and the previous binary's backup.
+func (n *nativeUpdater) cleanup(pending *pendingUpdate) error {
+	// NOTICE: The watchdog started as a detached process exits by itself, as the pending update's state is removed.
+	if pending.Unit != "" {
+		exec.Command("systemctl", "stop", watchdogUnit(pending.Unit)+".timer").Run() //nolint:errcheck
+	}
+
+	for _, path := range []string{n.marker(), n.backup()} {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (n *nativeUpdater) readMarker() (*pendingUpdate, error) {
+	data, err := os.ReadFile(n.marker())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+
+	pending := new(pendingUpdate)
+	if err := json.Unmarshal(data, pending); err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+func (n *nativeUpdater) writeMarker(pending *pendingUpdate) error {
+	data, err := json.Marshal(pending)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(n.marker(), data, 0o600)
+}
+
+// startWatchdog starts the watchdog from the previous binary, kept as backup, apart from the agent: as a transient
+// systemd's timer, when the agent is a systemd's service, what would stop any process started by the agent on its
+// restart, or as a detached process otherwise.
+func (n *nativeUpdater) startWatchdog(pending *pendingUpdate) error {
+	if pending.Unit != "" {
+		return exec.Command(
+			"systemd-run",
+			"--collect",
+			"--unit", watchdogUnit(pending.Unit),
+			"--on-active", fmt.Sprintf("%ds", int(time.Until(pending.Deadline).Seconds())+1),
+			"--timer-property", "AccuracySec=1s",
+			n.backup(), WatchdogCommand, n.executable,
+		).Run()
+	}
+
+	cmd := exec.Command(n.backup(), WatchdogCommand, n.executable)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	return cmd.Process.Release()
+}
+
+// takeoverAgent restarts the agent through systemd, when it is the agent's service manager, or replaces the updated
+// agent's process with the previous binary otherwise.
+func (n *nativeUpdater) takeoverAgent(pending *pendingUpdate) error {
+	if pending.Unit != "" {
+		return exec.Command("systemctl", "restart", pending.Unit).Run()
+	}
+
+	// NOTICE: The process is only killed when it still runs the updated binary, as its PID could have been reused.
+	if exe, err := os.Readlink(fmt.Sprintf("/proc/%d/exe", pending.PID)); err == nil && strings.TrimSuffix(exe, " (deleted)") == n.executable {
+		if err := syscall.Kill(pending.PID, syscall.SIGKILL); err != nil && !errors.Is(err, syscall.ESRCH) {
+			return err
+		}
+	}
+
+	return syscall.Exec(n.executable, pending.Args, os.Environ())
+}
+
+// watchdogUnit gets the name of the systemd's transient unit running the watchdog of the agent's service unit.
+func watchdogUnit(unit string) string {
+	return strings.TrimSuffix(unit, ".service") + "-update-watchdog"
+}
+
+// restartService restarts the agent through systemd, when it is the agent's service manager, or replaces the running
+// process with the binary otherwise, keeping its PID for the init system that supervises it.
+func (n *nativeUpdater) restartService() error {
+	if unit := systemdUnit(); unit != "" {
+		// NOTICE: The restart is not blocked on, as systemd stops this process to complete it.
+		return exec.Command("systemctl", "restart", "--no-block", unit).Run()
+	}
+
+	return syscall.Exec(n.executable, os.Args, os.Environ())
+}
+
+// systemdUnit gets the systemd's service unit running the agent, from its control group, if any.
+func systemdUnit() string {
+	file, err := os.Open("/proc/self/cgroup")
+	if err != nil {
+		return ""
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		unit := filepath.Base(scanner.Text())
+		if strings.HasSuffix(unit, ".service") {
+			return unit
+		}
+	}
+
+	return ""
+}
+
+// arch gets the device's architecture as named on the agent's releases.
+func arch() string {
+	switch runtime.GOARCH {
+	case "386":
+		return "i386"
+	case "arm64":
+		return "arm64v8"
+	case "arm":
+		variant := "7"
+		if info, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range info.Settings {
+				if setting.Key == "GOARM" {
+					variant = setting.Value
+				}
+			}
+		}
+
+		return "arm32v" + variant
+	default:
+		return runtime.GOARCH
+	}
+}
+
+// download gets the content from the URL, failing when it is larger than limit.
+func (n *nativeUpdater) download(url string, limit int64) ([]byte, error) {
+	res, err := n.client.Get(url) //nolint:noctx
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %s: %s", url, res.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(res.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("failed to download %s: larger than %d bytes", url, limit)
+	}
+
+	return data, nil
+}
+
+// verify checks the binary against its checksum, in the `sha256sum` format, and its base64 encoded detached signature.
+// The signature covers the version with the binary's checksum, see [signedMessage], so a binary signed to a version
+// cannot be installed as another one.
+func verify(version string, binary, checksum, signature []byte, key ed25519.PublicKey) error {
+	fields := bytes.Fields(checksum)
+	if len(fields) == 0 {
+		return ErrChecksumMismatch
+	}
+
+	sum := sha256.Sum256(binary)
+	if !strings.EqualFold(string(fields[0]), hex.EncodeToString(sum[:])) {
+		return ErrChecksumMismatch
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil || !ed25519.Verify(key, signedMessage(version, sum), decoded) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+// signedMessage is the message signed to release the agent's binary: its version, like `v0.15.0`, and its hex encoded
+// SHA-256 checksum, separated by a space.
+func signedMessage(version string, sum [sha256.Size]byte) []byte {
+	return []byte(version + " " + hex.EncodeToString(sum[:]))
+}
+
+// writeFile writes the data to the file, flushing it to the disk before it is renamed.
+func writeFile(path string, data []byte, mode os.FileMode) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+
+		return err
+	}
+
+	return file.Close()
+}
+
+// syncDir flushes the directory's entries to the disk, persisting the renames done inside it.
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return file.Sync()
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+
+		return err
+	}
+
+	if err := out.Sync(); err != nil {
+		out.Close()
+
+		return err
+	}
+
+	return out.Close()
+}
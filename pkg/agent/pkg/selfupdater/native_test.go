@@ -0,0 +1,277 @@
+package selfupdater
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// release serves the binary, with its checksum and signature, as the agent's v0.15.0 release. The signature is made to
+// the signed binary released as the version.
+func release(t *testing.T, binary, signed []byte, version string, key ed25519.PrivateKey) *httptest.Server {
+	t.Helper()
+
+	sum := sha256.Sum256(binary)
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, signedMessage(version, sha256.Sum256(signed))))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v0.15.0/agent-"+arch(), func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(binary) //nolint:errcheck
+	})
+	mux.HandleFunc("/v0.15.0/agent-"+arch()+".sha256", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(hex.EncodeToString(sum[:]) + "  agent\n")) //nolint:errcheck
+	})
+	mux.HandleFunc("/v0.15.0/agent-"+arch()+".sig", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(signature + "\n")) //nolint:errcheck
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func updater(t *testing.T, version, url string, key ed25519.PublicKey) (*nativeUpdater, *int) {
+	t.Helper()
+
+	executable := filepath.Join(t.TempDir(), "agent")
+	require.NoError(t, os.WriteFile(executable, []byte("previous"), 0o755))
+
+	// NOTICE: The restarts count both the agent's restarts and the watchdog's takeovers.
+	restarts := new(int)
+
+	n := &nativeUpdater{
+		version: version,
+		config: &Config{
+			URL:       url + "/{version}/agent-{arch}",
+			PublicKey: base64.StdEncoding.EncodeToString(key),
+			Deadline:  time.Minute,
+		},
+		executable: executable,
+		client:     http.DefaultClient,
+		restart: func() error {
+			*restarts++
+
+			return nil
+		},
+		watchdog: func(*pendingUpdate) error {
+			return nil
+		},
+		takeover: func(*pendingUpdate) error {
+			*restarts++
+
+			return nil
+		},
+	}
+
+	return n, restarts
+}
+
+func TestApplyUpdate(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	server := release(t, []byte("updated"), []byte("updated"), "v0.15.0", private)
+	n, restarts := updater(t, "v0.14.0", server.URL, public)
+
+	require.NoError(t, n.ApplyUpdate(semver.MustParse("v0.15.0")))
+	assert.Equal(t, 1, *restarts)
+
+	binary, err := os.ReadFile(n.executable)
+	require.NoError(t, err)
+	assert.Equal(t, "updated", string(binary))
+
+	backup, err := os.ReadFile(n.backup())
+	require.NoError(t, err)
+	assert.Equal(t, "previous", string(backup))
+
+	info, err := os.Stat(n.executable)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o755), info.Mode().Perm())
+
+	pending, err := n.readMarker()
+	require.NoError(t, err)
+	assert.Equal(t, "v0.15.0", pending.Version)
+	assert.Equal(t, "v0.14.0", pending.Previous)
+
+	_, err = os.Stat(n.staging())
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestApplyUpdateInvalidSignature(t *testing.T) {
+	cases := []struct {
+		description string
+		signed      string
+		version     string
+	}{
+		{
+			description: "fails when the signature is made to another binary",
+			signed:      "tampered",
+			version:     "v0.15.0",
+		},
+		{
+			description: "fails when the signature is made to another version",
+			signed:      "updated",
+			version:     "v0.14.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			public, private, err := ed25519.GenerateKey(rand.Reader)
+			require.NoError(t, err)
+
+			server := release(t, []byte("updated"), []byte(tc.signed), tc.version, private)
+			n, restarts := updater(t, "v0.14.0", server.URL, public)
+
+			assert.ErrorIs(t, n.ApplyUpdate(semver.MustParse("v0.15.0")), ErrSignatureMismatch)
+			assert.Equal(t, 0, *restarts)
+
+			binary, err := os.ReadFile(n.executable)
+			require.NoError(t, err)
+			assert.Equal(t, "previous", string(binary))
+
+			_, err = os.Stat(n.marker())
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
+
+func TestApplyUpdateDisabled(t *testing.T) {
+	n, restarts := updater(t, "v0.14.0", "http://localhost", nil)
+	n.config.PublicKey = ""
+
+	assert.ErrorIs(t, n.ApplyUpdate(semver.MustParse("v0.15.0")), ErrUpdateDisabled)
+	assert.Equal(t, 0, *restarts)
+}
+
+func TestCompleteUpdate(t *testing.T) {
+	cases := []struct {
+		description string
+		version     string
+		deadline    time.Duration
+		confirm     bool
+		expected    error
+		binary      string
+		restarts    int
+	}{
+		{
+			description: "keeps the update when it is confirmed before the deadline",
+			version:     "v0.15.0",
+			deadline:    time.Minute,
+			confirm:     true,
+			binary:      "updated",
+			restarts:    0,
+		},
+		{
+			description: "rolls back the update when the deadline has passed",
+			version:     "v0.15.0",
+			deadline:    -time.Minute,
+			expected:    ErrUpdateRolledBack,
+			binary:      "previous",
+			restarts:    1,
+		},
+		{
+			description: "discards the update when the running binary is not the updated one",
+			version:     "v0.14.0",
+			deadline:    time.Minute,
+			binary:      "updated",
+			restarts:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			n, restarts := updater(t, tc.version, "http://localhost", nil)
+
+			require.NoError(t, os.WriteFile(n.backup(), []byte("previous"), 0o755))
+			require.NoError(t, os.WriteFile(n.executable, []byte("updated"), 0o755))
+			require.NoError(t, n.writeMarker(&pendingUpdate{
+				Version:  "v0.15.0",
+				Previous: "v0.14.0",
+				Deadline: time.Now().Add(tc.deadline),
+			}))
+
+			assert.ErrorIs(t, n.CompleteUpdate(), tc.expected)
+
+			if tc.confirm {
+				require.NoError(t, n.ConfirmUpdate())
+			}
+
+			binary, err := os.ReadFile(n.executable)
+			require.NoError(t, err)
+			assert.Equal(t, tc.binary, string(binary))
+			assert.Equal(t, tc.restarts, *restarts)
+
+			for _, path := range []string{n.marker(), n.backup()} {
+				_, err := os.Stat(path)
+				assert.ErrorIs(t, err, os.ErrNotExist)
+			}
+		})
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	cases := []struct {
+		description string
+		confirm     bool
+		binary      string
+		restarts    int
+	}{
+		{
+			description: "rolls back the update when it is not confirmed before the deadline",
+			confirm:     false,
+			binary:      "previous",
+			restarts:    1,
+		},
+		{
+			description: "keeps the update when it is confirmed before the deadline",
+			confirm:     true,
+			binary:      "updated",
+			restarts:    0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			n, restarts := updater(t, "v0.15.0", "http://localhost", nil)
+
+			require.NoError(t, os.WriteFile(n.backup(), []byte("previous"), 0o755))
+			require.NoError(t, os.WriteFile(n.executable, []byte("updated"), 0o755))
+			require.NoError(t, n.writeMarker(&pendingUpdate{
+				Version:  "v0.15.0",
+				Previous: "v0.14.0",
+				Deadline: time.Now().Add(50 * time.Millisecond),
+			}))
+
+			require.NoError(t, n.CompleteUpdate())
+
+			if tc.confirm {
+				require.NoError(t, n.ConfirmUpdate())
+			}
+
+			// NOTICE: The watchdog runs from the previous binary, apart from the updated agent.
+			require.NoError(t, n.watch())
+			assert.Equal(t, tc.restarts, *restarts)
+
+			binary, err := os.ReadFile(n.executable)
+			require.NoError(t, err)
+			assert.Equal(t, tc.binary, string(binary))
+
+			_, err = os.Stat(n.marker())
+			assert.ErrorIs(t, err, os.ErrNotExist)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package selfupdater
 
 import (
+	"time"
+
 	"github.com/Masterminds/semver"
 )
 
@@ -8,20 +10,20 @@ type Updater interface {
 	CurrentVersion() (*semver.Version, error)
 	ApplyUpdate(v *semver.Version) error
 	CompleteUpdate() error
+	// ConfirmUpdate confirms the update completed by [Updater.CompleteUpdate], after the updated agent connects to the
+	// server, what keeps it from being rolled back.
+	ConfirmUpdate() error
 }
 
-type nativeUpdater struct {
-	version string
-}
-
-func (n *nativeUpdater) CurrentVersion() (*semver.Version, error) {
-	return semver.NewVersion(n.version)
-}
-
-func (n *nativeUpdater) ApplyUpdate(_ *semver.Version) error {
-	return nil
-}
-
-func (n *nativeUpdater) CompleteUpdate() error {
-	return nil
+// Config is the configuration of the self-update of native installs.
+type Config struct {
+	// URL is the template of the agent's binary URL, with the `{version}` and `{arch}` placeholders.
+	URL string
+	// PublicKey is the base64 encoded Ed25519 public key that verifies the binary's signature.
+	PublicKey string
+	// Deadline is the time the updated agent has to connect to the server before the update is rolled back.
+	Deadline time.Duration
+	// Proxy is the proxy used to download the binary, in the same format of the agent's proxy. When empty, the proxy
+	// from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables is used.
+	Proxy string
 }
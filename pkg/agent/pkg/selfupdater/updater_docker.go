@@ -141,6 +141,10 @@ func (d *dockerUpdater) CompleteUpdate() error {
 	return nil
 }
 
+func (d *dockerUpdater) ConfirmUpdate() error {
+	return nil
+}
+
 func (d *dockerUpdater) getContainer(id string) (*dockerContainer, error) {
 	ctx := context.Background()
 
@@ -223,10 +227,10 @@ func (d *dockerUpdater) updateContainer(container *dockerContainer, image, name
 	return d.getContainer(clone.ID)
 }
 
-func NewUpdater(version string) (Updater, error) {
-	// ensure we are running inside a docker container, otherwise returns a dummy updater implementation
+func NewUpdater(version string, config *Config) (Updater, error) {
+	// ensure we are running inside a docker container, otherwise returns the native updater implementation
 	if _, err := os.Stat("/.dockerenv"); os.IsNotExist(err) {
-		return &nativeUpdater{version}, nil
+		return newNativeUpdater(version, config)
 	}
 
 	api, err := client.NewClientWithOpts(client.FromEnv)
@@ -3,6 +3,6 @@
 
 package selfupdater
 
-func NewUpdater(version string) (Updater, error) {
-	return &nativeUpdater{version}, nil
+func NewUpdater(version string, config *Config) (Updater, error) {
+	return newNativeUpdater(version, config)
 }
@@ -0,0 +1,320 @@
+package sysinfo
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	DefaultCPUInfoFilename       = "/proc/cpuinfo"
+	DefaultMountsFilename        = "/proc/mounts"
+	DefaultLocaltimePath         = "/etc/localtime"
+	DefaultTimezoneFilename      = "/etc/timezone"
+	DefaultDockerSocket          = "/var/run/docker.sock"
+	DefaultKernelReleaseFilename = "/proc/sys/kernel/osrelease"
+	DefaultHostnameFilename      = "/proc/sys/kernel/hostname"
+)
+
+// Probe collects part of the device's inventory.
+type Probe func(inventory *models.DeviceInventory) error
+
+// Probes are the probes run to collect the device's inventory. To extend the inventory, append a new probe to it.
+var Probes = []Probe{
+	ProbeKernel,
+	ProbeHostname,
+	ProbeTimezone,
+	ProbeCPU,
+	ProbeMemory,
+	ProbeDisks,
+	ProbeInterfaces,
+	ProbePackages,
+	ProbeDocker,
+}
+
+// GetInventory collects the device's inventory running all the [Probes]. A probe that fails doesn't stop the others,
+// leaving its part of the inventory empty.
+func GetInventory() *models.DeviceInventory {
+	inventory := new(models.DeviceInventory)
+	for _, probe := range Probes {
+		_ = probe(inventory)
+	}
+
+	return inventory
+}
+
+// ProbeKernel gets the kernel's release.
+func ProbeKernel(inventory *models.DeviceInventory) error {
+	kernel, err := readTrimmed(DefaultKernelReleaseFilename)
+	if err != nil {
+		return err
+	}
+
+	inventory.Kernel = kernel
+
+	return nil
+}
+
+// ProbeHostname gets the device's hostname.
+func ProbeHostname(inventory *models.DeviceInventory) error {
+	hostname, err := readTrimmed(DefaultHostnameFilename)
+	if err != nil {
+		if hostname, err = os.Hostname(); err != nil {
+			return err
+		}
+	}
+
+	inventory.Hostname = hostname
+
+	return nil
+}
+
+// ProbeTimezone gets the device's timezone, from the `/etc/timezone` file or from the zoneinfo's file linked by the
+// `/etc/localtime`.
+func ProbeTimezone(inventory *models.DeviceInventory) error {
+	if timezone, err := readTrimmed(DefaultTimezoneFilename); err == nil && timezone != "" {
+		inventory.Timezone = timezone
+
+		return nil
+	}
+
+	target, err := filepath.EvalSymlinks(DefaultLocaltimePath)
+	if err != nil {
+		return err
+	}
+
+	if _, timezone, ok := strings.Cut(target, "zoneinfo/"); ok {
+		inventory.Timezone = timezone
+	}
+
+	return nil
+}
+
+// ProbeCPU gets the CPU's model and the number of logical CPUs.
+func ProbeCPU(inventory *models.DeviceInventory) error {
+	return readProcFileInto(DefaultCPUInfoFilename, inventory, parseCPUInfo)
+}
+
+// ProbeMemory gets the device's total memory.
+func ProbeMemory(inventory *models.DeviceInventory) error {
+	usage, err := readProcFile(DefaultMemInfoFilename, parseMemInfo)
+	if err != nil {
+		return err
+	}
+
+	inventory.Memory = usage.Total
+
+	return nil
+}
+
+// ProbeDisks gets the device's mounted block devices and their sizes.
+func ProbeDisks(inventory *models.DeviceInventory) error {
+	disks, err := readProcFile(DefaultMountsFilename, parseMounts)
+	if err != nil {
+		return err
+	}
+
+	for i := range disks {
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(disks[i].Mountpoint, &stat); err == nil {
+			disks[i].Size = stat.Blocks * uint64(stat.Bsize) //nolint:unconvert
+		}
+	}
+
+	inventory.Disks = disks
+
+	return nil
+}
+
+// ProbeInterfaces gets the device's network interfaces, but the loopback, and their addresses.
+func ProbeInterfaces(inventory *models.DeviceInventory) error {
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return err
+	}
+
+	inventory.Interfaces = make([]models.DeviceInventoryInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagLoopback > 0 {
+			continue
+		}
+
+		ips := make([]string, 0)
+		if addrs, err := iface.Addrs(); err == nil {
+			for _, addr := range addrs {
+				if ipnet, ok := addr.(*net.IPNet); ok {
+					ips = append(ips, ipnet.IP.String())
+				}
+			}
+		}
+
+		inventory.Interfaces = append(inventory.Interfaces, models.DeviceInventoryInterface{
+			Name: iface.Name,
+			MAC:  iface.HardwareAddr.String(),
+			IPs:  ips,
+		})
+	}
+
+	return nil
+}
+
+// packageDatabases are the databases of the supported package managers, with the function that counts the packages
+// installed from them.
+var packageDatabases = []struct {
+	path  string
+	count func(path string) (int, error)
+}{
+	{path: "/var/lib/dpkg/status", count: countDpkgPackages},
+	{path: "/lib/apk/db/installed", count: countApkPackages},
+	{path: "/var/lib/pacman/local", count: countDirEntries},
+}
+
+// ProbePackages gets the number of packages installed through the first package manager found on the device.
+func ProbePackages(inventory *models.DeviceInventory) error {
+	for _, db := range packageDatabases {
+		if _, err := os.Stat(db.path); err != nil {
+			continue
+		}
+
+		count, err := db.count(db.path)
+		if err != nil {
+			return err
+		}
+
+		inventory.Packages = count
+
+		return nil
+	}
+
+	return nil
+}
+
+// ProbeDocker checks if the Docker engine is present on the device.
+func ProbeDocker(inventory *models.DeviceInventory) error {
+	info, err := os.Stat(DefaultDockerSocket)
+	inventory.Docker = err == nil && info.Mode()&os.ModeSocket != 0
+
+	return nil
+}
+
+func readTrimmed(filename string) (string, error) {
+	data, err := os.ReadFile(filename)
+
+	return strings.TrimSpace(string(data)), err
+}
+
+func readProcFileInto(filename string, inventory *models.DeviceInventory, parse func(io.Reader, *models.DeviceInventory) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	return parse(file, inventory)
+}
+
+// parseCPUInfo parses the CPU's model and counts the logical CPUs from the `/proc/cpuinfo`.
+func parseCPUInfo(r io.Reader, inventory *models.DeviceInventory) error {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+
+		switch strings.TrimSpace(key) {
+		case "processor":
+			inventory.CPUs++
+		case "model name", "Model", "cpu model":
+			if inventory.CPU == "" {
+				inventory.CPU = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseMounts parses the mounted block devices from the `/proc/mounts`, skipping the pseudo filesystems and the
+// devices mounted more than once.
+func parseMounts(r io.Reader) ([]models.DeviceInventoryDisk, error) {
+	disks := make([]models.DeviceInventoryDisk, 0)
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || !strings.HasPrefix(fields[0], "/dev/") || seen[fields[0]] {
+			continue
+		}
+
+		seen[fields[0]] = true
+
+		disks = append(disks, models.DeviceInventoryDisk{
+			Device:     fields[0],
+			Mountpoint: fields[1],
+			Filesystem: fields[2],
+		})
+	}
+
+	return disks, scanner.Err()
+}
+
+// countDpkgPackages counts the installed packages in the dpkg's status database.
+func countDpkgPackages(path string) (int, error) {
+	return countLines(path, func(line string) bool {
+		return line == "Status: install ok installed"
+	})
+}
+
+// countApkPackages counts the packages in the apk's installed database.
+func countApkPackages(path string) (int, error) {
+	return countLines(path, func(line string) bool {
+		return strings.HasPrefix(line, "P:")
+	})
+}
+
+func countLines(path string, match func(line string) bool) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+
+	defer file.Close()
+
+	count := 0
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if match(scanner.Text()) {
+			count++
+		}
+	}
+
+	return count, scanner.Err()
+}
+
+// countDirEntries counts the directories inside path, what is how pacman stores each installed package.
+func countDirEntries(path string) (int, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,126 @@
+package sysinfo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUInfo(t *testing.T) {
+	cpuinfo := `processor	: 0
+vendor_id	: GenuineIntel
+model name	: Intel(R) Core(TM) i7-8550U CPU @ 1.80GHz
+
+processor	: 1
+vendor_id	: GenuineIntel
+model name	: Intel(R) Core(TM) i7-8550U CPU @ 1.80GHz
+`
+
+	inventory := new(models.DeviceInventory)
+	assert.NoError(t, parseCPUInfo(strings.NewReader(cpuinfo), inventory))
+	assert.Equal(t, "Intel(R) Core(TM) i7-8550U CPU @ 1.80GHz", inventory.CPU)
+	assert.Equal(t, 2, inventory.CPUs)
+}
+
+func TestParseMounts(t *testing.T) {
+	mounts := `proc /proc proc rw,nosuid,nodev,noexec,relatime 0 0
+/dev/sda1 / ext4 rw,relatime 0 0
+tmpfs /tmp tmpfs rw,nosuid,nodev 0 0
+/dev/sda1 /var/lib/docker ext4 rw,relatime 0 0
+/dev/sdb1 /data xfs rw,relatime 0 0
+`
+
+	disks, err := parseMounts(strings.NewReader(mounts))
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DeviceInventoryDisk{
+		{Device: "/dev/sda1", Mountpoint: "/", Filesystem: "ext4"},
+		{Device: "/dev/sdb1", Mountpoint: "/data", Filesystem: "xfs"},
+	}, disks)
+}
+
+func TestCountPackages(t *testing.T) {
+	dir := t.TempDir()
+
+	dpkg := filepath.Join(dir, "status")
+	assert.NoError(t, os.WriteFile(dpkg, []byte(`Package: bash
+Status: install ok installed
+
+Package: vim
+Status: deinstall ok config-files
+
+Package: curl
+Status: install ok installed
+`), 0o600))
+
+	count, err := countDpkgPackages(dpkg)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	apk := filepath.Join(dir, "installed")
+	assert.NoError(t, os.WriteFile(apk, []byte("C:Q1\nP:musl\nV:1.2\n\nC:Q2\nP:busybox\nV:1.36\n"), 0o600))
+
+	count, err = countApkPackages(apk)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	pacman := filepath.Join(dir, "local")
+	assert.NoError(t, os.MkdirAll(filepath.Join(pacman, "bash-5.2-1"), 0o700))
+	assert.NoError(t, os.MkdirAll(filepath.Join(pacman, "curl-8.0-1"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(pacman, "ALPM_DB_VERSION"), []byte("9"), 0o600))
+
+	count, err = countDirEntries(pacman)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestProbeTimezone(t *testing.T) {
+	dir := t.TempDir()
+
+	timezone, localtime := DefaultTimezoneFilename, DefaultLocaltimePath
+	defer func() { DefaultTimezoneFilename, DefaultLocaltimePath = timezone, localtime }()
+
+	zoneinfo := filepath.Join(dir, "zoneinfo", "America", "Sao_Paulo")
+	assert.NoError(t, os.MkdirAll(filepath.Dir(zoneinfo), 0o700))
+	assert.NoError(t, os.WriteFile(zoneinfo, []byte("TZif"), 0o600))
+	assert.NoError(t, os.Symlink(zoneinfo, filepath.Join(dir, "localtime")))
+
+	DefaultTimezoneFilename = filepath.Join(dir, "timezone")
+	DefaultLocaltimePath = filepath.Join(dir, "localtime")
+
+	inventory := new(models.DeviceInventory)
+	assert.NoError(t, ProbeTimezone(inventory))
+	assert.Equal(t, "America/Sao_Paulo", inventory.Timezone)
+
+	assert.NoError(t, os.WriteFile(DefaultTimezoneFilename, []byte("Etc/UTC\n"), 0o600))
+
+	inventory = new(models.DeviceInventory)
+	assert.NoError(t, ProbeTimezone(inventory))
+	assert.Equal(t, "Etc/UTC", inventory.Timezone)
+}
+
+func TestGetInventory(t *testing.T) {
+	probes := Probes
+	defer func() { Probes = probes }()
+
+	Probes = []Probe{
+		func(inventory *models.DeviceInventory) error {
+			inventory.Kernel = "6.1.0"
+
+			return nil
+		},
+		func(*models.DeviceInventory) error {
+			return os.ErrNotExist
+		},
+		func(inventory *models.DeviceInventory) error {
+			inventory.Docker = true
+
+			return nil
+		},
+	}
+
+	assert.Equal(t, &models.DeviceInventory{Kernel: "6.1.0", Docker: true}, GetInventory())
+}
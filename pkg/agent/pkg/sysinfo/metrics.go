@@ -0,0 +1,268 @@
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+)
+
+var (
+	DefaultStatFilename    = "/proc/stat"
+	DefaultMemInfoFilename = "/proc/meminfo"
+	DefaultLoadAvgFilename = "/proc/loadavg"
+	DefaultUptimeFilename  = "/proc/uptime"
+	DefaultNetDevFilename  = "/proc/net/dev"
+	// DefaultDiskPath is the path whose filesystem's usage is reported as the disk's usage.
+	DefaultDiskPath = "/"
+)
+
+// ErrInvalidProcFile is returned when a procfs' file doesn't have the expected format.
+var ErrInvalidProcFile = errors.New("invalid procfs file")
+
+// cpuTimes are the CPU's busy and total times, in jiffies, since the boot.
+type cpuTimes struct {
+	busy  uint64
+	total uint64
+}
+
+// Collector collects the device's metrics. As the CPU's usage is relative to the previous collection, the same
+// collector must be used across collections.
+type Collector struct {
+	mu   sync.Mutex
+	prev cpuTimes
+}
+
+// NewCollector creates a new Collector.
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Collect gets the device's current metrics.
+func (c *Collector) Collect() (*models.DeviceMetrics, error) {
+	metrics := &models.DeviceMetrics{
+		Time: time.Now(),
+	}
+
+	times, err := readProcFile(DefaultStatFilename, parseCPUTimes)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	metrics.CPU = cpuUsage(c.prev, times)
+	c.prev = times
+	c.mu.Unlock()
+
+	if metrics.Memory, err = readProcFile(DefaultMemInfoFilename, parseMemInfo); err != nil {
+		return nil, err
+	}
+
+	if metrics.Load, err = readProcFile(DefaultLoadAvgFilename, parseLoadAvg); err != nil {
+		return nil, err
+	}
+
+	if metrics.Uptime, err = readProcFile(DefaultUptimeFilename, parseUptime); err != nil {
+		return nil, err
+	}
+
+	if metrics.Network, err = readProcFile(DefaultNetDevFilename, parseNetDev); err != nil {
+		return nil, err
+	}
+
+	if metrics.Disk, err = diskUsage(DefaultDiskPath); err != nil {
+		return nil, err
+	}
+
+	return metrics, nil
+}
+
+func readProcFile[T any](filename string, parse func(io.Reader) (T, error)) (T, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		var zero T
+
+		return zero, err
+	}
+
+	defer file.Close()
+
+	return parse(file)
+}
+
+// cpuUsage calculates the percentage of the CPU's time used between the prev and the curr times.
+func cpuUsage(prev, curr cpuTimes) float64 {
+	if curr.total <= prev.total || curr.busy < prev.busy {
+		return 0
+	}
+
+	return float64(curr.busy-prev.busy) / float64(curr.total-prev.total) * 100
+}
+
+// parseCPUTimes parses the aggregated CPU line from the `/proc/stat`, where the idle and iowait times are not busy.
+func parseCPUTimes(r io.Reader) (cpuTimes, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 5 || fields[0] != "cpu" {
+			continue
+		}
+
+		var times cpuTimes
+		for i, field := range fields[1:] {
+			value, err := strconv.ParseUint(field, 10, 64)
+			if err != nil {
+				return cpuTimes{}, fmt.Errorf("%w: %w", ErrInvalidProcFile, err)
+			}
+
+			// NOTICE: The guest's times are already accounted in the user's ones.
+			if i >= 8 {
+				break
+			}
+
+			times.total += value
+			if i != 3 && i != 4 {
+				times.busy += value
+			}
+		}
+
+		return times, nil
+	}
+
+	return cpuTimes{}, ErrInvalidProcFile
+}
+
+// parseMemInfo parses the `/proc/meminfo`, considering as used the memory not available to start new applications.
+func parseMemInfo(r io.Reader) (models.DeviceMetricsUsage, error) {
+	values := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		// The values are in kibibytes.
+		values[strings.TrimSuffix(fields[0], ":")] = value * 1024
+	}
+
+	total, ok := values["MemTotal"]
+	if !ok {
+		return models.DeviceMetricsUsage{}, ErrInvalidProcFile
+	}
+
+	available, ok := values["MemAvailable"]
+	if !ok {
+		available = values["MemFree"] + values["Buffers"] + values["Cached"]
+	}
+
+	if available > total {
+		available = total
+	}
+
+	return models.DeviceMetricsUsage{Total: total, Used: total - available}, nil
+}
+
+// parseLoadAvg parses the load averages from the `/proc/loadavg`.
+func parseLoadAvg(r io.Reader) ([]float64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil, ErrInvalidProcFile
+	}
+
+	load := make([]float64, 3)
+	for i := range load {
+		if load[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProcFile, err)
+		}
+	}
+
+	return load, nil
+}
+
+// parseUptime parses the seconds since the boot from the `/proc/uptime`.
+func parseUptime(r io.Reader) (int64, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return 0, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 1 {
+		return 0, ErrInvalidProcFile
+	}
+
+	uptime, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidProcFile, err)
+	}
+
+	return int64(uptime), nil
+}
+
+// parseNetDev parses the received and transmitted bytes of all interfaces, but the loopback, from the `/proc/net/dev`.
+func parseNetDev(r io.Reader) (models.DeviceMetricsNetwork, error) {
+	var network models.DeviceMetricsNetwork
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name, counters, ok := strings.Cut(scanner.Text(), ":")
+		if !ok || strings.TrimSpace(name) == "lo" {
+			continue
+		}
+
+		fields := strings.Fields(counters)
+		if len(fields) < 9 {
+			return models.DeviceMetricsNetwork{}, ErrInvalidProcFile
+		}
+
+		rx, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			return models.DeviceMetricsNetwork{}, fmt.Errorf("%w: %w", ErrInvalidProcFile, err)
+		}
+
+		tx, err := strconv.ParseUint(fields[8], 10, 64)
+		if err != nil {
+			return models.DeviceMetricsNetwork{}, fmt.Errorf("%w: %w", ErrInvalidProcFile, err)
+		}
+
+		network.RxBytes += rx
+		network.TxBytes += tx
+	}
+
+	return network, scanner.Err()
+}
+
+// diskUsage gets the usage of the filesystem where path is mounted.
+func diskUsage(path string) (models.DeviceMetricsUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return models.DeviceMetricsUsage{}, err
+	}
+
+	size := uint64(stat.Bsize) //nolint:unconvert
+
+	return models.DeviceMetricsUsage{
+		Total: stat.Blocks * size,
+		Used:  (stat.Blocks - stat.Bfree) * size,
+	}, nil
+}
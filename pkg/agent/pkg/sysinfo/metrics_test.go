@@ -0,0 +1,93 @@
+package sysinfo
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCPUTimes(t *testing.T) {
+	stat := `cpu  100 10 50 800 40 0 0 0 5 0
+cpu0 50 5 25 400 20 0 0 0 5 0
+intr 12345
+`
+
+	times, err := parseCPUTimes(strings.NewReader(stat))
+	assert.NoError(t, err)
+	assert.Equal(t, cpuTimes{busy: 160, total: 1000}, times)
+
+	_, err = parseCPUTimes(strings.NewReader("intr 12345\n"))
+	assert.ErrorIs(t, err, ErrInvalidProcFile)
+}
+
+func TestCPUUsage(t *testing.T) {
+	assert.Equal(t, float64(0), cpuUsage(cpuTimes{}, cpuTimes{}))
+	assert.Equal(t, float64(25), cpuUsage(cpuTimes{busy: 100, total: 400}, cpuTimes{busy: 200, total: 800}))
+	assert.Equal(t, float64(0), cpuUsage(cpuTimes{busy: 200, total: 800}, cpuTimes{busy: 100, total: 400}))
+}
+
+func TestParseMemInfo(t *testing.T) {
+	cases := []struct {
+		description string
+		meminfo     string
+		expected    models.DeviceMetricsUsage
+		err         error
+	}{
+		{
+			description: "uses the available memory",
+			meminfo:     "MemTotal:       1000 kB\nMemFree:         100 kB\nMemAvailable:    400 kB\n",
+			expected:    models.DeviceMetricsUsage{Total: 1000 * 1024, Used: 600 * 1024},
+		},
+		{
+			description: "falls back to free, buffers and cached memory",
+			meminfo:     "MemTotal:       1000 kB\nMemFree:         100 kB\nBuffers:          50 kB\nCached:          150 kB\n",
+			expected:    models.DeviceMetricsUsage{Total: 1000 * 1024, Used: 700 * 1024},
+		},
+		{
+			description: "fails without the total memory",
+			meminfo:     "MemFree:         100 kB\n",
+			err:         ErrInvalidProcFile,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			usage, err := parseMemInfo(strings.NewReader(tc.meminfo))
+			assert.ErrorIs(t, err, tc.err)
+			assert.Equal(t, tc.expected, usage)
+		})
+	}
+}
+
+func TestParseLoadAvg(t *testing.T) {
+	load, err := parseLoadAvg(strings.NewReader("0.52 0.58 0.59 1/467 12345\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{0.52, 0.58, 0.59}, load)
+
+	_, err = parseLoadAvg(strings.NewReader("0.52\n"))
+	assert.ErrorIs(t, err, ErrInvalidProcFile)
+}
+
+func TestParseUptime(t *testing.T) {
+	uptime, err := parseUptime(strings.NewReader("3600.75 7000.10\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3600), uptime)
+
+	_, err = parseUptime(strings.NewReader("invalid\n"))
+	assert.ErrorIs(t, err, ErrInvalidProcFile)
+}
+
+func TestParseNetDev(t *testing.T) {
+	netdev := `Inter-|   Receive                                                |  Transmit
+ face |bytes    packets errs drop fifo frame compressed multicast|bytes    packets errs drop fifo colls carrier compressed
+    lo:  5000      50    0    0    0     0          0         0     5000      50    0    0    0     0       0          0
+  eth0:  1000      10    0    0    0     0          0         0     2000      20    0    0    0     0       0          0
+ wlan0:   300       3    0    0    0     0          0         0      400       4    0    0    0     0       0          0
+`
+
+	network, err := parseNetDev(strings.NewReader(netdev))
+	assert.NoError(t, err)
+	assert.Equal(t, models.DeviceMetricsNetwork{RxBytes: 1300, TxBytes: 2400}, network)
+}
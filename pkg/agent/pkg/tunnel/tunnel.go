@@ -6,15 +6,17 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 )
 
 type Tunnel struct {
 	router       *echo.Echo
 	srv          *http.Server
 	HTTPHandler  func(e echo.Context) error
+	TCPHandler   func(e echo.Context) error
 	ConnHandler  func(e echo.Context) error
 	CloseHandler func(e echo.Context) error
+	FilesHandler func(e echo.Context) error
+	PushHandler  func(e echo.Context) error
 }
 
 type Builder struct {
@@ -33,6 +35,12 @@ func (t *Builder) WithHTTPHandler(handler func(e echo.Context) error) *Builder {
 	return t
 }
 
+func (t *Builder) WithTCPHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.TCPHandler = handler
+
+	return t
+}
+
 func (t *Builder) WithConnHandler(handler func(e echo.Context) error) *Builder {
 	t.tunnel.ConnHandler = handler
 
@@ -45,6 +53,18 @@ func (t *Builder) WithCloseHandler(handler func(e echo.Context) error) *Builder
 	return t
 }
 
+func (t *Builder) WithFilesHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.FilesHandler = handler
+
+	return t
+}
+
+func (t *Builder) WithPushHandler(handler func(e echo.Context) error) *Builder {
+	t.tunnel.PushHandler = handler
+
+	return t
+}
+
 func (t *Builder) Build() *Tunnel {
 	return t.tunnel
 }
@@ -63,16 +83,34 @@ func NewTunnel() *Tunnel {
 		HTTPHandler: func(e echo.Context) error {
 			panic("HTTPHandler can not be nil")
 		},
+		TCPHandler: func(e echo.Context) error {
+			panic("TCPHandler can not be nil")
+		},
 		ConnHandler: func(e echo.Context) error {
 			panic("connHandler can not be nil")
 		},
 		CloseHandler: func(e echo.Context) error {
 			panic("closeHandler can not be nil")
 		},
+		FilesHandler: func(e echo.Context) error {
+			panic("FilesHandler can not be nil")
+		},
+		PushHandler: func(e echo.Context) error {
+			panic("PushHandler can not be nil")
+		},
 	}
 	e.GET("/ssh/http", func(e echo.Context) error {
 		return t.HTTPHandler(e)
 	})
+	e.GET("/ssh/tcp", func(e echo.Context) error {
+		return t.TCPHandler(e)
+	})
+	e.Any("/ssh/files/:action", func(e echo.Context) error {
+		return t.FilesHandler(e)
+	})
+	e.Any("/ssh/push/:action", func(e echo.Context) error {
+		return t.PushHandler(e)
+	})
 	e.GET("/ssh/:id", func(e echo.Context) error {
 		return t.ConnHandler(e)
 	})
@@ -84,7 +122,7 @@ func NewTunnel() *Tunnel {
 }
 
 // Listen to reverse listener.
-func (t *Tunnel) Listen(l *revdial.Listener) error {
+func (t *Tunnel) Listen(l net.Listener) error {
 	return t.srv.Serve(l)
 }
 
@@ -1,16 +1,12 @@
 package connector
 
 import (
-	"archive/tar"
-	"context"
 	"crypto"
 	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
-	"io"
 
-	dockerclient "github.com/docker/docker/client"
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
@@ -33,41 +29,26 @@ type Authenticator struct {
 	//
 	// NOTICE: Uses a pointer for later assignment.
 	container *string
-	// docker is a client to communicate with the Docker's API.
-	docker dockerclient.APIClient
+	// runtime is the container runtime where the container runs.
+	runtime Runtime
 	// osauth is an instance of the OSAuth interface to authenticate the user on the Operating System.
 	osauth osauth.OSAuther
 }
 
 // NewAuthenticator creates a new instance of Authenticator for the connector mode.
-func NewAuthenticator(api client.Client, docker dockerclient.APIClient, authData *models.DeviceAuthResponse, container *string) *Authenticator {
+func NewAuthenticator(api client.Client, runtime Runtime, authData *models.DeviceAuthResponse, container *string) *Authenticator {
 	return &Authenticator{
 		api:       api,
 		authData:  authData,
 		container: container,
-		docker:    docker,
+		runtime:   runtime,
 		osauth:    new(osauth.OSAuth),
 	}
 }
 
-// getPasswd return a [io.Reader] for the container's passwd file.
-func getPasswd(ctx context.Context, cli dockerclient.APIClient, container string) (io.Reader, error) {
-	passwdTar, _, err := cli.CopyFromContainer(ctx, container, "/etc/passwd")
-	if err != nil {
-		return nil, err
-	}
-
-	passwd := tar.NewReader(passwdTar)
-	if _, err := passwd.Next(); err != nil {
-		return nil, err
-	}
-
-	return passwd, nil
-}
-
 // Password handles the server's SSH password authentication when server is running in connector mode.
 func (a *Authenticator) Password(ctx gliderssh.Context, username string, password string) bool {
-	passwd, err := getPasswd(ctx, a.docker, *a.container)
+	passwd, err := a.runtime.ReadFile(ctx, *a.container, "/etc/passwd")
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -79,6 +60,8 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 		return false
 	}
 
+	defer passwd.Close()
+
 	user, err := a.osauth.LookupUserFromPasswd(username, passwd)
 	if err != nil {
 		log.WithFields(
@@ -103,7 +86,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 		return false
 	}
 
-	shadowTar, _, err := a.docker.CopyFromContainer(ctx, *a.container, "/etc/shadow")
+	shadow, err := a.runtime.ReadFile(ctx, *a.container, "/etc/shadow")
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -115,17 +98,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 		return false
 	}
 
-	shadow := tar.NewReader(shadowTar)
-	if _, err := shadow.Next(); err != nil {
-		log.WithFields(
-			log.Fields{
-				"container": *a.container,
-				"username":  username,
-			},
-		).WithError(err).Error("failed to get the shadow file from the tar")
-
-		return false
-	}
+	defer shadow.Close()
 
 	if !a.osauth.AuthUserFromShadow(username, password, shadow) {
 		log.WithFields(
@@ -153,7 +126,7 @@ func (a *Authenticator) Password(ctx gliderssh.Context, username string, passwor
 
 // PublicKey handles the server's SSH public key authentication when server is running in connector mode.
 func (a *Authenticator) PublicKey(ctx gliderssh.Context, username string, key gliderssh.PublicKey) bool {
-	passwd, err := getPasswd(ctx, a.docker, *a.container)
+	passwd, err := a.runtime.ReadFile(ctx, *a.container, "/etc/passwd")
 	if err != nil {
 		log.WithFields(
 			log.Fields{
@@ -165,6 +138,8 @@ func (a *Authenticator) PublicKey(ctx gliderssh.Context, username string, key gl
 		return false
 	}
 
+	defer passwd.Close()
+
 	user, err := a.osauth.LookupUserFromPasswd(username, passwd)
 	if err != nil {
 		log.WithFields(
@@ -0,0 +1,175 @@
+package connector
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	dockerclient "github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/process"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+)
+
+// NOTICE: Ensures the Runtime interface is implemented.
+var _ Runtime = (*DockerRuntime)(nil)
+
+// DockerRuntime implements the Runtime interface through the Docker's API, what is also served by Podman.
+type DockerRuntime struct {
+	// name is the runtime's name.
+	name string
+	// docker is a client to communicate with the Docker's API.
+	docker dockerclient.APIClient
+}
+
+// NewDockerRuntime creates a new instance of DockerRuntime named name, like `docker` or `podman`, using the Docker's
+// API client.
+func NewDockerRuntime(name string, docker dockerclient.APIClient) *DockerRuntime {
+	return &DockerRuntime{
+		name:   name,
+		docker: docker,
+	}
+}
+
+func (r *DockerRuntime) Name() string {
+	return r.name
+}
+
+func (r *DockerRuntime) Image(ctx context.Context, container string) (string, error) {
+	info, err := r.docker.ContainerInspect(ctx, container)
+	if err != nil {
+		return "", err
+	}
+
+	return info.Config.Image, nil
+}
+
+// ReadFile reads the file at path from the container's filesystem, what the Docker's API copies as a tar archive.
+func (r *DockerRuntime) ReadFile(ctx context.Context, container string, path string) (io.ReadCloser, error) {
+	archive, _, err := r.docker.CopyFromContainer(ctx, container, path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := tar.NewReader(archive)
+	if _, err := file.Next(); err != nil {
+		archive.Close()
+
+		return nil, err
+	}
+
+	return struct {
+		io.Reader
+		io.Closer
+	}{file, archive}, nil
+}
+
+func (r *DockerRuntime) Exec(ctx context.Context, container string, user *osauth.User, command []string, tty bool, size [2]uint) (Process, error) {
+	id, err := r.docker.ContainerExecCreate(ctx, container, types.ExecConfig{
+		User:         user.Username,
+		Tty:          tty,
+		ConsoleSize:  &size,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          command,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := r.docker.ContainerExecAttach(ctx, id.ID, types.ExecStartCheck{
+		Tty:         tty,
+		ConsoleSize: &size,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := &dockerProcess{
+		docker: r.docker,
+		id:     id.ID,
+		res:    res,
+		stdout: res.Reader,
+		stderr: strings.NewReader(""),
+	}
+
+	// NOTICE: According to the [Docker] documentation, we can "demultiplex" a command sent to container, but only
+	// when the exec started doesn't allocate a TTY. As a result, we check if the exec's is requesting it and do
+	// what was recommended by [Docker]'s to get the stdout and stderr separately.
+	//
+	// [Docker]: https://pkg.go.dev/github.com/docker/docker/client#Client.ContainerAttach
+	if !tty {
+		stdout, stdoutWriter := io.Pipe()
+		stderr, stderrWriter := io.Pipe()
+
+		go func() {
+			_, err := stdcopy.StdCopy(stdoutWriter, stderrWriter, res.Reader)
+
+			stdoutWriter.CloseWithError(err)
+			stderrWriter.CloseWithError(err)
+		}()
+
+		p.stdout = stdout
+		p.stderr = stderr
+	}
+
+	return p, nil
+}
+
+// dockerProcess is a command started inside a container through the Docker's API.
+type dockerProcess struct {
+	docker dockerclient.APIClient
+	// id is the exec's ID.
+	id     string
+	res    types.HijackedResponse
+	stdout io.Reader
+	stderr io.Reader
+}
+
+// dockerStdin writes to the exec's hijacked connection, closing only its write side.
+type dockerStdin struct {
+	res types.HijackedResponse
+}
+
+func (s *dockerStdin) Write(p []byte) (int, error) {
+	return s.res.Conn.Write(p)
+}
+
+func (s *dockerStdin) Close() error {
+	return s.res.CloseWrite()
+}
+
+func (p *dockerProcess) Stdin() io.WriteCloser {
+	return &dockerStdin{res: p.res}
+}
+
+func (p *dockerProcess) Stdout() io.Reader {
+	return p.stdout
+}
+
+func (p *dockerProcess) Stderr() io.Reader {
+	return p.stderr
+}
+
+func (p *dockerProcess) Wait() (int, error) {
+	inspected, err := p.docker.ContainerExecInspect(context.Background(), p.id)
+	if err != nil {
+		return -1, err
+	}
+
+	if inspected.Running {
+		// NOTICE: when a process is running after the exec command, it is necessary to kill it.
+		return 0, process.Kill(inspected.Pid)
+	}
+
+	return inspected.ExitCode, nil
+}
+
+func (p *dockerProcess) Close() error {
+	p.res.Close()
+
+	return nil
+}
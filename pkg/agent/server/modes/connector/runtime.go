@@ -0,0 +1,36 @@
+package connector
+
+import (
+	"context"
+	"io"
+
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+)
+
+// Runtime is the container runtime where the containers turned into devices by the connector run. The connector mode
+// uses it to authenticate the users through the container's files and to run their sessions inside the container.
+type Runtime interface {
+	// Name is the runtime's name, like `docker`, reported as the device's operating system.
+	Name() string
+	// Image gets the name of the container's image.
+	Image(ctx context.Context, container string) (string, error)
+	// ReadFile reads the file at path from the container's filesystem.
+	ReadFile(ctx context.Context, container string, path string) (io.ReadCloser, error)
+	// Exec starts the command inside the container as the user. When tty is true, the command is attached to a
+	// pseudo-terminal with the size, as height and width, and its whole output is read from the process' stdout.
+	Exec(ctx context.Context, container string, user *osauth.User, command []string, tty bool, size [2]uint) (Process, error)
+}
+
+// Process is a command started inside a container.
+type Process interface {
+	// Stdin is the process' standard input. Closing it sends an EOF to the process.
+	Stdin() io.WriteCloser
+	// Stdout is the process' standard output, read until the process exits.
+	Stdout() io.Reader
+	// Stderr is the process' standard error, read until the process exits.
+	Stderr() io.Reader
+	// Wait waits the process to exit, after its output is read, returning its exit code.
+	Wait() (int, error)
+	// Close releases the process' resources.
+	Close() error
+}
@@ -6,8 +6,6 @@ import (
 	"io"
 	"sync"
 
-	dockerclient "github.com/docker/docker/client"
-	"github.com/docker/docker/pkg/stdcopy"
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
@@ -24,16 +22,17 @@ type Sessioner struct {
 	//
 	// NOTICE: It's a pointer because when the server is created, we don't know the device name yet, that is set later.
 	container *string
-	docker    dockerclient.APIClient
+	// runtime is the container runtime where the container runs.
+	runtime Runtime
 }
 
 // NewSessioner creates a new instance of Sessioner for the connector mode.
 // The container is a pointer to a string because when the server is created, we don't know the device name yet, that
 // is set later.
-func NewSessioner(container *string, docker dockerclient.APIClient) *Sessioner {
+func NewSessioner(container *string, runtime Runtime) *Sessioner {
 	return &Sessioner{
 		container: container,
-		docker:    docker,
+		runtime:   runtime,
 	}
 }
 
@@ -49,18 +48,18 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachShellToContainer(session.Context(), s.docker, container, user, [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
+	proc, err := attachShellToContainer(session.Context(), s.runtime, container, user, [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
 	if err != nil {
-		return err
+		return refuse(session, err)
 	}
-	defer resp.Close()
+	defer proc.Close()
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
+			code, err := proc.Wait()
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -68,7 +67,7 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 			session.Exit(code) //nolint:errcheck
 		}()
 
-		if _, err := io.Copy(session, resp.Conn); err != nil && err != io.EOF {
+		if _, err := io.Copy(session, proc.Stdout()); err != nil && err != io.EOF {
 			fmt.Println(err)
 		}
 	}()
@@ -76,9 +75,9 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		defer resp.Close()
+		defer proc.Stdin().Close() //nolint:errcheck
 
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
+		if _, err := io.Copy(proc.Stdin(), session); err != nil && err != io.EOF {
 			fmt.Println(err)
 		}
 	}()
@@ -100,18 +99,18 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachExecToContainer(session.Context(), s.docker, container, user, isPty, session.Command(), [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
+	proc, err := attachExecToContainer(session.Context(), s.runtime, container, user, isPty, session.Command(), [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
 	if err != nil {
-		return err
+		return refuse(session, err)
 	}
-	defer resp.Close()
+	defer proc.Close()
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
+			code, err := proc.Wait()
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -119,28 +118,15 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 			session.Exit(code) //nolint:errcheck
 		}()
 
-		// NOTICE: According to the [Docker] documentation, we can "demultiplex" a command sent to container, but only
-		// when the exec started doesn't allocate a TTY. As a result, we check if the exec's is requesting it and do
-		// what was recommended by [Docker]'s to get the stdout and stderr separately.
-		//
-		// [Docker]: https://pkg.go.dev/github.com/docker/docker/client#Client.ContainerAttach
-		if isPty {
-			if _, err := io.Copy(session, resp.Reader); err != nil && err != io.EOF {
-				fmt.Println(err)
-			}
-		} else {
-			if _, err := stdcopy.StdCopy(session, session.Stderr(), resp.Reader); err != nil && err != io.EOF {
-				fmt.Println(err)
-			}
-		}
+		copyOutput(session, proc)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		defer resp.CloseWrite() //nolint:errcheck
+		defer proc.Stdin().Close() //nolint:errcheck
 
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
+		if _, err := io.Copy(proc.Stdin(), session); err != nil && err != io.EOF {
 			fmt.Println(err)
 		}
 	}()
@@ -165,18 +151,18 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 		return ErrUserNotFound
 	}
 
-	resp, id, err := attachHereDocToContainer(session.Context(), s.docker, container, user, [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
+	proc, err := attachHereDocToContainer(session.Context(), s.runtime, container, user, [2]uint{uint(sspty.Window.Height), uint(sspty.Window.Width)})
 	if err != nil {
-		return err
+		return refuse(session, err)
 	}
-	defer resp.Close()
+	defer proc.Close()
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 		defer func() {
-			code, err := exitCodeExecFromContainer(s.docker, id)
+			code, err := proc.Wait()
 			if err != nil {
 				fmt.Println(err)
 			}
@@ -184,17 +170,15 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 			session.Exit(code) //nolint:errcheck
 		}()
 
-		if _, err := stdcopy.StdCopy(session, session.Stderr(), resp.Reader); err != nil && err != io.EOF {
-			fmt.Println(err)
-		}
+		copyOutput(session, proc)
 	}()
 
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		defer resp.CloseWrite() //nolint:errcheck
+		defer proc.Stdin().Close() //nolint:errcheck
 
-		if _, err := io.Copy(resp.Conn, session); err != nil && err != io.EOF {
+		if _, err := io.Copy(proc.Stdin(), session); err != nil && err != io.EOF {
 			fmt.Println(err)
 		}
 	}()
@@ -204,6 +188,33 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 	return nil
 }
 
+// refuse ends the session that couldn't be started inside the container, telling its user why.
+func refuse(session gliderssh.Session, err error) error {
+	fmt.Fprintln(session.Stderr(), err) //nolint:errcheck
+	session.Exit(1)                     //nolint:errcheck
+
+	return err
+}
+
+// copyOutput copies the process' stdout and stderr to the session's ones until the process exits.
+func copyOutput(session gliderssh.Session, proc Process) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		if _, err := io.Copy(session.Stderr(), proc.Stderr()); err != nil && err != io.EOF {
+			fmt.Println(err)
+		}
+	}()
+
+	if _, err := io.Copy(session, proc.Stdout()); err != nil && err != io.EOF {
+		fmt.Println(err)
+	}
+
+	wg.Wait()
+}
+
 // SFTP handles the SSH's server sftp session when server is running in connector mode.
 //
 // sftp is a subsystem of SSH that allows file operations over SSH.
@@ -9,6 +9,7 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/provisioner"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/api/client"
 	"github.com/shellhub-io/shellhub/pkg/models"
@@ -34,8 +35,14 @@ type Authenticator struct {
 	deviceName *string
 	// osauth is an instance of the OSAuth interface to authenticate the user on the Operating System.
 	osauth osauth.OSAuther
+	// provisioner creates the accounts to the members authenticated by ShellHub that don't exist on the device.
+	// When it is nil, the provisioning is disabled.
+	provisioner *provisioner.Provisioner
 }
 
+// identityContextKey is the key of the ShellHub's member authenticated by the public key on the session's context.
+type identityContextKey struct{}
+
 // NewAuthenticator creates a new instance of Authenticator for the host mode.
 // It receives the api client to perform requests to the ShellHub's API, the authentication data received by the agent
 // when started the communication between it and the agent, the singleUserPassword, what indicates is is running at
@@ -43,13 +50,17 @@ type Authenticator struct {
 //
 // The deviceName is a pointer to a string because when the server is created, we don't know the device name yet, that
 // is set later.
-func NewAuthenticator(api client.Client, authData *models.DeviceAuthResponse, singleUserPassword string, deviceName *string) *Authenticator {
+//
+// The provisioner, when not nil, lets the members authenticated through public keys log in as the account named after
+// their ShellHub username even when it doesn't exist on the device yet, what is created by the [Sessioner].
+func NewAuthenticator(api client.Client, authData *models.DeviceAuthResponse, singleUserPassword string, deviceName *string, provisioner *provisioner.Provisioner) *Authenticator {
 	return &Authenticator{
 		api:                api,
 		authData:           authData,
 		singleUserPassword: singleUserPassword,
 		deviceName:         deviceName,
 		osauth:             new(osauth.OSAuth),
+		provisioner:        provisioner,
 	}
 }
 
@@ -77,7 +88,10 @@ func (a *Authenticator) Password(ctx gliderssh.Context, _ string, pass string) b
 
 // PublicKey handles the server's SSH public key authentication when server is running in host mode.
 func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh.PublicKey) bool {
-	if a.osauth.LookupUser(ctx.User()) == nil {
+	// NOTICE: The users that don't exist on the device are only accepted, to be provisioned when the session starts,
+	// after the public key is verified by ShellHub.
+	exists := a.osauth.LookupUser(ctx.User()) != nil
+	if !exists && a.provisioner == nil {
 		return false
 	}
 
@@ -175,6 +189,24 @@ func (a *Authenticator) PublicKey(ctx gliderssh.Context, _ string, key gliderssh
 		return false
 	}
 
+	// NOTICE: Only the account named after the ShellHub's member on whose behalf the key was signed is provisioned,
+	// never the one requested by the client.
+	if !exists && (res.Identity == nil || res.Identity.Username != ctx.User()) {
+		log.WithFields(
+			log.Fields{
+				"container":   *a.deviceName,
+				"username":    ctx.User(),
+				"fingerprint": fingerprint,
+			},
+		).Error("the user doesn't exist and isn't the member's account to provision")
+
+		return false
+	}
+
+	if res.Identity != nil {
+		ctx.SetValue(identityContextKey{}, res.Identity)
+	}
+
 	log.WithFields(
 		log.Fields{
 			"container":   *a.deviceName,
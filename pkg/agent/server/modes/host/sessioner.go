@@ -12,9 +12,12 @@ import (
 
 	gliderssh "github.com/gliderlabs/ssh"
 	"github.com/shellhub-io/shellhub/pkg/agent/pkg/osauth"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/policy"
+	"github.com/shellhub-io/shellhub/pkg/agent/pkg/provisioner"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/modes/host/command"
 	"github.com/shellhub-io/shellhub/pkg/agent/server/utmp"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	log "github.com/sirupsen/logrus"
 	gossh "golang.org/x/crypto/ssh"
 )
@@ -48,32 +51,167 @@ type Sessioner struct {
 	//
 	// NOTICE: It's a pointer because when the server is created, we don't know the device name yet, that is set later.
 	deviceName *string
+	// policy loads the device-local authorization policy. When it is nil, or loads no policy, no policy is enforced.
+	policy func() (*policy.Policy, error)
+	// provisioner creates the accounts to the members authenticated by ShellHub that don't exist on the device. When
+	// it is nil, the provisioning is disabled.
+	provisioner *provisioner.Provisioner
+	// jobsDisabled reports whether the exec sessions opened by the ShellHub server to run the jobs' commands are
+	// denied. When it is nil, the jobs are allowed.
+	jobsDisabled func() bool
 }
 
+// ErrJobsDisabled is returned when a job's exec session is denied because the jobs are disabled on the device.
+var ErrJobsDisabled = errors.New("jobs are disabled on this device")
+
 func (s *Sessioner) SetCmds(cmds map[string]*exec.Cmd) {
 	s.cmds = cmds
 }
 
 // NewSessioner creates a new instance of Sessioner for the host mode.
 // The device name is a pointer to a string because when the server is created, we don't know the device name yet, that
-// is set later.
-func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd) *Sessioner {
+// is set later. The policy loads the device-local authorization policy, enforced on every session when it loads one.
+// The provisioner, when not nil, creates the account of the member authenticated by ShellHub when the session starts.
+// The jobsDisabled reports whether the exec sessions that run the jobs' commands are denied.
+func NewSessioner(deviceName *string, cmds map[string]*exec.Cmd, policy func() (*policy.Policy, error), provisioner *provisioner.Provisioner, jobsDisabled func() bool) *Sessioner {
 	return &Sessioner{
-		deviceName: deviceName,
-		cmds:       cmds,
+		deviceName:   deviceName,
+		cmds:         cmds,
+		policy:       policy,
+		provisioner:  provisioner,
+		jobsDisabled: jobsDisabled,
+	}
+}
+
+// isJob checks if the exec session was opened by the ShellHub server to run a job's command, what is identified by
+// the [models.JobEnv] environment variable set on it.
+func isJob(session gliderssh.Session) bool {
+	for _, env := range session.Environ() {
+		if strings.HasPrefix(env, models.JobEnv+"=") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// provision creates the account of the ShellHub's member authenticated on the session when it doesn't exist on the
+// device, or updates its last activity otherwise.
+func (s *Sessioner) provision(session gliderssh.Session) error {
+	if s.provisioner == nil {
+		return nil
+	}
+
+	if new(osauth.OSAuth).LookupUser(session.User()) != nil {
+		s.provisioner.Touch(session.User())
+
+		return nil
+	}
+
+	identity, ok := session.Context().Value(identityContextKey{}).(*models.PrivateKeyIdentity)
+	if !ok || identity.Username != session.User() {
+		return osauth.ErrUserNotFound
+	}
+
+	if err := s.provisioner.Provision(identity); err != nil {
+		log.WithError(err).WithFields(log.Fields{
+			"user":       session.User(),
+			"remoteaddr": session.RemoteAddr(),
+		}).Error("Failed to provision the user's account")
+
+		fmt.Fprintln(session.Stderr(), "Failed to create the user's account on the device") //nolint:errcheck
+		session.Exit(1)                                                                     //nolint:errcheck
+
+		return err
+	}
+
+	return nil
+}
+
+// authorize checks the session against the device-local authorization policy, returning the command forced to the
+// user, if any.
+//
+// The policy is loaded on every session, so the changes made by the device's owner are applied without restarting
+// the agent. When the policy cannot be loaded, the session is denied.
+func (s *Sessioner) authorize(session gliderssh.Session, kind policy.Session) (string, error) {
+	if s.policy == nil {
+		return "", nil
+	}
+
+	p, err := s.policy()
+	if err != nil {
+		log.WithError(err).Error("Failed to load the device's policy")
+
+		return "", err
+	}
+
+	if p == nil {
+		return "", nil
+	}
+
+	auth := new(osauth.OSAuth)
+
+	user := auth.LookupUser(session.User())
+	if user == nil {
+		return "", policy.ErrUserDenied
+	}
+
+	groups, err := auth.LookupGroups(user)
+	if err != nil {
+		log.WithError(err).WithField("user", session.User()).Error("Failed to lookup the user's groups")
+
+		return "", err
 	}
+
+	return p.Authorize(session.User(), groups, kind)
+}
+
+// deny rejects the session denied by the device-local authorization policy.
+func deny(session gliderssh.Session, kind policy.Session, err error) error {
+	log.WithError(err).WithFields(log.Fields{
+		"user":       session.User(),
+		"type":       kind,
+		"remoteaddr": session.RemoteAddr(),
+	}).Warn("Session denied by the device's policy")
+
+	fmt.Fprintln(session.Stderr(), "Access denied by the device's policy") //nolint:errcheck
+	session.Exit(1)                                                        //nolint:errcheck
+
+	return err
 }
 
 // Shell manages the SSH shell session of the server when operating in host mode.
 func (s *Sessioner) Shell(session gliderssh.Session) error {
+	if err := s.provision(session); err != nil {
+		return err
+	}
+
+	forced, err := s.authorize(session, policy.SessionShell)
+	if err != nil {
+		return deny(session, policy.SessionShell, err)
+	}
+
+	if forced != "" {
+		return s.exec(session, forced, "SSH_ORIGINAL_COMMAND=")
+	}
+
 	sspty, winCh, isPty := session.Pty()
 
 	scmd := newShellCmd(*s.deviceName, session.User(), sspty.Term)
+	scmd.Env = append(scmd.Env, session.Environ()...)
+
+	var pts *os.File
+	closeSession, err := osauth.OpenSession(session.User(), func(env []string) error {
+		scmd.Env = append(scmd.Env, env...)
 
-	pts, err := startPty(scmd, session, winCh)
+		pts, err = startPty(scmd, session, winCh)
+
+		return err
+	})
 	if err != nil {
-		log.Warn(err)
+		return err
 	}
+	defer closeSession()
 
 	u := new(osauth.OSAuth).LookupUser(session.User())
 
@@ -123,9 +261,23 @@ func (s *Sessioner) Shell(session gliderssh.Session) error {
 // heredoc is special block of code that contains multi-line strings that will be redirected to a stdin of a shell. It
 // request a shell, but doesn't allocate a pty.
 func (s *Sessioner) Heredoc(session gliderssh.Session) error {
+	if err := s.provision(session); err != nil {
+		return err
+	}
+
+	forced, err := s.authorize(session, policy.SessionHeredoc)
+	if err != nil {
+		return deny(session, policy.SessionHeredoc, err)
+	}
+
+	if forced != "" {
+		return s.exec(session, forced, "SSH_ORIGINAL_COMMAND=")
+	}
+
 	_, _, isPty := session.Pty()
 
 	cmd := newShellCmd(*s.deviceName, session.User(), "")
+	cmd.Env = append(cmd.Env, session.Environ()...)
 
 	stdout, _ := cmd.StdoutPipe()
 	stdin, _ := cmd.StdinPipe()
@@ -136,11 +288,6 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 		return fmt.Errorf("failed to get server connection from session context")
 	}
 
-	go func() {
-		serverConn.Wait()  // nolint:errcheck
-		cmd.Process.Kill() // nolint:errcheck
-	}()
-
 	log.WithFields(log.Fields{
 		"user":        session.User(),
 		"ispty":       isPty,
@@ -149,10 +296,20 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 		"Raw command": session.RawCommand(),
 	}).Info("Command started")
 
-	err := cmd.Start()
+	closeSession, err := osauth.OpenSession(session.User(), func(env []string) error {
+		cmd.Env = append(cmd.Env, env...)
+
+		return cmd.Start()
+	})
 	if err != nil {
-		log.Warn(err)
+		return err
 	}
+	defer closeSession()
+
+	go func() {
+		serverConn.Wait()  // nolint:errcheck
+		cmd.Process.Kill() // nolint:errcheck
+	}()
 
 	go func() {
 		if _, err := io.Copy(stdin, session); err != nil {
@@ -188,6 +345,23 @@ func (s *Sessioner) Heredoc(session gliderssh.Session) error {
 
 // Exec handles the SSH's server exec session when server is running in host mode.
 func (s *Sessioner) Exec(session gliderssh.Session) error {
+	if s.jobsDisabled != nil && s.jobsDisabled() && isJob(session) {
+		return deny(session, policy.SessionExec, ErrJobsDisabled)
+	}
+
+	if err := s.provision(session); err != nil {
+		return err
+	}
+
+	forced, err := s.authorize(session, policy.SessionExec)
+	if err != nil {
+		return deny(session, policy.SessionExec, err)
+	}
+
+	if forced != "" {
+		return s.exec(session, forced, "SSH_ORIGINAL_COMMAND="+session.RawCommand())
+	}
+
 	if len(session.Command()) == 0 {
 		log.WithFields(log.Fields{
 			"user":      session.User(),
@@ -200,6 +374,12 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		return nil
 	}
 
+	return s.exec(session, strings.Join(session.Command(), " "))
+}
+
+// exec runs the command line, through the user's shell, attached to the session. The environment sent by the client is
+// appended to the command's one, followed by env.
+func (s *Sessioner) exec(session gliderssh.Session, line string, env ...string) error {
 	user := new(osauth.OSAuth).LookupUser(session.User())
 	sPty, sWinCh, sIsPty := session.Pty()
 
@@ -213,7 +393,9 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		term = "xterm"
 	}
 
-	cmd := command.NewCmd(user, shell, term, *s.deviceName, shell, "-c", strings.Join(session.Command(), " "))
+	cmd := command.NewCmd(user, shell, term, *s.deviceName, shell, "-c", line)
+	cmd.Env = append(cmd.Env, session.Environ()...)
+	cmd.Env = append(cmd.Env, env...)
 
 	wg := &sync.WaitGroup{}
 	if sIsPty {
@@ -262,9 +444,15 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 		"Raw command": session.RawCommand(),
 	}).Info("Command started")
 
-	if err := cmd.Start(); err != nil {
+	closeSession, err := osauth.OpenSession(session.User(), func(env []string) error {
+		cmd.Env = append(cmd.Env, env...)
+
+		return cmd.Start()
+	})
+	if err != nil {
 		return err
 	}
+	defer closeSession()
 
 	if !sIsPty {
 		wg.Wait()
@@ -303,7 +491,18 @@ func (s *Sessioner) Exec(session gliderssh.Session) error {
 // SFTP handles the SSH's server sftp session when server is running in host mode.
 //
 // sftp is a subsystem of SSH that allows file operations over SSH.
+//
+// The command forced by the device-local authorization policy is not applied to sftp sessions, what should be
+// controlled by the allowed sessions' types.
 func (s *Sessioner) SFTP(session gliderssh.Session) error {
+	if err := s.provision(session); err != nil {
+		return err
+	}
+
+	if _, err := s.authorize(session, policy.SessionSFTP); err != nil {
+		return deny(session, policy.SessionSFTP, err)
+	}
+
 	log.WithFields(log.Fields{
 		"user": session.Context().User(),
 	}).Info("SFTP session started")
@@ -355,13 +554,19 @@ func (s *Sessioner) SFTP(session gliderssh.Session) error {
 		return errors.New("failed to get stderr pipe")
 	}
 
-	if err := cmd.Start(); err != nil {
+	closeSession, err := osauth.OpenSession(session.User(), func(env []string) error {
+		cmd.Env = append(cmd.Env, env...)
+
+		return cmd.Start()
+	})
+	if err != nil {
 		log.WithError(err).WithFields(log.Fields{
 			"user": session.Context().User(),
 		}).Error("Failed to start command")
 
 		return errors.New("failed to start command")
 	}
+	defer closeSession()
 
 	go func() {
 		log.WithFields(log.Fields{
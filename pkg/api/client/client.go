@@ -11,7 +11,6 @@ import (
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -25,7 +24,13 @@ type publicAPI interface {
 	Endpoints() (*models.Endpoints, error)
 	AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)
 	AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error)
-	NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error)
+	// ReportMetrics sends the device's system metrics to the ShellHub's server.
+	ReportMetrics(metrics *models.DeviceMetrics, token string) error
+	// GetUpdateTarget polls the device's update policy for the agent's version it should update to now.
+	GetUpdateTarget(token string) (*models.DeviceUpdateTarget, error)
+	// ReportUpdate sends the device's update attempt to the ShellHub's server.
+	ReportUpdate(update *models.DeviceUpdate, token string) error
+	NewReverseListener(ctx context.Context, token string) (net.Listener, error)
 }
 
 //go:generate mockery --name=Client --filename=client.go
@@ -42,6 +47,8 @@ type client struct {
 	logger *log.Logger
 	// reverser is used to create a reverse listener to Agent from ShellHub's SSH server.
 	reverser IReverser
+	// proxy is the proxy used to connect to the ShellHub's server.
+	proxy *url.URL
 }
 
 var ErrParseAddress = fmt.Errorf("could not parse the address to the required format")
@@ -81,13 +88,15 @@ func NewClient(address string, opts ...Opt) (Client, error) {
 		client.http.SetLogger(&LeveledLogger{client.logger})
 	}
 
-	client.reverser = NewReverser(client.http.BaseURL)
-
 	for _, opt := range opts {
 		if err := opt(client); err != nil {
 			return nil, err
 		}
 	}
 
+	if client.reverser == nil {
+		client.reverser = NewReverser(client.http.BaseURL, client.proxy)
+	}
+
 	return client, nil
 }
@@ -3,10 +3,10 @@ package client
 import (
 	"context"
 	"errors"
+	"net"
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/shellhub-io/shellhub/pkg/models"
-	"github.com/shellhub-io/shellhub/pkg/revdial"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -102,6 +102,48 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 	return res, nil
 }
 
+func (c *client) ReportMetrics(metrics *models.DeviceMetrics, token string) error {
+	response, err := c.http.R().
+		SetBody(metrics).
+		SetAuthToken(token).
+		Post("/api/devices/metrics")
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
+func (c *client) GetUpdateTarget(token string) (*models.DeviceUpdateTarget, error) {
+	var target *models.DeviceUpdateTarget
+
+	response, err := c.http.R().
+		SetAuthToken(token).
+		SetResult(&target).
+		Get("/api/devices/update")
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ErrorFromResponse(response); err != nil {
+		return nil, err
+	}
+
+	return target, nil
+}
+
+func (c *client) ReportUpdate(update *models.DeviceUpdate, token string) error {
+	response, err := c.http.R().
+		SetBody(update).
+		SetAuthToken(token).
+		Post("/api/devices/update")
+	if err != nil {
+		return err
+	}
+
+	return ErrorFromResponse(response)
+}
+
 // NewReverseListener creates a new reverse listener connection for the Agent from ShellHub's SSH server.
 //
 // Every time the ShellHub's SSH server receives a new connection to the Agent, the server sends that connection
@@ -110,7 +152,7 @@ func (c *client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (
 // To obtain this listener from the server, the Agent needs to authenticates it using the token provided, and getting a
 // reverse authenticated connection, after that, it dials the server again for a new reverse connection on ShellHub's
 // SSH tunnel list.
-func (c *client) NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error) {
+func (c *client) NewReverseListener(ctx context.Context, token string) (net.Listener, error) {
 	if token == "" {
 		return nil, errors.New("token is empty")
 	}
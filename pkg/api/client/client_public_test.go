@@ -414,6 +414,177 @@ func TestAuthPublicKey(t *testing.T) {
 	}
 }
 
+func TestReportMetrics(t *testing.T) {
+	tests := []struct {
+		description   string
+		metrics       *models.DeviceMetrics
+		token         string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fail to report metrics when the device is not authorized",
+			metrics:     &models.DeviceMetrics{CPU: 10},
+			token:       "token",
+			requiredMocks: func() {
+				responder, _ := mock.NewJsonResponder(401, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/metrics", responder)
+			},
+			expected: ErrUnauthorized,
+		},
+		{
+			description: "success to report metrics",
+			metrics:     &models.DeviceMetrics{CPU: 10},
+			token:       "token",
+			requiredMocks: func() {
+				mock.RegisterResponder("POST", "/api/devices/metrics", func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Authorization") != "Bearer token" {
+						return mock.NewStringResponse(401, ""), nil
+					}
+
+					return mock.NewStringResponse(200, ""), nil
+				})
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli, err := NewClient("https://www.cloud.shellhub.io/")
+			assert.NoError(t, err)
+
+			client, ok := cli.(*client)
+			assert.True(t, ok)
+
+			mock.ActivateNonDefault(client.http.GetClient())
+			defer mock.DeactivateAndReset()
+
+			test.requiredMocks()
+
+			assert.Equal(t, test.expected, cli.ReportMetrics(test.metrics, test.token))
+		})
+	}
+}
+
+func TestGetUpdateTarget(t *testing.T) {
+	type Expected struct {
+		target *models.DeviceUpdateTarget
+		err    error
+	}
+
+	tests := []struct {
+		description   string
+		token         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description: "fail to get the update target when the device is not authorized",
+			token:       "token",
+			requiredMocks: func() {
+				responder, _ := mock.NewJsonResponder(401, nil)
+
+				mock.RegisterResponder("GET", "/api/devices/update", responder)
+			},
+			expected: Expected{
+				target: nil,
+				err:    ErrUnauthorized,
+			},
+		},
+		{
+			description: "success to get the update target",
+			token:       "token",
+			requiredMocks: func() {
+				mock.RegisterResponder("GET", "/api/devices/update", func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Authorization") != "Bearer token" {
+						return mock.NewStringResponse(401, ""), nil
+					}
+
+					return mock.NewJsonResponse(200, &models.DeviceUpdateTarget{Version: "v0.15.0"})
+				})
+			},
+			expected: Expected{
+				target: &models.DeviceUpdateTarget{Version: "v0.15.0"},
+				err:    nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli, err := NewClient("https://www.cloud.shellhub.io/")
+			assert.NoError(t, err)
+
+			client, ok := cli.(*client)
+			assert.True(t, ok)
+
+			mock.ActivateNonDefault(client.http.GetClient())
+			defer mock.DeactivateAndReset()
+
+			test.requiredMocks()
+
+			target, err := cli.GetUpdateTarget(test.token)
+			assert.Equal(t, test.expected, Expected{target: target, err: err})
+		})
+	}
+}
+
+func TestReportUpdate(t *testing.T) {
+	tests := []struct {
+		description   string
+		update        *models.DeviceUpdate
+		token         string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description: "fail to report the update when the device is not authorized",
+			update:      &models.DeviceUpdate{Version: "v0.15.0", Status: models.DeviceUpdateStatusUpdating},
+			token:       "token",
+			requiredMocks: func() {
+				responder, _ := mock.NewJsonResponder(401, nil)
+
+				mock.RegisterResponder("POST", "/api/devices/update", responder)
+			},
+			expected: ErrUnauthorized,
+		},
+		{
+			description: "success to report the update",
+			update:      &models.DeviceUpdate{Version: "v0.15.0", Status: models.DeviceUpdateStatusFailed, Error: "error"},
+			token:       "token",
+			requiredMocks: func() {
+				mock.RegisterResponder("POST", "/api/devices/update", func(req *http.Request) (*http.Response, error) {
+					if req.Header.Get("Authorization") != "Bearer token" {
+						return mock.NewStringResponse(401, ""), nil
+					}
+
+					return mock.NewStringResponse(200, ""), nil
+				})
+			},
+			expected: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cli, err := NewClient("https://www.cloud.shellhub.io/")
+			assert.NoError(t, err)
+
+			client, ok := cli.(*client)
+			assert.True(t, ok)
+
+			mock.ActivateNonDefault(client.http.GetClient())
+			defer mock.DeactivateAndReset()
+
+			test.requiredMocks()
+
+			assert.Equal(t, test.expected, cli.ReportUpdate(test.update, test.token))
+		})
+	}
+}
+
 func TestReverseListener(t *testing.T) {
 	mock := new(reversermock.IReverser)
 
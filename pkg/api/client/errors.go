@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type Response interface {
@@ -35,6 +37,44 @@ var (
 	ErrInternalServerError = errors.New("internal server error")
 )
 
+// RetryAfterError is returned when the server is not able to handle the request now, like when it is overloaded, and
+// hints the client, through the Retry-After header, to retry after a period.
+type RetryAfterError struct {
+	// After is the period the client should wait before retrying.
+	After time.Duration
+	Err   error
+}
+
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%s: retry after %s", e.Err, e.After)
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// parseRetryAfter parses the value of the Retry-After header, either as seconds or as the HTTP date, relative to now.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0, false
+	}
+
+	if after := date.Sub(now); after > 0 {
+		return after, true
+	}
+
+	return 0, true
+}
+
 // ErrorFromResponse returns an error based on the response status code.
 // Each Error is mapped to a specific status code, if the status code is not mapped ErrUnknown is returned.
 func ErrorFromResponse(response Response) error {
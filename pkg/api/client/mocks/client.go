@@ -1,4 +1,4 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
@@ -8,7 +8,7 @@ import (
 	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 
-	revdial "github.com/shellhub-io/shellhub/pkg/revdial"
+	net "net"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -20,6 +20,10 @@ type Client struct {
 func (_m *Client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthResponse, error) {
 	ret := _m.Called(req)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AuthDevice")
+	}
+
 	var r0 *models.DeviceAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*models.DeviceAuthRequest) (*models.DeviceAuthResponse, error)); ok {
@@ -46,6 +50,10 @@ func (_m *Client) AuthDevice(req *models.DeviceAuthRequest) (*models.DeviceAuthR
 func (_m *Client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string) (*models.PublicKeyAuthResponse, error) {
 	ret := _m.Called(req, token)
 
+	if len(ret) == 0 {
+		panic("no return value specified for AuthPublicKey")
+	}
+
 	var r0 *models.PublicKeyAuthResponse
 	var r1 error
 	if rf, ok := ret.Get(0).(func(*models.PublicKeyAuthRequest, string) (*models.PublicKeyAuthResponse, error)); ok {
@@ -72,6 +80,10 @@ func (_m *Client) AuthPublicKey(req *models.PublicKeyAuthRequest, token string)
 func (_m *Client) Endpoints() (*models.Endpoints, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for Endpoints")
+	}
+
 	var r0 *models.Endpoints
 	var r1 error
 	if rf, ok := ret.Get(0).(func() (*models.Endpoints, error)); ok {
@@ -98,6 +110,10 @@ func (_m *Client) Endpoints() (*models.Endpoints, error) {
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
 	var r0 *models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Device, error)); ok {
@@ -124,6 +140,10 @@ func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 func (_m *Client) GetInfo(agentVersion string) (*models.Info, error) {
 	ret := _m.Called(agentVersion)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetInfo")
+	}
+
 	var r0 *models.Info
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Info, error)); ok {
@@ -146,10 +166,44 @@ func (_m *Client) GetInfo(agentVersion string) (*models.Info, error) {
 	return r0, r1
 }
 
+// GetUpdateTarget provides a mock function with given fields: token
+func (_m *Client) GetUpdateTarget(token string) (*models.DeviceUpdateTarget, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUpdateTarget")
+	}
+
+	var r0 *models.DeviceUpdateTarget
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.DeviceUpdateTarget, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.DeviceUpdateTarget); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeviceUpdateTarget)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListDevices provides a mock function with given fields:
 func (_m *Client) ListDevices() ([]models.Device, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevices")
+	}
+
 	var r0 []models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]models.Device, error)); ok {
@@ -173,19 +227,23 @@ func (_m *Client) ListDevices() ([]models.Device, error) {
 }
 
 // NewReverseListener provides a mock function with given fields: ctx, token
-func (_m *Client) NewReverseListener(ctx context.Context, token string) (*revdial.Listener, error) {
+func (_m *Client) NewReverseListener(ctx context.Context, token string) (net.Listener, error) {
 	ret := _m.Called(ctx, token)
 
-	var r0 *revdial.Listener
+	if len(ret) == 0 {
+		panic("no return value specified for NewReverseListener")
+	}
+
+	var r0 net.Listener
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (*revdial.Listener, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) (net.Listener, error)); ok {
 		return rf(ctx, token)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) *revdial.Listener); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string) net.Listener); ok {
 		r0 = rf(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*revdial.Listener)
+			r0 = ret.Get(0).(net.Listener)
 		}
 	}
 
@@ -198,13 +256,48 @@ func (_m *Client) NewReverseListener(ctx context.Context, token string) (*revdia
 	return r0, r1
 }
 
-type mockConstructorTestingTNewClient interface {
-	mock.TestingT
-	Cleanup(func())
+// ReportMetrics provides a mock function with given fields: metrics, token
+func (_m *Client) ReportMetrics(metrics *models.DeviceMetrics, token string) error {
+	ret := _m.Called(metrics, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportMetrics")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DeviceMetrics, string) error); ok {
+		r0 = rf(metrics, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReportUpdate provides a mock function with given fields: update, token
+func (_m *Client) ReportUpdate(update *models.DeviceUpdate, token string) error {
+	ret := _m.Called(update, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReportUpdate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.DeviceUpdate, string) error); ok {
+		r0 = rf(update, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewClient(t mockConstructorTestingTNewClient) *Client {
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Client {
 	mock := &Client{}
 	mock.Mock.Test(t)
 
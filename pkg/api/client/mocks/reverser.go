@@ -1,11 +1,11 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
 import (
 	context "context"
+	net "net"
 
-	revdial "github.com/shellhub-io/shellhub/pkg/revdial"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -18,6 +18,10 @@ type IReverser struct {
 func (_m *IReverser) Auth(ctx context.Context, token string) error {
 	ret := _m.Called(ctx, token)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Auth")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
 		r0 = rf(ctx, token)
@@ -29,19 +33,23 @@ func (_m *IReverser) Auth(ctx context.Context, token string) error {
 }
 
 // NewListener provides a mock function with given fields:
-func (_m *IReverser) NewListener() (*revdial.Listener, error) {
+func (_m *IReverser) NewListener() (net.Listener, error) {
 	ret := _m.Called()
 
-	var r0 *revdial.Listener
+	if len(ret) == 0 {
+		panic("no return value specified for NewListener")
+	}
+
+	var r0 net.Listener
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*revdial.Listener, error)); ok {
+	if rf, ok := ret.Get(0).(func() (net.Listener, error)); ok {
 		return rf()
 	}
-	if rf, ok := ret.Get(0).(func() *revdial.Listener); ok {
+	if rf, ok := ret.Get(0).(func() net.Listener); ok {
 		r0 = rf()
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*revdial.Listener)
+			r0 = ret.Get(0).(net.Listener)
 		}
 	}
 
@@ -54,13 +62,12 @@ func (_m *IReverser) NewListener() (*revdial.Listener, error) {
 	return r0, r1
 }
 
-type mockConstructorTestingTNewIReverser interface {
+// NewIReverser creates a new instance of IReverser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIReverser(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewIReverser creates a new instance of IReverser. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewIReverser(t mockConstructorTestingTNewIReverser) *IReverser {
+}) *IReverser {
 	mock := &IReverser{}
 	mock.Mock.Test(t)
 
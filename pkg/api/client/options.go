@@ -73,3 +73,21 @@ func WithReverser(reverser IReverser) Opt {
 		return nil
 	}
 }
+
+// WithProxy sets the proxy used to connect to the server, both by the API calls and by the reverse tunnel. proxy must
+// be in the format accepted by [ParseProxy].
+//
+// Without this option, the proxy is read from the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func WithProxy(proxy string) Opt {
+	return func(c *client) error {
+		uri, err := ParseProxy(proxy)
+		if err != nil {
+			return err
+		}
+
+		c.proxy = uri
+		c.http.SetProxy(uri.String())
+
+		return nil
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 
 	"github.com/gorilla/websocket"
+	"github.com/hashicorp/yamux"
+	"github.com/shellhub-io/shellhub/pkg/connman"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
 )
@@ -15,7 +18,7 @@ import (
 //go:generate mockery --name=IReverser --filename=reverser.go
 type IReverser interface {
 	Auth(ctx context.Context, token string) error
-	NewListener() (*revdial.Listener, error)
+	NewListener() (net.Listener, error)
 }
 
 type Reverser struct {
@@ -24,13 +27,18 @@ type Reverser struct {
 	//
 	// It is used to create the websocket connection to the ShellHub's server.
 	host string
+	// dialer is the websocket dialer used to connect to the ShellHub's server.
+	dialer *websocket.Dialer
 }
 
 var _ IReverser = new(Reverser)
 
-func NewReverser(host string) *Reverser {
+// NewReverser creates a reverser to the ShellHub's server at host. When proxy is not nil, the connections are created
+// through it. Otherwise, the proxy is read from the environment variables.
+func NewReverser(host string, proxy *url.URL) *Reverser {
 	return &Reverser{
-		host: host,
+		host:   host,
+		dialer: NewWebsocketDialer(proxy),
 	}
 }
 
@@ -43,9 +51,12 @@ func (r *Reverser) Auth(ctx context.Context, token string) error {
 
 	header := http.Header{
 		"Authorization": []string{fmt.Sprintf("Bearer %s", token)},
+		// Servers supporting it multiplex all the connections over this websocket. Older servers ignore it and keep
+		// asking for a new websocket to each connection.
+		"Sec-WebSocket-Protocol": []string{connman.MultiplexedSubprotocol},
 	}
 
-	conn, _, err := DialContext(ctx, uri, header)
+	conn, _, err := DialContextWithDialer(ctx, r.dialer, uri, header)
 	if err != nil {
 		return err
 	}
@@ -60,17 +71,28 @@ func (r *Reverser) Auth(ctx context.Context, token string) error {
 // It uses the authenticated connection generate by the [Auth] method to create a new reverse listener. Through this
 // connection, the Agent will be able to receive connections from the ShellHub's server. This connections are,
 // essentially, the SSH operations requested by the user.
-func (r *Reverser) NewListener() (*revdial.Listener, error) {
+//
+// When the server has negotiated the multiplexed subprotocol, the connections are received as streams over the
+// authenticated connection itself, without opening a new websocket to each one.
+func (r *Reverser) NewListener() (net.Listener, error) {
 	if r.conn == nil {
 		return nil, errors.New("listener is not authenticated")
 	}
 
+	if r.conn.Subprotocol() == connman.MultiplexedSubprotocol {
+		config := yamux.DefaultConfig()
+		// The websocket's ping is already used to check the connection's liveness.
+		config.EnableKeepAlive = false
+
+		return yamux.Server(wsconnadapter.New(r.conn), config)
+	}
+
 	return revdial.NewListener(wsconnadapter.New(r.conn), func(ctx context.Context, path string) (*websocket.Conn, *http.Response, error) {
 		uri, err := url.JoinPath(r.host, path)
 		if err != nil {
 			return nil, nil, err
 		}
 
-		return DialContext(ctx, uri, nil)
+		return DialContextWithDialer(ctx, r.dialer, uri, nil)
 	}), nil
 }
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	resty "github.com/go-resty/resty/v2"
 	"github.com/gorilla/websocket"
@@ -46,12 +47,48 @@ func SameDomainRedirectPolicy() resty.RedirectPolicyFunc {
 	}
 }
 
+// ErrProxyScheme is returned when the proxy's scheme is not supported.
+var ErrProxyScheme = errors.New("proxy scheme must be http or socks5")
+
+// ParseProxy parses a proxy's URL, in the format `scheme://[user:password@]host:port`, where the scheme is http, to
+// a HTTP proxy using the CONNECT method, or socks5.
+func ParseProxy(proxy string) (*url.URL, error) {
+	uri, err := url.Parse(proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	if uri.Scheme != "http" && uri.Scheme != "socks5" {
+		return nil, ErrProxyScheme
+	}
+
+	return uri, nil
+}
+
+// NewWebsocketDialer creates a websocket dialer connecting through proxy. When proxy is nil, the proxy is read from the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.
+func NewWebsocketDialer(proxy *url.URL) *websocket.Dialer {
+	dialer := *websocket.DefaultDialer
+	if proxy != nil {
+		dialer.Proxy = http.ProxyURL(proxy)
+	}
+
+	return &dialer
+}
+
 // DialContext creates a websocket connection to ShellHub's SSH server.
 //
 // It receivees the endpoint to connect and the necessary headers for authentication on the server. If the server
 // redirect the connection with status [http.StatusTemporaryRedirect] or [http.StatusPermanentRedirect], the DialContext
-// method will follow. Any other response from the server will result in an error as result of this function.
+// method will follow. When the server replies with [http.StatusServiceUnavailable] or [http.StatusTooManyRequests] and
+// the Retry-After header, the error is a [RetryAfterError] with the period hinted. Any other response from the server
+// will result in an error as result of this function.
 func DialContext(ctx context.Context, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	return DialContextWithDialer(ctx, websocket.DefaultDialer, address, header)
+}
+
+// DialContextWithDialer is like [DialContext], but it uses dialer to create the websocket connection.
+func DialContextWithDialer(ctx context.Context, dialer *websocket.Dialer, address string, header http.Header) (*websocket.Conn, *http.Response, error) {
 	parseToWS := func(uri string) string {
 		return regexp.MustCompile(`^http`).ReplaceAllString(uri, "ws")
 	}
@@ -61,7 +98,7 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 		return nil, nil, err
 	}
 
-	conn, res, err := websocket.DefaultDialer.DialContext(ctx, parseToWS(uri), header)
+	conn, res, err := dialer.DialContext(ctx, parseToWS(uri), header)
 	if err != nil {
 		if res == nil {
 			return nil, nil, err
@@ -74,7 +111,13 @@ func DialContext(ctx context.Context, address string, header http.Header) (*webs
 				return nil, nil, err
 			}
 
-			return DialContext(ctx, parseToWS(location.String()), header)
+			return DialContextWithDialer(ctx, dialer, parseToWS(location.String()), header)
+		case http.StatusServiceUnavailable, http.StatusTooManyRequests:
+			if after, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				return nil, nil, &RetryAfterError{After: after, Err: err}
+			}
+
+			return nil, nil, err
 		default:
 			return nil, nil, err
 		}
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialContextWithDialer(t *testing.T) {
+	tests := []struct {
+		description string
+		status      int
+		retryAfter  string
+		expected    time.Duration
+		hinted      bool
+	}{
+		{
+			description: "fails with the hint when the server is unavailable",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "30",
+			expected:    30 * time.Second,
+			hinted:      true,
+		},
+		{
+			description: "fails with the hint when the server limits the requests",
+			status:      http.StatusTooManyRequests,
+			retryAfter:  "5",
+			expected:    5 * time.Second,
+			hinted:      true,
+		},
+		{
+			description: "fails with the hint when it is a date in the past",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "Wed, 21 Oct 2015 07:28:00 GMT",
+			expected:    0,
+			hinted:      true,
+		},
+		{
+			description: "fails without the hint when the header is not sent",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "",
+			hinted:      false,
+		},
+		{
+			description: "fails without the hint when the header is invalid",
+			status:      http.StatusServiceUnavailable,
+			retryAfter:  "soon",
+			hinted:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+
+				w.WriteHeader(test.status)
+			}))
+			defer server.Close()
+
+			conn, _, err := DialContextWithDialer(context.Background(), websocket.DefaultDialer, server.URL, nil)
+			assert.Nil(t, conn)
+			assert.ErrorIs(t, err, websocket.ErrBadHandshake)
+
+			var retry *RetryAfterError
+			assert.Equal(t, test.hinted, errors.As(err, &retry))
+
+			if test.hinted {
+				assert.Equal(t, test.expected, retry.After)
+			}
+		})
+	}
+}
@@ -23,6 +23,9 @@ type (
 	// FilterTypeProperty holds data to filter a property based on value and comparison operator.
 	FilterTypeProperty struct { //nolint:revive
 		// Property name
+		//
+		// Nested properties are accessed through dot notation, like the device's inventory reported by the agent:
+		// `info.kernel`, `info.memory`, `info.interfaces.ips`, `info.packages` or `info.docker`.
 		Name string `json:"name"`
 		// Comparison operator
 		//
@@ -22,6 +22,8 @@ var (
 	ErrConnectionFailed = errors.New("connection failed")
 	ErrNotFound         = errors.New("not found")
 	ErrUnknown          = errors.New("unknown error")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
 )
 
 // Options wraps injectable values to a new API internal client.
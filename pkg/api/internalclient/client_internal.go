@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/hibiken/asynq"
@@ -26,10 +27,14 @@ type Client interface {
 type internalAPI interface {
 	LookupDevice()
 	GetPublicKey(fingerprint, tenant string) (*models.PublicKey, error)
-	CreatePrivateKey() (*models.PrivateKey, error)
+	// CreatePrivateKey creates a private key to authenticate on a device. When the fingerprint and the tenant identify a
+	// public key created by a member of the namespace, the private key authenticates on behalf of the member.
+	CreatePrivateKey(fingerprint, tenant string) (*models.PrivateKey, error)
 	EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error)
 	DevicesOffline(id string) error
 	DevicesHeartbeat(id string) error
+	JobRun(id string, timeout time.Duration) error
+	FilePushRun(id string, timeout time.Duration) error
 	FirewallEvaluate(lookup map[string]string) error
 	SessionAsAuthenticated(uid string) []error
 	FinishSession(uid string) []error
@@ -37,6 +42,12 @@ type internalAPI interface {
 	RecordSession(session *models.SessionRecorded, recordURL string)
 	Lookup(lookup map[string]string) (string, []error)
 	DeviceLookup(lookup map[string]string) (*models.Device, []error)
+	// GetTunnel gets a tunnel by its address, to a connection coming from the source IP address.
+	GetTunnel(address, source string) (*models.Tunnel, error)
+	// GetTunnelByPort gets a tunnel in the port mode by its listen port, to a connection coming from the source IP
+	// address.
+	GetTunnelByPort(port int, source string) (*models.Tunnel, error)
+	AuthUserToken(token string) (*models.UserAuthClaims, error)
 	BillingReport(tenant string, action string) (int, error)
 	BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error)
 }
@@ -103,9 +114,13 @@ func (c *client) EvaluateKey(fingerprint string, dev *models.Device, username st
 	return false, nil
 }
 
-func (c *client) CreatePrivateKey() (*models.PrivateKey, error) {
+func (c *client) CreatePrivateKey(fingerprint, tenant string) (*models.PrivateKey, error) {
 	var privKey *models.PrivateKey
 	_, err := c.http.R().
+		SetBody(map[string]string{
+			"fingerprint": fingerprint,
+			"tenant_id":   tenant,
+		}).
 		SetResult(&privKey).
 		Post(buildURL(c, "/internal/sshkeys/private-keys"))
 	if err != nil {
@@ -131,6 +146,34 @@ func (c *client) DevicesHeartbeat(id string) error {
 	return err
 }
 
+// JobRun enqueues the execution of a job on its devices, what is allowed to take up to timeout. The job is not retried
+// when it fails, as its command could have been executed on some of the devices.
+func (c *client) JobRun(id string, timeout time.Duration) error {
+	_, err := c.asynq.Enqueue(
+		asynq.NewTask("job:run", []byte(id)),
+		asynq.Queue("jobs"),
+		asynq.TaskID(id),
+		asynq.MaxRetry(0),
+		asynq.Timeout(timeout),
+	)
+
+	return err
+}
+
+// FilePushRun enqueues the delivery of a pushed file to its pending devices, what is allowed to take up to timeout.
+// The task is not retried, as an interrupted delivery is resumed when the file push is retried.
+func (c *client) FilePushRun(id string, timeout time.Duration) error {
+	_, err := c.asynq.Enqueue(
+		asynq.NewTask("file_push:run", []byte(id)),
+		asynq.Queue("jobs"),
+		asynq.TaskID(id),
+		asynq.MaxRetry(0),
+		asynq.Timeout(timeout),
+	)
+
+	return err
+}
+
 var (
 	ErrFirewallConnection = errors.New("failed to make the request to evaluate the firewall")
 	ErrFirewallBlock      = errors.New("a firewall rule prohibit this connection")
@@ -239,3 +282,67 @@ func (c *client) DeviceLookup(lookup map[string]string) (*models.Device, []error
 
 	return device, nil
 }
+
+func (c *client) GetTunnel(address, source string) (*models.Tunnel, error) {
+	return c.getTunnel(fmt.Sprintf("/internal/tunnels/%s", address), source)
+}
+
+func (c *client) GetTunnelByPort(port int, source string) (*models.Tunnel, error) {
+	return c.getTunnel(fmt.Sprintf("/internal/tunnels/ports/%d", port), source)
+}
+
+func (c *client) getTunnel(path, source string) (*models.Tunnel, error) {
+	var tunnel *models.Tunnel
+	resp, err := c.http.R().
+		SetQueryParam("source", source).
+		SetResult(&tunnel).
+		Get(buildURL(c, path))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	case http.StatusForbidden:
+		return nil, ErrForbidden
+	case http.StatusOK:
+		return tunnel, nil
+	default:
+		return nil, ErrUnknown
+	}
+}
+
+// AuthUserToken checks if a user's token is valid, returning the claims of the user who owns it, including if the token
+// was issued after its MFA was validated.
+func (c *client) AuthUserToken(token string) (*models.UserAuthClaims, error) {
+	resp, err := c.http.R().
+		SetAuthToken(token).
+		Get(buildURL(c, "/internal/auth"))
+	if err != nil {
+		return nil, ErrConnectionFailed
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden:
+		return nil, ErrUnauthorized
+	default:
+		return nil, ErrUnknown
+	}
+
+	if resp.Header().Get("X-Tenant-ID") == "" {
+		return nil, ErrUnauthorized
+	}
+
+	return &models.UserAuthClaims{
+		Tenant:   resp.Header().Get("X-Tenant-ID"),
+		Username: resp.Header().Get("X-Username"),
+		ID:       resp.Header().Get("X-ID"),
+		Role:     resp.Header().Get("X-Role"),
+		MFA: models.MFA{
+			Status:   resp.Header().Get("X-MFA") == "true",
+			Validate: resp.Header().Get("X-Validate-MFA") == "true",
+		},
+	}, nil
+}
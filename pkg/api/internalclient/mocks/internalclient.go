@@ -1,10 +1,12 @@
-// Code generated by mockery v2.20.0. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
 import (
 	models "github.com/shellhub-io/shellhub/pkg/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -12,10 +14,44 @@ type Client struct {
 	mock.Mock
 }
 
+// AuthUserToken provides a mock function with given fields: token
+func (_m *Client) AuthUserToken(token string) (*models.UserAuthClaims, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AuthUserToken")
+	}
+
+	var r0 *models.UserAuthClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*models.UserAuthClaims, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *models.UserAuthClaims); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserAuthClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BillingEvaluate provides a mock function with given fields: tenantID
 func (_m *Client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, int, error) {
 	ret := _m.Called(tenantID)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BillingEvaluate")
+	}
+
 	var r0 *models.BillingEvaluation
 	var r1 int
 	var r2 error
@@ -49,6 +85,10 @@ func (_m *Client) BillingEvaluate(tenantID string) (*models.BillingEvaluation, i
 func (_m *Client) BillingReport(tenant string, action string) (int, error) {
 	ret := _m.Called(tenant, action)
 
+	if len(ret) == 0 {
+		panic("no return value specified for BillingReport")
+	}
+
 	var r0 int
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, string) (int, error)); ok {
@@ -69,25 +109,29 @@ func (_m *Client) BillingReport(tenant string, action string) (int, error) {
 	return r0, r1
 }
 
-// CreatePrivateKey provides a mock function with given fields:
-func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
-	ret := _m.Called()
+// CreatePrivateKey provides a mock function with given fields: fingerprint, tenant
+func (_m *Client) CreatePrivateKey(fingerprint string, tenant string) (*models.PrivateKey, error) {
+	ret := _m.Called(fingerprint, tenant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreatePrivateKey")
+	}
 
 	var r0 *models.PrivateKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*models.PrivateKey, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(string, string) (*models.PrivateKey, error)); ok {
+		return rf(fingerprint, tenant)
 	}
-	if rf, ok := ret.Get(0).(func() *models.PrivateKey); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(string, string) *models.PrivateKey); ok {
+		r0 = rf(fingerprint, tenant)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.PrivateKey)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(fingerprint, tenant)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -99,6 +143,10 @@ func (_m *Client) CreatePrivateKey() (*models.PrivateKey, error) {
 func (_m *Client) DeviceLookup(lookup map[string]string) (*models.Device, []error) {
 	ret := _m.Called(lookup)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeviceLookup")
+	}
+
 	var r0 *models.Device
 	var r1 []error
 	if rf, ok := ret.Get(0).(func(map[string]string) (*models.Device, []error)); ok {
@@ -127,6 +175,10 @@ func (_m *Client) DeviceLookup(lookup map[string]string) (*models.Device, []erro
 func (_m *Client) DevicesHeartbeat(id string) error {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesHeartbeat")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
 		r0 = rf(id)
@@ -141,6 +193,10 @@ func (_m *Client) DevicesHeartbeat(id string) error {
 func (_m *Client) DevicesOffline(id string) error {
 	ret := _m.Called(id)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DevicesOffline")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(string) error); ok {
 		r0 = rf(id)
@@ -155,6 +211,10 @@ func (_m *Client) DevicesOffline(id string) error {
 func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username string) (bool, error) {
 	ret := _m.Called(fingerprint, dev, username)
 
+	if len(ret) == 0 {
+		panic("no return value specified for EvaluateKey")
+	}
+
 	var r0 bool
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, *models.Device, string) (bool, error)); ok {
@@ -175,10 +235,32 @@ func (_m *Client) EvaluateKey(fingerprint string, dev *models.Device, username s
 	return r0, r1
 }
 
+// FilePushRun provides a mock function with given fields: id, timeout
+func (_m *Client) FilePushRun(id string, timeout time.Duration) error {
+	ret := _m.Called(id, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FilePushRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) error); ok {
+		r0 = rf(id, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // FinishSession provides a mock function with given fields: uid
 func (_m *Client) FinishSession(uid string) []error {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FinishSession")
+	}
+
 	var r0 []error
 	if rf, ok := ret.Get(0).(func(string) []error); ok {
 		r0 = rf(uid)
@@ -195,6 +277,10 @@ func (_m *Client) FinishSession(uid string) []error {
 func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 	ret := _m.Called(lookup)
 
+	if len(ret) == 0 {
+		panic("no return value specified for FirewallEvaluate")
+	}
+
 	var r0 error
 	if rf, ok := ret.Get(0).(func(map[string]string) error); ok {
 		r0 = rf(lookup)
@@ -209,6 +295,10 @@ func (_m *Client) FirewallEvaluate(lookup map[string]string) error {
 func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDevice")
+	}
+
 	var r0 *models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Device, error)); ok {
@@ -235,6 +325,10 @@ func (_m *Client) GetDevice(uid string) (*models.Device, error) {
 func (_m *Client) GetDeviceByPublicURLAddress(address string) (*models.Device, error) {
 	ret := _m.Called(address)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeviceByPublicURLAddress")
+	}
+
 	var r0 *models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string) (*models.Device, error)); ok {
@@ -261,6 +355,10 @@ func (_m *Client) GetDeviceByPublicURLAddress(address string) (*models.Device, e
 func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.PublicKey, error) {
 	ret := _m.Called(fingerprint, tenant)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicKey")
+	}
+
 	var r0 *models.PublicKey
 	var r1 error
 	if rf, ok := ret.Get(0).(func(string, string) (*models.PublicKey, error)); ok {
@@ -283,10 +381,92 @@ func (_m *Client) GetPublicKey(fingerprint string, tenant string) (*models.Publi
 	return r0, r1
 }
 
+// GetTunnel provides a mock function with given fields: address, source
+func (_m *Client) GetTunnel(address string, source string) (*models.Tunnel, error) {
+	ret := _m.Called(address, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTunnel")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (*models.Tunnel, error)); ok {
+		return rf(address, source)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) *models.Tunnel); ok {
+		r0 = rf(address, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(address, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTunnelByPort provides a mock function with given fields: port, source
+func (_m *Client) GetTunnelByPort(port int, source string) (*models.Tunnel, error) {
+	ret := _m.Called(port, source)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTunnelByPort")
+	}
+
+	var r0 *models.Tunnel
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, string) (*models.Tunnel, error)); ok {
+		return rf(port, source)
+	}
+	if rf, ok := ret.Get(0).(func(int, string) *models.Tunnel); ok {
+		r0 = rf(port, source)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Tunnel)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, string) error); ok {
+		r1 = rf(port, source)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// JobRun provides a mock function with given fields: id, timeout
+func (_m *Client) JobRun(id string, timeout time.Duration) error {
+	ret := _m.Called(id, timeout)
+
+	if len(ret) == 0 {
+		panic("no return value specified for JobRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, time.Duration) error); ok {
+		r0 = rf(id, timeout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // KeepAliveSession provides a mock function with given fields: uid
 func (_m *Client) KeepAliveSession(uid string) []error {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for KeepAliveSession")
+	}
+
 	var r0 []error
 	if rf, ok := ret.Get(0).(func(string) []error); ok {
 		r0 = rf(uid)
@@ -303,6 +483,10 @@ func (_m *Client) KeepAliveSession(uid string) []error {
 func (_m *Client) ListDevices() ([]models.Device, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for ListDevices")
+	}
+
 	var r0 []models.Device
 	var r1 error
 	if rf, ok := ret.Get(0).(func() ([]models.Device, error)); ok {
@@ -329,6 +513,10 @@ func (_m *Client) ListDevices() ([]models.Device, error) {
 func (_m *Client) Lookup(lookup map[string]string) (string, []error) {
 	ret := _m.Called(lookup)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Lookup")
+	}
+
 	var r0 string
 	var r1 []error
 	if rf, ok := ret.Get(0).(func(map[string]string) (string, []error)); ok {
@@ -365,6 +553,10 @@ func (_m *Client) RecordSession(session *models.SessionRecorded, recordURL strin
 func (_m *Client) SessionAsAuthenticated(uid string) []error {
 	ret := _m.Called(uid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for SessionAsAuthenticated")
+	}
+
 	var r0 []error
 	if rf, ok := ret.Get(0).(func(string) []error); ok {
 		r0 = rf(uid)
@@ -377,13 +569,12 @@ func (_m *Client) SessionAsAuthenticated(uid string) []error {
 	return r0
 }
 
-type mockConstructorTestingTNewClient interface {
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewClient(t mockConstructorTestingTNewClient) *Client {
+}) *Client {
 	mock := &Client{}
 	mock.Mock.Test(t)
 
@@ -1,5 +1,7 @@
 package requests
 
+import "github.com/shellhub-io/shellhub/pkg/models"
+
 // DeviceParam is a structure to represent and validate a device UID as path param.
 type DeviceParam struct {
 	UID string `param:"uid" validate:"required"`
@@ -73,16 +75,22 @@ type DeviceInfo struct {
 	Version    string `json:"version"`
 	Arch       string `json:"arch"`
 	Platform   string `json:"platform"`
+	// DeviceInventory is the device's inventory, reported only by agents able to collect it.
+	models.DeviceInventory
 }
 
 // DeviceAuth is the structure to represent the request data for device auth endpoint.
 type DeviceAuth struct {
 	Info      *DeviceInfo     `json:"info" validate:"required"`
 	Sessions  []string        `json:"sessions,omitempty"`
+	Tags      []string        `json:"tags,omitempty" validate:"omitempty,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 	Hostname  string          `json:"hostname,omitempty" validate:"required_without=Identity,omitempty,hostname_rfc1123" hash:"-"`
 	Identity  *DeviceIdentity `json:"identity,omitempty" validate:"required_without=Hostname,omitempty"`
 	PublicKey string          `json:"public_key" validate:"required"`
 	TenantID  string          `json:"tenant_id" validate:"required"`
+	// EnrollmentToken is either one of the namespace's enrollment tokens, checked when the device registers, or the
+	// token matched against the namespace's auto-accept rules.
+	EnrollmentToken string `json:"enrollment_token,omitempty"`
 }
 
 type DeviceGetPublicURL struct {
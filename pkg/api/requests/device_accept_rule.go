@@ -0,0 +1,50 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// DeviceAcceptRuleParam is a structure to represent and validate a device accept rule ID as path param.
+type DeviceAcceptRuleParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// DeviceAcceptRuleList is the structure to represent the request data for list device accept rules endpoint.
+type DeviceAcceptRuleList struct {
+	paginator.Query
+}
+
+// DeviceAcceptRuleData is the structure to represent the data of a device accept rule sent to the create and update
+// endpoints. At least one condition must be set. When updating the rule, it keeps its token if none is sent.
+type DeviceAcceptRuleData struct {
+	Name string `json:"name" validate:"required"`
+	// MACPrefixes match the devices whose identity's MAC address starts with any of them, like "00:1a:2b".
+	MACPrefixes []string `json:"mac_prefixes" validate:"omitempty,dive,required"`
+	// Hostname is a pattern, like "web-*", matching the hostname the device registers with.
+	Hostname string `json:"hostname"`
+	// Info are the patterns matching the fields of the device's info, keyed by the fields' JSON names.
+	Info map[string]string `json:"info" validate:"omitempty,dive,keys,required,endkeys,required"`
+	// Sources match the devices registering from any of the IP addresses or CIDR blocks.
+	Sources []string `json:"sources" validate:"omitempty,dive,required"`
+	// Token matches the devices registering with the same enrollment token. It is stored hashed, so it can't be got.
+	Token string `json:"token"`
+}
+
+// DeviceAcceptRuleCreate is the structure to represent the request data for create device accept rule endpoint.
+type DeviceAcceptRuleCreate struct {
+	DeviceAcceptRuleData
+}
+
+// DeviceAcceptRuleUpdate is the structure to represent the request data for update device accept rule endpoint.
+type DeviceAcceptRuleUpdate struct {
+	DeviceAcceptRuleParam
+	DeviceAcceptRuleData
+}
+
+// DeviceAcceptRuleGet is the structure to represent the request data for get device accept rule endpoint.
+type DeviceAcceptRuleGet struct {
+	DeviceAcceptRuleParam
+}
+
+// DeviceAcceptRuleDelete is the structure to represent the request data for delete device accept rule endpoint.
+type DeviceAcceptRuleDelete struct {
+	DeviceAcceptRuleParam
+}
@@ -0,0 +1,40 @@
+package requests
+
+import (
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/api/paginator"
+)
+
+// EnrollmentTokenParam is a structure to represent and validate an enrollment token ID as path param.
+type EnrollmentTokenParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// EnrollmentTokenList is the structure to represent the request data for list enrollment tokens endpoint.
+type EnrollmentTokenList struct {
+	paginator.Query
+}
+
+// EnrollmentTokenCreate is the structure to represent the request data for create enrollment token endpoint.
+type EnrollmentTokenCreate struct {
+	Name string `json:"name" validate:"required"`
+	// Tags are applied to the devices registered with the token.
+	Tags []string `json:"tags" validate:"omitempty,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
+	// Status is the status of the devices registered with the token, pending when it is empty.
+	Status string `json:"status" validate:"omitempty,oneof=pending accepted"`
+	// SingleUse allows only one device to register with the token.
+	SingleUse bool `json:"single_use"`
+	// ExpiresAt is when the token expires. It never expires when it is empty.
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// EnrollmentTokenGet is the structure to represent the request data for get enrollment token endpoint.
+type EnrollmentTokenGet struct {
+	EnrollmentTokenParam
+}
+
+// EnrollmentTokenRevoke is the structure to represent the request data for revoke enrollment token endpoint.
+type EnrollmentTokenRevoke struct {
+	EnrollmentTokenParam
+}
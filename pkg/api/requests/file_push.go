@@ -0,0 +1,40 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// FilePushParam is a structure to represent and validate a file push ID as path param.
+type FilePushParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// FilePushList is the structure to represent the request data for list file pushes endpoint.
+type FilePushList struct {
+	paginator.Query
+}
+
+// FilePushCreate is the structure to represent the request data for create file push endpoint. It is sent as a
+// multipart form, with the file's content on its `file` field.
+type FilePushCreate struct {
+	// Path is the absolute path where the file is written on the devices.
+	Path string `form:"path" validate:"required,startswith=/"`
+	// Owner is the device's user that owns the file.
+	Owner string `form:"owner"`
+	// Group is the device's group that owns the file.
+	Group string `form:"group"`
+	// Mode is the file's permission bits, in octal, like "0644".
+	Mode string `form:"mode" validate:"omitempty,file_mode"`
+	// Tags select the accepted devices, having all of them, where the file is written.
+	Tags []string `form:"tags" validate:"required,min=1,dive,required"`
+	// Concurrency is the maximum number of devices receiving the file at the same time.
+	Concurrency int `form:"concurrency" validate:"omitempty,min=1,max=100"`
+}
+
+// FilePushGet is the structure to represent the request data for get file push endpoint.
+type FilePushGet struct {
+	FilePushParam
+}
+
+// FilePushRetry is the structure to represent the request data for retry file push endpoint.
+type FilePushRetry struct {
+	FilePushParam
+}
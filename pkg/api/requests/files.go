@@ -0,0 +1,8 @@
+package requests
+
+// DeviceFiles is the structure to represent the request data for the device's file browser endpoint.
+type DeviceFiles struct {
+	DeviceParam
+	// Action is the file browser's operation requested to the device.
+	Action string `param:"action" validate:"required,oneof=list stat download upload mkdir delete"`
+}
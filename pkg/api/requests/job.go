@@ -0,0 +1,91 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// JobParam is a structure to represent and validate a job ID as path param.
+type JobParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// JobList is the structure to represent the request data for list jobs endpoint.
+type JobList struct {
+	paginator.Query
+}
+
+// JobCreate is the structure to represent the request data for create job endpoint.
+type JobCreate struct {
+	// Command is the command line executed, through the user's shell, on each device.
+	Command string `json:"command" validate:"required"`
+	// User is the device's user that executes the command.
+	User string `json:"user" validate:"required"`
+	// Devices are the UIDs of the devices where the command is executed.
+	Devices []string `json:"devices" validate:"required_without=Tags,omitempty,dive,required"`
+	// Tags select the accepted devices, having all of them, where the command is executed.
+	Tags []string `json:"tags" validate:"required_without=Devices,omitempty,dive,required"`
+	// Concurrency is the maximum number of devices executing the command at the same time.
+	Concurrency int `json:"concurrency" validate:"omitempty,min=1,max=100"`
+	// Timeout is the time, in seconds, the command is allowed to run on each device.
+	Timeout int `json:"timeout" validate:"omitempty,min=1,max=3600"`
+}
+
+// JobGet is the structure to represent the request data for get job endpoint.
+type JobGet struct {
+	JobParam
+}
+
+// JobScheduleParam is a structure to represent and validate a job schedule ID as path param.
+type JobScheduleParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// JobScheduleList is the structure to represent the request data for list job schedules endpoint.
+type JobScheduleList struct {
+	paginator.Query
+}
+
+// JobScheduleData is the structure to represent the data of a job schedule sent to the create and update endpoints.
+type JobScheduleData struct {
+	Name string `json:"name" validate:"required"`
+	// Cron is the cron expression, or descriptor like "@daily" and "@every 1h", of when the schedule runs, in UTC.
+	Cron    string `json:"cron" validate:"required"`
+	Command string `json:"command" validate:"required"`
+	User    string `json:"user" validate:"required"`
+	// Tags select the accepted devices, having all of them, where the command is executed.
+	Tags        []string `json:"tags" validate:"required,min=1,dive,required"`
+	Concurrency int      `json:"concurrency" validate:"omitempty,min=1,max=100"`
+	Timeout     int      `json:"timeout" validate:"omitempty,min=1,max=3600"`
+	// Offline is what the schedule does with the devices that are offline when it runs: "skip", the default, or
+	// "queue".
+	Offline string `json:"offline" validate:"omitempty,oneof=skip queue"`
+	// NotifyURL, when defined, receives a POST request with the job when it finishes with failures.
+	NotifyURL string `json:"notify_url" validate:"omitempty,url"`
+	// Enabled, when not sent, is true on creation and unchanged on update.
+	Enabled *bool `json:"enabled"`
+}
+
+// JobScheduleCreate is the structure to represent the request data for create job schedule endpoint.
+type JobScheduleCreate struct {
+	JobScheduleData
+}
+
+// JobScheduleUpdate is the structure to represent the request data for update job schedule endpoint.
+type JobScheduleUpdate struct {
+	JobScheduleParam
+	JobScheduleData
+}
+
+// JobScheduleGet is the structure to represent the request data for get job schedule endpoint.
+type JobScheduleGet struct {
+	JobScheduleParam
+}
+
+// JobScheduleDelete is the structure to represent the request data for delete job schedule endpoint.
+type JobScheduleDelete struct {
+	JobScheduleParam
+}
+
+// JobScheduleJobs is the structure to represent the request data for list job schedule's jobs endpoint.
+type JobScheduleJobs struct {
+	JobScheduleParam
+	paginator.Query
+}
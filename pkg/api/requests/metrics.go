@@ -0,0 +1,12 @@
+package requests
+
+import "time"
+
+// DeviceMetricsList is the structure to represent the request data for list device's metrics endpoint.
+type DeviceMetricsList struct {
+	DeviceParam
+	// From is the start of the metrics' range, in RFC 3339 format. When empty, the range starts 24 hours before To.
+	From time.Time `query:"from"`
+	// To is the end of the metrics' range, in RFC 3339 format. When empty, the range ends at the current time.
+	To time.Time `query:"to"`
+}
@@ -62,3 +62,10 @@ type SessionEditRecordStatus struct {
 	TenantParam
 	SessionRecord bool `json:"session_record"`
 }
+
+// NamespaceEditEnrollment is the structure to represent the request data for edit namespace enrollment endpoint.
+type NamespaceEditEnrollment struct {
+	TenantParam
+	// RequireToken allows only the devices presenting an enrollment token to register into the namespace.
+	RequireToken bool `json:"require_token"`
+}
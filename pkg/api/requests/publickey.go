@@ -20,14 +20,26 @@ type PublicKeyFilter struct {
 	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// PublicKeyOptions restricts the sessions opened with a public key.
+type PublicKeyOptions struct {
+	Command          string            `json:"command,omitempty"`
+	NoPTY            bool              `json:"no_pty,omitempty"`
+	NoPortForwarding bool              `json:"no_port_forwarding,omitempty"`
+	NoSFTP           bool              `json:"no_sftp,omitempty"`
+	Environment      map[string]string `json:"environment,omitempty" validate:"max=32,dive,keys,env_name,endkeys,max=1024"`
+}
+
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
 type PublicKeyCreate struct {
-	Data        []byte          `json:"data" validate:"required"`
-	Filter      PublicKeyFilter `json:"filter" validate:"required"`
-	Name        string          `json:"name" validate:"required"`
-	Username    string          `json:"username" validate:"required,regexp"`
-	TenantID    string          `json:"-"`
-	Fingerprint string          `json:"-"`
+	Data        []byte           `json:"data" validate:"required"`
+	Filter      PublicKeyFilter  `json:"filter" validate:"required"`
+	Name        string           `json:"name" validate:"required"`
+	Username    string           `json:"username" validate:"required,regexp"`
+	Options     PublicKeyOptions `json:"options"`
+	TenantID    string           `json:"-"`
+	Fingerprint string           `json:"-"`
+	// UserID is the ID of the member creating the public key.
+	UserID string `json:"-"`
 }
 
 // PublicKeyUpdate is the structure to represent the request data for update public key endpoint.
@@ -39,6 +51,8 @@ type PublicKeyUpdate struct {
 	Username string `json:"username" validate:"required,regexp"`
 	// Filter is the public key's filter.
 	Filter PublicKeyFilter `json:"filter" validate:"required"`
+	// Options are the restrictions applied to the sessions opened with the public key.
+	Options PublicKeyOptions `json:"options"`
 }
 
 // PublicKeyDelete is the structure to represent the request data for delete public key endpoint.
@@ -69,3 +83,12 @@ type PublicKeyAuth struct {
 	Fingerprint string `json:"fingerprint" validate:"required"`
 	Data        string `json:"data" validate:"required"`
 }
+
+// PrivateKeyCreate is the structure to represent the request data for create private key endpoint.
+//
+// When the fingerprint and the tenant are set, the private key is created to the member that created the public key
+// with that fingerprint in the namespace.
+type PrivateKeyCreate struct {
+	Fingerprint string `json:"fingerprint"`
+	TenantID    string `json:"tenant_id"`
+}
@@ -0,0 +1,56 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// TunnelAddressParam is a structure to represent and validate a tunnel address as path param.
+type TunnelAddressParam struct {
+	Address string `param:"address" validate:"required"`
+}
+
+// TunnelList is the structure to represent the request data for list device's tunnels endpoint.
+type TunnelList struct {
+	DeviceParam
+	paginator.Query
+}
+
+// TunnelCreate is the structure to represent the request data for create device's tunnel endpoint.
+type TunnelCreate struct {
+	DeviceParam
+	// Host is the address, on the device's side, where the tunnel's connections will be forwarded to.
+	Host string `json:"host" validate:"required"`
+	// Port is the port, on the device's side, where the tunnel's connections will be forwarded to.
+	Port int `json:"port" validate:"required,min=1,max=65535"`
+	// Mode is how the tunnel's connections reach ShellHub, either "sni", through TLS with the tunnel's address as the
+	// server name, or "port", through plain TCP to a port allocated to the tunnel. It is "sni" when it is empty.
+	Mode string `json:"mode" validate:"omitempty,oneof=sni port"`
+}
+
+// TunnelDelete is the structure to represent the request data for delete device's tunnel endpoint.
+type TunnelDelete struct {
+	DeviceParam
+	TunnelAddressParam
+}
+
+// TunnelGet is the structure to represent the request data for get tunnel endpoint.
+type TunnelGet struct {
+	TunnelAddressParam
+	// Source is the IP address the tunnel's connection comes from.
+	Source string `query:"source" validate:"required,ip"`
+}
+
+// TunnelGetByPort is the structure to represent the request data for get tunnel by port endpoint.
+type TunnelGetByPort struct {
+	Port int `param:"port" validate:"required,min=1,max=65535"`
+	// Source is the IP address the tunnel's connection comes from.
+	Source string `query:"source" validate:"required,ip"`
+}
+
+// TunnelGrant is the structure to represent the request data for grant tunnel access endpoint.
+type TunnelGrant struct {
+	DeviceParam
+	TunnelAddressParam
+	// Source is the IP address the member connects to the tunnel from.
+	Source string `json:"source" validate:"required,ip"`
+	// Duration is for how long, in seconds, the access is granted. It is one hour when it is empty.
+	Duration int `json:"duration" validate:"omitempty,min=60,max=86400"`
+}
@@ -0,0 +1,72 @@
+package requests
+
+import "github.com/shellhub-io/shellhub/pkg/api/paginator"
+
+// UpdatePolicyParam is a structure to represent and validate an update policy ID as path param.
+type UpdatePolicyParam struct {
+	ID string `param:"id" validate:"required"`
+}
+
+// UpdatePolicyList is the structure to represent the request data for list update policies endpoint.
+type UpdatePolicyList struct {
+	paginator.Query
+}
+
+// UpdateWindowData is the structure to represent a maintenance window of an update policy.
+type UpdateWindowData struct {
+	// Days are the days of the week when the window starts, from 0, Sunday, to 6, Saturday. When empty, the window
+	// starts every day.
+	Days []int `json:"days" validate:"omitempty,dive,min=0,max=6"`
+	// Start is the time of the day, in UTC and in the format "15:04", when the window opens.
+	Start string `json:"start" validate:"required,datetime=15:04"`
+	// End is the time of the day, in UTC and in the format "15:04", when the window closes.
+	End string `json:"end" validate:"required,datetime=15:04"`
+}
+
+// UpdatePolicyData is the structure to represent the data of an update policy sent to the create and update endpoints.
+type UpdatePolicyData struct {
+	Name string `json:"name" validate:"required"`
+	// Tags select the devices having all of them. When empty, the policy applies to all devices from the namespace.
+	Tags []string `json:"tags" validate:"omitempty,dive,required"`
+	// Version is the agent's version the devices are updated to, like "v0.15.0".
+	Version string `json:"version" validate:"required"`
+	// Canary is the percentage of the selected devices allowed to update. When not sent, it is 100.
+	Canary *int `json:"canary" validate:"omitempty,min=0,max=100"`
+	// Windows are the maintenance windows when the devices are allowed to update. When empty, they update anytime.
+	Windows []UpdateWindowData `json:"windows" validate:"omitempty,dive"`
+}
+
+// UpdatePolicyCreate is the structure to represent the request data for create update policy endpoint.
+type UpdatePolicyCreate struct {
+	UpdatePolicyData
+}
+
+// UpdatePolicyUpdate is the structure to represent the request data for update update policy endpoint.
+type UpdatePolicyUpdate struct {
+	UpdatePolicyParam
+	UpdatePolicyData
+}
+
+// UpdatePolicyGet is the structure to represent the request data for get update policy endpoint.
+type UpdatePolicyGet struct {
+	UpdatePolicyParam
+}
+
+// UpdatePolicyDelete is the structure to represent the request data for delete update policy endpoint.
+type UpdatePolicyDelete struct {
+	UpdatePolicyParam
+}
+
+// DeviceUpdateList is the structure to represent the request data for list devices' update status endpoint.
+type DeviceUpdateList struct {
+	paginator.Query
+}
+
+// DeviceUpdateReport is the structure to represent the update attempt reported by the device's agent.
+type DeviceUpdateReport struct {
+	// Version is the agent's version the device is updating to.
+	Version string `json:"version" validate:"required"`
+	// Status is either "updating", when the update is being applied, or "failed".
+	Status string `json:"status" validate:"required,oneof=updating failed"`
+	Error  string `json:"error"`
+}
@@ -9,12 +9,22 @@ type PublicKeyFilter struct {
 	Tags []string `json:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// PublicKeyOptions restricts the sessions opened with a public key.
+type PublicKeyOptions struct {
+	Command          string            `json:"command,omitempty"`
+	NoPTY            bool              `json:"no_pty,omitempty"`
+	NoPortForwarding bool              `json:"no_port_forwarding,omitempty"`
+	NoSFTP           bool              `json:"no_sftp,omitempty"`
+	Environment      map[string]string `json:"environment,omitempty"`
+}
+
 // PublicKeyCreate is the structure to represent the request data for create public key endpoint.
 type PublicKeyCreate struct {
-	Data        []byte          `json:"data"`
-	Filter      PublicKeyFilter `json:"filter"`
-	Name        string          `json:"name"`
-	Username    string          `json:"username"`
-	TenantID    string          `json:"tenant_id"`
-	Fingerprint string          `json:"fingerprint"`
+	Data        []byte           `json:"data"`
+	Filter      PublicKeyFilter  `json:"filter"`
+	Name        string           `json:"name"`
+	Username    string           `json:"username"`
+	Options     PublicKeyOptions `json:"options"`
+	TenantID    string           `json:"tenant_id"`
+	Fingerprint string           `json:"fingerprint"`
 }
@@ -9,4 +9,7 @@ type Cache interface {
 	Get(ctx context.Context, key string, value interface{}) error
 	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
 	Delete(ctx context.Context, key string) error
+	// CompareAndDelete atomically deletes the key only when its value is equal to value, returning true when it was
+	// deleted.
+	CompareAndDelete(ctx context.Context, key string, value interface{}) (bool, error)
 }
@@ -24,3 +24,7 @@ func (n *nullCache) Set(_ context.Context, _ string, _ interface{}, _ time.Durat
 func (n *nullCache) Delete(_ context.Context, _ string) error {
 	return nil
 }
+
+func (n *nullCache) CompareAndDelete(_ context.Context, _ string, _ interface{}) (bool, error) {
+	return false, nil
+}
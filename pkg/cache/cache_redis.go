@@ -9,9 +9,19 @@ import (
 )
 
 type redisCache struct {
-	cache *rediscache.Cache
+	cache  *rediscache.Cache
+	client *redis.Client
 }
 
+// compareAndDeleteScript deletes the key only when its value is equal to the argument, in a single step.
+var compareAndDeleteScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+
+return 0
+`)
+
 var _ Cache = &redisCache{}
 
 // NewRedisCache creates and returns a new redis cache.
@@ -21,10 +31,13 @@ func NewRedisCache(uri string) (Cache, error) {
 		return nil, err
 	}
 
+	client := redis.NewClient(opt)
+
 	return &redisCache{
 		cache: rediscache.New(&rediscache.Options{
-			Redis: redis.NewClient(opt),
+			Redis: client,
 		}),
+		client: client,
 	}, nil
 }
 
@@ -52,3 +65,19 @@ func (c *redisCache) Delete(ctx context.Context, key string) error {
 
 	return c.cache.Delete(ctx, key)
 }
+
+// CompareAndDelete deletes cached value by given key only when it is equal to value.
+func (c *redisCache) CompareAndDelete(ctx context.Context, key string, value interface{}) (bool, error) {
+	// NOTICE: The value is compared as it is stored, so it is encoded the same way the cache encodes it when set.
+	data, err := c.cache.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := compareAndDeleteScript.Run(ctx, c.client, []string{key}, data).Int()
+	if err != nil {
+		return false, err
+	}
+
+	return deleted > 0, nil
+}
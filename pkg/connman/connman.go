@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net"
 
+	"github.com/hashicorp/yamux"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
 	"github.com/sirupsen/logrus"
@@ -12,23 +13,91 @@ import (
 
 var ErrNoConnection = errors.New("no connection")
 
+// MultiplexedSubprotocol is the websocket subprotocol negotiated by the agents able to multiplex all the connections
+// over the control websocket, instead of opening a new websocket for each one.
+const MultiplexedSubprotocol = "yamux"
+
+// Dialer opens new connections to a device through its control connection.
+type Dialer interface {
+	Dial(ctx context.Context) (net.Conn, error)
+	Done() <-chan struct{}
+}
+
+// multiplexedDialer is a [Dialer] opening the connections as streams of a yamux session.
+type multiplexedDialer struct {
+	session *yamux.Session
+}
+
+func (d *multiplexedDialer) Dial(ctx context.Context) (net.Conn, error) {
+	type result struct {
+		conn net.Conn
+		err  error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		conn, err := d.session.Open()
+		done <- result{conn, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.conn, r.err
+	case <-ctx.Done():
+		go func() {
+			if r := <-done; r.conn != nil {
+				r.conn.Close()
+			}
+		}()
+
+		return nil, ctx.Err()
+	}
+}
+
+func (d *multiplexedDialer) Done() <-chan struct{} {
+	return d.session.CloseChan()
+}
+
 type ConnectionManager struct {
 	dialers                 *SyncSliceMap
-	DialerDoneCallback      func(string, *revdial.Dialer)
-	DialerKeepAliveCallback func(string, *revdial.Dialer)
+	DialerDoneCallback      func(string, Dialer)
+	DialerKeepAliveCallback func(string, Dialer)
+
+	// DialerPath is the path, with an optional query, used by the agents to pick up the reverse connections.
+	DialerPath string
 }
 
 func New() *ConnectionManager {
 	return &ConnectionManager{
-		dialers: &SyncSliceMap{},
-		DialerDoneCallback: func(string, *revdial.Dialer) {
+		dialers:    &SyncSliceMap{},
+		DialerPath: "/ssh/revdial",
+		DialerDoneCallback: func(string, Dialer) {
 		},
 	}
 }
 
+// Set stores a dialer which asks the agent, through conn, to open a new websocket for each connection.
 func (m *ConnectionManager) Set(key string, conn *wsconnadapter.Adapter) {
-	dialer := revdial.NewDialer(conn, "/ssh/revdial")
+	m.store(key, conn, revdial.NewDialer(conn, m.DialerPath))
+}
+
+// SetMultiplexed stores a dialer which opens the connections as streams multiplexed over conn.
+func (m *ConnectionManager) SetMultiplexed(key string, conn *wsconnadapter.Adapter) error {
+	config := yamux.DefaultConfig()
+	// The websocket's ping is already used to check the connection's liveness.
+	config.EnableKeepAlive = false
+
+	session, err := yamux.Client(conn, config)
+	if err != nil {
+		return err
+	}
+
+	m.store(key, conn, &multiplexedDialer{session: session})
+
+	return nil
+}
 
+func (m *ConnectionManager) store(key string, conn *wsconnadapter.Adapter, dialer Dialer) {
 	m.dialers.Store(key, dialer)
 
 	if size := m.dialers.Size(key); size > 1 {
@@ -73,5 +142,5 @@ func (m *ConnectionManager) Dial(ctx context.Context, key string) (net.Conn, err
 		}).Warning("Multiple connections found for the same identifier during reverse tunnel dialing.")
 	}
 
-	return dialer.(*revdial.Dialer).Dial(ctx)
+	return dialer.(Dialer).Dial(ctx)
 }
@@ -0,0 +1,87 @@
+package connman
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/hashicorp/yamux"
+	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetMultiplexed(t *testing.T) {
+	manager := New()
+
+	keepAlive := make(chan string, 1)
+	manager.DialerKeepAliveCallback = func(key string, _ Dialer) {
+		keepAlive <- key
+	}
+
+	done := make(chan string, 1)
+	manager.DialerDoneCallback = func(key string, _ Dialer) {
+		done <- key
+	}
+
+	upgrader := websocket.Upgrader{Subprotocols: []string{MultiplexedSubprotocol}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		require.Equal(t, MultiplexedSubprotocol, conn.Subprotocol())
+
+		require.NoError(t, manager.SetMultiplexed("device", wsconnadapter.New(conn)))
+	}))
+	defer server.Close()
+
+	dialer := websocket.Dialer{Subprotocols: []string{MultiplexedSubprotocol}}
+
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+
+	listener, err := yamux.Server(wsconnadapter.New(conn), yamux.DefaultConfig())
+	require.NoError(t, err)
+
+	assert.Equal(t, "device", <-keepAlive)
+
+	go func() {
+		stream, err := listener.Accept()
+		if err != nil {
+			return
+		}
+
+		io.Copy(stream, stream) // nolint:errcheck
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	stream, err := manager.Dial(ctx, "device")
+	require.NoError(t, err)
+
+	_, err = stream.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	buffer := make([]byte, 5)
+	_, err = io.ReadFull(stream, buffer)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buffer))
+
+	listener.Close()
+
+	select {
+	case key := <-done:
+		assert.Equal(t, "device", key)
+	case <-time.After(5 * time.Second):
+		t.Fatal("dialer was not closed")
+	}
+
+	_, err = manager.Dial(ctx, "device")
+	assert.ErrorIs(t, err, ErrNoConnection)
+}
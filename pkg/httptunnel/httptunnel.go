@@ -3,21 +3,25 @@ package httptunnel
 import (
 	"bufio"
 	"context"
+	"errors"
 	"io"
+	"math"
 	"net"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/connman"
 	"github.com/shellhub-io/shellhub/pkg/revdial"
 	"github.com/shellhub-io/shellhub/pkg/wsconnadapter"
+	"golang.org/x/time/rate"
 )
 
 var upgrader = websocket.Upgrader{
 	ReadBufferSize:  1024,
 	WriteBufferSize: 1024,
-	Subprotocols:    []string{"binary"},
+	Subprotocols:    []string{connman.MultiplexedSubprotocol, "binary"},
 	CheckOrigin: func(r *http.Request) bool {
 		return true
 	},
@@ -37,6 +41,14 @@ type Tunnel struct {
 	connman           *connman.ConnectionManager
 	id                chan string
 	online            chan bool
+
+	// ForwardHandler, when defined, is called to dial a device that is not connected to this tunnel.
+	ForwardHandler func(context.Context, string) (net.Conn, error)
+
+	// ConnectionLimiter, when defined, limits the rate of the agents' connections accepted by the tunnel. The agents
+	// exceeding it are replied with [http.StatusServiceUnavailable] and asked, through the Retry-After header, to
+	// retry when the limiter has refilled.
+	ConnectionLimiter *rate.Limiter
 }
 
 func NewTunnel(connectionPath, dialerPath string) *Tunnel {
@@ -55,11 +67,13 @@ func NewTunnel(connectionPath, dialerPath string) *Tunnel {
 		online:  make(chan bool),
 	}
 
-	tunnel.connman.DialerDoneCallback = func(id string, _ *revdial.Dialer) {
+	tunnel.connman.DialerPath = dialerPath
+
+	tunnel.connman.DialerDoneCallback = func(id string, _ connman.Dialer) {
 		tunnel.CloseHandler(id)
 	}
 
-	tunnel.connman.DialerKeepAliveCallback = func(id string, _ *revdial.Dialer) {
+	tunnel.connman.DialerKeepAliveCallback = func(id string, _ connman.Dialer) {
 		tunnel.KeepAliveHandler(id)
 	}
 
@@ -70,6 +84,12 @@ func (t *Tunnel) Router() http.Handler {
 	e := echo.New()
 
 	e.GET(t.ConnectionPath, func(c echo.Context) error {
+		if t.ConnectionLimiter != nil && !t.ConnectionLimiter.Allow() {
+			c.Response().Header().Set("Retry-After", strconv.Itoa(retryAfter(t.ConnectionLimiter)))
+
+			return c.NoContent(http.StatusServiceUnavailable)
+		}
+
 		conn, err := upgrader.Upgrade(c.Response(), c.Request(), nil)
 		if err != nil {
 			return c.String(http.StatusInternalServerError, err.Error())
@@ -82,6 +102,17 @@ func (t *Tunnel) Router() http.Handler {
 			return c.String(http.StatusBadRequest, err.Error())
 		}
 
+		// Agents negotiating the multiplexed subprotocol receive all the connections over this websocket.
+		if conn.Subprotocol() == connman.MultiplexedSubprotocol {
+			if err := t.connman.SetMultiplexed(id, wsconnadapter.New(conn)); err != nil {
+				conn.Close()
+
+				return c.String(http.StatusInternalServerError, err.Error())
+			}
+
+			return nil
+		}
+
 		t.connman.Set(id, wsconnadapter.New(conn))
 
 		return nil
@@ -92,8 +123,33 @@ func (t *Tunnel) Router() http.Handler {
 	return e
 }
 
+// retryAfter gets the time, in seconds, the limiter takes to refill from empty.
+func retryAfter(limiter *rate.Limiter) int {
+	if limiter.Limit() <= 0 || limiter.Limit() == rate.Inf {
+		return 1
+	}
+
+	seconds := int(math.Ceil(float64(limiter.Burst()) / float64(limiter.Limit())))
+	if seconds < 1 {
+		return 1
+	}
+
+	return seconds
+}
+
 func (t *Tunnel) Dial(ctx context.Context, id string) (net.Conn, error) {
-	return t.connman.Dial(ctx, id)
+	conn, err := t.connman.Dial(ctx, id)
+	if errors.Is(err, connman.ErrNoConnection) && t.ForwardHandler != nil {
+		return t.ForwardHandler(ctx, id)
+	}
+
+	return conn, err
+}
+
+// SetDialerQuery sets a query to be sent, with the DialerPath, to the agents when they should pick up a reverse
+// connection.
+func (t *Tunnel) SetDialerQuery(query string) {
+	t.connman.DialerPath = t.DialerPath + "?" + query
 }
 
 func (t *Tunnel) SendRequest(ctx context.Context, id string, req *http.Request) (*http.Response, error) {
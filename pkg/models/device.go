@@ -36,6 +36,12 @@ type Device struct {
 	PublicURL        bool            `json:"public_url" bson:"public_url,omitempty"`
 	PublicURLAddress string          `json:"public_url_address" bson:"public_url_address,omitempty"`
 	Acceptable       bool            `json:"acceptable" bson:"acceptable,omitempty"`
+	// Update is the last update attempt reported by the device's agent, if any.
+	Update *DeviceUpdate `json:"update,omitempty" bson:"update,omitempty"`
+	// Acceptance records the rule or the enrollment token that accepted the device automatically, if any.
+	Acceptance *DeviceAcceptance `json:"acceptance,omitempty" bson:"acceptance,omitempty"`
+	// EnrollmentToken is the ID of the enrollment token the device registered with, if any.
+	EnrollmentToken string `json:"enrollment_token,omitempty" bson:"enrollment_token,omitempty"`
 }
 
 type DeviceAuthClaims struct {
@@ -48,6 +54,11 @@ type DeviceAuthClaims struct {
 type DeviceAuthRequest struct {
 	Info     *DeviceInfo `json:"info"`
 	Sessions []string    `json:"sessions,omitempty"`
+	// Tags are applied to the device when it is registered for the first time.
+	Tags []string `json:"tags,omitempty"`
+	// EnrollmentToken is either one of the namespace's enrollment tokens, checked when the device registers, or the
+	// token matched against the namespace's auto-accept rules.
+	EnrollmentToken string `json:"enrollment_token,omitempty"`
 	*DeviceAuth
 }
 
@@ -75,6 +86,39 @@ type DeviceInfo struct {
 	Version    string `json:"version"`
 	Arch       string `json:"arch"`
 	Platform   string `json:"platform"`
+	// DeviceInventory is the device's hardware and software inventory, reported by agents running in host mode.
+	DeviceInventory `bson:",inline"`
+}
+
+// DeviceInventory is the inventory of the device's hardware and software collected by the agent. Its fields are
+// stored inside the device's info, so they can be filtered as `info.<field>` when listing the devices.
+type DeviceInventory struct {
+	Kernel   string `json:"kernel,omitempty" bson:"kernel,omitempty"`
+	Hostname string `json:"hostname,omitempty" bson:"hostname,omitempty"`
+	Timezone string `json:"timezone,omitempty" bson:"timezone,omitempty"`
+	CPU      string `json:"cpu,omitempty" bson:"cpu,omitempty"`
+	CPUs     int    `json:"cpus,omitempty" bson:"cpus,omitempty"`
+	// Memory is the device's total memory, in bytes.
+	Memory     uint64                     `json:"memory,omitempty" bson:"memory,omitempty"`
+	Disks      []DeviceInventoryDisk      `json:"disks,omitempty" bson:"disks,omitempty"`
+	Interfaces []DeviceInventoryInterface `json:"interfaces,omitempty" bson:"interfaces,omitempty"`
+	// Packages is the number of packages installed through the device's package manager.
+	Packages int  `json:"packages,omitempty" bson:"packages,omitempty"`
+	Docker   bool `json:"docker,omitempty" bson:"docker,omitempty"`
+}
+
+type DeviceInventoryDisk struct {
+	Device     string `json:"device" bson:"device"`
+	Mountpoint string `json:"mountpoint" bson:"mountpoint"`
+	Filesystem string `json:"filesystem" bson:"filesystem"`
+	// Size is the filesystem's size, in bytes.
+	Size uint64 `json:"size" bson:"size"`
+}
+
+type DeviceInventoryInterface struct {
+	Name string   `json:"name" bson:"name"`
+	MAC  string   `json:"mac" bson:"mac"`
+	IPs  []string `json:"ips" bson:"ips"`
 }
 
 type ConnectedDevice struct {
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"crypto/subtle"
+	"net"
+	"path"
+	"strings"
+	"time"
+)
+
+// DeviceAcceptRule accepts, when they register, the pending devices from a namespace that match all of its conditions.
+type DeviceAcceptRule struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// MACPrefixes match the devices whose identity's MAC address starts with any of them, like "00:1a:2b".
+	MACPrefixes []string `json:"mac_prefixes" bson:"mac_prefixes"`
+	// Hostname is a pattern, like "web-*", matching the hostname the device registers with.
+	Hostname string `json:"hostname" bson:"hostname"`
+	// Info are the patterns matching the fields of the device's info, keyed by the fields' JSON names, like
+	// {"platform": "docker", "arch": "arm*"}.
+	Info map[string]string `json:"info" bson:"info"`
+	// Sources match the devices registering from any of the IP addresses or CIDR blocks, like "10.0.0.0/8".
+	Sources []string `json:"sources" bson:"sources"`
+	// TokenHash is the SHA-256 hash of the enrollment token matching the devices registering with the same one. The
+	// token itself isn't stored, so it can't be got.
+	TokenHash string    `json:"-" bson:"token_hash"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" bson:"updated_at"`
+}
+
+// DeviceAcceptRuleInfoFields are the fields of the device's info, by their JSON names, matched by the rules.
+var DeviceAcceptRuleInfoFields = map[string]func(info *DeviceInfo) string{
+	"id":          func(info *DeviceInfo) string { return info.ID },
+	"pretty_name": func(info *DeviceInfo) string { return info.PrettyName },
+	"version":     func(info *DeviceInfo) string { return info.Version },
+	"arch":        func(info *DeviceInfo) string { return info.Arch },
+	"platform":    func(info *DeviceInfo) string { return info.Platform },
+	"kernel":      func(info *DeviceInfo) string { return info.Kernel },
+	"timezone":    func(info *DeviceInfo) string { return info.Timezone },
+}
+
+// IsEmpty checks if the rule has no conditions, what would accept every device.
+func (r *DeviceAcceptRule) IsEmpty() bool {
+	return len(r.MACPrefixes) == 0 && r.Hostname == "" && len(r.Info) == 0 && len(r.Sources) == 0 && r.TokenHash == ""
+}
+
+// Matches checks if the device, registering with the hostname and the enrollment token, matches all the rule's
+// conditions.
+func (r *DeviceAcceptRule) Matches(device *Device, hostname string, token string) bool {
+	if r.IsEmpty() {
+		return false
+	}
+
+	if len(r.MACPrefixes) > 0 && !r.matchesMAC(device.Identity) {
+		return false
+	}
+
+	if r.Hostname != "" {
+		if ok, _ := path.Match(strings.ToLower(r.Hostname), strings.ToLower(hostname)); !ok {
+			return false
+		}
+	}
+
+	for field, pattern := range r.Info {
+		value, ok := DeviceAcceptRuleInfoFields[field]
+		if !ok || device.Info == nil {
+			return false
+		}
+
+		if ok, _ := path.Match(pattern, value(device.Info)); !ok {
+			return false
+		}
+	}
+
+	if len(r.Sources) > 0 && !r.matchesSource(device.RemoteAddr) {
+		return false
+	}
+
+	if r.TokenHash != "" && subtle.ConstantTimeCompare([]byte(r.TokenHash), []byte(HashEnrollmentToken(token))) != 1 {
+		return false
+	}
+
+	return true
+}
+
+func (r *DeviceAcceptRule) matchesMAC(identity *DeviceIdentity) bool {
+	if identity == nil || identity.MAC == "" {
+		return false
+	}
+
+	mac := strings.ToLower(identity.MAC)
+	for _, prefix := range r.MACPrefixes {
+		if strings.HasPrefix(mac, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *DeviceAcceptRule) matchesSource(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, source := range r.Sources {
+		if _, block, err := net.ParseCIDR(source); err == nil {
+			if block.Contains(ip) {
+				return true
+			}
+
+			continue
+		}
+
+		if other := net.ParseIP(source); other != nil && other.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeviceAcceptance records what accepted the device automatically, either an auto-accept rule or an enrollment token.
+type DeviceAcceptance struct {
+	// Rule is the ID of the rule.
+	Rule string `json:"rule,omitempty" bson:"rule,omitempty"`
+	// EnrollmentToken is the ID of the enrollment token.
+	EnrollmentToken string `json:"enrollment_token,omitempty" bson:"enrollment_token,omitempty"`
+	// Name is the rule's or the enrollment token's name when the device was accepted, kept after it is deleted.
+	Name       string    `json:"name" bson:"name"`
+	AcceptedAt time.Time `json:"accepted_at" bson:"accepted_at"`
+}
@@ -0,0 +1,58 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// EnrollmentToken lets the devices presenting it register into a namespace, binding them to its tags and status.
+type EnrollmentToken struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Hash is the SHA-256 hash of the token's secret, what is only shown when the token is created.
+	Hash string `json:"-" bson:"hash"`
+	// Tags are applied to the devices registered with the token.
+	Tags []string `json:"tags" bson:"tags"`
+	// Status is the status of the devices registered with the token, either [DeviceStatusPending] or
+	// [DeviceStatusAccepted].
+	Status DeviceStatus `json:"status" bson:"status"`
+	// SingleUse allows only one device to register with the token.
+	SingleUse bool `json:"single_use" bson:"single_use"`
+	// Uses is the number of devices registered with the token.
+	Uses       int        `json:"uses" bson:"uses"`
+	ExpiresAt  *time.Time `json:"expires_at" bson:"expires_at"`
+	RevokedAt  *time.Time `json:"revoked_at" bson:"revoked_at"`
+	LastUsedAt *time.Time `json:"last_used_at" bson:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+}
+
+// IsValid checks if a device can register with the token at now, what it can't after the token is revoked, expired or,
+// when it is single-use, used.
+func (t *EnrollmentToken) IsValid(now time.Time) bool {
+	switch {
+	case t.RevokedAt != nil:
+		return false
+	case t.ExpiresAt != nil && !now.Before(*t.ExpiresAt):
+		return false
+	case t.SingleUse && t.Uses > 0:
+		return false
+	default:
+		return true
+	}
+}
+
+// HashEnrollmentToken hashes the enrollment token's secret as it is stored.
+func HashEnrollmentToken(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(hash[:])
+}
+
+// EnrollmentTokenCreated is the enrollment token just created, with its secret.
+type EnrollmentTokenCreated struct {
+	EnrollmentToken
+	// Token is the token's secret presented by the devices, what can't be got again.
+	Token string `json:"token"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// FileInfo describes a file, or directory, on a device, as returned by the agent's file browser.
+type FileInfo struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	Mode    string    `json:"mode"`
+	Dir     bool      `json:"dir"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Headers of the file browser's requests, what carry the device's user credentials apart from the ShellHub's token,
+// never forwarded to the device.
+const (
+	// FilesAuthorizationHeader carries the device's user credentials, through the HTTP basic authentication scheme, or
+	// the ShellHub's assertion of the public key authentication, through the [FilesShellHubScheme].
+	FilesAuthorizationHeader = "X-Device-Authorization"
+	// FilesUsernameHeader is the device's user authenticated through a public key.
+	FilesUsernameHeader = "X-Device-Username"
+	// FilesFingerprintHeader is the fingerprint of the public key used to authenticate the device's user.
+	FilesFingerprintHeader = "X-Device-Fingerprint"
+	// FilesSignatureHeader is the signature of the device's user by the public key, encoded in base64.
+	FilesSignatureHeader = "X-Device-Signature"
+)
+
+// FilesShellHubScheme is the authorization scheme used by ShellHub to assert the public key authentication of the
+// file browser's user to the device, followed by the fingerprint of the private key created to it and the signature
+// of the [PublicKeyAuthRequest]'s data.
+const FilesShellHubScheme = "ShellHub"
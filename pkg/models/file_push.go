@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// FilePushStatus is the status of a file push as a whole.
+type FilePushStatus string
+
+const (
+	FilePushStatusPending  FilePushStatus = "pending"
+	FilePushStatusRunning  FilePushStatus = "running"
+	FilePushStatusFinished FilePushStatus = "finished"
+)
+
+// FilePushResultStatus is the status of a file push's delivery to a single device.
+type FilePushResultStatus string
+
+const (
+	FilePushResultStatusPending   FilePushResultStatus = "pending"
+	FilePushResultStatusRunning   FilePushResultStatus = "running"
+	FilePushResultStatusSucceeded FilePushResultStatus = "succeeded"
+	// FilePushResultStatusFailed means the device refused the file, like when its checksum does not match or the path
+	// is not writable.
+	FilePushResultStatusFailed FilePushResultStatus = "failed"
+	// FilePushResultStatusError means the device could not be reached to receive the file.
+	FilePushResultStatusError FilePushResultStatus = "error"
+)
+
+// FilePushGracePeriod is the time allowed to deliver the file to each device.
+const FilePushGracePeriod = 5 * time.Minute
+
+// FilePush is a file delivered to a path on a set of devices from a namespace.
+type FilePush struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Name is the name of the uploaded file.
+	Name string `json:"name" bson:"name"`
+	// Path is the absolute path where the file is written on the devices, what each one must allow.
+	Path string `json:"path" bson:"path"`
+	// Owner is the device's user that owns the file. When empty, the file is owned by the agent's user.
+	Owner string `json:"owner,omitempty" bson:"owner,omitempty"`
+	// Group is the device's group that owns the file. When empty, the owner's primary group is used.
+	Group string `json:"group,omitempty" bson:"group,omitempty"`
+	// Mode is the file's permission bits, in octal, like "0644".
+	Mode string `json:"mode" bson:"mode"`
+	Size int64  `json:"size" bson:"size"`
+	// SHA256 is the hex encoded SHA-256 checksum of the file, verified by each device before writing it to its path.
+	SHA256 string `json:"sha256" bson:"sha256"`
+	// Tags are the tags used to select the devices.
+	Tags []string `json:"tags" bson:"tags"`
+	// Concurrency is the maximum number of devices receiving the file at the same time.
+	Concurrency int              `json:"concurrency" bson:"concurrency"`
+	Status      FilePushStatus   `json:"status" bson:"status"`
+	CreatedAt   time.Time        `json:"created_at" bson:"created_at"`
+	FinishedAt  *time.Time       `json:"finished_at" bson:"finished_at"`
+	Results     []FilePushResult `json:"results" bson:"results"`
+}
+
+// Duration is the maximum time the file push takes to deliver the file to n devices, as many rounds as needed to reach
+// all of them with the push's concurrency.
+func (p *FilePush) Duration(n int) time.Duration {
+	concurrency := p.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	rounds := (n + concurrency - 1) / concurrency
+
+	return time.Duration(rounds) * FilePushGracePeriod
+}
+
+// FilePushResult is the result of a file push's delivery to a single device.
+type FilePushResult struct {
+	Device UID                  `json:"device" bson:"device"`
+	Status FilePushResultStatus `json:"status" bson:"status"`
+	// Transferred is the number of bytes the device has received, what is kept on the device when the transfer is
+	// interrupted, allowing it to be resumed.
+	Transferred int64      `json:"transferred" bson:"transferred"`
+	Error       string     `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt   *time.Time `json:"started_at" bson:"started_at"`
+	FinishedAt  *time.Time `json:"finished_at" bson:"finished_at"`
+}
+
+// IsFailure checks if the file could not be delivered to the device.
+func (r *FilePushResult) IsFailure() bool {
+	return r.Status == FilePushResultStatusFailed || r.Status == FilePushResultStatusError
+}
+
+// FilePushOffset is the agent's response with the number of bytes it has received from a pushed file.
+type FilePushOffset struct {
+	Offset int64 `json:"offset"`
+}
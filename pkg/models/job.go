@@ -0,0 +1,168 @@
+package models
+
+import "time"
+
+// JobStatus is the status of a job as a whole.
+type JobStatus string
+
+const (
+	JobStatusPending  JobStatus = "pending"
+	JobStatusRunning  JobStatus = "running"
+	JobStatusFinished JobStatus = "finished"
+)
+
+// JobResultStatus is the status of a job's execution on a single device.
+type JobResultStatus string
+
+const (
+	JobResultStatusPending   JobResultStatus = "pending"
+	JobResultStatusRunning   JobResultStatus = "running"
+	JobResultStatusSucceeded JobResultStatus = "succeeded"
+	JobResultStatusFailed    JobResultStatus = "failed"
+	JobResultStatusTimeout   JobResultStatus = "timeout"
+	// JobResultStatusSkipped means the device was offline when a scheduled job ran, and the schedule skips offline
+	// devices.
+	JobResultStatusSkipped JobResultStatus = "skipped"
+	// JobResultStatusQueued means the device was offline when a scheduled job ran, and the command waits for the device
+	// to be online.
+	JobResultStatusQueued JobResultStatus = "queued"
+	// JobResultStatusError means the command could not be executed on the device, what is different from a command that
+	// ran and exited with a non-zero code.
+	JobResultStatusError JobResultStatus = "error"
+)
+
+// JobGracePeriod is the time, besides the job's timeout, allowed to reach each device and to receive its result.
+const JobGracePeriod = 30 * time.Second
+
+// Job is a command executed on a set of devices from a namespace.
+type Job struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	// Schedule is the ID of the schedule that created the job, if any.
+	Schedule string `json:"schedule,omitempty" bson:"schedule,omitempty"`
+	Command  string `json:"command" bson:"command"`
+	// User is the device's user that executes the command.
+	User string `json:"user" bson:"user"`
+	// Tags are the tags used to select the devices, when the job was not created to a list of devices.
+	Tags []string `json:"tags,omitempty" bson:"tags,omitempty"`
+	// Concurrency is the maximum number of devices executing the command at the same time.
+	Concurrency int `json:"concurrency" bson:"concurrency"`
+	// Timeout is the time, in seconds, the command is allowed to run on each device.
+	Timeout    int        `json:"timeout" bson:"timeout"`
+	Status     JobStatus  `json:"status" bson:"status"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	FinishedAt *time.Time `json:"finished_at" bson:"finished_at"`
+	// Results are the job's results from each one of its devices, stored apart from the job, as each one could hold
+	// the command's output.
+	Results []JobResult `json:"results" bson:"-"`
+}
+
+// Duration is the maximum time the job takes to execute its command on n devices, as many rounds as needed to reach
+// all of them with the job's concurrency.
+func (j *Job) Duration(n int) time.Duration {
+	concurrency := j.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	rounds := (n + concurrency - 1) / concurrency
+
+	return time.Duration(rounds) * (time.Duration(j.Timeout)*time.Second + JobGracePeriod)
+}
+
+// Full checks if the job is executing its command on as many devices as its concurrency.
+func (j *Job) Full() bool {
+	running := 0
+	for _, result := range j.Results {
+		if result.Status == JobResultStatusRunning {
+			running++
+		}
+	}
+
+	return running >= j.Concurrency && running > 0
+}
+
+// JobResult is the result of a job's execution on a single device.
+type JobResult struct {
+	Device     UID             `json:"device" bson:"device"`
+	Status     JobResultStatus `json:"status" bson:"status"`
+	ExitCode   int             `json:"exit_code" bson:"exit_code"`
+	Stdout     string          `json:"stdout" bson:"stdout"`
+	Stderr     string          `json:"stderr" bson:"stderr"`
+	Error      string          `json:"error,omitempty" bson:"error,omitempty"`
+	StartedAt  *time.Time      `json:"started_at" bson:"started_at"`
+	FinishedAt *time.Time      `json:"finished_at" bson:"finished_at"`
+}
+
+// IsTerminal checks if the result will not change anymore.
+func (r *JobResult) IsTerminal() bool {
+	switch r.Status {
+	case JobResultStatusPending, JobResultStatusRunning, JobResultStatusQueued:
+		return false
+	default:
+		return true
+	}
+}
+
+// IsFailure checks if the command failed, timed out or could not be executed on the device.
+func (r *JobResult) IsFailure() bool {
+	switch r.Status {
+	case JobResultStatusFailed, JobResultStatusTimeout, JobResultStatusError:
+		return true
+	default:
+		return false
+	}
+}
+
+// JobScheduleOffline is what a schedule does with the devices that are offline when it runs.
+type JobScheduleOffline string
+
+const (
+	// JobScheduleOfflineSkip skips the offline devices.
+	JobScheduleOfflineSkip JobScheduleOffline = "skip"
+	// JobScheduleOfflineQueue executes the command on the offline devices when they are online again.
+	JobScheduleOfflineQueue JobScheduleOffline = "queue"
+)
+
+// JobSchedule creates, periodically, a job to the devices from a namespace having all its tags.
+type JobSchedule struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Cron is the cron expression, or descriptor like "@daily" and "@every 1h", of when the schedule runs, in UTC.
+	Cron        string   `json:"cron" bson:"cron"`
+	Command     string   `json:"command" bson:"command"`
+	User        string   `json:"user" bson:"user"`
+	Tags        []string `json:"tags" bson:"tags"`
+	Concurrency int      `json:"concurrency" bson:"concurrency"`
+	Timeout     int      `json:"timeout" bson:"timeout"`
+	// Offline is what the schedule does with the devices that are offline when it runs.
+	Offline JobScheduleOffline `json:"offline" bson:"offline"`
+	// NotifyURL, when defined, receives a POST request with the job when it finishes with failures.
+	NotifyURL string     `json:"notify_url,omitempty" bson:"notify_url,omitempty"`
+	Enabled   bool       `json:"enabled" bson:"enabled"`
+	CreatedAt time.Time  `json:"created_at" bson:"created_at"`
+	LastRunAt *time.Time `json:"last_run_at" bson:"last_run_at"`
+}
+
+// JobEnv is the environment variable, set on the exec sessions of the jobs' commands, with the job's ID, what lets the
+// device refuse the jobs.
+const JobEnv = "SHELLHUB_JOB"
+
+// JobExecRequest is the request sent to the SSH server to execute a job's command on a device.
+type JobExecRequest struct {
+	// Job is the ID of the job whose command is executed.
+	Job     string `json:"job"`
+	User    string `json:"user"`
+	Command string `json:"command"`
+	// Timeout is the time, in seconds, the command is allowed to run.
+	Timeout int `json:"timeout"`
+}
+
+// JobExecResponse is the SSH server's response to a [JobExecRequest].
+type JobExecResponse struct {
+	ExitCode int    `json:"exit_code"`
+	Stdout   string `json:"stdout"`
+	Stderr   string `json:"stderr"`
+	TimedOut bool   `json:"timed_out"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// DeviceMetricsUsage is the usage of a device's resource, in bytes.
+type DeviceMetricsUsage struct {
+	Total uint64 `json:"total" bson:"total"`
+	Used  uint64 `json:"used" bson:"used"`
+}
+
+// DeviceMetricsNetwork are the network counters of a device, summed from all its interfaces but the loopback.
+type DeviceMetricsNetwork struct {
+	RxBytes uint64 `json:"rx_bytes" bson:"rx_bytes"`
+	TxBytes uint64 `json:"tx_bytes" bson:"tx_bytes"`
+}
+
+// DeviceMetrics are the system's metrics collected by the agent on a device.
+type DeviceMetrics struct {
+	UID      string    `json:"uid" bson:"uid"`
+	TenantID string    `json:"tenant_id" bson:"tenant_id"`
+	Time     time.Time `json:"time" bson:"time"`
+	// CPU is the percentage of the CPU's time used since the previous collection.
+	CPU    float64            `json:"cpu" bson:"cpu"`
+	Memory DeviceMetricsUsage `json:"memory" bson:"memory"`
+	// Disk is the usage of the filesystem mounted on the root.
+	Disk DeviceMetricsUsage `json:"disk" bson:"disk"`
+	// Load is the system's load average over 1, 5 and 15 minutes.
+	Load []float64 `json:"load" bson:"load"`
+	// Uptime is the time, in seconds, since the device was booted.
+	Uptime  int64                `json:"uptime" bson:"uptime"`
+	Network DeviceMetricsNetwork `json:"network" bson:"network"`
+	// Reconnects is the number of attempts the agent has made to reconnect to the server since it started.
+	Reconnects uint64 `json:"reconnects" bson:"reconnects"`
+}
@@ -44,6 +44,8 @@ func (n *Namespace) FindMember(id string) (*Member, bool) {
 
 type NamespaceSettings struct {
 	SessionRecord bool `json:"session_record" bson:"session_record,omitempty"`
+	// RequireEnrollmentToken only lets the devices presenting a valid enrollment token register into the namespace.
+	RequireEnrollmentToken bool `json:"require_enrollment_token" bson:"require_enrollment_token,omitempty"`
 }
 
 type Member struct {
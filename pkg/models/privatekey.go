@@ -6,4 +6,15 @@ type PrivateKey struct {
 	Data        []byte    `json:"data"`
 	Fingerprint string    `json:"fingerprint"`
 	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	// Identity is the ShellHub's member whose public key the private key was created to authenticate, when the key
+	// was created by one.
+	Identity *PrivateKeyIdentity `json:"identity,omitempty" bson:"identity,omitempty"`
+}
+
+// PrivateKeyIdentity identifies the ShellHub's member on whose behalf a private key authenticates to a device.
+type PrivateKeyIdentity struct {
+	// Username is the member's ShellHub username.
+	Username string `json:"username" bson:"username"`
+	// Role is the member's role in the device's namespace.
+	Role string `json:"role" bson:"role"`
 }
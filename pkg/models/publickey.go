@@ -15,10 +15,27 @@ type PublicKeyFilter struct {
 	Tags     []string `json:"tags,omitempty" bson:"tags,omitempty" validate:"required_without=Hostname,excluded_with=Hostname,max=3,unique,dive,min=3,max=255,alphanum,ascii,excludes=/@&:"`
 }
 
+// PublicKeyOptions restricts the sessions opened with a public key, like the options of the OpenSSH's authorized_keys.
+type PublicKeyOptions struct {
+	// Command, when defined, is executed instead of the shell, the command or the subsystem requested by the user, what
+	// is available to it through the SSH_ORIGINAL_COMMAND environment variable.
+	Command string `json:"command,omitempty" bson:"command,omitempty"`
+	// NoPTY denies the allocation of a pty.
+	NoPTY bool `json:"no_pty,omitempty" bson:"no_pty,omitempty"`
+	// NoPortForwarding denies the port forwarding.
+	NoPortForwarding bool `json:"no_port_forwarding,omitempty" bson:"no_port_forwarding,omitempty"`
+	// NoSFTP denies the SFTP subsystem.
+	NoSFTP bool `json:"no_sftp,omitempty" bson:"no_sftp,omitempty"`
+	// Environment are the environment variables set to the sessions. The ones read by the dynamic loader or by the shell
+	// at startup, like LD_PRELOAD, BASH_ENV or PATH, are refused.
+	Environment map[string]string `json:"environment,omitempty" bson:"environment,omitempty"`
+}
+
 type PublicKeyFields struct {
-	Name     string          `json:"name"`
-	Username string          `json:"username" bson:"username" validate:"regexp"`
-	Filter   PublicKeyFilter `json:"filter" bson:"filter" validate:"required"`
+	Name     string           `json:"name"`
+	Username string           `json:"username" bson:"username" validate:"regexp"`
+	Filter   PublicKeyFilter  `json:"filter" bson:"filter" validate:"required"`
+	Options  PublicKeyOptions `json:"options" bson:"options"`
 }
 
 func (p *PublicKeyFields) Validate() error {
@@ -34,10 +51,12 @@ func (p *PublicKeyFields) Validate() error {
 }
 
 type PublicKey struct {
-	Data            []byte    `json:"data"`
-	Fingerprint     string    `json:"fingerprint"`
-	CreatedAt       time.Time `json:"created_at" bson:"created_at"`
-	TenantID        string    `json:"tenant_id" bson:"tenant_id"`
+	Data        []byte    `json:"data"`
+	Fingerprint string    `json:"fingerprint"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+	TenantID    string    `json:"tenant_id" bson:"tenant_id"`
+	// CreatedBy is the ID of the member that created the public key.
+	CreatedBy       string `json:"created_by,omitempty" bson:"created_by,omitempty"`
 	PublicKeyFields `bson:",inline"`
 }
 
@@ -52,4 +71,6 @@ type PublicKeyAuthRequest struct {
 
 type PublicKeyAuthResponse struct {
 	Signature string `json:"signature"`
+	// Identity is the ShellHub's member authenticated by the signed key, when the key was created to one.
+	Identity *PrivateKeyIdentity `json:"identity,omitempty"`
 }
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTunnelInvalidPorts is returned when the range of ports to the tunnels in the port mode is invalid.
+var ErrTunnelInvalidPorts = errors.New("invalid tunnel ports range")
+
+// Tunnel is a raw TCP endpoint exposed by ShellHub to a service running on, or reachable from, a device.
+//
+// Each tunnel is identified by its Address, a random label used to route the incoming TLS connections, through SNI, to
+// the device agent, which connects to Host and Port on its side. A tunnel created in the port mode also has a
+// ListenPort, where ShellHub listens for its plain TCP connections.
+//
+// The connections are only accepted from the sources granted by the namespace's members allowed to connect to it,
+// while the device is accepted.
+type Tunnel struct {
+	Address    string        `json:"address" bson:"address"`
+	TenantID   string        `json:"tenant_id" bson:"tenant_id"`
+	Device     string        `json:"device" bson:"device"`
+	Host       string        `json:"host" bson:"host" validate:"required"`
+	Port       int           `json:"port" bson:"port" validate:"required,min=1,max=65535"`
+	ListenPort int           `json:"listen_port,omitempty" bson:"listen_port,omitempty"`
+	Grants     []TunnelGrant `json:"grants" bson:"grants"`
+	CreatedAt  time.Time     `json:"created_at" bson:"created_at"`
+}
+
+// TunnelGrant allows the connections from a source IP address to the tunnel until it expires.
+type TunnelGrant struct {
+	// Source is the IP address the connections come from.
+	Source string `json:"source" bson:"source"`
+	// User is the ID of the member who granted the access.
+	User      string    `json:"user" bson:"user"`
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"`
+}
+
+// ActiveGrants returns the tunnel's grants to the source that are not expired at now.
+func (t *Tunnel) ActiveGrants(source string, now time.Time) []TunnelGrant {
+	grants := make([]TunnelGrant, 0)
+
+	ip := net.ParseIP(source)
+	if ip == nil {
+		return grants
+	}
+
+	for _, grant := range t.Grants {
+		if now.Before(grant.ExpiresAt) && ip.Equal(net.ParseIP(grant.Source)) {
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants
+}
+
+// ParseTunnelPorts parses the range of ports, like "40000-40099", or a single port, to the tunnels in the port mode.
+//
+// The range is read by the API, to allocate the tunnels' ports, and by the SSH server, to listen on them, from the same
+// SHELLHUB_TUNNELS_PORTS variable.
+func ParseTunnelPorts(ports string) (int, int, error) {
+	from, to, found := strings.Cut(ports, "-")
+	if !found {
+		to = from
+	}
+
+	first, err := strconv.Atoi(strings.TrimSpace(from))
+	if err != nil {
+		return 0, 0, ErrTunnelInvalidPorts
+	}
+
+	last, err := strconv.Atoi(strings.TrimSpace(to))
+	if err != nil {
+		return 0, 0, ErrTunnelInvalidPorts
+	}
+
+	if first < 1 || last > 65535 || first > last {
+		return 0, 0, ErrTunnelInvalidPorts
+	}
+
+	return first, last, nil
+}
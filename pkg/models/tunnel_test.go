@@ -0,0 +1,54 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTunnelPorts(t *testing.T) {
+	type Expected struct {
+		first int
+		last  int
+		err   error
+	}
+
+	cases := []struct {
+		description string
+		ports       string
+		expected    Expected
+	}{
+		{
+			description: "fails when ports is empty",
+			ports:       "",
+			expected:    Expected{0, 0, ErrTunnelInvalidPorts},
+		},
+		{
+			description: "fails when the range is reversed",
+			ports:       "40099-40000",
+			expected:    Expected{0, 0, ErrTunnelInvalidPorts},
+		},
+		{
+			description: "fails when a port is out of range",
+			ports:       "65000-70000",
+			expected:    Expected{0, 0, ErrTunnelInvalidPorts},
+		},
+		{
+			description: "succeeds when ports is a single port",
+			ports:       "40000",
+			expected:    Expected{40000, 40000, nil},
+		},
+		{
+			description: "succeeds when ports is a range",
+			ports:       "40000-40099",
+			expected:    Expected{40000, 40099, nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			first, last, err := ParseTunnelPorts(tc.ports)
+			assert.Equal(t, tc.expected, Expected{first, last, err})
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package models
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// UpdateWindow is a period of the week, in UTC, when the devices are allowed to update.
+type UpdateWindow struct {
+	// Days are the days of the week when the window starts, from 0, Sunday, to 6, Saturday. When empty, the window
+	// starts every day.
+	Days []int `json:"days" bson:"days"`
+	// Start is the time of the day, in the format "15:04", when the window opens.
+	Start string `json:"start" bson:"start"`
+	// End is the time of the day, in the format "15:04", when the window closes. When it is before the start, the
+	// window closes on the next day.
+	End string `json:"end" bson:"end"`
+}
+
+// Contains checks if the window is open at t.
+func (w *UpdateWindow) Contains(t time.Time) bool {
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return false
+	}
+
+	end, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return false
+	}
+
+	t = t.UTC()
+
+	minute := t.Hour()*60 + t.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+
+	if from <= to {
+		return w.startsOn(t.Weekday()) && minute >= from && minute < to
+	}
+
+	// NOTICE: A window that crosses midnight belongs to the day it opens.
+	return (w.startsOn(t.Weekday()) && minute >= from) || (w.startsOn((t.Weekday()+6)%7) && minute < to)
+}
+
+func (w *UpdateWindow) startsOn(day time.Weekday) bool {
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	for _, d := range w.Days {
+		if time.Weekday(d) == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UpdatePolicy pins the agent's version of the devices from a namespace, or of those having all its tags, rolling the
+// update out to a percentage of them, during the maintenance windows.
+type UpdatePolicy struct {
+	ID       string `json:"id" bson:"id"`
+	TenantID string `json:"tenant_id" bson:"tenant_id"`
+	Name     string `json:"name" bson:"name"`
+	// Tags select the devices having all of them. When empty, the policy applies to all devices from the namespace.
+	Tags []string `json:"tags" bson:"tags"`
+	// Version is the agent's version the devices are updated to, like "v0.15.0".
+	Version string `json:"version" bson:"version"`
+	// Canary is the percentage, from 0 to 100, of the selected devices allowed to update. The same devices are kept
+	// when it is increased, so the rollout is widened gradually.
+	Canary int `json:"canary" bson:"canary"`
+	// Windows are the maintenance windows when the devices are allowed to update. When empty, they update anytime.
+	Windows   []UpdateWindow `json:"windows" bson:"windows"`
+	CreatedAt time.Time      `json:"created_at" bson:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at" bson:"updated_at"`
+}
+
+// Matches checks if the device's tags contain all the policy's tags.
+func (p *UpdatePolicy) Matches(tags []string) bool {
+	for _, tag := range p.Tags {
+		found := false
+		for _, t := range tags {
+			if t == tag {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// InCanary checks if the device is among the policy's canary percentage of devices.
+func (p *UpdatePolicy) InCanary(uid UID) bool {
+	if p.Canary >= 100 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%s:%s", p.ID, uid)
+
+	return int(hash.Sum32()%100) < p.Canary
+}
+
+// InWindow checks if any of the policy's maintenance windows is open at t.
+func (p *UpdatePolicy) InWindow(t time.Time) bool {
+	if len(p.Windows) == 0 {
+		return true
+	}
+
+	for _, window := range p.Windows {
+		if window.Contains(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DeviceUpdateStatus is the status of a device in the rollout of the agent's version it should run.
+type DeviceUpdateStatus string
+
+const (
+	// DeviceUpdateStatusUnmanaged means no policy applies to the device, so it follows the server's version.
+	DeviceUpdateStatusUnmanaged DeviceUpdateStatus = "unmanaged"
+	// DeviceUpdateStatusUpdated means the device runs the policy's version.
+	DeviceUpdateStatusUpdated DeviceUpdateStatus = "updated"
+	// DeviceUpdateStatusPending means the device updates to the policy's version when it polls the policy next.
+	DeviceUpdateStatusPending DeviceUpdateStatus = "pending"
+	// DeviceUpdateStatusWaiting means the device waits for a maintenance window to update.
+	DeviceUpdateStatusWaiting DeviceUpdateStatus = "waiting"
+	// DeviceUpdateStatusExcluded means the device is not among the policy's canary devices.
+	DeviceUpdateStatusExcluded DeviceUpdateStatus = "excluded"
+	// DeviceUpdateStatusAhead means the device runs a newer version than the policy's, what is never downgraded.
+	DeviceUpdateStatusAhead DeviceUpdateStatus = "ahead"
+	// DeviceUpdateStatusUpdating means the device's agent has reported it is updating to the policy's version.
+	DeviceUpdateStatusUpdating DeviceUpdateStatus = "updating"
+	// DeviceUpdateStatusFailed means the device's agent could not update to the policy's version, or did not come back
+	// with it, what keeps the device from trying the same version again.
+	DeviceUpdateStatusFailed DeviceUpdateStatus = "failed"
+)
+
+// DeviceUpdateTimeout is the time the device has to come back with the version it has reported to be updating to
+// before the update is considered failed.
+const DeviceUpdateTimeout = time.Hour
+
+// DeviceUpdate is the update attempt reported by the device's agent.
+type DeviceUpdate struct {
+	// Version is the agent's version the device is updating to.
+	Version string `json:"version" bson:"version"`
+	// Status is either [DeviceUpdateStatusUpdating] or [DeviceUpdateStatusFailed].
+	Status    DeviceUpdateStatus `json:"status" bson:"status"`
+	Error     string             `json:"error,omitempty" bson:"error,omitempty"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// DeviceUpdateTarget is the response to the agent's poll of its update policy.
+type DeviceUpdateTarget struct {
+	// Version is the agent's version the device should update to now. When empty, the device should not update.
+	Version string `json:"version"`
+}
+
+// DeviceUpdateState is the rollout's state of a single device.
+type DeviceUpdateState struct {
+	UID  string `json:"uid"`
+	Name string `json:"name"`
+	// Version is the agent's version the device runs.
+	Version string `json:"version"`
+	// Policy is the ID of the policy that applies to the device, if any.
+	Policy string `json:"policy,omitempty"`
+	// Target is the agent's version the device should run.
+	Target string             `json:"target"`
+	Status DeviceUpdateStatus `json:"status"`
+	// Error is the error reported by the device's agent when the update failed.
+	Error     string     `json:"error,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
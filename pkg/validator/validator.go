@@ -35,8 +35,16 @@ const (
 	UserPasswordTag = "password"
 	// DeviceNameTag contains the rule to validate the device's name.
 	DeviceNameTag = "device_name"
+	// EnvNameTag contains the rule to validate an environment variable's name.
+	EnvNameTag = "env_name"
+	// FileModeTag contains the rule to validate a file's permission bits in octal.
+	FileModeTag = "file_mode"
 )
 
+// envNameDenylist matches the environment variables read by the dynamic loader, by the shells at startup or by the SSH
+// server itself, what could change the program a session executes.
+var envNameDenylist = regexp.MustCompile(`^(LD_[A-Z0-9_]*|DYLD_[A-Z0-9_]*|SSH_[A-Z0-9_]*|BASH_ENV|ENV|PATH|IFS|CDPATH|SHELLOPTS|BASHOPTS|PS4|PROMPT_COMMAND|ZDOTDIR|HOME|SHELL|USER|LOGNAME|GCONV_PATH|NLSPATH|HOSTALIASES|LOCALDOMAIN|RES_OPTIONS|PYTHONPATH|PYTHONSTARTUP|PERL5LIB|PERL5OPT|RUBYOPT|NODE_OPTIONS)$`)
+
 // Rules is a slice that contains all validation rules.
 var Rules = []Rule{
 	{
@@ -76,6 +84,22 @@ var Rules = []Rule{
 		},
 		Error: fmt.Errorf("the device name can only contain `_`, `-` and alpha numeric characters"),
 	},
+	{
+		Tag: EnvNameTag,
+		Handler: func(field validator.FieldLevel) bool {
+			name := field.Field().String()
+
+			return regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`).MatchString(name) && !envNameDenylist.MatchString(name)
+		},
+		Error: fmt.Errorf("the environment variable name must start with a letter or `_`, can only contain `_` and alpha numeric characters, and cannot be one read by the loader or by the shell, like LD_PRELOAD or PATH"),
+	},
+	{
+		Tag: FileModeTag,
+		Handler: func(field validator.FieldLevel) bool {
+			return regexp.MustCompile(`^0?[0-7]{3}$`).MatchString(field.Field().String())
+		},
+		Error: fmt.Errorf("the file mode must be the permission bits in octal, like 0644, without the setuid, setgid and sticky bits"),
+	},
 }
 
 // Validator is the ShellHub validator.
@@ -265,3 +265,139 @@ func TestDeviceName(t *testing.T) {
 		})
 	}
 }
+
+func TestEnvName(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the env name is empty",
+			value:       "",
+			want:        false,
+		},
+		{
+			description: "failed when the env name starts with a number",
+			value:       "1TEST",
+			want:        false,
+		},
+		{
+			description: "failed when the env name contains invalid characters",
+			value:       "TEST=",
+			want:        false,
+		},
+		{
+			description: "failed when the env name is read by the dynamic loader",
+			value:       "LD_PRELOAD",
+			want:        false,
+		},
+		{
+			description: "failed when the env name is any of the dynamic loader's",
+			value:       "LD_AUDIT",
+			want:        false,
+		},
+		{
+			description: "failed when the env name is read by the shell at startup",
+			value:       "BASH_ENV",
+			want:        false,
+		},
+		{
+			description: "failed when the env name changes the command's lookup",
+			value:       "PATH",
+			want:        false,
+		},
+		{
+			description: "failed when the env name changes the shell's field splitting",
+			value:       "IFS",
+			want:        false,
+		},
+		{
+			description: "failed when the env name is set by the SSH server",
+			value:       "SSH_ORIGINAL_COMMAND",
+			want:        false,
+		},
+		{
+			description: "success when the env name is valid",
+			value:       "BACKUP_TARGET",
+			want:        true,
+		},
+		{
+			description: "success when the env name only starts like a denied one",
+			value:       "PATHNAME",
+			want:        true,
+		},
+		{
+			description: "success when the env name starts with _",
+			value:       "_test",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				EnvName string `validate:"required,env_name"`
+			}{
+				EnvName: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	tests := []struct {
+		description string
+		value       string
+		want        bool
+	}{
+		{
+			description: "failed when the file mode is empty",
+			value:       "",
+			want:        false,
+		},
+		{
+			description: "failed when the file mode is not octal",
+			value:       "0689",
+			want:        false,
+		},
+		{
+			description: "failed when the file mode is too long",
+			value:       "00644",
+			want:        false,
+		},
+		{
+			description: "failed when the file mode has the setuid bit",
+			value:       "4755",
+			want:        false,
+		},
+		{
+			description: "success when the file mode has 3 digits",
+			value:       "644",
+			want:        true,
+		},
+		{
+			description: "success when the file mode has 4 digits",
+			value:       "0755",
+			want:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			data := struct {
+				Mode string `validate:"required,file_mode"`
+			}{
+				Mode: tt.value,
+			}
+
+			ok, _ := New().Struct(data)
+
+			assert.Equal(t, tt.want, ok)
+		})
+	}
+}
@@ -131,11 +131,14 @@ func (a *Adapter) Write(b []byte) (int, error) {
 }
 
 func (a *Adapter) Close() error {
-	select {
-	case <-a.stopPingCh:
-	default:
-		a.stopPingCh <- struct{}{}
-		close(a.stopPingCh)
+	// The ping loop only exists when Ping was called.
+	if a.stopPingCh != nil {
+		select {
+		case <-a.stopPingCh:
+		default:
+			a.stopPingCh <- struct{}{}
+			close(a.stopPingCh)
+		}
 	}
 
 	return a.conn.Close()
@@ -2,14 +2,18 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"os"
 
 	"github.com/hibiken/asynq"
 	"github.com/labstack/echo/v4"
 	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	pkgcache "github.com/shellhub-io/shellhub/pkg/cache"
 	"github.com/shellhub-io/shellhub/pkg/envs"
 	"github.com/shellhub-io/shellhub/pkg/loglevel"
 	sshTunnel "github.com/shellhub-io/shellhub/ssh/pkg/tunnel"
@@ -18,6 +22,7 @@ import (
 	"github.com/shellhub-io/shellhub/ssh/web"
 	"github.com/shellhub-io/shellhub/ssh/web/pkg/cache"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
 )
 
 func init() {
@@ -57,6 +62,36 @@ func main() {
 
 	tunnel.API = internalclient.NewClient(withAsynq)
 
+	if env.Cluster {
+		address := env.InstanceAddress
+		if address == "" {
+			hostname, err := os.Hostname()
+			if err != nil {
+				log.WithError(err).Fatal("Failed to get the instance's hostname")
+			}
+
+			address = net.JoinHostPort(hostname, "8080")
+		}
+
+		registry, err := pkgcache.NewRedisCache(env.RedisURI)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to connect to the connections registry")
+		}
+
+		tunnel.SetRegistry(sshTunnel.NewRegistry(registry, address))
+
+		log.WithField("address", address).Info("Cluster mode enabled")
+	}
+
+	if env.ConnectionRate > 0 {
+		tunnel.Tunnel.ConnectionLimiter = rate.NewLimiter(rate.Limit(env.ConnectionRate), env.ConnectionBurst)
+
+		log.WithFields(log.Fields{
+			"rate":  env.ConnectionRate,
+			"burst": env.ConnectionBurst,
+		}).Info("Agents' connections rate limited")
+	}
+
 	router := tunnel.GetRouter()
 	router.Any("/sessions/:uid/close", func(c echo.Context) error {
 		exit := func(status int, err error) error {
@@ -147,5 +182,42 @@ func main() {
 
 	go http.ListenAndServe(":8080", router) // nolint:errcheck
 
+	if env.TunnelsCertificate != "" {
+		certificate, err := tls.LoadX509KeyPair(env.TunnelsCertificate, env.TunnelsKey)
+		if err != nil {
+			log.WithError(err).Fatal("Failed to load the tunnels' TLS certificate")
+		}
+
+		go func() {
+			log.WithField("addr", env.TunnelsAddress).Info("tcp tunnels listening")
+
+			if err := tunnel.ListenTCP(env.TunnelsAddress, &tls.Config{Certificates: []tls.Certificate{certificate}}); err != nil { // nolint:gosec
+				log.WithError(err).Error("failed to listen and serve the tcp tunnels")
+			}
+		}()
+	}
+
+	// NOTICE: The range of ports to the tunnels in the port mode is shared with the API, which allocates them, so it is
+	// read from the same variable. When it is empty, the port mode is disabled.
+	if ports := envs.DefaultBackend.Get("SHELLHUB_TUNNELS_PORTS"); ports != "" {
+		go func() {
+			log.WithField("ports", ports).Info("tcp tunnels ports listening")
+
+			if err := tunnel.ListenPorts("", ports); err != nil {
+				log.WithError(err).Error("failed to listen and serve the tcp tunnels ports")
+			}
+		}()
+	}
+
+	if env.SOCKSAddress != "" {
+		go func() {
+			log.WithField("addr", env.SOCKSAddress).Info("socks proxy listening")
+
+			if err := tunnel.ListenSOCKS(env.SOCKSAddress); err != nil {
+				log.WithError(err).Error("failed to listen and serve the socks proxy")
+			}
+		}()
+	}
+
 	log.Fatal(server.NewServer(env, tunnel.Tunnel).ListenAndServe())
 }
@@ -10,6 +10,9 @@ const (
 	// fingerprint is the key to store and restore the public key from the context.
 	fingerprint = "public_key"
 
+	// options is the key to store and restore the public key's options from the context.
+	options = "public_key_options"
+
 	// api is the key to store and restore an instance of internal api client.
 	api = "api"
 
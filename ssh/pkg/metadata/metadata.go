@@ -29,6 +29,7 @@ type Metadata interface {
 	RestoreAuthenticationMethod(ctx gliderssh.Context) AuthenticationMethod
 	RestorePassword(ctx gliderssh.Context) string
 	RestoreFingerprint(ctx gliderssh.Context) string
+	RestorePublicKeyOptions(ctx gliderssh.Context) *models.PublicKeyOptions
 	RestoreTarget(ctx gliderssh.Context) *target.Target
 	RestoreAPI(ctx gliderssh.Context) internalclient.Client
 	RestoreLookup(ctx gliderssh.Context) map[string]string
@@ -38,6 +39,7 @@ type Metadata interface {
 	StoreRequest(ctx gliderssh.Context, value string)
 	StoreAuthenticationMethod(ctx gliderssh.Context, method AuthenticationMethod)
 	StorePassword(ctx gliderssh.Context, value string)
+	StorePublicKeyOptions(ctx gliderssh.Context, value *models.PublicKeyOptions)
 	MaybeStoreSSHID(ctx gliderssh.Context, value string) string
 	MaybeStoreFingerprint(ctx gliderssh.Context, value string) string
 	MaybeStoreTarget(ctx gliderssh.Context, sshid string) (*target.Target, error)
@@ -68,6 +70,12 @@ func RestoreFingerprint(ctx gliderssh.Context) string {
 	return bd.RestoreFingerprint(ctx)
 }
 
+// RestorePublicKeyOptions restores the options of the public key used to authenticate from context as metadata. It
+// returns nil when the connection was not authenticated by a public key with options.
+func RestorePublicKeyOptions(ctx gliderssh.Context) *models.PublicKeyOptions {
+	return bd.RestorePublicKeyOptions(ctx)
+}
+
 // RestoreTarget restores the target from context as metadata.
 func RestoreTarget(ctx gliderssh.Context) *target.Target {
 	return bd.RestoreTarget(ctx)
@@ -113,6 +121,11 @@ func StorePassword(ctx gliderssh.Context, value string) {
 	bd.StorePassword(ctx, value)
 }
 
+// StorePublicKeyOptions stores the options of the public key used to authenticate in the context as metadata.
+func StorePublicKeyOptions(ctx gliderssh.Context, value *models.PublicKeyOptions) {
+	bd.StorePublicKeyOptions(ctx, value)
+}
+
 // MaybeStoreSSHID stores the SSHID in the context as metadata if is not set yet.
 func MaybeStoreSSHID(ctx gliderssh.Context, value string) string {
 	return bd.MaybeStoreSSHID(ctx, value)
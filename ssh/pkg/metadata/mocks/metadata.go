@@ -1,4 +1,4 @@
-// Code generated by mockery v2.32.4. DO NOT EDIT.
+// Code generated by mockery v2.38.0. DO NOT EDIT.
 
 package mocks
 
@@ -25,6 +25,10 @@ type Metadata struct {
 func (_m *Metadata) MaybeSetAPI(ctx ssh.Context, client internalclient.Client) internalclient.Client {
 	ret := _m.Called(ctx, client)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeSetAPI")
+	}
+
 	var r0 internalclient.Client
 	if rf, ok := ret.Get(0).(func(ssh.Context, internalclient.Client) internalclient.Client); ok {
 		r0 = rf(ctx, client)
@@ -41,6 +45,10 @@ func (_m *Metadata) MaybeSetAPI(ctx ssh.Context, client internalclient.Client) i
 func (_m *Metadata) MaybeStoreAgentConn(ctx ssh.Context, client *cryptossh.Client) *cryptossh.Client {
 	ret := _m.Called(ctx, client)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreAgentConn")
+	}
+
 	var r0 *cryptossh.Client
 	if rf, ok := ret.Get(0).(func(ssh.Context, *cryptossh.Client) *cryptossh.Client); ok {
 		r0 = rf(ctx, client)
@@ -57,6 +65,10 @@ func (_m *Metadata) MaybeStoreAgentConn(ctx ssh.Context, client *cryptossh.Clien
 func (_m *Metadata) MaybeStoreDevice(ctx ssh.Context, lookup map[string]string, api internalclient.Client) (*models.Device, []error) {
 	ret := _m.Called(ctx, lookup, api)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreDevice")
+	}
+
 	var r0 *models.Device
 	var r1 []error
 	if rf, ok := ret.Get(0).(func(ssh.Context, map[string]string, internalclient.Client) (*models.Device, []error)); ok {
@@ -85,6 +97,10 @@ func (_m *Metadata) MaybeStoreDevice(ctx ssh.Context, lookup map[string]string,
 func (_m *Metadata) MaybeStoreEstablished(ctx ssh.Context, value bool) bool {
 	ret := _m.Called(ctx, value)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreEstablished")
+	}
+
 	var r0 bool
 	if rf, ok := ret.Get(0).(func(ssh.Context, bool) bool); ok {
 		r0 = rf(ctx, value)
@@ -99,6 +115,10 @@ func (_m *Metadata) MaybeStoreEstablished(ctx ssh.Context, value bool) bool {
 func (_m *Metadata) MaybeStoreFingerprint(ctx ssh.Context, value string) string {
 	ret := _m.Called(ctx, value)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreFingerprint")
+	}
+
 	var r0 string
 	if rf, ok := ret.Get(0).(func(ssh.Context, string) string); ok {
 		r0 = rf(ctx, value)
@@ -113,6 +133,10 @@ func (_m *Metadata) MaybeStoreFingerprint(ctx ssh.Context, value string) string
 func (_m *Metadata) MaybeStoreLookup(ctx ssh.Context, tag *target.Target, api internalclient.Client) (map[string]string, error) {
 	ret := _m.Called(ctx, tag, api)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreLookup")
+	}
+
 	var r0 map[string]string
 	var r1 error
 	if rf, ok := ret.Get(0).(func(ssh.Context, *target.Target, internalclient.Client) (map[string]string, error)); ok {
@@ -139,6 +163,10 @@ func (_m *Metadata) MaybeStoreLookup(ctx ssh.Context, tag *target.Target, api in
 func (_m *Metadata) MaybeStoreSSHID(ctx ssh.Context, value string) string {
 	ret := _m.Called(ctx, value)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreSSHID")
+	}
+
 	var r0 string
 	if rf, ok := ret.Get(0).(func(ssh.Context, string) string); ok {
 		r0 = rf(ctx, value)
@@ -153,6 +181,10 @@ func (_m *Metadata) MaybeStoreSSHID(ctx ssh.Context, value string) string {
 func (_m *Metadata) MaybeStoreTarget(ctx ssh.Context, sshid string) (*target.Target, error) {
 	ret := _m.Called(ctx, sshid)
 
+	if len(ret) == 0 {
+		panic("no return value specified for MaybeStoreTarget")
+	}
+
 	var r0 *target.Target
 	var r1 error
 	if rf, ok := ret.Get(0).(func(ssh.Context, string) (*target.Target, error)); ok {
@@ -179,6 +211,10 @@ func (_m *Metadata) MaybeStoreTarget(ctx ssh.Context, sshid string) (*target.Tar
 func (_m *Metadata) RestoreAPI(ctx ssh.Context) internalclient.Client {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAPI")
+	}
+
 	var r0 internalclient.Client
 	if rf, ok := ret.Get(0).(func(ssh.Context) internalclient.Client); ok {
 		r0 = rf(ctx)
@@ -195,6 +231,10 @@ func (_m *Metadata) RestoreAPI(ctx ssh.Context) internalclient.Client {
 func (_m *Metadata) RestoreAgentConn(ctx ssh.Context) *cryptossh.Client {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAgentConn")
+	}
+
 	var r0 *cryptossh.Client
 	if rf, ok := ret.Get(0).(func(ssh.Context) *cryptossh.Client); ok {
 		r0 = rf(ctx)
@@ -211,6 +251,10 @@ func (_m *Metadata) RestoreAgentConn(ctx ssh.Context) *cryptossh.Client {
 func (_m *Metadata) RestoreAuthenticationMethod(ctx ssh.Context) metadata.AuthenticationMethod {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAuthenticationMethod")
+	}
+
 	var r0 metadata.AuthenticationMethod
 	if rf, ok := ret.Get(0).(func(ssh.Context) metadata.AuthenticationMethod); ok {
 		r0 = rf(ctx)
@@ -225,6 +269,10 @@ func (_m *Metadata) RestoreAuthenticationMethod(ctx ssh.Context) metadata.Authen
 func (_m *Metadata) RestoreDevice(ctx ssh.Context) *models.Device {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreDevice")
+	}
+
 	var r0 *models.Device
 	if rf, ok := ret.Get(0).(func(ssh.Context) *models.Device); ok {
 		r0 = rf(ctx)
@@ -241,6 +289,10 @@ func (_m *Metadata) RestoreDevice(ctx ssh.Context) *models.Device {
 func (_m *Metadata) RestoreEstablished(ctx ssh.Context) bool {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEstablished")
+	}
+
 	var r0 bool
 	if rf, ok := ret.Get(0).(func(ssh.Context) bool); ok {
 		r0 = rf(ctx)
@@ -255,6 +307,10 @@ func (_m *Metadata) RestoreEstablished(ctx ssh.Context) bool {
 func (_m *Metadata) RestoreFingerprint(ctx ssh.Context) string {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreFingerprint")
+	}
+
 	var r0 string
 	if rf, ok := ret.Get(0).(func(ssh.Context) string); ok {
 		r0 = rf(ctx)
@@ -269,6 +325,10 @@ func (_m *Metadata) RestoreFingerprint(ctx ssh.Context) string {
 func (_m *Metadata) RestoreLookup(ctx ssh.Context) map[string]string {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreLookup")
+	}
+
 	var r0 map[string]string
 	if rf, ok := ret.Get(0).(func(ssh.Context) map[string]string); ok {
 		r0 = rf(ctx)
@@ -285,6 +345,10 @@ func (_m *Metadata) RestoreLookup(ctx ssh.Context) map[string]string {
 func (_m *Metadata) RestorePassword(ctx ssh.Context) string {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestorePassword")
+	}
+
 	var r0 string
 	if rf, ok := ret.Get(0).(func(ssh.Context) string); ok {
 		r0 = rf(ctx)
@@ -295,10 +359,34 @@ func (_m *Metadata) RestorePassword(ctx ssh.Context) string {
 	return r0
 }
 
+// RestorePublicKeyOptions provides a mock function with given fields: ctx
+func (_m *Metadata) RestorePublicKeyOptions(ctx ssh.Context) *models.PublicKeyOptions {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestorePublicKeyOptions")
+	}
+
+	var r0 *models.PublicKeyOptions
+	if rf, ok := ret.Get(0).(func(ssh.Context) *models.PublicKeyOptions); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PublicKeyOptions)
+		}
+	}
+
+	return r0
+}
+
 // RestoreRequest provides a mock function with given fields: ctx
 func (_m *Metadata) RestoreRequest(ctx ssh.Context) string {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRequest")
+	}
+
 	var r0 string
 	if rf, ok := ret.Get(0).(func(ssh.Context) string); ok {
 		r0 = rf(ctx)
@@ -313,6 +401,10 @@ func (_m *Metadata) RestoreRequest(ctx ssh.Context) string {
 func (_m *Metadata) RestoreTarget(ctx ssh.Context) *target.Target {
 	ret := _m.Called(ctx)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTarget")
+	}
+
 	var r0 *target.Target
 	if rf, ok := ret.Get(0).(func(ssh.Context) *target.Target); ok {
 		r0 = rf(ctx)
@@ -335,6 +427,11 @@ func (_m *Metadata) StorePassword(ctx ssh.Context, value string) {
 	_m.Called(ctx, value)
 }
 
+// StorePublicKeyOptions provides a mock function with given fields: ctx, value
+func (_m *Metadata) StorePublicKeyOptions(ctx ssh.Context, value *models.PublicKeyOptions) {
+	_m.Called(ctx, value)
+}
+
 // StoreRequest provides a mock function with given fields: ctx, value
 func (_m *Metadata) StoreRequest(ctx ssh.Context, value string) {
 	_m.Called(ctx, value)
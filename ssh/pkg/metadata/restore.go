@@ -39,6 +39,15 @@ func (*backend) RestorePassword(ctx gliderssh.Context) string {
 	return value.(string)
 }
 
+func (*backend) RestorePublicKeyOptions(ctx gliderssh.Context) *models.PublicKeyOptions {
+	value := restore(ctx, options)
+	if value == nil {
+		return nil
+	}
+
+	return value.(*models.PublicKeyOptions)
+}
+
 func (*backend) RestoreFingerprint(ctx gliderssh.Context) string {
 	value := restore(ctx, fingerprint)
 	if value == nil {
@@ -254,6 +254,70 @@ func TestRestorePassword(t *testing.T) {
 	}
 }
 
+func TestRestorePublicKeyOptions(t *testing.T) {
+	cases := []struct {
+		description string
+		setup       func(ctx *gliderssh.Context) *sshsrvtest.Conn
+		expected    *models.PublicKeyOptions
+	}{
+		{
+			description: "fails when options are not set",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				return sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							*ctx = s.Context()
+						},
+					},
+					&gossh.ClientConfig{
+						User: "user",
+						Auth: []gossh.AuthMethod{
+							gossh.Password("123"),
+						},
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+			},
+			expected: nil,
+		},
+		{
+			description: "succeeds in restoring options",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				return sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							s.Context().SetValue(options, &models.PublicKeyOptions{Command: "backup", NoPTY: true})
+							*ctx = s.Context()
+						},
+					},
+					&gossh.ClientConfig{
+						User: "user",
+						Auth: []gossh.AuthMethod{
+							gossh.Password("123"),
+						},
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+			},
+			expected: &models.PublicKeyOptions{Command: "backup", NoPTY: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var ctx gliderssh.Context
+
+			srv := tc.setup(&ctx)
+
+			srv.Start()
+			defer srv.Teardown()
+
+			assert.NoError(t, srv.Agent.Run(""))
+			assert.Equal(t, tc.expected, RestorePublicKeyOptions(ctx))
+		})
+	}
+}
+
 func TestRestoreFingerprint(t *testing.T) {
 	cases := []struct {
 		description string
@@ -25,6 +25,10 @@ func (*backend) StorePassword(ctx gliderssh.Context, value string) {
 	store(ctx, password, value)
 }
 
+func (*backend) StorePublicKeyOptions(ctx gliderssh.Context, value *models.PublicKeyOptions) {
+	store(ctx, options, value)
+}
+
 // maybeStore stores a value into a context if it does not exist yet. If the value already exists, it will be returned.
 //
 // Its return must be cast.
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/shellhub-io/shellhub/pkg/models"
 	"github.com/shellhub-io/shellhub/ssh/pkg/sshsrvtest"
 	"github.com/shellhub-io/shellhub/ssh/pkg/target"
 	"github.com/stretchr/testify/assert"
@@ -191,6 +192,50 @@ func TestStorePassword(t *testing.T) {
 	}
 }
 
+func TestStorePublicKeyOptions(t *testing.T) {
+	cases := []struct {
+		description string
+		setup       func(ctx *gliderssh.Context) *sshsrvtest.Conn
+		expected    *models.PublicKeyOptions
+	}{
+		{
+			description: "succeeds in storing options",
+			setup: func(ctx *gliderssh.Context) *sshsrvtest.Conn {
+				return sshsrvtest.New(
+					&gliderssh.Server{
+						Handler: func(s gliderssh.Session) {
+							*ctx = s.Context()
+							StorePublicKeyOptions(*ctx, &models.PublicKeyOptions{NoSFTP: true})
+						},
+					},
+					&gossh.ClientConfig{
+						User: "user",
+						Auth: []gossh.AuthMethod{
+							gossh.Password("123"),
+						},
+						HostKeyCallback: gossh.InsecureIgnoreHostKey(),
+					},
+				)
+			},
+			expected: &models.PublicKeyOptions{NoSFTP: true},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			var ctx gliderssh.Context
+
+			srv := tc.setup(&ctx)
+
+			srv.Start()
+			defer srv.Teardown()
+
+			assert.NoError(t, srv.Agent.Run(""))
+			assert.Equal(t, tc.expected, ctx.Value(options).(*models.PublicKeyOptions))
+		})
+	}
+}
+
 func TestMaybeStore(t *testing.T) {
 	cases := []struct {
 		description string
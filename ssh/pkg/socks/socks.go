@@ -0,0 +1,326 @@
+// Package socks implements the server side of the SOCKS5 protocol, as defined by RFC 1928, limited to the CONNECT
+// command and to the username/password authentication, defined by RFC 1929.
+package socks
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	socksVersion    = 0x05
+	userPassVersion = 0x01
+)
+
+const (
+	methodNoAuth       = 0x00
+	methodUserPass     = 0x02
+	methodNoAcceptable = 0xff
+)
+
+const (
+	commandConnect = 0x01
+)
+
+const (
+	addressIPv4   = 0x01
+	addressDomain = 0x03
+	addressIPv6   = 0x04
+)
+
+const (
+	replySucceeded           = 0x00
+	replyGeneralFailure      = 0x01
+	replyNotAllowed          = 0x02
+	replyHostUnreachable     = 0x04
+	replyCommandNotSupported = 0x07
+	replyAddressNotSupported = 0x08
+)
+
+var (
+	// ErrVersion is returned when the client does not speak the SOCKS5 protocol.
+	ErrVersion = errors.New("unsupported socks version")
+	// ErrMethod is returned when the client does not support any of the server's authentication methods.
+	ErrMethod = errors.New("no acceptable authentication method")
+	// ErrCommand is returned when the client requests a command other than CONNECT.
+	ErrCommand = errors.New("unsupported socks command")
+	// ErrAddressType is returned when the client requests an unknown address type.
+	ErrAddressType = errors.New("unsupported address type")
+	// ErrUnauthorized should be returned by [Server.Authenticate] when the credentials are invalid.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrNotAllowed should be returned by [Server.Dial] when the destination is not allowed to the identity.
+	ErrNotAllowed = errors.New("connection not allowed")
+	// ErrDestination is returned when the destination is not in the format `device-name.namespace`.
+	ErrDestination = errors.New("invalid destination")
+	// ErrHostUnreachable should be returned by [Server.Dial] when the destination could not be found.
+	ErrHostUnreachable = errors.New("host unreachable")
+)
+
+// HandshakeTimeout is the maximum duration to the SOCKS5 negotiation be completed.
+const HandshakeTimeout = 30 * time.Second
+
+// Identity is the client connected to the server.
+type Identity struct {
+	// Username is the username sent by the client when authenticated.
+	Username string
+	// Value is what [Server.Authenticate] returned to the client's credentials.
+	Value string
+	// Source is the IP address the client is connected from.
+	Source string
+}
+
+// Server is a SOCKS5 server.
+type Server struct {
+	// Authenticate, when defined, requires the username/password authentication and checks the credentials received,
+	// returning a value to the client's identity passed to [Server.Dial].
+	Authenticate func(username, password string) (string, error)
+	// Dial connects to the destination requested by the client.
+	Dial func(ctx context.Context, identity *Identity, host string, port int) (net.Conn, error)
+}
+
+// Serve accepts connections on listener, handling each one in a new goroutine.
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := s.ServeConn(conn); err != nil {
+				log.WithError(err).WithField("remote", conn.RemoteAddr().String()).Error("failed to serve the socks connection")
+			}
+		}()
+	}
+}
+
+// ServeConn negotiates the SOCKS5 protocol with the client and, when succeeded, pipes the data between it and the
+// destination. It closes the connection when done.
+func (s *Server) ServeConn(conn net.Conn) error {
+	defer conn.Close()
+
+	conn.SetDeadline(time.Now().Add(HandshakeTimeout)) // nolint:errcheck
+
+	identity, err := s.negotiate(conn)
+	if err != nil {
+		return err
+	}
+
+	identity.Source, _, _ = net.SplitHostPort(conn.RemoteAddr().String())
+
+	host, port, err := s.request(conn)
+	if err != nil {
+		return err
+	}
+
+	dest, err := s.Dial(context.Background(), identity, host, port)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrNotAllowed):
+			reply(conn, replyNotAllowed) // nolint:errcheck
+		case errors.Is(err, ErrHostUnreachable):
+			reply(conn, replyHostUnreachable) // nolint:errcheck
+		default:
+			reply(conn, replyGeneralFailure) // nolint:errcheck
+		}
+
+		return err
+	}
+
+	defer dest.Close()
+
+	if err := reply(conn, replySucceeded); err != nil {
+		return err
+	}
+
+	conn.SetDeadline(time.Time{}) // nolint:errcheck
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(dest, conn) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	go func() {
+		io.Copy(conn, dest) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	<-done
+
+	return nil
+}
+
+// negotiate selects the authentication method and, when required, authenticates the client.
+func (s *Server) negotiate(conn net.Conn) (*Identity, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return nil, err
+	}
+
+	if header[0] != socksVersion {
+		return nil, ErrVersion
+	}
+
+	methods := make([]byte, header[1])
+	if _, err := io.ReadFull(conn, methods); err != nil {
+		return nil, err
+	}
+
+	expected := byte(methodNoAuth)
+	if s.Authenticate != nil {
+		expected = methodUserPass
+	}
+
+	for _, method := range methods {
+		if method != expected {
+			continue
+		}
+
+		if _, err := conn.Write([]byte{socksVersion, expected}); err != nil {
+			return nil, err
+		}
+
+		if expected == methodNoAuth {
+			return &Identity{}, nil
+		}
+
+		return s.authenticate(conn)
+	}
+
+	conn.Write([]byte{socksVersion, methodNoAcceptable}) // nolint:errcheck
+
+	return nil, ErrMethod
+}
+
+// authenticate reads the username and password sent by the client and checks them.
+func (s *Server) authenticate(conn net.Conn) (*Identity, error) {
+	readField := func() (string, error) {
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return "", err
+		}
+
+		field := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, field); err != nil {
+			return "", err
+		}
+
+		return string(field), nil
+	}
+
+	version := make([]byte, 1)
+	if _, err := io.ReadFull(conn, version); err != nil {
+		return nil, err
+	}
+
+	if version[0] != userPassVersion {
+		return nil, ErrVersion
+	}
+
+	username, err := readField()
+	if err != nil {
+		return nil, err
+	}
+
+	password, err := readField()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := s.Authenticate(username, password)
+	if err != nil {
+		conn.Write([]byte{userPassVersion, 0x01}) // nolint:errcheck
+
+		return nil, err
+	}
+
+	if _, err := conn.Write([]byte{userPassVersion, 0x00}); err != nil {
+		return nil, err
+	}
+
+	return &Identity{Username: username, Value: value}, nil
+}
+
+// request reads the client's request, returning the destination's host and port.
+func (s *Server) request(conn net.Conn) (string, int, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return "", 0, err
+	}
+
+	if header[0] != socksVersion {
+		return "", 0, ErrVersion
+	}
+
+	if header[1] != commandConnect {
+		reply(conn, replyCommandNotSupported) // nolint:errcheck
+
+		return "", 0, ErrCommand
+	}
+
+	var host string
+	switch header[3] {
+	case addressIPv4, addressIPv6:
+		size := net.IPv4len
+		if header[3] == addressIPv6 {
+			size = net.IPv6len
+		}
+
+		ip := make([]byte, size)
+		if _, err := io.ReadFull(conn, ip); err != nil {
+			return "", 0, err
+		}
+
+		host = net.IP(ip).String()
+	case addressDomain:
+		size := make([]byte, 1)
+		if _, err := io.ReadFull(conn, size); err != nil {
+			return "", 0, err
+		}
+
+		domain := make([]byte, size[0])
+		if _, err := io.ReadFull(conn, domain); err != nil {
+			return "", 0, err
+		}
+
+		host = string(domain)
+	default:
+		reply(conn, replyAddressNotSupported) // nolint:errcheck
+
+		return "", 0, ErrAddressType
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(conn, port); err != nil {
+		return "", 0, err
+	}
+
+	return host, int(binary.BigEndian.Uint16(port)), nil
+}
+
+// reply writes a reply to the client's request. As the server does not expose the address used to connect to the
+// destination, the bound address is always zero.
+func reply(conn net.Conn, code byte) error {
+	_, err := conn.Write([]byte{socksVersion, code, 0x00, addressIPv4, 0, 0, 0, 0, 0, 0})
+
+	return err
+}
+
+// SplitDestination splits a destination in the format `device-name.namespace` into the device's name and namespace.
+func SplitDestination(host string) (string, string, error) {
+	i := strings.LastIndex(host, ".")
+	if i <= 0 || i == len(host)-1 {
+		return "", "", ErrDestination
+	}
+
+	return host[:i], host[i+1:], nil
+}
@@ -0,0 +1,183 @@
+package socks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitDestination(t *testing.T) {
+	type Expected struct {
+		name      string
+		namespace string
+		err       error
+	}
+
+	cases := []struct {
+		description string
+		host        string
+		expected    Expected
+	}{
+		{
+			description: "fails when host does not contain a dot",
+			host:        "device",
+			expected:    Expected{"", "", ErrDestination},
+		},
+		{
+			description: "fails when host starts with a dot",
+			host:        ".namespace",
+			expected:    Expected{"", "", ErrDestination},
+		},
+		{
+			description: "fails when host ends with a dot",
+			host:        "device.",
+			expected:    Expected{"", "", ErrDestination},
+		},
+		{
+			description: "succeeds when host is valid",
+			host:        "device.namespace",
+			expected:    Expected{"device", "namespace", nil},
+		},
+		{
+			description: "succeeds when device name contains a dot",
+			host:        "device.local.namespace",
+			expected:    Expected{"device.local", "namespace", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			name, namespace, err := SplitDestination(tc.host)
+			assert.Equal(t, tc.expected, Expected{name, namespace, err})
+		})
+	}
+}
+
+func TestServeConn(t *testing.T) {
+	type Expected struct {
+		response []byte
+		err      error
+	}
+
+	connect := []byte{0x05, 0x01, 0x00, 0x03, 0x10}
+	connect = append(connect, []byte("device.namespace")...)
+	connect = append(connect, 0x00, 0x16)
+
+	cases := []struct {
+		description string
+		server      *Server
+		request     []byte
+		expected    Expected
+	}{
+		{
+			description: "fails when version is not supported",
+			server:      &Server{},
+			request:     []byte{0x04, 0x01, 0x00},
+			expected:    Expected{[]byte{}, ErrVersion},
+		},
+		{
+			description: "fails when client does not support the authentication method",
+			server: &Server{
+				Authenticate: func(_, _ string) (string, error) {
+					return "", nil
+				},
+			},
+			request:  []byte{0x05, 0x01, 0x00},
+			expected: Expected{[]byte{0x05, 0xff}, ErrMethod},
+		},
+		{
+			description: "fails when credentials are invalid",
+			server: &Server{
+				Authenticate: func(username, password string) (string, error) {
+					assert.Equal(t, "user", username)
+					assert.Equal(t, "token", password)
+
+					return "", ErrUnauthorized
+				},
+			},
+			request:  []byte{0x05, 0x01, 0x02, 0x01, 0x04, 'u', 's', 'e', 'r', 0x05, 't', 'o', 'k', 'e', 'n'},
+			expected: Expected{[]byte{0x05, 0x02, 0x01, 0x01}, ErrUnauthorized},
+		},
+		{
+			description: "fails when command is not supported",
+			server:      &Server{},
+			request:     []byte{0x05, 0x01, 0x00, 0x05, 0x02, 0x00, 0x01},
+			expected:    Expected{[]byte{0x05, 0x00, 0x05, 0x07, 0x00, 0x01, 0, 0, 0, 0, 0, 0}, ErrCommand},
+		},
+		{
+			description: "fails when destination is not allowed",
+			server: &Server{
+				Dial: func(_ context.Context, _ *Identity, _ string, _ int) (net.Conn, error) {
+					return nil, ErrNotAllowed
+				},
+			},
+			request:  append([]byte{0x05, 0x01, 0x00}, connect...),
+			expected: Expected{[]byte{0x05, 0x00, 0x05, 0x02, 0x00, 0x01, 0, 0, 0, 0, 0, 0}, ErrNotAllowed},
+		},
+		{
+			description: "fails when destination is unreachable",
+			server: &Server{
+				Dial: func(_ context.Context, _ *Identity, _ string, _ int) (net.Conn, error) {
+					return nil, ErrHostUnreachable
+				},
+			},
+			request:  append([]byte{0x05, 0x01, 0x00}, connect...),
+			expected: Expected{[]byte{0x05, 0x00, 0x05, 0x04, 0x00, 0x01, 0, 0, 0, 0, 0, 0}, ErrHostUnreachable},
+		},
+		{
+			description: "succeeds when destination is connected",
+			server: &Server{
+				Authenticate: func(_, _ string) (string, error) {
+					return "tenant", nil
+				},
+				Dial: func(_ context.Context, identity *Identity, host string, port int) (net.Conn, error) {
+					assert.Equal(t, &Identity{Username: "u", Value: "tenant"}, identity)
+					assert.Equal(t, "device.namespace", host)
+					assert.Equal(t, 22, port)
+
+					client, server := net.Pipe()
+					go func() {
+						server.Write([]byte("hello")) // nolint:errcheck
+						server.Close()
+					}()
+
+					return client, nil
+				},
+			},
+			request: append([]byte{0x05, 0x01, 0x02, 0x01, 0x01, 'u', 0x01, 't'}, connect...),
+			expected: Expected{
+				append([]byte{0x05, 0x02, 0x01, 0x00, 0x05, 0x00, 0x00, 0x01, 0, 0, 0, 0, 0, 0}, []byte("hello")...),
+				nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			client, server := net.Pipe()
+
+			result := make(chan error, 1)
+			go func() {
+				result <- tc.server.ServeConn(server)
+			}()
+
+			go func() {
+				client.Write(tc.request) // nolint:errcheck
+			}()
+
+			response, _ := io.ReadAll(client)
+			client.Close()
+
+			err := <-result
+			if !errors.Is(err, tc.expected.err) {
+				assert.Equal(t, tc.expected.err, err)
+			}
+
+			assert.Equal(t, tc.expected.response, response)
+		})
+	}
+}
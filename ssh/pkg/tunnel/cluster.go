@@ -0,0 +1,189 @@
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/connman"
+	log "github.com/sirupsen/logrus"
+)
+
+// ForwardURL is the route used by the instances to dial a device connected to another instance.
+const ForwardURL = "/ssh/forward/:id"
+
+// instanceParam is the query param, sent to the agents within the reverse connection's pick up path, to identify the
+// instance which owns the dialer.
+const instanceParam = "revdial.instance"
+
+// forwardedKey is the context key used to indicate that a dial was forwarded from another instance.
+type forwardedKey struct{}
+
+// SetRegistry enables the routing of the devices' connections between the instances sharing the registry, what allows
+// multiple SSH server instances to run behind a load balancer.
+func (t *Tunnel) SetRegistry(registry *Registry) {
+	t.Registry = registry
+
+	keepAliveHandler := t.Tunnel.KeepAliveHandler
+	t.Tunnel.KeepAliveHandler = func(id string) {
+		if err := registry.Register(context.Background(), id); err != nil {
+			log.WithError(err).WithField("device", id).Error("failed to register the device's connection")
+		}
+
+		keepAliveHandler(id)
+	}
+
+	closeHandler := t.Tunnel.CloseHandler
+	t.Tunnel.CloseHandler = func(id string) {
+		owned, err := registry.Unregister(context.Background(), id)
+		if err != nil {
+			log.WithError(err).WithField("device", id).Error("failed to unregister the device's connection")
+		}
+
+		// When the device's connection is owned by another instance, the device is still online.
+		if !owned {
+			return
+		}
+
+		closeHandler(id)
+	}
+
+	t.Tunnel.ForwardHandler = t.forward
+	t.Tunnel.SetDialerQuery(url.Values{instanceParam: []string{registry.Address()}}.Encode())
+
+	go t.announce()
+}
+
+// announce keeps the current instance announced as alive in the registry.
+func (t *Tunnel) announce() {
+	ticker := time.NewTicker(RegistryInstanceTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		if err := t.Registry.Announce(context.Background()); err != nil {
+			log.WithError(err).Error("failed to announce the instance")
+		}
+
+		<-ticker.C
+	}
+}
+
+// forward dials a device connected to another instance, through the instance which owns its connection.
+func (t *Tunnel) forward(ctx context.Context, id string) (net.Conn, error) {
+	// A forwarded dial is never forwarded again to avoid loops between the instances.
+	if ctx.Value(forwardedKey{}) != nil {
+		return nil, connman.ErrNoConnection
+	}
+
+	owner, err := t.Registry.Owner(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner == "" || owner == t.Registry.Address() {
+		return nil, connman.ErrNoConnection
+	}
+
+	dialer := new(net.Dialer)
+
+	conn, err := dialer.DialContext(ctx, "tcp", owner)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/forward/%s", id), nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		conn.Close()
+
+		return nil, connman.ErrNoConnection
+	}
+
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+// forwardHandler handles the dials forwarded from other instances to devices connected to the current one.
+func (t *Tunnel) forwardHandler(c echo.Context) error {
+	ctx := context.WithValue(c.Request().Context(), forwardedKey{}, true)
+
+	in, err := t.Tunnel.Dial(ctx, c.Param("id"))
+	if err != nil {
+		return c.String(http.StatusNotFound, err.Error())
+	}
+
+	defer in.Close()
+
+	out, _, err := c.Response().Hijack()
+	if err != nil {
+		return c.String(http.StatusInternalServerError, "failed to hijack connection")
+	}
+
+	defer out.Close()
+
+	if _, err := out.Write([]byte("HTTP/1.1 200 OK\r\n\r\n")); err != nil {
+		return nil
+	}
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(in, out) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	go func() {
+		io.Copy(out, in) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	<-done
+
+	return nil
+}
+
+// pickupMiddleware proxies the reverse connections picked up by the agents to the instance which owns the dialer,
+// as the load balancer can route them to any instance.
+func (t *Tunnel) pickupMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		address := c.QueryParam(instanceParam)
+		if c.Request().URL.Path != t.Tunnel.DialerPath || address == "" || address == t.Registry.Address() {
+			return next(c)
+		}
+
+		// Only announced instances are accepted to avoid proxying the connection to arbitrary addresses.
+		if ok, err := t.Registry.IsInstance(c.Request().Context(), address); err != nil || !ok {
+			return c.String(http.StatusBadRequest, "unknown instance")
+		}
+
+		httputil.NewSingleHostReverseProxy(&url.URL{Scheme: "http", Host: address}).ServeHTTP(c.Response(), c.Request())
+
+		return nil
+	}
+}
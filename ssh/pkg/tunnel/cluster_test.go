@@ -0,0 +1,97 @@
+package tunnel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/connman"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForward(t *testing.T) {
+	cases := []struct {
+		description string
+		ctx         context.Context
+		setup       func(registry *Registry)
+		expected    error
+	}{
+		{
+			description: "fails when the dial was already forwarded",
+			ctx:         context.WithValue(context.Background(), forwardedKey{}, true),
+			setup: func(registry *Registry) {
+				NewRegistry(registry.cache, "ssh-2:8080").Register(context.Background(), "device") // nolint:errcheck
+			},
+			expected: connman.ErrNoConnection,
+		},
+		{
+			description: "fails when the device is not connected to any instance",
+			ctx:         context.Background(),
+			setup:       func(_ *Registry) {},
+			expected:    connman.ErrNoConnection,
+		},
+		{
+			description: "fails when the device is registered to the current instance",
+			ctx:         context.Background(),
+			setup: func(registry *Registry) {
+				registry.Register(context.Background(), "device") // nolint:errcheck
+			},
+			expected: connman.ErrNoConnection,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			registry := NewRegistry(new(memoryCache), "ssh-1:8080")
+			tc.setup(registry)
+
+			tunnel := &Tunnel{Registry: registry}
+			conn, err := tunnel.forward(tc.ctx, "device")
+			assert.Nil(t, conn)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
+
+func TestPickupMiddleware(t *testing.T) {
+	cases := []struct {
+		description string
+		url         string
+		expected    int
+	}{
+		{
+			description: "passes when the pick up does not have an instance",
+			url:         "/ssh/revdial?revdial.dialer=id",
+			expected:    http.StatusTeapot,
+		},
+		{
+			description: "passes when the pick up is to the current instance",
+			url:         "/ssh/revdial?revdial.instance=ssh-1:8080&revdial.dialer=id",
+			expected:    http.StatusTeapot,
+		},
+		{
+			description: "fails when the pick up is to an unknown instance",
+			url:         "/ssh/revdial?revdial.instance=example.com:80&revdial.dialer=id",
+			expected:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tunnel := NewTunnel("/ssh/connection", "/ssh/revdial")
+			tunnel.Registry = NewRegistry(new(memoryCache), "ssh-1:8080")
+
+			router := tunnel.GetRouter()
+			router.GET("/ssh/revdial", func(c echo.Context) error {
+				return c.NoContent(http.StatusTeapot)
+			})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.url, nil))
+
+			assert.Equal(t, tc.expected, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/magickey"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ExecURL is the route used by the API's workers to execute a job's command on a device.
+const ExecURL = "/exec/:uid"
+
+// ExecSSHAddress is the address of the SSH server where the jobs' commands are executed as exec sessions.
+var ExecSSHAddress = "localhost:2222"
+
+// ExecMaxOutputSize is the maximum number of bytes captured from each of the command's output streams.
+const ExecMaxOutputSize = 64 * 1024
+
+// ErrExecRequest is returned when the job's execution request is invalid.
+var ErrExecRequest = errors.New("user, command and timeout are required")
+
+// limitedBuffer is a [bytes.Buffer] that silently discards what is written beyond its limit.
+type limitedBuffer struct {
+	buffer bytes.Buffer
+	limit  int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if left := b.limit - b.buffer.Len(); left > 0 {
+		if len(p) > left {
+			b.buffer.Write(p[:left])
+		} else {
+			b.buffer.Write(p)
+		}
+	}
+
+	// NOTICE: the whole input is reported as written to keep the command from failing with a broken pipe.
+	return len(p), nil
+}
+
+// execHandler executes the job's command on the device, replying with its result.
+//
+// The command is executed through an exec session opened on the SSH server, like the ones from the web terminal, so it
+// goes through the same checks, recording and device's authentication of any other session.
+func (t *Tunnel) execHandler(c echo.Context) error {
+	uid := c.Param("uid")
+
+	logger := log.WithField("device", uid)
+
+	var req models.JobExecRequest
+	if err := c.Bind(&req); err != nil || req.User == "" || req.Command == "" || req.Timeout <= 0 {
+		return c.String(http.StatusBadRequest, ErrExecRequest.Error())
+	}
+
+	res, err := execSSH(c.Request().Context(), uid, &req)
+	if err != nil {
+		logger.WithError(err).Error("failed to execute the job's command on the device")
+
+		return c.String(http.StatusBadGateway, err.Error())
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// execSSH executes the job's command on the device through an exec session opened on the SSH server. The command is
+// interrupted when it runs for longer than the job's timeout.
+func execSSH(ctx context.Context, uid string, req *models.JobExecRequest) (*models.JobExecResponse, error) {
+	signer, err := gossh.NewSignerFromKey(magickey.GetRerefence())
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := new(net.Dialer).DialContext(ctx, "tcp", ExecSSHAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	sshConn, chans, reqs, err := gossh.NewClientConn(conn, ExecSSHAddress, &gossh.ClientConfig{ //nolint:exhaustruct
+		User:            fmt.Sprintf("%s@%s", req.User, uid),
+		Auth:            []gossh.AuthMethod{gossh.PublicKeys(signer)},
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), //nolint:gosec
+		Timeout:         SSHHandshakeTimeout,
+	})
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	client := gossh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	defer session.Close()
+
+	if err := session.Setenv(models.JobEnv, req.Job); err != nil {
+		return nil, err
+	}
+
+	stdout := &limitedBuffer{limit: ExecMaxOutputSize}
+	stderr := &limitedBuffer{limit: ExecMaxOutputSize}
+
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	if err := session.Start(req.Command); err != nil {
+		return nil, err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Wait()
+	}()
+
+	response := new(models.JobExecResponse)
+
+	timer := time.NewTimer(time.Duration(req.Timeout) * time.Second)
+	defer timer.Stop()
+
+	select {
+	case err = <-done:
+	case <-timer.C:
+		response.TimedOut = true
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var exit *gossh.ExitError
+	switch {
+	case response.TimedOut:
+		// NOTICE: Closing the connection ends the session on the device, what kills the command.
+		response.ExitCode = -1
+	case err == nil:
+		response.ExitCode = 0
+	case errors.As(err, &exit):
+		response.ExitCode = exit.ExitStatus()
+	default:
+		return nil, err
+	}
+
+	response.Stdout = stdout.buffer.String()
+	response.Stderr = stderr.buffer.String()
+
+	return response, nil
+}
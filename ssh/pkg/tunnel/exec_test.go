@@ -0,0 +1,99 @@
+package tunnel
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gliderssh "github.com/gliderlabs/ssh"
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecHandler(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &gliderssh.Server{
+		PublicKeyHandler: func(gliderssh.Context, gliderssh.PublicKey) bool {
+			return true
+		},
+		Handler: func(s gliderssh.Session) {
+			switch s.RawCommand() {
+			case "uptime":
+				fmt.Fprintf(s, "%s %s", s.User(), strings.Join(s.Environ(), " "))
+				s.Exit(0) //nolint:errcheck
+			case "false":
+				fmt.Fprint(s.Stderr(), "failed")
+				s.Exit(2) //nolint:errcheck
+			case "sleep":
+				<-s.Context().Done()
+			}
+		},
+	}
+
+	go server.Serve(listener) //nolint:errcheck
+	defer server.Close()
+
+	address := ExecSSHAddress
+	ExecSSHAddress = listener.Addr().String()
+	defer func() { ExecSSHAddress = address }()
+
+	cases := []struct {
+		description string
+		body        string
+		expected    *models.JobExecResponse
+		status      int
+	}{
+		{
+			description: "fails when the request is invalid",
+			body:        `{"job":"job","user":"root","timeout":60}`,
+			status:      http.StatusBadRequest,
+		},
+		{
+			description: "succeeds to execute the command on the device",
+			body:        `{"job":"job","user":"root","command":"uptime","timeout":60}`,
+			expected:    &models.JobExecResponse{ExitCode: 0, Stdout: "root@device " + models.JobEnv + "=job"},
+			status:      http.StatusOK,
+		},
+		{
+			description: "succeeds to report the command's failure",
+			body:        `{"job":"job","user":"root","command":"false","timeout":60}`,
+			expected:    &models.JobExecResponse{ExitCode: 2, Stderr: "failed"},
+			status:      http.StatusOK,
+		},
+		{
+			description: "succeeds to report the command's timeout",
+			body:        `{"job":"job","user":"root","command":"sleep","timeout":1}`,
+			expected:    &models.JobExecResponse{ExitCode: -1, TimedOut: true},
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/exec/device", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("uid")
+			c.SetParamValues("device")
+
+			assert.NoError(t, new(Tunnel).execHandler(c))
+			assert.Equal(t, tc.status, rec.Code)
+
+			if tc.expected != nil {
+				response := new(models.JobExecResponse)
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), response))
+				assert.Equal(t, tc.expected, response)
+			}
+		})
+	}
+}
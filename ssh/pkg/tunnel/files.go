@@ -0,0 +1,145 @@
+package tunnel
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// FilesURL is the route used by the API to proxy the file browser's requests to the device's agent.
+const FilesURL = "/files/:uid/:action"
+
+// ErrFilesPublicKey is returned when the public key can't authenticate the file browser's user on the device.
+var ErrFilesPublicKey = errors.New("public key cannot authenticate the user on the device")
+
+// filesHandler proxies the file browser's request to the agent of the device, keeping its method, query and body.
+//
+// The device's user is authenticated by the agent, through the credentials sent by the HTTP basic authentication
+// scheme in the [models.FilesAuthorizationHeader], or by ShellHub, when the request is signed by a public key, what
+// is asserted to the agent the same way as the SSH sessions authenticated through public keys.
+func (t *Tunnel) filesHandler(c echo.Context) error {
+	uid, action := c.Param("uid"), c.Param("action")
+
+	logger := log.WithFields(log.Fields{
+		"device": uid,
+		"action": action,
+	})
+
+	req := c.Request()
+
+	if fingerprint := req.Header.Get(models.FilesFingerprintHeader); fingerprint != "" {
+		authorization, err := t.filesPublicKey(uid, req.Header.Get(models.FilesUsernameHeader), fingerprint, req.Header.Get(models.FilesSignatureHeader))
+		if err != nil {
+			logger.WithError(err).Info("failed to authenticate the file browser's user through the public key")
+
+			return c.String(http.StatusUnauthorized, "invalid credentials")
+		}
+
+		req.Header.Set(models.FilesAuthorizationHeader, authorization)
+	} else if !strings.HasPrefix(req.Header.Get(models.FilesAuthorizationHeader), "Basic ") {
+		return c.String(http.StatusUnauthorized, "credentials are required")
+	}
+
+	req.Header.Del(models.FilesFingerprintHeader)
+	req.Header.Del(models.FilesSignatureHeader)
+
+	t.deviceProxy(uid, "/ssh/files/"+url.PathEscape(action), logger).ServeHTTP(c.Response(), req)
+
+	return nil
+}
+
+// filesPublicKey authenticates the username on the device through the public key, like the web terminal does, and
+// returns the assertion of the authentication to the agent, signed by a private key created by ShellHub to the
+// member that created the public key.
+func (t *Tunnel) filesPublicKey(uid, username, fingerprint, signature string) (string, error) {
+	if username == "" {
+		return "", ErrFilesPublicKey
+	}
+
+	device, err := t.API.GetDevice(uid)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := t.API.GetPublicKey(fingerprint, device.TenantID)
+	if err != nil {
+		return "", err
+	}
+
+	if ok, err := t.API.EvaluateKey(fingerprint, device, username); err != nil || !ok {
+		return "", errors.Join(ErrFilesPublicKey, err)
+	}
+
+	// NOTICE: The file browser is served by the agent's SFTP server, what the public key's options could forbid.
+	if key.Options.NoSFTP || key.Options.Command != "" {
+		return "", ErrFilesPublicKey
+	}
+
+	pubKey, _, _, _, err := gossh.ParseAuthorizedKey(key.Data) //nolint:dogsled
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return "", errors.Join(ErrFilesPublicKey, err)
+	}
+
+	if err := pubKey.Verify([]byte(username), &gossh.Signature{ //nolint:exhaustruct
+		Format: pubKey.Type(),
+		Blob:   digest,
+	}); err != nil {
+		return "", errors.Join(ErrFilesPublicKey, err)
+	}
+
+	privateKey, err := t.API.CreatePrivateKey(fingerprint, device.TenantID)
+	if err != nil {
+		return "", err
+	}
+
+	block, _ := pem.Decode(privateKey.Data)
+	if block == nil {
+		return "", ErrSSHPrivateKey
+	}
+
+	parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return "", errors.Join(ErrSSHPrivateKey, err)
+	}
+
+	// NOTICE: The data is the same signed, through the API, by the agent to authenticate the SSH sessions.
+	data, err := json.Marshal(struct {
+		Username  string
+		Namespace string
+	}{
+		Username:  username,
+		Namespace: device.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+
+	signed, err := rsa.SignPKCS1v15(rand.Reader, parsed, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s %s", models.FilesShellHubScheme, privateKey.Fingerprint, base64.StdEncoding.EncodeToString(signed)), nil
+}
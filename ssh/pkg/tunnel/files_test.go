@@ -0,0 +1,217 @@
+package tunnel
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+func TestFilesHandler(t *testing.T) {
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		fmt.Fprintf(w, "%s %s %s %s %s", r.Method, r.URL.Path, r.URL.Query().Get("path"), r.Header.Get(models.FilesAuthorizationHeader), body)
+	}))
+	defer agent.Close()
+
+	cases := []struct {
+		description   string
+		device        string
+		authorization string
+		expected      string
+		status        int
+	}{
+		{
+			description:   "fails when there are no credentials",
+			device:        "device",
+			authorization: "",
+			expected:      "credentials are required",
+			status:        http.StatusUnauthorized,
+		},
+		{
+			description:   "fails when the credentials are asserted by the client",
+			device:        "device",
+			authorization: models.FilesShellHubScheme + " fingerprint signature",
+			expected:      "credentials are required",
+			status:        http.StatusUnauthorized,
+		},
+		{
+			description:   "fails when the device is not connected",
+			device:        "offline",
+			authorization: "Basic cm9vdDpzZWNyZXQ=",
+			status:        http.StatusBadGateway,
+		},
+		{
+			description:   "succeeds to proxy the request to the device",
+			device:        "device",
+			authorization: "Basic cm9vdDpzZWNyZXQ=",
+			expected:      "PUT /ssh/files/upload /tmp/file Basic cm9vdDpzZWNyZXQ= content",
+			status:        http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tunnel := &Tunnel{Tunnel: httptunnel.NewTunnel("/ssh/connection", "/ssh/revdial")}
+			tunnel.Tunnel.ForwardHandler = func(ctx context.Context, id string) (net.Conn, error) {
+				if id != "device" {
+					return nil, errors.New("device is not connected")
+				}
+
+				return new(net.Dialer).DialContext(ctx, "tcp", agent.Listener.Addr().String())
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/files/"+tc.device+"/upload?path=/tmp/file", strings.NewReader("content"))
+			req.Header.Set(models.FilesAuthorizationHeader, tc.authorization)
+			rec := httptest.NewRecorder()
+
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("uid", "action")
+			c.SetParamValues(tc.device, "upload")
+
+			assert.NoError(t, tunnel.filesHandler(c))
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, tc.expected, rec.Body.String())
+		})
+	}
+}
+
+func TestFilesPublicKey(t *testing.T) {
+	_, userKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := gossh.NewSignerFromKey(userKey)
+	require.NoError(t, err)
+
+	sign := func(data string) string {
+		signature, err := signer.Sign(rand.Reader, []byte(data))
+		require.NoError(t, err)
+
+		return base64.StdEncoding.EncodeToString(signature.Blob)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	privateKey := &models.PrivateKey{
+		Data:        pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)}),
+		Fingerprint: "private",
+	}
+
+	device := &models.Device{UID: "uid", Name: "device", TenantID: "tenant"}
+	publicKey := &models.PublicKey{Data: gossh.MarshalAuthorizedKey(signer.PublicKey()), Fingerprint: "fingerprint"}
+
+	cases := []struct {
+		description   string
+		username      string
+		signature     string
+		requiredMocks func(mock *mocks.Client)
+		err           error
+	}{
+		{
+			description:   "fails when the username is empty",
+			username:      "",
+			signature:     sign(""),
+			requiredMocks: func(*mocks.Client) {},
+			err:           ErrFilesPublicKey,
+		},
+		{
+			description: "fails when the public key cannot be used to the user",
+			username:    "root",
+			signature:   sign("root"),
+			requiredMocks: func(mock *mocks.Client) {
+				mock.On("GetDevice", "uid").Return(device, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").Return(publicKey, nil).Once()
+				mock.On("EvaluateKey", "fingerprint", device, "root").Return(false, nil).Once()
+			},
+			err: ErrFilesPublicKey,
+		},
+		{
+			description: "fails when the public key denies the SFTP",
+			username:    "root",
+			signature:   sign("root"),
+			requiredMocks: func(mock *mocks.Client) {
+				denied := *publicKey
+				denied.Options.NoSFTP = true
+
+				mock.On("GetDevice", "uid").Return(device, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").Return(&denied, nil).Once()
+				mock.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+			},
+			err: ErrFilesPublicKey,
+		},
+		{
+			description: "fails when the signature is not of the user",
+			username:    "root",
+			signature:   sign("admin"),
+			requiredMocks: func(mock *mocks.Client) {
+				mock.On("GetDevice", "uid").Return(device, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").Return(publicKey, nil).Once()
+				mock.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+			},
+			err: ErrFilesPublicKey,
+		},
+		{
+			description: "succeeds to assert the authentication to the device",
+			username:    "root",
+			signature:   sign("root"),
+			requiredMocks: func(mock *mocks.Client) {
+				mock.On("GetDevice", "uid").Return(device, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").Return(publicKey, nil).Once()
+				mock.On("EvaluateKey", "fingerprint", device, "root").Return(true, nil).Once()
+				mock.On("CreatePrivateKey", "fingerprint", "tenant").Return(privateKey, nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			mock := new(mocks.Client)
+			tc.requiredMocks(mock)
+
+			tunnel := &Tunnel{API: mock}
+
+			authorization, err := tunnel.filesPublicKey("uid", tc.username, "fingerprint", tc.signature)
+			assert.ErrorIs(t, err, tc.err)
+			mock.AssertExpectations(t)
+
+			if tc.err != nil {
+				return
+			}
+
+			scheme, credentials, _ := strings.Cut(authorization, " ")
+			assert.Equal(t, models.FilesShellHubScheme, scheme)
+
+			fingerprint, signature, _ := strings.Cut(credentials, " ")
+			assert.Equal(t, "private", fingerprint)
+
+			digest, err := base64.StdEncoding.DecodeString(signature)
+			require.NoError(t, err)
+
+			hash := sha256.Sum256([]byte(`{"Username":"root","Namespace":"device"}`))
+			assert.NoError(t, rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, hash[:], digest))
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package tunnel
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httputil"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// deviceProxy creates a reverse proxy to the path on the agent of the device, keeping the request's method, query,
+// headers and body. When the device cannot be reached, the proxy replies with a bad gateway status.
+func (t *Tunnel) deviceProxy(uid, path string, logger *log.Entry) *httputil.ReverseProxy {
+	return &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "http"
+			req.URL.Host = uid
+			req.URL.Path = path
+			req.URL.RawPath = ""
+			req.Host = uid
+		},
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return t.Dial(ctx, uid)
+			},
+			DisableKeepAlives: true,
+		},
+		ErrorHandler: func(w http.ResponseWriter, _ *http.Request, err error) {
+			logger.WithError(err).Error("failed to proxy the request to the device")
+
+			w.WriteHeader(http.StatusBadGateway)
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package tunnel
+
+import (
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// PushURL is the route used by the API's workers to transfer a pushed file to the device's agent.
+const PushURL = "/push/:uid/:action"
+
+// pushHandler proxies the file push's request to the agent of the device, keeping its method, query and body.
+func (t *Tunnel) pushHandler(c echo.Context) error {
+	uid, action := c.Param("uid"), c.Param("action")
+
+	logger := log.WithFields(log.Fields{
+		"device": uid,
+		"action": action,
+	})
+
+	t.deviceProxy(uid, "/ssh/push/"+url.PathEscape(action), logger).ServeHTTP(c.Response(), c.Request())
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shellhub-io/shellhub/pkg/httptunnel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushHandler(t *testing.T) {
+	agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		fmt.Fprintf(w, "%s %s?%s %s", r.Method, r.URL.Path, r.URL.RawQuery, body)
+	}))
+	defer agent.Close()
+
+	cases := []struct {
+		description string
+		device      string
+		expected    string
+		status      int
+	}{
+		{
+			description: "fails when the device is not connected",
+			device:      "offline",
+			status:      http.StatusBadGateway,
+		},
+		{
+			description: "succeeds to proxy the request to the device",
+			device:      "device",
+			expected:    "PUT /ssh/push/upload?id=1&offset=4 content",
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tunnel := &Tunnel{Tunnel: httptunnel.NewTunnel("/ssh/connection", "/ssh/revdial")}
+			tunnel.Tunnel.ForwardHandler = func(ctx context.Context, id string) (net.Conn, error) {
+				if id != "device" {
+					return nil, errors.New("device is not connected")
+				}
+
+				return new(net.Dialer).DialContext(ctx, "tcp", agent.Listener.Addr().String())
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/push/"+tc.device+"/upload?id=1&offset=4", strings.NewReader("content"))
+			rec := httptest.NewRecorder()
+
+			c := echo.New().NewContext(req, rec)
+			c.SetParamNames("uid", "action")
+			c.SetParamValues(tc.device, "upload")
+
+			assert.NoError(t, tunnel.pushHandler(c))
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Equal(t, tc.expected, rec.Body.String())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package tunnel
+
+import (
+	"context"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/cache"
+)
+
+const (
+	// RegistryConnectionTTL is the time a device's connection is kept in the registry without a keep alive.
+	RegistryConnectionTTL = 2 * time.Minute
+	// RegistryInstanceTTL is the time an instance is kept in the registry without being refreshed.
+	RegistryInstanceTTL = time.Minute
+)
+
+// Registry is a shared registry of which SSH server instance owns each device's connection.
+//
+// Each instance is identified by its address, reachable by the other instances, what is used to forward the
+// connections to devices connected to other instances.
+type Registry struct {
+	cache   cache.Cache
+	address string
+}
+
+// NewRegistry creates a registry to the instance reachable at address.
+func NewRegistry(cache cache.Cache, address string) *Registry {
+	return &Registry{
+		cache:   cache,
+		address: address,
+	}
+}
+
+// Address returns the address of the current instance.
+func (r *Registry) Address() string {
+	return r.address
+}
+
+func connectionKey(device string) string {
+	return "ssh:connection:" + device
+}
+
+func instanceKey(address string) string {
+	return "ssh:instance:" + address
+}
+
+// Register sets the current instance as owner of the device's connection.
+func (r *Registry) Register(ctx context.Context, device string) error {
+	return r.cache.Set(ctx, connectionKey(device), r.address, RegistryConnectionTTL)
+}
+
+// Unregister removes the device's connection from the registry when it is owned by the current instance. It returns
+// true when the connection was owned by the current instance, or by none.
+//
+// The connection is removed only if it is still owned by the current instance in a single step, so a newer
+// registration from another instance, where the device has just reconnected, is never removed.
+func (r *Registry) Unregister(ctx context.Context, device string) (bool, error) {
+	deleted, err := r.cache.CompareAndDelete(ctx, connectionKey(device), r.address)
+	if err != nil {
+		return false, err
+	}
+
+	if deleted {
+		return true, nil
+	}
+
+	owner, err := r.Owner(ctx, device)
+	if err != nil {
+		return false, err
+	}
+
+	return owner == "", nil
+}
+
+// Owner returns the address of the instance which owns the device's connection, or an empty string if none does.
+func (r *Registry) Owner(ctx context.Context, device string) (string, error) {
+	var owner string
+	if err := r.cache.Get(ctx, connectionKey(device), &owner); err != nil {
+		return "", err
+	}
+
+	return owner, nil
+}
+
+// Announce registers the current instance as alive.
+func (r *Registry) Announce(ctx context.Context) error {
+	return r.cache.Set(ctx, instanceKey(r.address), true, RegistryInstanceTTL)
+}
+
+// IsInstance checks if address belongs to an instance announced as alive.
+func (r *Registry) IsInstance(ctx context.Context, address string) (bool, error) {
+	var alive bool
+	if err := r.cache.Get(ctx, instanceKey(address), &alive); err != nil {
+		return false, err
+	}
+
+	return alive, nil
+}
@@ -0,0 +1,123 @@
+package tunnel
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache is an in memory implementation of cache.Cache used to test the registry.
+type memoryCache struct {
+	values sync.Map
+	mu     sync.Mutex
+}
+
+func (m *memoryCache) Get(_ context.Context, key string, value interface{}) error {
+	data, ok := m.values.Load(key)
+	if !ok {
+		return nil
+	}
+
+	return json.Unmarshal(data.([]byte), value)
+}
+
+func (m *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	m.values.Store(key, data)
+
+	return nil
+}
+
+func (m *memoryCache) Delete(_ context.Context, key string) error {
+	m.values.Delete(key)
+
+	return nil
+}
+
+func (m *memoryCache) CompareAndDelete(_ context.Context, key string, value interface{}) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	expected, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	data, ok := m.values.Load(key)
+	if !ok || !bytes.Equal(data.([]byte), expected) {
+		return false, nil
+	}
+
+	m.values.Delete(key)
+
+	return true, nil
+}
+
+func TestRegistry(t *testing.T) {
+	ctx := context.Background()
+	cache := new(memoryCache)
+
+	first := NewRegistry(cache, "ssh-1:8080")
+	second := NewRegistry(cache, "ssh-2:8080")
+
+	owner, err := first.Owner(ctx, "device")
+	assert.NoError(t, err)
+	assert.Equal(t, "", owner)
+
+	assert.NoError(t, first.Register(ctx, "device"))
+
+	owner, err = second.Owner(ctx, "device")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh-1:8080", owner)
+
+	// The device reconnects to the second instance before the first one notices the connection is closed.
+	assert.NoError(t, second.Register(ctx, "device"))
+
+	owned, err := first.Unregister(ctx, "device")
+	assert.NoError(t, err)
+	assert.False(t, owned)
+
+	owner, err = first.Owner(ctx, "device")
+	assert.NoError(t, err)
+	assert.Equal(t, "ssh-2:8080", owner)
+
+	owned, err = second.Unregister(ctx, "device")
+	assert.NoError(t, err)
+	assert.True(t, owned)
+
+	owner, err = first.Owner(ctx, "device")
+	assert.NoError(t, err)
+	assert.Equal(t, "", owner)
+
+	// The device's connection expired from the registry before being closed.
+	owned, err = first.Unregister(ctx, "device")
+	assert.NoError(t, err)
+	assert.True(t, owned)
+}
+
+func TestRegistryInstances(t *testing.T) {
+	ctx := context.Background()
+	cache := new(memoryCache)
+
+	first := NewRegistry(cache, "ssh-1:8080")
+	second := NewRegistry(cache, "ssh-2:8080")
+
+	alive, err := first.IsInstance(ctx, "ssh-2:8080")
+	assert.NoError(t, err)
+	assert.False(t, alive)
+
+	assert.NoError(t, second.Announce(ctx))
+
+	alive, err = first.IsInstance(ctx, "ssh-2:8080")
+	assert.NoError(t, err)
+	assert.True(t, alive)
+}
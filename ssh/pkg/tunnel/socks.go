@@ -0,0 +1,214 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/envs"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/socks"
+	log "github.com/sirupsen/logrus"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// socksDeviceHost is the host, on the device's side, where the SOCKS5 connections are forwarded to.
+const socksDeviceHost = "localhost"
+
+// socksRoles are the namespace's roles granted, by the API, the permission to connect to the namespace's devices.
+var socksRoles = map[string]bool{
+	"observer":      true,
+	"operator":      true,
+	"administrator": true,
+	"owner":         true,
+}
+
+var (
+	// ErrSOCKSUsername is returned when the client does not send the device's user and its credential as username.
+	ErrSOCKSUsername = errors.New("username and credential are required")
+	// ErrSOCKSMFA is returned when the token was issued before the user's MFA was validated.
+	ErrSOCKSMFA = errors.New("multi-factor authentication not validated")
+	// ErrSOCKSRole is returned when the token's role is not allowed to connect to the namespace's devices.
+	ErrSOCKSRole = errors.New("token's role cannot connect to devices")
+	// ErrSOCKSPublicKey is returned when the public key cannot authenticate the user to forward ports on the device.
+	ErrSOCKSPublicKey = errors.New("public key cannot forward ports on the device")
+)
+
+// socksCredential is the device's user, and the credential that authenticates it on the device, sent by the client as
+// the SOCKS5 username.
+type socksCredential struct {
+	User string
+	// Fingerprint identifies the namespace's public key, created by the token's owner, used to authenticate the user.
+	Fingerprint string
+	// Password is the user's password on the device.
+	Password string
+}
+
+// parseSOCKSCredential parses the SOCKS5 username, what is the device's user followed by the fingerprint of a public
+// key, like "root@fingerprint", or by the user's password, like "root:password".
+func parseSOCKSCredential(username string) (*socksCredential, error) {
+	i := strings.IndexAny(username, "@:")
+	if i < 1 || i == len(username)-1 {
+		return nil, ErrSOCKSUsername
+	}
+
+	if username[i] == '@' {
+		return &socksCredential{User: username[:i], Fingerprint: username[i+1:]}, nil
+	}
+
+	return &socksCredential{User: username[:i], Password: username[i+1:]}, nil
+}
+
+// ListenSOCKS listens for SOCKS5 connections on address, connecting the clients to destinations in the format
+// `device-name.namespace:port`, what is the port on the device itself.
+//
+// The client must authenticate using the username/password method, sending the device's user with its credential as
+// username and a ShellHub user's token as password, and it is only allowed to connect to devices from the namespace the
+// token belongs to. The credential is the fingerprint of a namespace's public key created by the token's owner, like
+// "root@fingerprint", or the user's password on the device, like "root:password". Each connection is a direct-tcpip
+// channel opened by the device's user on the device's SSH server, subject to the firewall rules, to the public key's
+// filter and options, and to the device's own checks, like any other SSH port forwarding.
+func (t *Tunnel) ListenSOCKS(address string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	defer listener.Close()
+
+	server := &socks.Server{
+		Authenticate: t.authenticateSOCKS,
+		Dial:         t.dialSOCKS,
+	}
+
+	return server.Serve(listener)
+}
+
+// authenticateSOCKS checks the token sent as password, returning the tenant of the user who owns it. The token must
+// belong to a namespace's member allowed to connect to its devices and, when the user has MFA enabled, it must have been
+// validated. When the device's user is authenticated by a public key, it must have been created by the token's owner
+// and allow the port forwarding.
+func (t *Tunnel) authenticateSOCKS(username, password string) (string, error) {
+	credential, err := parseSOCKSCredential(username)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, err)
+	}
+
+	claims, err := t.API.AuthUserToken(password)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, err)
+	}
+
+	if claims.MFA.Status && !claims.MFA.Validate {
+		return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, ErrSOCKSMFA)
+	}
+
+	if !socksRoles[claims.Role] {
+		return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, ErrSOCKSRole)
+	}
+
+	if credential.Fingerprint != "" {
+		key, err := t.API.GetPublicKey(credential.Fingerprint, claims.Tenant)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, err)
+		}
+
+		if key.CreatedBy != claims.ID || key.Options.NoPortForwarding {
+			return "", fmt.Errorf("%w: %w", socks.ErrUnauthorized, ErrSOCKSPublicKey)
+		}
+	}
+
+	return claims.Tenant, nil
+}
+
+// dialSOCKS resolves the destination to a device from the identity's namespace and connects to the port on it, as the
+// device's user sent with the identity's username.
+func (t *Tunnel) dialSOCKS(ctx context.Context, identity *socks.Identity, host string, port int) (net.Conn, error) {
+	credential, err := parseSOCKSCredential(identity.Username)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", socks.ErrNotAllowed, err)
+	}
+
+	name, namespace, err := socks.SplitDestination(host)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", socks.ErrHostUnreachable, err)
+	}
+
+	logger := log.WithFields(log.Fields{
+		"name":      name,
+		"namespace": namespace,
+		"port":      port,
+		"username":  credential.User,
+	})
+
+	device, errs := t.API.DeviceLookup(map[string]string{
+		"domain": namespace,
+		"name":   name,
+	})
+	if len(errs) > 0 || device == nil {
+		logger.Error("failed to lookup the socks destination")
+
+		return nil, socks.ErrHostUnreachable
+	}
+
+	if identity.Value == "" || device.TenantID != identity.Value {
+		logger.Warn("socks destination does not belong to the authenticated namespace")
+
+		return nil, socks.ErrNotAllowed
+	}
+
+	if envs.IsCloud() || envs.IsEnterprise() {
+		if err := t.API.FirewallEvaluate(map[string]string{
+			"domain":     namespace,
+			"name":       name,
+			"username":   credential.User,
+			"ip_address": identity.Source,
+		}); err != nil {
+			logger.WithError(err).Warn("socks destination blocked by a firewall rule")
+
+			if errors.Is(err, internalclient.ErrFirewallBlock) {
+				return nil, fmt.Errorf("%w: %w", socks.ErrNotAllowed, err)
+			}
+
+			return nil, err
+		}
+	}
+
+	auth, err := t.socksAuth(device, credential)
+	if err != nil {
+		logger.WithError(err).Warn("socks credential cannot authenticate the user on the device")
+
+		return nil, fmt.Errorf("%w: %w", socks.ErrNotAllowed, err)
+	}
+
+	conn, err := t.DialSSHTCP(ctx, device.UID, credential.User, auth, socksDeviceHost, port)
+	if err != nil {
+		logger.WithError(err).Error("failed to open the direct-tcpip channel to the socks destination")
+
+		return nil, fmt.Errorf("%w: %w", socks.ErrHostUnreachable, err)
+	}
+
+	return conn, nil
+}
+
+// socksAuth returns the method that authenticates the credential's user on the device. A public key must be allowed,
+// by its filter and username, to authenticate the user on the device.
+func (t *Tunnel) socksAuth(device *models.Device, credential *socksCredential) (gossh.AuthMethod, error) {
+	if credential.Fingerprint == "" {
+		return gossh.Password(credential.Password), nil
+	}
+
+	if ok, err := t.API.EvaluateKey(credential.Fingerprint, device, credential.User); err != nil || !ok {
+		return nil, errors.Join(ErrSOCKSPublicKey, err)
+	}
+
+	signer, err := t.SSHSigner(credential.Fingerprint, device.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gossh.PublicKeys(signer), nil
+}
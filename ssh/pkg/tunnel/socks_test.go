@@ -0,0 +1,231 @@
+package tunnel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient"
+	"github.com/shellhub-io/shellhub/pkg/api/internalclient/mocks"
+	"github.com/shellhub-io/shellhub/pkg/models"
+	"github.com/shellhub-io/shellhub/ssh/pkg/socks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticateSOCKS(t *testing.T) {
+	mock := new(mocks.Client)
+
+	type Expected struct {
+		tenant string
+		err    error
+	}
+
+	cases := []struct {
+		description   string
+		username      string
+		token         string
+		requiredMocks func()
+		expected      Expected
+	}{
+		{
+			description:   "fails when username is empty",
+			username:      "",
+			token:         "token",
+			requiredMocks: func() {},
+			expected:      Expected{"", ErrSOCKSUsername},
+		},
+		{
+			description:   "fails when username has no credential",
+			username:      "root",
+			token:         "token",
+			requiredMocks: func() {},
+			expected:      Expected{"", ErrSOCKSUsername},
+		},
+		{
+			description: "fails when token is invalid",
+			username:    "root:password",
+			token:       "invalid",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "invalid").Return(nil, internalclient.ErrUnauthorized).Once()
+			},
+			expected: Expected{"", socks.ErrUnauthorized},
+		},
+		{
+			description: "fails when token was issued before the MFA was validated",
+			username:    "root:password",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").
+					Return(&models.UserAuthClaims{Tenant: "tenant", Role: "operator", MFA: models.MFA{Status: true}}, nil).Once()
+			},
+			expected: Expected{"", ErrSOCKSMFA},
+		},
+		{
+			description: "fails when token has no namespace role",
+			username:    "root:password",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").Return(&models.UserAuthClaims{Tenant: "tenant"}, nil).Once()
+			},
+			expected: Expected{"", ErrSOCKSRole},
+		},
+		{
+			description: "fails when public key was not created by the token's owner",
+			username:    "root@fingerprint",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").
+					Return(&models.UserAuthClaims{ID: "user", Tenant: "tenant", Role: "observer"}, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").
+					Return(&models.PublicKey{Fingerprint: "fingerprint", CreatedBy: "other"}, nil).Once()
+			},
+			expected: Expected{"", ErrSOCKSPublicKey},
+		},
+		{
+			description: "fails when public key denies the port forwarding",
+			username:    "root@fingerprint",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").
+					Return(&models.UserAuthClaims{ID: "user", Tenant: "tenant", Role: "operator"}, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").
+					Return(&models.PublicKey{
+						Fingerprint:     "fingerprint",
+						CreatedBy:       "user",
+						PublicKeyFields: models.PublicKeyFields{Options: models.PublicKeyOptions{NoPortForwarding: true}},
+					}, nil).Once()
+			},
+			expected: Expected{"", ErrSOCKSPublicKey},
+		},
+		{
+			description: "succeeds when token is valid and credential is a password",
+			username:    "root:password",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").
+					Return(&models.UserAuthClaims{Tenant: "tenant", Role: "operator", MFA: models.MFA{Status: true, Validate: true}}, nil).Once()
+			},
+			expected: Expected{"tenant", nil},
+		},
+		{
+			description: "succeeds when token is valid and credential is a public key created by the token's owner",
+			username:    "root@fingerprint",
+			token:       "token",
+			requiredMocks: func() {
+				mock.On("AuthUserToken", "token").
+					Return(&models.UserAuthClaims{ID: "user", Tenant: "tenant", Role: "operator"}, nil).Once()
+				mock.On("GetPublicKey", "fingerprint", "tenant").
+					Return(&models.PublicKey{Fingerprint: "fingerprint", CreatedBy: "user"}, nil).Once()
+			},
+			expected: Expected{"tenant", nil},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			tc.requiredMocks()
+
+			tunnel := &Tunnel{API: mock}
+			tenant, err := tunnel.authenticateSOCKS(tc.username, tc.token)
+			assert.Equal(t, tc.expected.tenant, tenant)
+			assert.ErrorIs(t, err, tc.expected.err)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestDialSOCKS(t *testing.T) {
+	mock := new(mocks.Client)
+
+	cases := []struct {
+		description   string
+		cloud         string
+		username      string
+		tenant        string
+		host          string
+		requiredMocks func()
+		expected      error
+	}{
+		{
+			description:   "fails when username has no credential",
+			username:      "root",
+			tenant:        "tenant",
+			host:          "device.namespace",
+			requiredMocks: func() {},
+			expected:      ErrSOCKSUsername,
+		},
+		{
+			description:   "fails when destination is invalid",
+			username:      "root:password",
+			tenant:        "tenant",
+			host:          "device",
+			requiredMocks: func() {},
+			expected:      socks.ErrHostUnreachable,
+		},
+		{
+			description: "fails when device is not found",
+			username:    "root:password",
+			tenant:      "tenant",
+			host:        "device.namespace",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(nil, []error{errors.New("error")}).Once()
+			},
+			expected: socks.ErrHostUnreachable,
+		},
+		{
+			description: "fails when device does not belong to the tenant",
+			username:    "root:password",
+			tenant:      "tenant",
+			host:        "device.namespace",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(&models.Device{UID: "uid", TenantID: "other"}, nil).Once()
+			},
+			expected: socks.ErrNotAllowed,
+		},
+		{
+			description: "fails when a firewall rule blocks the connection",
+			cloud:       "true",
+			username:    "root:password",
+			tenant:      "tenant",
+			host:        "device.namespace",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("FirewallEvaluate", map[string]string{"domain": "namespace", "name": "device", "username": "root", "ip_address": "10.0.0.1"}).
+					Return(internalclient.ErrFirewallBlock).Once()
+			},
+			expected: socks.ErrNotAllowed,
+		},
+		{
+			description: "fails when public key cannot authenticate the user on the device",
+			username:    "root@fingerprint",
+			tenant:      "tenant",
+			host:        "device.namespace",
+			requiredMocks: func() {
+				mock.On("DeviceLookup", map[string]string{"domain": "namespace", "name": "device"}).
+					Return(&models.Device{UID: "uid", TenantID: "tenant"}, nil).Once()
+				mock.On("EvaluateKey", "fingerprint", &models.Device{UID: "uid", TenantID: "tenant"}, "root").
+					Return(false, nil).Once()
+			},
+			expected: ErrSOCKSPublicKey,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			t.Setenv("SHELLHUB_CLOUD", tc.cloud)
+
+			tc.requiredMocks()
+
+			tunnel := &Tunnel{API: mock}
+			conn, err := tunnel.dialSOCKS(context.Background(), &socks.Identity{Username: tc.username, Value: tc.tenant, Source: "10.0.0.1"}, tc.host, 22)
+			assert.Nil(t, conn)
+			assert.ErrorIs(t, err, tc.expected)
+		})
+	}
+
+	mock.AssertExpectations(t)
+}
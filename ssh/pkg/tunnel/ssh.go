@@ -0,0 +1,117 @@
+package tunnel
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/uuid"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// ErrSSHPrivateKey is returned when the private key created by ShellHub to authenticate on the device is invalid.
+var ErrSSHPrivateKey = errors.New("invalid private key")
+
+// SSHHandshakeTimeout is the maximum duration to the SSH handshake with the device to be completed.
+const SSHHandshakeTimeout = 30 * time.Second
+
+// SSHSigner returns a signer from a private key created by ShellHub to authenticate, on a device, the user whose
+// namespace's public key is identified by fingerprint. The device checks the private key against the API, the same way
+// as the sessions from the users authenticated through their public keys.
+func (t *Tunnel) SSHSigner(fingerprint, tenant string) (gossh.Signer, error) {
+	privateKey, err := t.API.CreatePrivateKey(fingerprint, tenant)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(privateKey.Data)
+	if block == nil {
+		return nil, ErrSSHPrivateKey
+	}
+
+	parsed, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Join(ErrSSHPrivateKey, err)
+	}
+
+	signer, err := gossh.NewSignerFromKey(parsed)
+	if err != nil {
+		return nil, errors.Join(ErrSSHPrivateKey, err)
+	}
+
+	return signer, nil
+}
+
+// DialSSH connects to the device's SSH server, through its agent, as user authenticated by auth. The connection goes
+// through all the device's checks, like any other SSH session.
+func (t *Tunnel) DialSSH(ctx context.Context, device, user string, auth gossh.AuthMethod) (*gossh.Client, error) {
+	conn, err := t.Dial(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/ssh/%s", uuid.Generate()), nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	config := &gossh.ClientConfig{
+		User:            user,
+		Auth:            []gossh.AuthMethod{auth},
+		HostKeyCallback: gossh.InsecureIgnoreHostKey(), // nolint:gosec
+		Timeout:         SSHHandshakeTimeout,
+	}
+
+	client, chans, reqs, err := gossh.NewClientConn(conn, device, config)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	return gossh.NewClient(client, chans, reqs), nil
+}
+
+// sshConn is a connection opened through a SSH client that closes the client with it.
+type sshConn struct {
+	net.Conn
+	client *gossh.Client
+}
+
+func (c *sshConn) Close() error {
+	defer c.client.Close()
+
+	return c.Conn.Close()
+}
+
+// DialSSHTCP connects to the host and port reachable from the device through a direct-tcpip channel, opened by user,
+// authenticated by auth, on the device's SSH server.
+func (t *Tunnel) DialSSHTCP(ctx context.Context, device, user string, auth gossh.AuthMethod, host string, port int) (net.Conn, error) {
+	client, err := t.DialSSH(ctx, device, user, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.Dial("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		client.Close()
+
+		return nil, err
+	}
+
+	return &sshConn{Conn: conn, client: client}, nil
+}
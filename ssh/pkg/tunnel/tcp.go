@@ -0,0 +1,242 @@
+package tunnel
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shellhub-io/shellhub/pkg/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrTCPInvalidServerName is returned when the TLS server name, sent through SNI, does not contain a tunnel's address.
+var ErrTCPInvalidServerName = errors.New("invalid server name")
+
+// ErrTCPUnexpectedStatus is returned when the agent could not connect to the host and port requested.
+var ErrTCPUnexpectedStatus = errors.New("unexpected status code")
+
+// TCPHandshakeTimeout is the maximum duration to the TLS handshake from a tunnel's connection to be completed.
+const TCPHandshakeTimeout = 10 * time.Second
+
+// ListenTCP listens for TLS connections on address and routes each one, through the SNI's first label, to the device
+// that owns the tunnel with the same address.
+func (t *Tunnel) ListenTCP(address string, config *tls.Config) error {
+	listener, err := tls.Listen("tcp", address, config)
+	if err != nil {
+		return err
+	}
+
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go t.handleTCP(conn.(*tls.Conn))
+	}
+}
+
+// ListenPorts listens for plain TCP connections on each port from the ports range, like "40000-40099", and routes each
+// one to the device that owns the tunnel in the port mode listening on the same port.
+func (t *Tunnel) ListenPorts(host, ports string) error {
+	first, last, err := models.ParseTunnelPorts(ports)
+	if err != nil {
+		return err
+	}
+
+	listeners := make([]net.Listener, 0, last-first+1)
+	defer func() {
+		for _, listener := range listeners {
+			listener.Close()
+		}
+	}()
+
+	for port := first; port <= last; port++ {
+		listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+		if err != nil {
+			return err
+		}
+
+		listeners = append(listeners, listener)
+	}
+
+	errs := make(chan error, len(listeners))
+
+	for i, listener := range listeners {
+		go func(listener net.Listener, port int) {
+			for {
+				conn, err := listener.Accept()
+				if err != nil {
+					errs <- err
+
+					return
+				}
+
+				go t.handlePort(conn, port)
+			}
+		}(listener, first+i)
+	}
+
+	return <-errs
+}
+
+// sourceFromConn gets the IP address a tunnel's connection comes from.
+func sourceFromConn(conn net.Conn) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+
+	return host
+}
+
+// addressFromServerName gets the tunnel's address from the TLS server name, what is its first label.
+func addressFromServerName(name string) (string, error) {
+	address, _, _ := strings.Cut(name, ".")
+	if address == "" {
+		return "", ErrTCPInvalidServerName
+	}
+
+	return address, nil
+}
+
+// bufferedConn is a connection that reads from the buffer used to read a HTTP response before reading from the
+// connection itself.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// DialTCP connects to the host and port reachable from the device through its agent.
+func (t *Tunnel) DialTCP(ctx context.Context, device, host string, port int) (net.Conn, error) {
+	conn, err := t.Dial(ctx, device)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "/ssh/tcp", nil)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	req.Header.Set("X-Host", host)
+	req.Header.Set("X-Port", strconv.Itoa(port))
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		conn.Close()
+
+		return nil, fmt.Errorf("%w: %d", ErrTCPUnexpectedStatus, res.StatusCode)
+	}
+
+	return &bufferedConn{Conn: conn, reader: reader}, nil
+}
+
+func (t *Tunnel) handleTCP(conn *tls.Conn) {
+	defer conn.Close()
+
+	logger := log.WithFields(log.Fields{
+		"remote": conn.RemoteAddr().String(),
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), TCPHandshakeTimeout)
+	defer cancel()
+
+	if err := conn.HandshakeContext(ctx); err != nil {
+		logger.WithError(err).Error("failed to complete the TLS handshake")
+
+		return
+	}
+
+	address, err := addressFromServerName(conn.ConnectionState().ServerName)
+	if err != nil {
+		logger.WithError(err).Error("failed to get the tunnel's address")
+
+		return
+	}
+
+	logger = logger.WithField("address", address)
+
+	tunnel, err := t.API.GetTunnel(address, sourceFromConn(conn))
+	if err != nil {
+		logger.WithError(err).Error("failed to get the tunnel")
+
+		return
+	}
+
+	t.forwardTCP(conn, tunnel, logger)
+}
+
+func (t *Tunnel) handlePort(conn net.Conn, port int) {
+	defer conn.Close()
+
+	logger := log.WithFields(log.Fields{
+		"remote": conn.RemoteAddr().String(),
+		"port":   port,
+	})
+
+	tunnel, err := t.API.GetTunnelByPort(port, sourceFromConn(conn))
+	if err != nil {
+		logger.WithError(err).Error("failed to get the tunnel")
+
+		return
+	}
+
+	t.forwardTCP(conn, tunnel, logger.WithField("address", tunnel.Address))
+}
+
+// forwardTCP forwards the connection to the tunnel's host and port reachable from its device.
+func (t *Tunnel) forwardTCP(conn net.Conn, tunnel *models.Tunnel, logger *log.Entry) {
+	in, err := t.DialTCP(context.Background(), tunnel.Device, tunnel.Host, tunnel.Port)
+	if err != nil {
+		logger.WithError(err).Error("failed to connect to the tunnel's address on device")
+
+		return
+	}
+
+	defer in.Close()
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(in, conn) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	go func() {
+		io.Copy(conn, in) // nolint:errcheck
+		done <- struct{}{}
+	}()
+
+	<-done
+}
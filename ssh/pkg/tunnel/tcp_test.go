@@ -0,0 +1,60 @@
+package tunnel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressFromServerName(t *testing.T) {
+	type Expected struct {
+		address string
+		err     error
+	}
+
+	cases := []struct {
+		description string
+		name        string
+		expected    Expected
+	}{
+		{
+			description: "fails when server name is empty",
+			name:        "",
+			expected: Expected{
+				address: "",
+				err:     ErrTCPInvalidServerName,
+			},
+		},
+		{
+			description: "fails when server name starts with a dot",
+			name:        ".tunnels.localhost",
+			expected: Expected{
+				address: "",
+				err:     ErrTCPInvalidServerName,
+			},
+		},
+		{
+			description: "succeeds when server name has only the address",
+			name:        "a582b47a42d",
+			expected: Expected{
+				address: "a582b47a42d",
+				err:     nil,
+			},
+		},
+		{
+			description: "succeeds when server name has the address as first label",
+			name:        "a582b47a42d.tunnels.localhost",
+			expected: Expected{
+				address: "a582b47a42d",
+				err:     nil,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			address, err := addressFromServerName(tc.name)
+			assert.Equal(t, tc.expected, Expected{address, err})
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 type Tunnel struct {
 	Tunnel *httptunnel.Tunnel
 	API    internalclient.Client
+	// Registry, when defined, is the shared registry of the devices' connections between the instances.
+	Registry *Registry
 }
 
 func NewTunnel(connection, dial string) *Tunnel {
@@ -46,6 +48,15 @@ func (t *Tunnel) GetRouter() *echo.Echo {
 		log.Error("type assertion failed")
 	}
 
+	router.Any(FilesURL, t.filesHandler)
+	router.POST(ExecURL, t.execHandler)
+	router.Any(PushURL, t.pushHandler)
+
+	if t.Registry != nil {
+		router.Pre(t.pickupMiddleware)
+		router.GET(ForwardURL, t.forwardHandler)
+	}
+
 	return router
 }
 
@@ -78,7 +78,8 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 	}
 
 	if gossh.FingerprintLegacyMD5(magic) != fingerprint {
-		if _, err = api.GetPublicKey(fingerprint, device.TenantID); err != nil {
+		key, err := api.GetPublicKey(fingerprint, device.TenantID)
+		if err != nil {
 			log.WithError(err).
 				WithFields(log.Fields{
 					"session":     ctx.SessionID(),
@@ -101,6 +102,8 @@ func PublicKeyHandler(ctx gliderssh.Context, publicKey gliderssh.PublicKey) bool
 
 			return false
 		}
+
+		metadata.StorePublicKeyOptions(ctx, &key.Options)
 	}
 
 	metadata.StoreAuthenticationMethod(ctx, metadata.PublicKeyAuthenticationMethod)
@@ -336,13 +336,14 @@ func TestPublicKeyHandler(t *testing.T) {
 					Once()
 
 				api.On("GetPublicKey", "fingerprint", "00000000-0000-4000-0000-000000000000").
-					Return(nil, nil).
+					Return(&models.PublicKey{PublicKeyFields: models.PublicKeyFields{Options: models.PublicKeyOptions{NoPTY: true}}}, nil).
 					Once()
 
 				api.On("EvaluateKey", "fingerprint", &models.Device{TenantID: "00000000-0000-4000-0000-000000000000"}, "user").
 					Return(true, nil).
 					Once()
 
+				metadataMock.On("StorePublicKeyOptions", ctx, &models.PublicKeyOptions{NoPTY: true})
 				metadataMock.On("StoreAuthenticationMethod", ctx, metadata.PublicKeyAuthenticationMethod)
 			},
 			expected: true,
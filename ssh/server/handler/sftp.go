@@ -27,6 +27,15 @@ func SFTPSubsystemHandler(tunnel *httptunnel.Tunnel) gliderssh.SubsystemHandler
 		ctx := client.Context()
 		api := metadata.RestoreAPI(ctx)
 
+		if options := metadata.RestorePublicKeyOptions(ctx); options != nil && options.NoSFTP {
+			log.WithFields(log.Fields{"sshid": client.User()}).Warn("SFTP denied by the public key's options")
+
+			client.Stderr().Write([]byte(fmt.Sprintf("%s\n", ErrSFTPNotAllowed.Error()))) // nolint: errcheck
+			client.Exit(1)                                                                // nolint: errcheck
+
+			return
+		}
+
 		sess, err := session.NewSession(client, tunnel)
 		if err != nil {
 			log.WithError(err).
@@ -76,6 +85,34 @@ func connectSFTP(ctx context.Context, client gliderssh.Session, sess *session.Se
 
 	defer agent.Close()
 
+	if options := metadata.RestorePublicKeyOptions(client.Context()); options != nil {
+		for name, value := range options.Environment {
+			if err := agent.Setenv(name, value); err != nil {
+				log.WithError(err).
+					WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "env": name}).
+					Error("failed to set the public key's env variable")
+
+				return ErrEnvPublicKey
+			}
+		}
+
+		// Like the OpenSSH's `command=` option, the command forced by the public key is executed in place of the
+		// subsystem, which is only available through the SSH_ORIGINAL_COMMAND variable.
+		if options.Command != "" {
+			go session.HandleRequests(ctx, reqs, api, ctx.Done())
+
+			if err := agent.Setenv("SSH_ORIGINAL_COMMAND", SFTPSubsystem); err != nil {
+				return ErrEnvPublicKey
+			}
+
+			if err := exec(api, sess, metadata.RestoreDevice(client.Context()), agent, client, options.Command); err != nil {
+				return ErrRequestExec
+			}
+
+			return nil
+		}
+	}
+
 	log.WithFields(log.Fields{"session": sess.UID, "sshid": client.User()}).
 		Debug("requesting a subsystem for session")
 	if err = agent.RequestSubsystem(SFTPSubsystem); err != nil {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/Masterminds/semver"
 	gliderssh "github.com/gliderlabs/ssh"
@@ -52,6 +53,9 @@ var (
 	ErrConfiguration           = fmt.Errorf("failed to create communication configuration")
 	ErrInvalidVersion          = fmt.Errorf("failed to parse device version")
 	ErrUnsuportedPublicKeyAuth = fmt.Errorf("connections using public keys are not permitted when the agent version is 0.5.x or earlier")
+	ErrSFTPNotAllowed          = fmt.Errorf("sftp is not allowed to the public key")
+	ErrEnvPublicKey            = fmt.Errorf("failed to set the public key's env variables to agent")
+	ErrEnvJob                  = fmt.Errorf("failed to set the job's env variable to agent")
 )
 
 type ConfigOptions struct {
@@ -159,6 +163,44 @@ func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Ses
 
 	metadata.MaybeStoreEstablished(ctx.(gliderssh.Context), true)
 
+	// NOTICE: The job's ID, set on the sessions of the jobs' commands, is sent to the agent, what lets the device refuse
+	// the jobs.
+	for _, env := range client.Environ() {
+		if name, value, ok := strings.Cut(env, "="); ok && name == models.JobEnv {
+			if err := agent.Setenv(name, value); err != nil {
+				return ErrEnvJob
+			}
+		}
+	}
+
+	if options := metadata.RestorePublicKeyOptions(ctx.(gliderssh.Context)); options != nil {
+		for name, value := range options.Environment {
+			if err := agent.Setenv(name, value); err != nil {
+				log.WithError(err).
+					WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "env": name}).
+					Error("failed to set the public key's env variable")
+
+				return ErrEnvPublicKey
+			}
+		}
+
+		// The command forced by the public key is executed in place of any session requested by the client, like the
+		// OpenSSH's `command=` option.
+		if options.Command != "" {
+			if err := agent.Setenv("SSH_ORIGINAL_COMMAND", client.RawCommand()); err != nil {
+				return ErrEnvPublicKey
+			}
+
+			device := metadata.RestoreDevice(ctx.(gliderssh.Context))
+
+			if err := exec(api, sess, device, agent, client, options.Command); err != nil {
+				return ErrRequestExec
+			}
+
+			return nil
+		}
+	}
+
 	switch sess.GetType() {
 	case session.Term, session.Web:
 		if err := shell(api, sess, agent, client, opts); err != nil {
@@ -172,7 +214,7 @@ func connectSSH(ctx context.Context, client gliderssh.Session, sess *session.Ses
 	case session.Exec, session.SCP:
 		device := metadata.RestoreDevice(ctx.(gliderssh.Context))
 
-		if err := exec(api, sess, device, agent, client); err != nil {
+		if err := exec(api, sess, device, agent, client, client.RawCommand()); err != nil {
 			return ErrRequestExec
 		}
 	default:
@@ -395,8 +437,8 @@ func heredoc(api internalclient.Client, uid string, agent *gossh.Session, client
 	return nil
 }
 
-// exec handles a non-interactive session.
-func exec(api internalclient.Client, sess *session.Session, device *models.Device, agent *gossh.Session, client gliderssh.Session) error {
+// exec handles a non-interactive session, running the command on agent.
+func exec(api internalclient.Client, sess *session.Session, device *models.Device, agent *gossh.Session, client gliderssh.Session, command string) error {
 	uid := sess.UID
 
 	if errs := api.SessionAsAuthenticated(uid); len(errs) > 0 {
@@ -442,9 +484,9 @@ func exec(api internalclient.Client, sess *session.Session, device *models.Devic
 	go flw.PipeOut(client, waitPipeOut)
 	go flw.PipeErr(client.Stderr(), nil)
 
-	if err := agent.Start(client.RawCommand()); err != nil {
+	if err := agent.Start(command); err != nil {
 		log.WithError(err).
-			WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "command": client.RawCommand()}).
+			WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "command": command}).
 			Error("failed to start a command on agent")
 
 		return err
@@ -476,7 +518,7 @@ func exec(api internalclient.Client, sess *session.Session, device *models.Devic
 
 	if err = agent.Wait(); isUnknownExitError(err) {
 		log.WithError(err).
-			WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "command": client.RawCommand()}).
+			WithFields(log.Fields{"session": sess.UID, "sshid": client.User(), "command": command}).
 			Warning("command on agent returned an error")
 	}
 
@@ -129,6 +129,11 @@ func (c *WebData) GetAuth(magicKey *rsa.PrivateKey) ([]ssh.AuthMethod, error) {
 		return nil, ErrForbiddenPublicKey
 	}
 
+	// The web terminal always opens an interactive shell, what the public key's options could forbid.
+	if key.Options.Command != "" || key.Options.NoPTY {
+		return nil, ErrForbiddenPublicKey
+	}
+
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey(key.Data) //nolint: dogsled
 	if err != nil {
 		return nil, ErrDataPublicKey
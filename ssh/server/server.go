@@ -18,6 +18,26 @@ import (
 type Options struct {
 	ConnectTimeout time.Duration `env:"CONNECT_TIMEOUT,default=30s"`
 	RedisURI       string        `env:"REDIS_URI,default=redis://redis:6379"`
+	// TunnelsAddress is the address where the raw TCP tunnels listen for TLS connections.
+	TunnelsAddress string `env:"TUNNELS_ADDRESS,default=:8443"`
+	// TunnelsCertificate is the path to the TLS certificate used by the raw TCP tunnels. When it is empty, the tunnels
+	// are disabled.
+	TunnelsCertificate string `env:"TUNNELS_CERTIFICATE"`
+	// TunnelsKey is the path to the TLS private key used by the raw TCP tunnels.
+	TunnelsKey string `env:"TUNNELS_KEY"`
+	// SOCKSAddress is the address where the SOCKS5 proxy listens. When it is empty, the proxy is disabled.
+	SOCKSAddress string `env:"SOCKS_ADDRESS"`
+	// Cluster enables the sharing of the devices' connections between multiple SSH server instances through Redis.
+	Cluster bool `env:"CLUSTER,default=false"`
+	// InstanceAddress is the address where the instance is reachable by the other instances. When it is empty, the
+	// instance's hostname is used.
+	InstanceAddress string `env:"INSTANCE_ADDRESS"`
+	// ConnectionRate is the rate, per second, of the agents' connections accepted by the instance. The agents exceeding
+	// it are asked to retry later, what keeps them from overloading the instance when they reconnect at once, like
+	// after a restart. When it is 0, the connections are not limited.
+	ConnectionRate float64 `env:"CONNECTION_RATE,default=0"`
+	// ConnectionBurst is the number of the agents' connections accepted at once above the connection's rate.
+	ConnectionBurst int `env:"CONNECTION_BURST,default=100"`
 }
 
 type Server struct {
@@ -53,8 +73,15 @@ func NewServer(opts *Options, tunnel *httptunnel.Tunnel) *Server {
 		SubsystemHandlers: map[string]gliderssh.SubsystemHandler{
 			handler.SFTPSubsystem: handler.SFTPSubsystemHandler(tunnel),
 		},
+		PtyCallback: func(ctx gliderssh.Context, _ gliderssh.Pty) bool {
+			options := metadata.RestorePublicKeyOptions(ctx)
+
+			return options == nil || !options.NoPTY
+		},
 		LocalPortForwardingCallback: func(ctx gliderssh.Context, dhost string, dport uint32) bool {
-			return true
+			options := metadata.RestorePublicKeyOptions(ctx)
+
+			return options == nil || !options.NoPortForwarding
 		},
 		ReversePortForwardingCallback: func(ctx gliderssh.Context, bindHost string, bindPort uint32) bool {
 			return false
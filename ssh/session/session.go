@@ -308,7 +308,14 @@ func NewClientConfiguration(ctx gliderssh.Context) (*gossh.ClientConfig, error)
 
 	switch metadata.RestoreAuthenticationMethod(ctx) {
 	case metadata.PublicKeyAuthenticationMethod:
-		privateKey, err := api.CreatePrivateKey()
+		var tenant string
+		if device := metadata.RestoreDevice(ctx); device != nil {
+			tenant = device.TenantID
+		}
+
+		// NOTICE: The private key is created to the member that created the public key used by the client, what lets
+		// the agent know on behalf of who the session is opened.
+		privateKey, err := api.CreatePrivateKey(metadata.RestoreFingerprint(ctx), tenant)
 		if err != nil {
 			return nil, err
 		}